@@ -0,0 +1,23 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionType is network condition type
+type ConditionType string
+
+// Condition is network condition
+type Condition struct {
+	// Type is condition type
+	Type ConditionType `json:"type"`
+	// Status is condition status, one of True, False or Unknown
+	Status corev1.ConditionStatus `json:"status"`
+	// LastTransitionTime is the last time condition status changed
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is one word reason for condition last transition
+	Reason string `json:"reason,omitempty"`
+	// Message is human readable message about condition last transition
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,9 @@
+package v1alpha1
+
+// DevMode is geth development mode configuration
+// development network is a throwaway single node chain with a pre-funded developer account
+// developer account is the node imported account if provided, otherwise geth generates a random one
+type DevMode struct {
+	// Period is block period in seconds, 0 seals blocks instantly on pending transactions
+	Period uint `json:"period,omitempty"`
+}
@@ -0,0 +1,22 @@
+package v1alpha1
+
+// Explorer is blockscout block explorer configuration
+type Explorer struct {
+	// Node is the name of network node with rpc enabled blockscout indexes the chain from
+	// node should be a full sync node with debug rpc api enabled to index internal transactions
+	Node string `json:"node"`
+
+	// Host is the explorer ingress host, ingress is created only if host is specified
+	Host string `json:"host,omitempty"`
+
+	// Coin is the network coin symbol displayed by the explorer
+	Coin string `json:"coin,omitempty"`
+
+	// DatabaseSecretName is name of the secret holding external postgres database url in database-url key
+	// operator provisions explorer postgres database if not provided
+	DatabaseSecretName string `json:"databaseSecretName,omitempty"`
+
+	// Storage is explorer postgres database storage size
+	// +kubebuilder:validation:Pattern="^[1-9][0-9]*[KMGTPE]i$"
+	Storage string `json:"storage,omitempty"`
+}
@@ -0,0 +1,20 @@
+package v1alpha1
+
+// Faucet is private network faucet configuration
+type Faucet struct {
+	// Node is the name of network node with rpc enabled used to send faucet transactions
+	Node string `json:"node"`
+
+	// PrivateKeySecretName is the name of the secret holding funding account private key
+	// private key is expected under privatekey key, funding account should be funded in genesis block
+	PrivateKeySecretName string `json:"privateKeySecretName"`
+
+	// Amount is the amount of ether sent per request
+	Amount uint `json:"amount,omitempty"`
+
+	// Interval is the number of minutes an address must wait between requests
+	Interval uint `json:"interval,omitempty"`
+
+	// Host is the faucet ingress host, ingress is created only if host is specified
+	Host string `json:"host,omitempty"`
+}
@@ -2,12 +2,19 @@ package v1alpha1
 
 // Genesis is genesis block sepcficition
 type Genesis struct {
+	// FromConfigMap loads genesis.json verbatim from existing config map in network namespace
+	FromConfigMap *GenesisConfigMapSource `json:"fromConfigMap,omitempty"`
+
+	// FromURL loads genesis.json verbatim from http(s) url
+	// +kubebuilder:validation:Pattern="^https?://"
+	FromURL string `json:"fromURL,omitempty"`
+
 	// Accounts is array of accounts to fund or associate with code and storage
 	Accounts []Account `json:"accounts,omitempty"`
 
 	// ChainID is the the chain ID used in transaction signature to prevent reply attack
 	// more details https://github.com/ethereum/EIPs/blob/master/EIPS/eip-155.md
-	ChainID uint `json:"chainId"`
+	ChainID uint `json:"chainId,omitempty"`
 
 	// Address to pay mining rewards to
 	Coinbase EthereumAddress `json:"coinbase,omitempty"`
@@ -40,6 +47,15 @@ type Genesis struct {
 	Timestamp HexString `json:"timestamp,omitempty"`
 }
 
+// GenesisConfigMapSource is config map holding externally maintained genesis.json
+type GenesisConfigMapSource struct {
+	// Name is config map name
+	Name string `json:"name"`
+
+	// Key is config map key holding genesis.json
+	Key string `json:"key,omitempty"`
+}
+
 // PoA is Shared PoA engine config
 type PoA struct {
 	// BlockPeriod is block time in seconds
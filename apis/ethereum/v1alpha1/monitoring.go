@@ -0,0 +1,9 @@
+package v1alpha1
+
+// Monitoring is network monitoring configuration
+type Monitoring struct {
+	// Dashboards is whether to generate grafana dashboards configmap or no
+	Dashboards bool `json:"dashboards,omitempty"`
+	// Alerts is whether to generate prometheus operator alerting rules or no
+	Alerts bool `json:"alerts,omitempty"`
+}
@@ -1,24 +1,7 @@
 package v1alpha1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-
-const (
-	// MainNetwork is ethereum main network
-	MainNetwork = "mainnet"
-	// RopstenNetwork is ropsten pow network
-	RopstenNetwork = "ropsten"
-	// RinkebyNetwork is rinkeby poa network
-	RinkebyNetwork = "rinkeby"
-	// GoerliNetwork is goerli poa cross-client network
-	GoerliNetwork = "goerli"
-	// KottiNetwork is kotti poa ethereum classic test network
-	KottiNetwork = "kotti"
-	// ClassicNetwork is ethereum classic network
-	ClassicNetwork = "classic"
-	// MordorNetwork is mordon poe ethereum classic test network
-	MordorNetwork = "mordor"
-	// DevNetwork is local development network
-	DevNetwork = "dev"
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // NetworkSpec defines the desired state of Network
@@ -35,6 +18,9 @@ type NetworkSpec struct {
 	// Genesis is genesis block specification
 	Genesis *Genesis `json:"genesis,omitempty"`
 
+	// NodeTemplate is node settings inherited by all network nodes
+	NodeTemplate *NodeTemplate `json:"nodeTemplate,omitempty"`
+
 	// Nodes is array of node specifications
 	// +kubebuilder:validation:MinItems=1
 	Nodes []Node `json:"nodes"`
@@ -44,6 +30,21 @@ type NetworkSpec struct {
 
 	// TopologyKey is the k8s node label used to distribute blockchain nodes
 	TopologyKey string `json:"TopologyKey,omitempty"`
+
+	// Monitoring is network monitoring configuration
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+
+	// Dev runs geth in development mode with instant sealing
+	Dev *DevMode `json:"dev,omitempty"`
+
+	// Faucet deploys a faucet funded from genesis account
+	Faucet *Faucet `json:"faucet,omitempty"`
+
+	// Explorer deploys blockscout block explorer indexing the network
+	Explorer *Explorer `json:"explorer,omitempty"`
+
+	// Ethstats deploys ethstats dashboard that network nodes report to
+	Ethstats *Ethstats `json:"ethstats,omitempty"`
 }
 
 // HexString is String in hexadecial format
@@ -66,34 +67,63 @@ type PrivateKey string
 // +kubebuilder:validation:Enum=poa;pow;ibft2;quorum
 type ConsensusAlgorithm string
 
-const (
-	// ProofOfAuthority is proof of authority consensus algorithm
-	ProofOfAuthority ConsensusAlgorithm = "poa"
-
-	// ProofOfWork is proof of work (nakamoto consensus) consensus algorithm
-	ProofOfWork ConsensusAlgorithm = "pow"
-
-	// IstanbulBFT is Istanbul Byzantine Fault Tolerant consensus algorithm
-	IstanbulBFT ConsensusAlgorithm = "ibft2"
-
-	//Quorum is Quorum IBFT consensus algorithm
-	Quorum ConsensusAlgorithm = "quorum"
-)
-
 // NetworkStatus defines the observed state of Network
 type NetworkStatus struct {
 
 	// NodesCount is number of nodes in this network
 	NodesCount int `json:"nodesCount,omitempty"`
+
+	// Bootnodes is enode URLs of network bootnodes
+	Bootnodes []string `json:"bootnodes,omitempty"`
+
+	// Nodes is the observed state of network nodes
+	Nodes []NodeStatus `json:"nodes,omitempty"`
+
+	// Conditions is network conditions
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// NodeStatus defines the observed state of a network node
+type NodeStatus struct {
+	// Name is the node name
+	Name string `json:"name"`
+
+	// Bootnode is whether node is bootnode or no
+	Bootnode bool `json:"bootnode,omitempty"`
+
+	// EnodeURL is the node enode URL, published for nodes reachable by other nodes
+	EnodeURL string `json:"enodeURL,omitempty"`
+
+	// CurrentBlock is the latest block synced by the node, reported for nodes with rpc enabled
+	CurrentBlock uint64 `json:"currentBlock,omitempty"`
+
+	// HighestBlock is the highest block known to the node, reported for nodes with rpc enabled
+	HighestBlock uint64 `json:"highestBlock,omitempty"`
+
+	// Syncing is whether node is syncing or no, reported for nodes with rpc enabled
+	Syncing bool `json:"syncing,omitempty"`
+
+	// Peers is the number of peers connected to the node, reported for nodes with rpc enabled
+	Peers uint64 `json:"peers,omitempty"`
+
+	// NoPeersSince is the time node has been without peers since
+	NoPeersSince *metav1.Time `json:"noPeersSince,omitempty"`
+
+	// Failure is why node pod is failing, like CrashLoopBackOff or ImagePullBackOff
+	Failure string `json:"failure,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=enet,categories=kotal;ethereum
 
 // Network is the Schema for the networks API
 // +kubebuilder:printcolumn:name="Consensus",type=string,JSONPath=".spec.consensus"
 // +kubebuilder:printcolumn:name="Join",type=string,JSONPath=".spec.join"
 // +kubebuilder:printcolumn:name="Nodes",type=integer,JSONPath=".status.nodesCount"
+// +kubebuilder:printcolumn:name="Bootnodes",type=string,JSONPath=".status.nodes[?(@.bootnode==true)].name",priority=1
+// +kubebuilder:printcolumn:name="Degraded",type=string,JSONPath=".status.conditions[?(@.type==\"Degraded\")].status"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
 type Network struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
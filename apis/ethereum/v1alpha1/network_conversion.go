@@ -0,0 +1,186 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// DroppedFieldsAnnotation is the annotation keeping hub (v1beta1) fields this version doesn't have
+// it's set converting from the hub, and dropped fields are restored converting back to the hub
+const DroppedFieldsAnnotation = "kotal.io/v1beta1-fields"
+
+// droppedField is hub field dropped converting to this version
+// path is object keys and array elements keys, array element key is its name or index if it has no name
+type droppedField struct {
+	Path  []string    `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// ConvertTo converts this network to the hub (v1beta1) version
+func (n *Network) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Network)
+	typeMeta := dst.TypeMeta
+
+	src := n.DeepCopy()
+	var fields []droppedField
+	if raw, ok := src.Annotations[DroppedFieldsAnnotation]; ok {
+		if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+			return err
+		}
+		delete(src.Annotations, DroppedFieldsAnnotation)
+		if len(src.Annotations) == 0 {
+			src.Annotations = nil
+		}
+	}
+
+	obj, err := toUnstructured(src)
+	if err != nil {
+		return err
+	}
+
+	// fields changed by clients of this version take precedence, only fields this version doesn't have are restored
+	for _, field := range fields {
+		restoreField(obj, field.Path, field.Value)
+	}
+
+	if err := convert(obj, dst); err != nil {
+		return err
+	}
+	dst.TypeMeta = typeMeta
+	return nil
+}
+
+// ConvertFrom converts from the hub (v1beta1) version to this version
+func (n *Network) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Network)
+	typeMeta := n.TypeMeta
+	if err := convert(src, n); err != nil {
+		return err
+	}
+	n.TypeMeta = typeMeta
+
+	hubObj, err := toUnstructured(src)
+	if err != nil {
+		return err
+	}
+
+	obj, err := toUnstructured(n)
+	if err != nil {
+		return err
+	}
+
+	fields := droppedFields(hubObj, obj, nil)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	// annotation doesn't change unless dropped fields change
+	sort.Slice(fields, func(i, j int) bool {
+		return strings.Join(fields[i].Path, "/") < strings.Join(fields[j].Path, "/")
+	})
+
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	if n.Annotations == nil {
+		n.Annotations = map[string]string{}
+	}
+	n.Annotations[DroppedFieldsAnnotation] = string(raw)
+
+	return nil
+}
+
+// convert converts between network versions
+// fields with the same json name are copied as is, hub fields missing from this version are dropped
+func convert(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// toUnstructured returns object json representation
+func toUnstructured(obj interface{}) (out map[string]interface{}, err error) {
+	err = convert(obj, &out)
+	return
+}
+
+// droppedFields returns fields of src missing from dst
+// objects are compared key by key, and arrays element by element
+func droppedFields(src, dst interface{}, path []string) (fields []droppedField) {
+	switch s := src.(type) {
+	case map[string]interface{}:
+		d, _ := dst.(map[string]interface{})
+		for key, value := range s {
+			fieldPath := append(append([]string{}, path...), key)
+			dstValue, ok := d[key]
+			if !ok {
+				fields = append(fields, droppedField{Path: fieldPath, Value: value})
+				continue
+			}
+			fields = append(fields, droppedFields(value, dstValue, fieldPath)...)
+		}
+	case []interface{}:
+		d, _ := dst.([]interface{})
+		for i, elem := range s {
+			key := elementKey(elem, i)
+			if dstElem, ok := findElement(d, key); ok {
+				fields = append(fields, droppedFields(elem, dstElem, append(append([]string{}, path...), key))...)
+			}
+		}
+	}
+
+	return
+}
+
+// restoreField sets dropped field value, unless its parent object or array element has been removed
+func restoreField(obj interface{}, path []string, value interface{}) {
+	if len(path) == 0 {
+		return
+	}
+
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			if _, ok := o[path[0]]; !ok {
+				o[path[0]] = value
+			}
+			return
+		}
+		if next, ok := o[path[0]]; ok {
+			restoreField(next, path[1:], value)
+		}
+	case []interface{}:
+		if next, ok := findElement(o, path[0]); ok {
+			restoreField(next, path[1:], value)
+		}
+	}
+}
+
+// elementKey returns array element key, it's element name or index if element has no name
+func elementKey(elem interface{}, index int) string {
+	if obj, ok := elem.(map[string]interface{}); ok {
+		if name, ok := obj["name"].(string); ok {
+			return name
+		}
+	}
+	return strconv.Itoa(index)
+}
+
+// findElement returns array element with the given key
+func findElement(array []interface{}, key string) (interface{}, bool) {
+	for i, elem := range array {
+		if elementKey(elem, i) == key {
+			return elem, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,158 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var network = &Network{
+	TypeMeta: metav1.TypeMeta{
+		APIVersion: "ethereum.kotal.io/v1alpha1",
+		Kind:       "Network",
+	},
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "test-network",
+		Namespace: "default",
+	},
+	Spec: NetworkSpec{
+		Join: "rinkeby",
+		Nodes: []Node{
+			{
+				Name:     "node-1",
+				Client:   "geth",
+				RPC:      true,
+				RPCPort:  8545,
+				RPCAPI:   []API{"eth", "web3"},
+				SyncMode: "fast",
+				Resources: &NodeResources{
+					CPU:    "2",
+					Memory: "4Gi",
+				},
+			},
+		},
+	},
+	Status: NetworkStatus{
+		NodesCount: 1,
+	},
+}
+
+func TestConvertTo(t *testing.T) {
+	hub := &v1beta1.Network{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "ethereum.kotal.io/v1beta1",
+			Kind:       "Network",
+		},
+	}
+
+	if err := network.ConvertTo(hub); err != nil {
+		t.Fatalf("Expecting no error converting to hub, got %s", err)
+	}
+
+	if hub.APIVersion != "ethereum.kotal.io/v1beta1" {
+		t.Errorf("Expecting api version to be preserved, got %s", hub.APIVersion)
+	}
+
+	if hub.Name != network.Name || hub.Namespace != network.Namespace {
+		t.Errorf("Expecting object meta to be converted, got %s/%s", hub.Namespace, hub.Name)
+	}
+
+	node := hub.Spec.Nodes[0]
+	if node.Name != "node-1" || node.Client != v1beta1.GethClient || node.RPCPort != 8545 {
+		t.Errorf("Expecting node to be converted, got %+v", node)
+	}
+
+	if hub.Status.NodesCount != 1 {
+		t.Errorf("Expecting status to be converted, got %+v", hub.Status)
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	hub := &v1beta1.Network{}
+	if err := network.ConvertTo(hub); err != nil {
+		t.Fatalf("Expecting no error converting to hub, got %s", err)
+	}
+
+	got := &Network{
+		TypeMeta: network.TypeMeta,
+	}
+	if err := got.ConvertFrom(hub); err != nil {
+		t.Fatalf("Expecting no error converting from hub, got %s", err)
+	}
+
+	if !reflect.DeepEqual(got, network) {
+		t.Errorf("Expecting %+v got %+v", network, got)
+	}
+}
+
+func TestConvertHubRoundTrip(t *testing.T) {
+	hub := &v1beta1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-network",
+			Namespace: "default",
+		},
+		Spec: v1beta1.NetworkSpec{
+			Join:       "rinkeby",
+			RPCService: true,
+			Nodes: []v1beta1.Node{
+				{
+					Name:    "node-1",
+					Client:  v1beta1.GethClient,
+					RPC:     true,
+					RPCPort: 8545,
+					ConsoleScripts: []v1beta1.ConsoleScript{
+						{Name: "gas-price", Script: "miner.setGasPrice(1)"},
+					},
+				},
+				{
+					Name:   "node-2",
+					Client: v1beta1.GethClient,
+					Role:   v1beta1.BootnodeRole,
+				},
+			},
+		},
+		Status: v1beta1.NetworkStatus{
+			Nodes: []v1beta1.NodeStatus{
+				{Name: "node-1", Paused: true},
+			},
+		},
+	}
+
+	spoke := &Network{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("Expecting no error converting from hub, got %s", err)
+	}
+
+	if _, ok := spoke.Annotations[DroppedFieldsAnnotation]; !ok {
+		t.Fatalf("Expecting hub only fields to be kept in annotation")
+	}
+
+	// v1alpha1 client updates a shared field
+	spoke.Spec.Nodes[0].RPCPort = 8546
+
+	got := &v1beta1.Network{}
+	if err := spoke.ConvertTo(got); err != nil {
+		t.Fatalf("Expecting no error converting to hub, got %s", err)
+	}
+
+	expected := hub.DeepCopy()
+	expected.Spec.Nodes[0].RPCPort = 8546
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expecting %+v got %+v", expected, got)
+	}
+
+	// hub only fields of removed nodes aren't restored
+	spoke.Spec.Nodes = spoke.Spec.Nodes[1:]
+
+	got = &v1beta1.Network{}
+	if err := spoke.ConvertTo(got); err != nil {
+		t.Fatalf("Expecting no error converting to hub, got %s", err)
+	}
+
+	if len(got.Spec.Nodes) != 1 || got.Spec.Nodes[0].Name != "node-2" || got.Spec.Nodes[0].Role != v1beta1.BootnodeRole {
+		t.Errorf("Expecting remaining node hub only fields to be restored got %+v", got.Spec.Nodes)
+	}
+}
@@ -1,8 +1,10 @@
 package v1alpha1
 
-import "fmt"
+import (
+	corev1 "k8s.io/api/core/v1"
+)
 
-//Node is the specification of the node
+// Node is the specification of the node
 type Node struct {
 	// Client is ethereum client running on the node
 	Client EthereumClient `json:"client,omitempty"`
@@ -16,21 +18,45 @@ type Node struct {
 	// Bootnode is whether node is bootnode or no
 	Bootnode bool `json:"bootnode,omitempty"`
 
+	// Role is node role, dedicated bootnodes only serve peers discovery
+	Role NodeRole `json:"role,omitempty"`
+
 	// Nodekey is the node private key
 	Nodekey PrivateKey `json:"nodekey,omitempty"`
 
 	// P2PPort is port used for peer to peer communication
 	P2PPort uint `json:"p2pPort,omitempty"`
 
+	// AdvertisedAddress is the external ip address advertised to peers instead of pod ip
+	// it's load balancer ip for nodes behind load balancers
+	AdvertisedAddress string `json:"advertisedAddress,omitempty"`
+
+	// IPFamily is node service ip family, cluster default is used if not provided
+	IPFamily IPFamily `json:"ipFamily,omitempty"`
+
+	// DNSName is node dns name published by external-dns and used instead of ip in node enode url
+	// +kubebuilder:validation:Pattern="^([a-z0-9]([-a-z0-9]*[a-z0-9])?\\.)+[a-z]{2,}$"
+	DNSName string `json:"dnsName,omitempty"`
+
 	// SyncMode is the node synchronization mode
 	SyncMode SynchronizationMode `json:"syncMode,omitempty"`
 
+	// LightServe is the maximum percentage of time allowed for serving light clients requests
+	LightServe uint `json:"lightServe,omitempty"`
+
+	// Cache is memory (in megabytes) allocated to client internal caching
+	// geth cache defaults to a quarter of memory limit, besu database cache defaults to client default
+	Cache uint `json:"cache,omitempty"`
+
 	// Miner is whether node is mining/validating blocks or no
 	Miner bool `json:"miner,omitempty"`
 
 	// Logging is logging verboisty level
 	Logging VerbosityLevel `json:"logging,omitempty"`
 
+	// LogFormat is logging format
+	LogFormat LogFormat `json:"logFormat,omitempty"`
+
 	// Coinbase is the account to which mining rewards are paid
 	Coinbase EthereumAddress `json:"coinbase,omitempty"`
 
@@ -73,52 +99,45 @@ type Node struct {
 	// GraphQLPort is the GraphQL server listening port
 	GraphQLPort uint `json:"graphqlPort,omitempty"`
 
+	// Metrics is whether node metrics exporter is enabled or not
+	Metrics bool `json:"metrics,omitempty"`
+
+	// MetricsHost is metrics exporter host address
+	MetricsHost string `json:"metricsHost,omitempty"`
+
+	// MetricsPort is metrics exporter listening port
+	MetricsPort uint `json:"metricsPort,omitempty"`
+
 	// Resources is node compute and storage resources
 	Resources *NodeResources `json:"resources,omitempty"`
-}
 
-// IsBootnode is whether node is bootnode or no
-func (n *Node) IsBootnode() bool {
-	return n.Bootnode
-}
+	// Service is node service exposure configuration
+	Service *NodeService `json:"service,omitempty"`
 
-// WithNodekey is whether node is configured with private key
-func (n *Node) WithNodekey() bool {
-	return n.Nodekey != ""
-}
+	// ExtraArgs is extra client arguments appended to the generated arguments
+	ExtraArgs []string `json:"extraArgs,omitempty"`
 
-// DeploymentName returns name to be used by node deployment
-func (n *Node) DeploymentName(network string) string {
-	return fmt.Sprintf("%s-%s", network, n.Name)
+	// Env is extra environment variables set in the client container
+	Env []corev1.EnvVar `json:"env,omitempty"`
 }
 
-// ConfigmapName returns name to be used by genesis and scripts configmap
-func (n *Node) ConfigmapName(network string, client EthereumClient) string {
-	return fmt.Sprintf("%s-%s", network, client)
-}
+// NodeTemplate is network wide node settings inherited by every node
+// nodes can override template settings field by field
+type NodeTemplate struct {
+	// Client is ethereum client running on the nodes
+	Client EthereumClient `json:"client,omitempty"`
 
-// PVCName returns name to be used by node pvc
-func (n *Node) PVCName(network string) string {
-	return n.DeploymentName(network) // same as deployment name
-}
+	// SyncMode is the nodes synchronization mode
+	SyncMode SynchronizationMode `json:"syncMode,omitempty"`
 
-// SecretName returns name to be used by node secret
-func (n *Node) SecretName(network string) string {
-	return n.DeploymentName(network) // same as deployment name
-}
+	// Logging is logging verboisty level
+	Logging VerbosityLevel `json:"logging,omitempty"`
 
-// ServiceName returns name to be used by node service
-func (n *Node) ServiceName(network string) string {
-	return n.DeploymentName(network) // same as deployment name
-}
+	// LogFormat is logging format
+	LogFormat LogFormat `json:"logFormat,omitempty"`
 
-// Labels to be used by node resources
-func (n *Node) Labels(network string) map[string]string {
-	return map[string]string{
-		"name":     "node",
-		"instance": n.Name,
-		"network":  network,
-	}
+	// Resources is nodes compute and storage resources
+	Resources *NodeResources `json:"resources,omitempty"`
 }
 
 // NodeResources is node compute and storage resources
@@ -142,100 +161,48 @@ type NodeResources struct {
 	StorageClass *string `json:"storageClass,omitempty"`
 }
 
-// SynchronizationMode is the node synchronization mode
-// +kubebuilder:validation:Enum=fast;full;light
-type SynchronizationMode string
+// NodeRole is node role
+// +kubebuilder:validation:Enum=bootnode
+type NodeRole string
+
+// NodeService is node service exposure configuration
+type NodeService struct {
+	// Type is node service type
+	Type ServiceType `json:"type,omitempty"`
+	// LoadBalancerIP is static ip requested from cloud provider load balancer
+	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+	// LoadBalancerSourceRanges is client ip ranges allowed to access load balancer
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+}
 
-const (
-	//FastSynchronization is the fast synchronization mode
-	FastSynchronization SynchronizationMode = "fast"
+// ServiceType is node service type
+// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+type ServiceType string
 
-	//LightSynchronization is the light synchronization mode
-	LightSynchronization SynchronizationMode = "light"
+// IPFamily is node service ip family
+// +kubebuilder:validation:Enum=IPv4;IPv6
+type IPFamily string
 
-	//FullSynchronization is the fast (non-archival) synchronization mode
-	FullSynchronization SynchronizationMode = "full"
-)
+// SynchronizationMode is the node synchronization mode
+// +kubebuilder:validation:Enum=fast;full;light
+type SynchronizationMode string
 
 // VerbosityLevel is logging verbosity levels
 // +kubebuilder:validation:Enum=off;fatal;error;warn;info;debug;trace;all
 type VerbosityLevel string
 
-const (
-	// NoLogs outputs no logs
-	NoLogs VerbosityLevel = "off"
-	// FatalLogs outputs only fatal logs
-	FatalLogs VerbosityLevel = "fatal"
-	// ErrorLogs outputs only error logs
-	ErrorLogs VerbosityLevel = "error"
-	// WarnLogs outputs only warning logs
-	WarnLogs VerbosityLevel = "warn"
-	// InfoLogs outputs only informational logs
-	InfoLogs VerbosityLevel = "info"
-	// DebugLogs outputs only debugging logs
-	DebugLogs VerbosityLevel = "debug"
-	// TraceLogs outputs only tracing logs
-	TraceLogs VerbosityLevel = "trace"
-	// AllLogs outputs only all logs
-	AllLogs VerbosityLevel = "all"
-)
+// LogFormat is logging format
+// +kubebuilder:validation:Enum=plain;json
+type LogFormat string
 
 // API is RPC API to be exposed by RPC or web socket server
 // +kubebuilder:validation:Enum=admin;clique;debug;eea;eth;ibft;miner;net;perm;plugins;priv;txpool;web3
 type API string
 
-const (
-	// AdminAPI is administration API
-	AdminAPI API = "admin"
-
-	// CliqueAPI is clique (Proof of Authority consensus) API
-	CliqueAPI API = "clique"
-
-	// DebugAPI is debugging API
-	DebugAPI API = "debug"
-
-	// EEAAPI is EEA (Enterprise Ethereum Alliance) API
-	EEAAPI API = "eea"
-
-	// ETHAPI is ethereum API
-	ETHAPI API = "eth"
-
-	// IBFTAPI is IBFT consensus API
-	IBFTAPI API = "ibft"
-
-	// MinerAPI is miner API
-	MinerAPI API = "miner"
-
-	// NetworkAPI is network API
-	NetworkAPI API = "net"
-
-	// PermissionAPI is permission API
-	PermissionAPI API = "perm"
-
-	// PluginsAPI is plugins API
-	PluginsAPI API = "plugins"
-
-	// PrivacyAPI is privacy API
-	PrivacyAPI API = "privacy"
-
-	// TransactionPoolAPI is transaction pool API
-	TransactionPoolAPI API = "txpool"
-
-	// Web3API is web3 API
-	Web3API API = "web3"
-)
-
 // EthereumClient is the ethereum client running on a given node
 // +kubebuilder:validation:Enum=besu;geth
 type EthereumClient string
 
-const (
-	// BesuClient is hyperledger besu ethereum client
-	BesuClient EthereumClient = "besu"
-	// GethClient is go ethereum client
-	GethClient EthereumClient = "geth"
-)
-
 // ImportedAccount is account derived from private key
 type ImportedAccount struct {
 	// Privatekey is the account private key
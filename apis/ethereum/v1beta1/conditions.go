@@ -0,0 +1,80 @@
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionType is network condition type
+type ConditionType string
+
+const (
+	// DegradedCondition is whether network is degraded or no
+	DegradedCondition ConditionType = "Degraded"
+	// NodesFailingCondition is whether any of network nodes pods is crashlooping or failing to pull image
+	NodesFailingCondition ConditionType = "NodesFailing"
+	// BootnodesMissingCondition is whether private network nodes have no bootnodes to discover each other
+	BootnodesMissingCondition ConditionType = "BootnodesMissing"
+	// IntegrityCheckFailedCondition is whether any of network nodes failed its last data integrity check
+	IntegrityCheckFailedCondition ConditionType = "IntegrityCheckFailed"
+)
+
+// Condition is network condition
+type Condition struct {
+	// Type is condition type
+	Type ConditionType `json:"type"`
+	// Status is condition status, one of True, False or Unknown
+	Status corev1.ConditionStatus `json:"status"`
+	// LastTransitionTime is the last time condition status changed
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is one word reason for condition last transition
+	Reason string `json:"reason,omitempty"`
+	// Message is human readable message about condition last transition
+	Message string `json:"message,omitempty"`
+}
+
+// GetCondition returns network condition by type or nil if it doesn't exist
+func (s *NetworkStatus) GetCondition(conditionType ConditionType) *Condition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == conditionType {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds or updates network condition
+// last transition time is updated only if condition status changed
+func (s *NetworkStatus) SetCondition(conditionType ConditionType, status corev1.ConditionStatus, reason, message string) {
+	condition := s.GetCondition(conditionType)
+
+	if condition == nil {
+		s.Conditions = append(s.Conditions, Condition{
+			Type:               conditionType,
+			Status:             status,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reason,
+			Message:            message,
+		})
+		return
+	}
+
+	if condition.Status != status {
+		condition.Status = status
+		condition.LastTransitionTime = metav1.Now()
+	}
+
+	condition.Reason = reason
+	condition.Message = message
+}
+
+// RemoveCondition removes network condition by type if it exists
+func (s *NetworkStatus) RemoveCondition(conditionType ConditionType) {
+	conditions := []Condition{}
+	for _, condition := range s.Conditions {
+		if condition.Type != conditionType {
+			conditions = append(conditions, condition)
+		}
+	}
+	s.Conditions = conditions
+}
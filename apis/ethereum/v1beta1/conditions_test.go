@@ -0,0 +1,33 @@
+package v1beta1
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSetCondition(t *testing.T) {
+	status := &NetworkStatus{}
+
+	status.SetCondition(DegradedCondition, corev1.ConditionFalse, "PeersConnected", "all nodes have peers")
+	condition := status.GetCondition(DegradedCondition)
+	if condition == nil {
+		t.Fatalf("Expecting %s condition to be set", DegradedCondition)
+	}
+	transitionTime := condition.LastTransitionTime
+
+	// same status doesn't update transition time
+	status.SetCondition(DegradedCondition, corev1.ConditionFalse, "PeersConnected", "all nodes have peers")
+	if len(status.Conditions) != 1 {
+		t.Errorf("Expecting conditions count to be 1 got %d", len(status.Conditions))
+	}
+	if !status.GetCondition(DegradedCondition).LastTransitionTime.Equal(&transitionTime) {
+		t.Errorf("Expecting last transition time not to change if status didn't change")
+	}
+
+	status.SetCondition(DegradedCondition, corev1.ConditionTrue, "NoPeers", "nodes without peers: node-1")
+	condition = status.GetCondition(DegradedCondition)
+	if condition.Status != corev1.ConditionTrue || condition.Reason != "NoPeers" {
+		t.Errorf("Expecting condition to be updated got status %s and reason %s", condition.Status, condition.Reason)
+	}
+}
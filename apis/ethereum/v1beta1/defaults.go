@@ -1,4 +1,4 @@
-package v1alpha1
+package v1beta1
 
 var (
 	// DefaultAPIs is the default rpc, ws APIs
@@ -16,10 +16,14 @@ const (
 const (
 	// DefaultLogging is the default logging verbosity level
 	DefaultLogging = InfoLogs
+	// DefaultLogFormat is the default logging format
+	DefaultLogFormat = PlainLogFormat
 	// DefaultClient is the default ethereum client
 	DefaultClient = BesuClient
 	// DefaultHost is the default host
 	DefaultHost = "0.0.0.0"
+	// DefaultIPv6Host is the default host of IPv6 nodes
+	DefaultIPv6Host = "::"
 	// DefaultP2PPort is the default p2p port
 	DefaultP2PPort uint = 30303
 	// DefaultPublicNetworkSyncMode is the default sync mode for public networks
@@ -32,6 +36,36 @@ const (
 	DefaultWSPort uint = 8546
 	// DefaultGraphQLPort is the default graphQL port
 	DefaultGraphQLPort uint = 8547
+	// DefaultMetricsPort is the default metrics port
+	DefaultMetricsPort uint = 9545
+	// DefaultRPCProxyPort is the default rpc proxy port
+	DefaultRPCProxyPort uint = 8555
+	// DefaultMetricsExporterPort is the default chain metrics exporter port
+	DefaultMetricsExporterPort uint = 9090
+	// DefaultRPCProxyMaxBatchSize is the default maximum number of requests in a json-rpc batch
+	DefaultRPCProxyMaxBatchSize = 100
+	// DefaultInitScriptKey is the default config map key holding node init script
+	DefaultInitScriptKey = "init.sh"
+	// DefaultKeystoreSecretKey is the default secret key holding account keystore
+	DefaultKeystoreSecretKey = "keystore"
+	// DefaultKeystorePasswordSecretKey is the default secret key holding account keystore password
+	DefaultKeystorePasswordSecretKey = "password"
+	// DefaultSnapshotRetention is the default number of node data snapshots kept
+	DefaultSnapshotRetention = 7
+	// DefaultAutoExpandThreshold is the default used disk space percentage node data pvc is expanded at
+	DefaultAutoExpandThreshold = 80
+	// DefaultAutoExpandIncrement is the default storage added to node data pvc on every expansion
+	DefaultAutoExpandIncrement = "100Gi"
+	// DefaultBackupPort is the default backup status server port
+	DefaultBackupPort uint = 8556
+	// DefaultBackupFullEvery is the default number of backups between full backups
+	DefaultBackupFullEvery = 7
+	// DefaultS3Region is the default amazon s3 region
+	DefaultS3Region = "us-east-1"
+	// DefaultGCSEndpoint is google cloud storage s3 compatible xml api endpoint
+	DefaultGCSEndpoint = "https://storage.googleapis.com"
+	// DefaultGCSRegion is the region used to sign google cloud storage requests
+	DefaultGCSRegion = "auto"
 )
 
 // Genesis block defaults
@@ -50,6 +84,32 @@ const (
 	DefaultTimestamp = HexString("0x0")
 	// DefaultEIP150Hash is the default eip150 hash
 	DefaultEIP150Hash = Hash("0x2086799aeebeae135c246c65021c82b4e15a2c451340993aacfd2751886514f0")
+	// DefaultGenesisConfigMapKey is the default config map key holding external genesis
+	DefaultGenesisConfigMapKey = "genesis.json"
+	// DefaultNodesIngressContractAddress is the default besu nodes ingress contract address
+	DefaultNodesIngressContractAddress = EthereumAddress("0x0000000000000000000000000000000000009999")
+	// DefaultAccountsIngressContractAddress is the default besu accounts ingress contract address
+	DefaultAccountsIngressContractAddress = EthereumAddress("0x0000000000000000000000000000000000008888")
+	// DefaultMnemonicSecretKey is the default secret key holding mnemonic of funded accounts
+	DefaultMnemonicSecretKey = "mnemonic"
+	// DefaultMnemonicAccountsCount is the default number of accounts funded from mnemonic
+	DefaultMnemonicAccountsCount uint = 10
+)
+
+// Faucet defaults
+const (
+	// DefaultFaucetAmount is the default amount of ether sent per faucet request
+	DefaultFaucetAmount uint = 1
+	// DefaultFaucetInterval is the default number of minutes between faucet requests
+	DefaultFaucetInterval uint = 1440
+)
+
+// Explorer defaults
+const (
+	// DefaultExplorerCoin is the default coin symbol displayed by the explorer
+	DefaultExplorerCoin = "ETH"
+	// DefaultExplorerStorage is the default explorer database storage size
+	DefaultExplorerStorage = "10Gi"
 )
 
 // Ethash engine defaults
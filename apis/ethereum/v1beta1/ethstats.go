@@ -0,0 +1,8 @@
+package v1beta1
+
+// Ethstats is ethstats network dashboard configuration
+// geth nodes report to the dashboard, besu nodes don't support ethstats reporting yet
+type Ethstats struct {
+	// Host is the ethstats dashboard ingress host, ingress is created only if host is specified
+	Host string `json:"host,omitempty"`
+}
@@ -0,0 +1,479 @@
+package v1beta1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// MainNetwork is ethereum main network
+	MainNetwork = "mainnet"
+	// RopstenNetwork is ropsten pow network
+	RopstenNetwork = "ropsten"
+	// RinkebyNetwork is rinkeby poa network
+	RinkebyNetwork = "rinkeby"
+	// GoerliNetwork is goerli poa cross-client network
+	GoerliNetwork = "goerli"
+	// KottiNetwork is kotti poa ethereum classic test network
+	KottiNetwork = "kotti"
+	// ClassicNetwork is ethereum classic network
+	ClassicNetwork = "classic"
+	// MordorNetwork is mordon poe ethereum classic test network
+	MordorNetwork = "mordor"
+	// DevNetwork is local development network
+	DevNetwork = "dev"
+)
+
+// ProtectedAnnotation is the network annotation protecting network deletion and its critical nodes removal
+// protection is overridden by removing the annotation or setting it to any value other than "true"
+const ProtectedAnnotation = "kotal.io/protected"
+
+// NetworkSpec defines the desired state of Network
+type NetworkSpec struct {
+	// ID is network id
+	ID uint `json:"id,omitempty"`
+
+	// Join specifies the network to join
+	Join string `json:"join,omitempty"`
+
+	// Consensus is the consensus algorithm to be used by the network nodes to reach consensus
+	Consensus ConsensusAlgorithm `json:"consensus,omitempty"`
+
+	// Genesis is genesis block specification
+	Genesis *Genesis `json:"genesis,omitempty"`
+
+	// NodeTemplate is node settings inherited by all network nodes
+	NodeTemplate *NodeTemplate `json:"nodeTemplate,omitempty"`
+
+	// Nodes is array of node specifications
+	// nodes created from node pools are added by the operator
+	// +kubebuilder:validation:MinItems=1
+	Nodes []Node `json:"nodes,omitempty"`
+
+	// NodePools is array of identical nodes groups
+	NodePools []NodePool `json:"nodePools,omitempty"`
+
+	// Replicas is the number of nodes of the first node pool
+	// it's used by scale subresource, and takes precedence over first node pool replicas
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// JoinFrom joins private network exported by another network, possibly running in another cluster
+	// network id, consensus and genesis are imported, and exported bootnodes are used by network nodes
+	JoinFrom *JoinFrom `json:"joinFrom,omitempty"`
+
+	// From clones an existing network in network namespace, like a staging copy of a consortium network
+	// consensus, genesis and funded accounts are copied under a different chain id
+	From *NetworkSource `json:"from,omitempty"`
+
+	// Bootnodes is enode URLs of bootnodes running outside the cluster
+	// they're used by network nodes in addition to in-cluster bootnodes
+	Bootnodes []Enode `json:"bootnodes,omitempty"`
+
+	// HighlyAvailable is whether blockchain nodes can land on the same k8s node or no
+	HighlyAvailable bool `json:"highlyAvailable,omitempty"`
+
+	// TopologyKey is the k8s node label used to distribute blockchain nodes
+	TopologyKey string `json:"TopologyKey,omitempty"`
+
+	// Monitoring is network monitoring configuration
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+
+	// Dev runs geth in development mode with instant sealing
+	Dev *DevMode `json:"dev,omitempty"`
+
+	// Faucet deploys a faucet funded from genesis account
+	Faucet *Faucet `json:"faucet,omitempty"`
+
+	// Explorer deploys blockscout block explorer indexing the network
+	Explorer *Explorer `json:"explorer,omitempty"`
+
+	// Ethstats deploys ethstats dashboard that network nodes report to
+	Ethstats *Ethstats `json:"ethstats,omitempty"`
+
+	// IngressClass is the ingress class of network ingresses
+	// it's used to generate web socket timeouts and session affinity annotations of the ingress controller
+	IngressClass IngressClass `json:"ingressClass,omitempty"`
+
+	// RPCService is whether network rpc service is created, it balances rpc requests across network nodes with rpc enabled
+	// miners and nodes serving sensitive apis aren't selected, and nodes are selected only once they're synced
+	RPCService bool `json:"rpcService,omitempty"`
+
+	// TTLSecondsAfterCreation is how long network lives before it's deleted, network is kept if not provided
+	// +kubebuilder:validation:Minimum=0
+	TTLSecondsAfterCreation *int32 `json:"ttlSecondsAfterCreation,omitempty"`
+}
+
+// JoinFrom is the source of exported private network bootstrap information
+type JoinFrom struct {
+	// ConfigMapName is the name of config map in network namespace holding exported network
+	// it's a copy of the export config map maintained for the exporting network
+	ConfigMapName string `json:"configMapName"`
+}
+
+// NetworkSource is the source of a cloned network
+type NetworkSource struct {
+	// Network is the name of the cloned network in network namespace
+	Network string `json:"network"`
+
+	// ChainID is the clone genesis chain id, it must be different from the cloned network chain id
+	ChainID uint `json:"chainId"`
+
+	// Snapshots is whether nodes data is restored from the latest volume snapshot of the cloned network node with the same name
+	// it's ignored by nodes restoring their data from another volume snapshot
+	Snapshots bool `json:"snapshots,omitempty"`
+}
+
+// HexString is String in hexadecial format
+// +kubebuilder:validation:Pattern="^0[xX][0-9a-fA-F]+$"
+type HexString string
+
+// EthereumAddress is ethereum address
+// +kubebuilder:validation:Pattern="^0[xX][0-9a-fA-F]{40}$"
+type EthereumAddress string
+
+// Hash is KECCAK-256 hash
+// +kubebuilder:validation:Pattern="^0[xX][0-9a-fA-F]{64}$"
+type Hash string
+
+// Enode is ethereum node url
+// +kubebuilder:validation:Pattern="^enode://[0-9a-fA-F]{128}@(\\[[0-9a-fA-F:]+\\]|[^:\\[\\]]+):[0-9]+(\\?discport=[0-9]+)?$"
+type Enode string
+
+// PrivateKey is a private key
+// +kubebuilder:validation:Pattern="^0[xX][0-9a-fA-F]{64}$"
+type PrivateKey string
+
+// ConsensusAlgorithm is the algorithm nodes use to reach consensus
+// +kubebuilder:validation:Enum=poa;pow;ibft2;quorum
+type ConsensusAlgorithm string
+
+const (
+	// ProofOfAuthority is proof of authority consensus algorithm
+	ProofOfAuthority ConsensusAlgorithm = "poa"
+
+	// ProofOfWork is proof of work (nakamoto consensus) consensus algorithm
+	ProofOfWork ConsensusAlgorithm = "pow"
+
+	// IstanbulBFT is Istanbul Byzantine Fault Tolerant consensus algorithm
+	IstanbulBFT ConsensusAlgorithm = "ibft2"
+
+	//Quorum is Quorum IBFT consensus algorithm
+	Quorum ConsensusAlgorithm = "quorum"
+)
+
+// IngressClass is ingress controller class
+// +kubebuilder:validation:Enum=nginx;traefik;haproxy
+type IngressClass string
+
+const (
+	// NginxIngressClass is kubernetes nginx ingress controller class
+	NginxIngressClass IngressClass = "nginx"
+	// TraefikIngressClass is traefik ingress controller class
+	TraefikIngressClass IngressClass = "traefik"
+	// HAProxyIngressClass is haproxy ingress controller class
+	HAProxyIngressClass IngressClass = "haproxy"
+)
+
+// NetworkStatus defines the observed state of Network
+type NetworkStatus struct {
+
+	// NodesCount is number of nodes in this network
+	NodesCount int `json:"nodesCount,omitempty"`
+
+	// ReadyNodes is number of nodes with ready pods
+	ReadyNodes int `json:"readyNodes,omitempty"`
+
+	// Clients is network nodes clients and their nodes count, like besu:2,geth:1
+	Clients string `json:"clients,omitempty"`
+
+	// Replicas is number of nodes of the first node pool, it's used by scale subresource
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Selector is label selector of the first node pool nodes pods, it's used by scale subresource
+	Selector string `json:"selector,omitempty"`
+
+	// Bootnodes is enode URLs of network bootnodes
+	Bootnodes []string `json:"bootnodes,omitempty"`
+
+	// Nodes is the observed state of network nodes
+	Nodes []NodeStatus `json:"nodes,omitempty"`
+
+	// Conditions is network conditions
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the most recent network generation recorded in history
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// SpecHashes is hashes of observed spec fields, used to summarize changed fields
+	SpecHashes map[string]string `json:"specHashes,omitempty"`
+
+	// History is the most recent spec changes, newest first
+	History []SpecChange `json:"history,omitempty"`
+}
+
+// SpecChange is observed spec change
+type SpecChange struct {
+	// Generation is the observed generation
+	Generation int64 `json:"generation"`
+
+	// Time is when the change was observed
+	Time metav1.Time `json:"time"`
+
+	// Manager is the field manager that changed the spec, like kubectl
+	Manager string `json:"manager,omitempty"`
+
+	// Changes is summary of changed spec fields
+	Changes []string `json:"changes,omitempty"`
+}
+
+// NodeStatus defines the observed state of a network node
+type NodeStatus struct {
+	// Name is the node name
+	Name string `json:"name"`
+
+	// Bootnode is whether node is bootnode or no
+	Bootnode bool `json:"bootnode,omitempty"`
+
+	// Ready is whether node pod is ready or no
+	Ready bool `json:"ready,omitempty"`
+
+	// EnodeURL is the node enode URL, published for nodes reachable by other nodes
+	EnodeURL string `json:"enodeURL,omitempty"`
+
+	// CurrentBlock is the latest block synced by the node, reported for nodes with rpc enabled
+	CurrentBlock uint64 `json:"currentBlock,omitempty"`
+
+	// HighestBlock is the highest block known to the node, reported for nodes with rpc enabled
+	HighestBlock uint64 `json:"highestBlock,omitempty"`
+
+	// Syncing is whether node is syncing or no, reported for nodes with rpc enabled
+	Syncing bool `json:"syncing,omitempty"`
+
+	// Peers is the number of peers connected to the node, reported for nodes with rpc enabled
+	Peers uint64 `json:"peers,omitempty"`
+
+	// NoPeersSince is the time node has been without peers since
+	NoPeersSince *metav1.Time `json:"noPeersSince,omitempty"`
+
+	// Failure is why node pod is failing, like CrashLoopBackOff or ImagePullBackOff
+	Failure string `json:"failure,omitempty"`
+
+	// Backup is node data last backup result, reported for nodes with backup
+	Backup *BackupStatus `json:"backup,omitempty"`
+
+	// Paused is whether node is stopped by a running node task
+	Paused bool `json:"paused,omitempty"`
+
+	// IntegrityCheck is node data integrity check state, reported for nodes with integrity check
+	IntegrityCheck *IntegrityCheckStatus `json:"integrityCheck,omitempty"`
+
+	// ConsoleScripts is node console scripts results, reported for completed scripts
+	ConsoleScripts []ConsoleScriptStatus `json:"consoleScripts,omitempty"`
+}
+
+// ConsoleScriptStatus is node console script result
+type ConsoleScriptStatus struct {
+	// Name is console script name
+	Name string `json:"name"`
+	// Checksum is the checksum of the run script
+	Checksum string `json:"checksum"`
+	// Time is when script completed
+	Time metav1.Time `json:"time"`
+	// Succeeded is whether script succeeded
+	Succeeded bool `json:"succeeded,omitempty"`
+	// Output is script console output, truncated to 4KiB
+	Output string `json:"output,omitempty"`
+}
+
+// IntegrityCheckStatus is node data integrity check state
+type IntegrityCheckStatus struct {
+	// Pending is whether check is due or running, node is stopped until check completes
+	Pending bool `json:"pending,omitempty"`
+	// Requested is the last handled on demand check request
+	Requested string `json:"requested,omitempty"`
+	// Time is when last check completed
+	Time *metav1.Time `json:"time,omitempty"`
+	// Passed is whether last check passed
+	Passed bool `json:"passed,omitempty"`
+	// Message is why last check failed, or why node data can't be checked
+	Message string `json:"message,omitempty"`
+}
+
+// BackupStatus is node data backup result
+type BackupStatus struct {
+	// Time is when backup started
+	Time metav1.Time `json:"time"`
+	// Object is uploaded backup object key
+	Object string `json:"object,omitempty"`
+	// Full is whether all files are archived, or only files changed since previous backup
+	Full bool `json:"full,omitempty"`
+	// Files is the number of archived files
+	Files int `json:"files,omitempty"`
+	// Error is why backup failed
+	Error string `json:"error,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=enet,categories=kotal;ethereum
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+// +kubebuilder:storageversion
+
+// Network is the Schema for the networks API
+// +kubebuilder:printcolumn:name="Consensus",type=string,JSONPath=".spec.consensus"
+// +kubebuilder:printcolumn:name="Join",type=string,JSONPath=".spec.join"
+// +kubebuilder:printcolumn:name="Clients",type=string,JSONPath=".status.clients"
+// +kubebuilder:printcolumn:name="Nodes",type=integer,JSONPath=".status.nodesCount"
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=".status.readyNodes"
+// +kubebuilder:printcolumn:name="Bootnodes",type=string,JSONPath=".status.nodes[?(@.bootnode==true)].name",priority=1
+// +kubebuilder:printcolumn:name="Degraded",type=string,JSONPath=".status.conditions[?(@.type==\"Degraded\")].status"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+type Network struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NetworkSpec   `json:"spec,omitempty"`
+	Status NetworkStatus `json:"status,omitempty"`
+}
+
+// ConfigmapName returns name to be used by client genesis and scripts configmap
+// configmap is shared by all network nodes running the same client
+func (n *Network) ConfigmapName(client EthereumClient) string {
+	return fmt.Sprintf("%s-%s", n.Name, client)
+}
+
+// ConfigmapLabels returns labels to be used by client genesis and scripts configmap
+func (n *Network) ConfigmapLabels(client EthereumClient) map[string]string {
+	return map[string]string{
+		"name":    "genesis",
+		"network": n.Name,
+		"client":  string(client),
+	}
+}
+
+// ExportName returns name to be used by config map exporting private network bootstrap information
+func (n *Network) ExportName() string {
+	return fmt.Sprintf("%s-export", n.Name)
+}
+
+// ExportLabels returns labels to be used by network export config map
+func (n *Network) ExportLabels() map[string]string {
+	return map[string]string{
+		"name":    "export",
+		"network": n.Name,
+	}
+}
+
+// BootstrapName returns name to be used by config map holding files external nodes need to join private network
+func (n *Network) BootstrapName() string {
+	return fmt.Sprintf("%s-bootstrap", n.Name)
+}
+
+// BootstrapLabels returns labels to be used by network bootstrap config map
+func (n *Network) BootstrapLabels() map[string]string {
+	return map[string]string{
+		"name":    "bootstrap",
+		"network": n.Name,
+	}
+}
+
+// PoolLabels returns labels of node pool nodes pods
+func (n *Network) PoolLabels(pool string) map[string]string {
+	return map[string]string{
+		"name":    "node",
+		"network": n.Name,
+		"pool":    pool,
+	}
+}
+
+// IsPrivate returns whether network is a private network
+// private networks are created from genesis, imported or cloned from other networks or run in development mode
+func (n *Network) IsPrivate() bool {
+	return n.Spec.Genesis != nil || n.Spec.JoinFrom != nil || n.Spec.From != nil || n.Spec.Dev != nil
+}
+
+// DashboardsConfigmapName returns name to be used by grafana dashboards configmap
+func (n *Network) DashboardsConfigmapName() string {
+	return fmt.Sprintf("%s-dashboards", n.Name)
+}
+
+// AlertsName returns name to be used by prometheus alerting rules
+func (n *Network) AlertsName() string {
+	return fmt.Sprintf("%s-alerts", n.Name)
+}
+
+// FaucetName returns name to be used by faucet deployment, service and ingress
+func (n *Network) FaucetName() string {
+	return fmt.Sprintf("%s-faucet", n.Name)
+}
+
+// ExplorerName returns name to be used by blockscout explorer resources
+func (n *Network) ExplorerName() string {
+	return fmt.Sprintf("%s-explorer", n.Name)
+}
+
+// ExplorerDatabaseName returns name to be used by explorer postgres database resources
+func (n *Network) ExplorerDatabaseName() string {
+	return fmt.Sprintf("%s-explorer-db", n.Name)
+}
+
+// ExplorerLabels returns labels to be used by explorer resources
+func (n *Network) ExplorerLabels() map[string]string {
+	return map[string]string{
+		"name":    "explorer",
+		"network": n.Name,
+	}
+}
+
+// ExplorerDatabaseLabels returns labels to be used by explorer postgres database resources
+func (n *Network) ExplorerDatabaseLabels() map[string]string {
+	return map[string]string{
+		"name":    "explorer-db",
+		"network": n.Name,
+	}
+}
+
+// IsProtected returns whether network deletion and its critical nodes removal are rejected
+func (n *Network) IsProtected() bool {
+	return n.Annotations[ProtectedAnnotation] == "true"
+}
+
+// RPCServiceName returns name to be used by network rpc service
+func (n *Network) RPCServiceName() string {
+	return fmt.Sprintf("%s-rpc", n.Name)
+}
+
+// EthstatsName returns name to be used by ethstats server resources
+func (n *Network) EthstatsName() string {
+	return fmt.Sprintf("%s-ethstats", n.Name)
+}
+
+// EthstatsLabels returns labels to be used by ethstats server resources
+func (n *Network) EthstatsLabels() map[string]string {
+	return map[string]string{
+		"name":    "ethstats",
+		"network": n.Name,
+	}
+}
+
+// FaucetLabels returns labels to be used by faucet resources
+func (n *Network) FaucetLabels() map[string]string {
+	return map[string]string{
+		"name":    "faucet",
+		"network": n.Name,
+	}
+}
+
+// +kubebuilder:object:root=true
+
+// NetworkList contains a list of Network
+type NetworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Network `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Network{}, &NetworkList{})
+}
@@ -0,0 +1,4 @@
+package v1beta1
+
+// Hub marks v1beta1 network as the conversion hub
+func (n *Network) Hub() {}
@@ -0,0 +1,559 @@
+package v1beta1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/helpers"
+)
+
+// +kubebuilder:webhook:path=/mutate-ethereum-kotal-io-v1beta1-network,mutating=true,failurePolicy=fail,groups=ethereum.kotal.io,resources=networks,verbs=create;update,versions=v1beta1,name=mnetwork.kb.io
+
+var _ webhook.Defaulter = &Network{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *Network) Default() {
+	networklog.Info("default", "name", r.Name)
+
+	if r.Spec.HighlyAvailable {
+		if r.Spec.TopologyKey == "" {
+			r.Spec.TopologyKey = DefaultTopologyKey
+		}
+	}
+
+	// default genesis block
+	if r.Spec.Genesis != nil {
+		r.DefaultGenesis()
+	}
+
+	// default faucet
+	if r.Spec.Faucet != nil {
+		r.DefaultFaucet()
+	}
+
+	// default explorer
+	if r.Spec.Explorer != nil {
+		r.DefaultExplorer()
+	}
+
+	// create node pools nodes before defaulting network nodes
+	r.DefaultNodePools()
+
+	// default network nodes
+	// nodes inherit node template settings before falling back to defaults
+	for i := range r.Spec.Nodes {
+		if r.Spec.NodeTemplate != nil {
+			r.InheritNodeTemplate(&r.Spec.Nodes[i])
+		}
+		r.DefaultNode(&r.Spec.Nodes[i])
+	}
+
+}
+
+// DefaultNodePools syncs first node pool replicas with spec.replicas and creates node pools nodes
+// previously created pools nodes are replaced, so removed pools and scaled down nodes are removed
+func (r *Network) DefaultNodePools() {
+	if len(r.Spec.NodePools) != 0 {
+		pool := &r.Spec.NodePools[0]
+		if r.Spec.Replicas != nil {
+			pool.Replicas = *r.Spec.Replicas
+		}
+		replicas := pool.Replicas
+		r.Spec.Replicas = &replicas
+	}
+
+	nodes := []Node{}
+
+	for _, node := range r.Spec.Nodes {
+		if node.Pool == "" {
+			nodes = append(nodes, node)
+		}
+	}
+
+	for _, pool := range r.Spec.NodePools {
+		for i := 0; i < int(pool.Replicas); i++ {
+			node := *pool.Node.DeepCopy()
+			node.Name = fmt.Sprintf("%s-%d", pool.Name, i)
+			node.Pool = pool.Name
+			nodes = append(nodes, node)
+		}
+	}
+
+	r.Spec.Nodes = nodes
+}
+
+// defaultResourcesFromConfig defaults node resources from operator config resources
+// operator config resources take precedence over built-in defaults
+func defaultResourcesFromConfig(resources *NodeResources) {
+	config := helpers.Config().Resources
+
+	if resources.CPU == "" {
+		resources.CPU = config.CPU
+	}
+
+	if resources.CPULimit == "" {
+		resources.CPULimit = config.CPULimit
+	}
+
+	if resources.Memory == "" {
+		resources.Memory = config.Memory
+	}
+
+	if resources.MemoryLimit == "" {
+		resources.MemoryLimit = config.MemoryLimit
+	}
+
+	if resources.Storage == "" {
+		resources.Storage = config.Storage
+	}
+}
+
+// DefaultNodeResources defaults node cpu, memory and storage resources
+func (r *Network) DefaultNodeResources(node *Node) {
+	var cpu, cpuLimit, memory, memoryLimit, storage string
+	privateNetwork := r.IsPrivate()
+	join := r.Spec.Join
+
+	if node.Resources == nil {
+		node.Resources = &NodeResources{}
+	}
+
+	// operator config storage size of node kind takes precedence over operator config resources
+	if node.Resources.Storage == "" {
+		node.Resources.Storage = helpers.StorageSize(r.NodeStorageKinds(node)...)
+	}
+
+	// volumes that don't specify storage class use operator config storage class
+	if node.Resources.StorageClass == nil {
+		node.Resources.StorageClass = helpers.StorageClass(nil)
+	}
+
+	defaultResourcesFromConfig(node.Resources)
+
+	if node.Resources.CPU == "" {
+		if privateNetwork {
+			cpu = DefaultPrivateNetworkNodeCPURequest
+		} else {
+			cpu = DefaultPublicNetworkNodeCPURequest
+		}
+
+		node.Resources.CPU = cpu
+	}
+
+	if node.Resources.CPULimit == "" {
+		if privateNetwork {
+			cpuLimit = DefaultPrivateNetworkNodeCPULimit
+		} else {
+			cpuLimit = DefaultPublicNetworkNodeCPULimit
+		}
+
+		node.Resources.CPULimit = cpuLimit
+	}
+
+	if node.Resources.Memory == "" {
+		if privateNetwork {
+			memory = DefaultPrivateNetworkNodeMemoryRequest
+		} else {
+			memory = DefaultPublicNetworkNodeMemoryRequest
+		}
+
+		node.Resources.Memory = memory
+	}
+
+	if node.Resources.MemoryLimit == "" {
+		if privateNetwork {
+			memoryLimit = DefaultPrivateNetworkNodeMemoryLimit
+		} else {
+			memoryLimit = DefaultPublicNetworkNodeMemoryLimit
+		}
+
+		node.Resources.MemoryLimit = memoryLimit
+	}
+
+	if node.Resources.Storage == "" {
+		if privateNetwork {
+			storage = DefaultPrivateNetworkNodeStorageRequest
+		} else if join == MainNetwork && node.SyncMode == FastSynchronization {
+			storage = DefaultMainNetworkFastNodeStorageRequest
+		} else if join == MainNetwork && node.SyncMode == FullSynchronization {
+			storage = DefaultMainNetworkFullNodeStorageRequest
+		} else {
+			storage = DefaultTestNetworkStorageRequest
+		}
+
+		node.Resources.Storage = storage
+	}
+
+}
+
+// NodeStorageKinds returns node kinds used to look up node storage size in operator config
+// kinds are ordered from the most to the least specific
+func (r *Network) NodeStorageKinds(node *Node) []string {
+	if r.IsPrivate() {
+		return []string{"private"}
+	}
+	return []string{fmt.Sprintf("%s-%s", r.Spec.Join, node.SyncMode), r.Spec.Join}
+}
+
+// InheritNodeTemplate sets node settings missing from node spec to node template settings
+func (r *Network) InheritNodeTemplate(node *Node) {
+	template := r.Spec.NodeTemplate
+
+	if node.Client == "" {
+		node.Client = template.Client
+	}
+
+	if node.SyncMode == "" {
+		node.SyncMode = template.SyncMode
+	}
+
+	if node.Logging == "" {
+		node.Logging = template.Logging
+	}
+
+	if node.LogFormat == "" {
+		node.LogFormat = template.LogFormat
+	}
+
+	if node.Architecture == "" {
+		node.Architecture = template.Architecture
+	}
+
+	if template.Resources == nil {
+		return
+	}
+
+	if node.Resources == nil {
+		node.Resources = &NodeResources{}
+	}
+
+	if node.Resources.CPU == "" {
+		node.Resources.CPU = template.Resources.CPU
+	}
+
+	if node.Resources.CPULimit == "" {
+		node.Resources.CPULimit = template.Resources.CPULimit
+	}
+
+	if node.Resources.Memory == "" {
+		node.Resources.Memory = template.Resources.Memory
+	}
+
+	if node.Resources.MemoryLimit == "" {
+		node.Resources.MemoryLimit = template.Resources.MemoryLimit
+	}
+
+	if node.Resources.Storage == "" {
+		node.Resources.Storage = template.Resources.Storage
+	}
+
+	if node.Resources.StorageClass == nil {
+		node.Resources.StorageClass = template.Resources.StorageClass
+	}
+}
+
+// DefaultNode defaults a single node
+func (r *Network) DefaultNode(node *Node) {
+	defaultAPIs := []API{Web3API, ETHAPI, NetworkAPI}
+
+	if node.Client == "" {
+		node.Client = DefaultClient
+	}
+
+	if node.P2PPort == 0 {
+		node.P2PPort = DefaultP2PPort
+	}
+
+	for i := range node.InitScripts {
+		if node.InitScripts[i].Key == "" {
+			node.InitScripts[i].Key = DefaultInitScriptKey
+		}
+	}
+
+	if node.Keystore != nil {
+		if node.Keystore.KeystoreKey == "" {
+			node.Keystore.KeystoreKey = DefaultKeystoreSecretKey
+		}
+		if node.Keystore.PasswordKey == "" {
+			node.Keystore.PasswordKey = DefaultKeystorePasswordSecretKey
+		}
+	}
+
+	if node.Snapshots != nil && node.Snapshots.Retention == 0 {
+		node.Snapshots.Retention = DefaultSnapshotRetention
+	}
+
+	if node.AutoExpand != nil {
+		if node.AutoExpand.Threshold == 0 {
+			node.AutoExpand.Threshold = DefaultAutoExpandThreshold
+		}
+		if node.AutoExpand.Increment == "" {
+			node.AutoExpand.Increment = DefaultAutoExpandIncrement
+		}
+	}
+
+	if node.Backup != nil {
+		defaultBackupPolicy(node.Backup)
+	}
+
+	if node.SyncMode == "" {
+		// public network
+		if !r.IsPrivate() {
+			node.SyncMode = FastSynchronization
+		} else {
+			node.SyncMode = FullSynchronization
+		}
+	}
+
+	// must be called after defaulting sync mode because it's depending on its value
+	r.DefaultNodeResources(node)
+
+	// servers listen on all interfaces of node ip family
+	host := DefaultHost
+	if node.IPFamily == IPv6Family {
+		host = DefaultIPv6Host
+	}
+
+	if node.RPC || node.WS || node.GraphQL {
+		if len(node.Hosts) == 0 {
+			node.Hosts = DefaultOrigins
+		}
+
+		if len(node.CORSDomains) == 0 {
+			node.CORSDomains = DefaultOrigins
+		}
+	}
+
+	if node.RPC {
+		if node.RPCHost == "" {
+			node.RPCHost = host
+		}
+
+		if node.RPCPort == 0 {
+			node.RPCPort = 8545
+		}
+
+		if len(node.RPCAPI) == 0 {
+			node.RPCAPI = defaultAPIs
+		}
+
+		if node.RPCProxy != nil {
+			if node.RPCProxy.Port == 0 {
+				node.RPCProxy.Port = DefaultRPCProxyPort
+			}
+			if node.RPCProxy.MaxBatchSize == 0 {
+				node.RPCProxy.MaxBatchSize = DefaultRPCProxyMaxBatchSize
+			}
+			if node.RPCProxy.RateLimitBurst == 0 {
+				node.RPCProxy.RateLimitBurst = node.RPCProxy.RateLimit
+			}
+		}
+
+		if node.MetricsExporter != nil && node.MetricsExporter.Port == 0 {
+			node.MetricsExporter.Port = DefaultMetricsExporterPort
+		}
+	}
+
+	if node.WS {
+		if node.WSHost == "" {
+			node.WSHost = host
+		}
+
+		if node.WSPort == 0 {
+			node.WSPort = DefaultWSPort
+		}
+
+		if len(node.WSAPI) == 0 {
+			node.WSAPI = defaultAPIs
+		}
+	}
+
+	if node.GraphQL {
+		if node.GraphQLHost == "" {
+			node.GraphQLHost = host
+		}
+
+		if node.GraphQLPort == 0 {
+			node.GraphQLPort = DefaultGraphQLPort
+		}
+	}
+
+	if node.Metrics {
+		if node.MetricsHost == "" {
+			node.MetricsHost = host
+		}
+
+		if node.MetricsPort == 0 {
+			node.MetricsPort = DefaultMetricsPort
+		}
+	}
+
+	if node.Logging == "" {
+		node.Logging = DefaultLogging
+	}
+
+	if node.LogFormat == "" {
+		node.LogFormat = DefaultLogFormat
+	}
+
+}
+
+// DefaultFaucet defaults faucet amount and interval
+func (r *Network) DefaultFaucet() {
+	if r.Spec.Faucet.Amount == 0 {
+		r.Spec.Faucet.Amount = DefaultFaucetAmount
+	}
+
+	if r.Spec.Faucet.Interval == 0 {
+		r.Spec.Faucet.Interval = DefaultFaucetInterval
+	}
+}
+
+// DefaultExplorer defaults explorer coin and storage
+func (r *Network) DefaultExplorer() {
+	if r.Spec.Explorer.Coin == "" {
+		r.Spec.Explorer.Coin = DefaultExplorerCoin
+	}
+
+	if r.Spec.Explorer.Storage == "" {
+		r.Spec.Explorer.Storage = DefaultExplorerStorage
+	}
+}
+
+// DefaultGenesis defaults genesis block parameters
+// external genesis is used verbatim, only its config map key is defaulted
+func (r *Network) DefaultGenesis() {
+	if permissioning := r.Spec.Genesis.Permissioning; permissioning != nil {
+		if permissioning.Nodes && permissioning.NodesContractAddress == "" {
+			permissioning.NodesContractAddress = DefaultNodesIngressContractAddress
+		}
+		if permissioning.Accounts && permissioning.AccountsContractAddress == "" {
+			permissioning.AccountsContractAddress = DefaultAccountsIngressContractAddress
+		}
+	}
+
+	if r.Spec.Genesis.IsExternal() {
+		if r.Spec.Genesis.FromConfigMap != nil && r.Spec.Genesis.FromConfigMap.Key == "" {
+			r.Spec.Genesis.FromConfigMap.Key = DefaultGenesisConfigMapKey
+		}
+		return
+	}
+
+	if r.Spec.Genesis.Coinbase == "" {
+		r.Spec.Genesis.Coinbase = DefaultCoinbase
+	}
+
+	if mnemonic := r.Spec.Genesis.AccountsFromMnemonic; mnemonic != nil {
+		if mnemonic.SecretKey == "" {
+			mnemonic.SecretKey = DefaultMnemonicSecretKey
+		}
+		if mnemonic.Count == 0 {
+			mnemonic.Count = DefaultMnemonicAccountsCount
+		}
+	}
+
+	if r.Spec.Genesis.Difficulty == "" {
+		r.Spec.Genesis.Difficulty = DefaultDifficulty
+	}
+
+	if r.Spec.Genesis.Forks == nil {
+		// all milestones will be activated at block 0
+		r.Spec.Genesis.Forks = &Forks{}
+	}
+
+	if r.Spec.Genesis.Forks.EIP150Hash == "" {
+		r.Spec.Genesis.Forks.EIP150Hash = DefaultEIP150Hash
+	}
+
+	if r.Spec.Genesis.MixHash == "" {
+		r.Spec.Genesis.MixHash = DefaultMixHash
+	}
+
+	if r.Spec.Genesis.GasLimit == "" {
+		r.Spec.Genesis.GasLimit = DefaultGasLimit
+	}
+
+	if r.Spec.Genesis.Nonce == "" {
+		r.Spec.Genesis.Nonce = DefaultNonce
+	}
+
+	if r.Spec.Genesis.Timestamp == "" {
+		r.Spec.Genesis.Timestamp = DefaultTimestamp
+	}
+
+	if r.Spec.Consensus == ProofOfWork {
+		if r.Spec.Genesis.Ethash == nil {
+			r.Spec.Genesis.Ethash = &Ethash{}
+		}
+	}
+
+	if r.Spec.Consensus == ProofOfAuthority {
+		if r.Spec.Genesis.Clique == nil {
+			r.Spec.Genesis.Clique = &Clique{}
+		}
+		// instant sealing clique has no block period
+		if r.Spec.Genesis.Clique.BlockPeriod == 0 && !r.Spec.Genesis.Clique.InstantSeal {
+			r.Spec.Genesis.Clique.BlockPeriod = DefaultCliqueBlockPeriod
+		}
+		if r.Spec.Genesis.Clique.EpochLength == 0 {
+			r.Spec.Genesis.Clique.EpochLength = DefaultCliqueEpochLength
+		}
+	}
+
+	if r.Spec.Consensus == IstanbulBFT {
+		if r.Spec.Genesis.IBFT2 == nil {
+			r.Spec.Genesis.IBFT2 = &IBFT2{}
+		}
+		if r.Spec.Genesis.IBFT2.BlockPeriod == 0 {
+			r.Spec.Genesis.IBFT2.BlockPeriod = DefaultIBFT2BlockPeriod
+		}
+		if r.Spec.Genesis.IBFT2.EpochLength == 0 {
+			r.Spec.Genesis.IBFT2.EpochLength = DefaultIBFT2EpochLength
+		}
+		if r.Spec.Genesis.IBFT2.RequestTimeout == 0 {
+			r.Spec.Genesis.IBFT2.RequestTimeout = DefaultIBFT2RequestTimeout
+		}
+		if r.Spec.Genesis.IBFT2.MessageQueueLimit == 0 {
+			r.Spec.Genesis.IBFT2.MessageQueueLimit = DefaultIBFT2MessageQueueLimit
+		}
+		if r.Spec.Genesis.IBFT2.DuplicateMessageLimit == 0 {
+			r.Spec.Genesis.IBFT2.DuplicateMessageLimit = DefaultIBFT2DuplicateMessageLimit
+		}
+		if r.Spec.Genesis.IBFT2.FutureMessagesLimit == 0 {
+			r.Spec.Genesis.IBFT2.FutureMessagesLimit = DefaultIBFT2FutureMessagesLimit
+		}
+		if r.Spec.Genesis.IBFT2.FutureMessagesMaxDistance == 0 {
+			r.Spec.Genesis.IBFT2.FutureMessagesMaxDistance = DefaultIBFT2FutureMessagesMaxDistance
+		}
+
+	}
+}
+
+// defaultBackupPolicy defaults backup object storage endpoint and region by provider
+func defaultBackupPolicy(backup *BackupPolicy) {
+	if backup.Port == 0 {
+		backup.Port = DefaultBackupPort
+	}
+
+	if backup.FullEvery == 0 {
+		backup.FullEvery = DefaultBackupFullEvery
+	}
+
+	switch backup.Provider {
+	case S3BackupProvider:
+		if backup.Region == "" {
+			backup.Region = DefaultS3Region
+		}
+		if backup.Endpoint == "" {
+			backup.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", backup.Region)
+		}
+	case GCSBackupProvider:
+		if backup.Region == "" {
+			backup.Region = DefaultGCSRegion
+		}
+		if backup.Endpoint == "" {
+			backup.Endpoint = DefaultGCSEndpoint
+		}
+	}
+}
@@ -1,8 +1,12 @@
-package v1alpha1
+package v1beta1
 
 import (
+	"fmt"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+
+	"github.com/kotalco/kotal/helpers"
 )
 
 var _ = Describe("Ethereum defaulting", func() {
@@ -46,7 +50,163 @@ var _ = Describe("Ethereum defaulting", func() {
 		Expect(node2.Resources.MemoryLimit).To(Equal(DefaultPublicNetworkNodeMemoryLimit))
 		Expect(node2.Resources.Storage).To(Equal(DefaultMainNetworkFullNodeStorageRequest))
 		Expect(node2.Logging).To(Equal(DefaultLogging))
+		Expect(node2.LogFormat).To(Equal(DefaultLogFormat))
+
+	})
+
+	It("Should default external genesis config map key", func() {
+		network := &Network{
+			Spec: NetworkSpec{
+				Consensus: ProofOfAuthority,
+				Genesis: &Genesis{
+					FromConfigMap: &GenesisConfigMapSource{
+						Name: "genesis",
+					},
+				},
+				Nodes: []Node{
+					{
+						Name: "node-1",
+					},
+				},
+			},
+		}
+		network.Default()
+		Expect(network.Spec.Genesis.FromConfigMap.Key).To(Equal(DefaultGenesisConfigMapKey))
+		Expect(network.Spec.Genesis.Forks).To(BeNil())
+		Expect(network.Spec.Genesis.Clique).To(BeNil())
+	})
 
+	It("Should default faucet", func() {
+		network := &Network{
+			Spec: NetworkSpec{
+				Consensus: ProofOfAuthority,
+				Genesis: &Genesis{
+					ChainID: 4444,
+				},
+				Faucet: &Faucet{
+					Node:                 "node-1",
+					PrivateKeySecretName: "faucet-key",
+				},
+				Nodes: []Node{
+					{
+						Name: "node-1",
+						RPC:  true,
+					},
+				},
+			},
+		}
+		network.Default()
+		Expect(network.Spec.Faucet.Amount).To(Equal(DefaultFaucetAmount))
+		Expect(network.Spec.Faucet.Interval).To(Equal(DefaultFaucetInterval))
+	})
+
+	It("Should default explorer", func() {
+		network := &Network{
+			Spec: NetworkSpec{
+				Join: RinkebyNetwork,
+				Explorer: &Explorer{
+					Node: "node-1",
+				},
+				Nodes: []Node{
+					{
+						Name:     "node-1",
+						RPC:      true,
+						SyncMode: FullSynchronization,
+					},
+				},
+			},
+		}
+		network.Default()
+		Expect(network.Spec.Explorer.Coin).To(Equal(DefaultExplorerCoin))
+		Expect(network.Spec.Explorer.Storage).To(Equal(DefaultExplorerStorage))
+	})
+
+	It("Should inherit node template settings", func() {
+		network := &Network{
+			Spec: NetworkSpec{
+				Join: RinkebyNetwork,
+				NodeTemplate: &NodeTemplate{
+					Client:   GethClient,
+					SyncMode: LightSynchronization,
+					Logging:  DebugLogs,
+					Resources: &NodeResources{
+						CPU:     "4",
+						Storage: "100Gi",
+					},
+				},
+				Nodes: []Node{
+					{
+						Name: "node-1",
+					},
+					{
+						Name:     "node-2",
+						Client:   BesuClient,
+						SyncMode: FastSynchronization,
+						Resources: &NodeResources{
+							CPU: "2",
+						},
+					},
+				},
+			},
+		}
+		network.Default()
+		node1 := network.Spec.Nodes[0]
+		node2 := network.Spec.Nodes[1]
+		Expect(node1.Client).To(Equal(GethClient))
+		Expect(node1.SyncMode).To(Equal(LightSynchronization))
+		Expect(node1.Logging).To(Equal(DebugLogs))
+		Expect(node1.Resources.CPU).To(Equal("4"))
+		Expect(node1.Resources.Storage).To(Equal("100Gi"))
+		Expect(node1.Resources.Memory).To(Equal(DefaultPublicNetworkNodeMemoryRequest))
+		Expect(node2.Client).To(Equal(BesuClient))
+		Expect(node2.SyncMode).To(Equal(FastSynchronization))
+		Expect(node2.Logging).To(Equal(DebugLogs))
+		Expect(node2.Resources.CPU).To(Equal("2"))
+		Expect(node2.Resources.Storage).To(Equal("100Gi"))
+	})
+
+	It("Should default IPv6 node hosts", func() {
+		network := &Network{
+			Spec: NetworkSpec{
+				Join: RinkebyNetwork,
+				Nodes: []Node{
+					{
+						Name:     "node-1",
+						IPFamily: IPv6Family,
+						RPC:      true,
+						WS:       true,
+					},
+				},
+			},
+		}
+		network.Default()
+		node := network.Spec.Nodes[0]
+		Expect(node.RPCHost).To(Equal(DefaultIPv6Host))
+		Expect(node.WSHost).To(Equal(DefaultIPv6Host))
+	})
+
+	It("Should default node metrics", func() {
+		network := &Network{
+			Spec: NetworkSpec{
+				Join: RinkebyNetwork,
+				Nodes: []Node{
+					{
+						Name:    "node-1",
+						Metrics: true,
+					},
+					{
+						Name: "node-2",
+					},
+				},
+			},
+		}
+		network.Default()
+		node1 := network.Spec.Nodes[0]
+		node2 := network.Spec.Nodes[1]
+		Expect(node1.MetricsHost).To(Equal(DefaultHost))
+		Expect(node1.MetricsPort).To(Equal(DefaultMetricsPort))
+		Expect(node2.MetricsHost).To(BeEmpty())
+		Expect(node2.MetricsPort).To(BeZero())
 	})
 
 	It("Should default network joining rinkeby", func() {
@@ -180,6 +340,30 @@ var _ = Describe("Ethereum defaulting", func() {
 		Expect(network.Spec.Genesis.Clique.EpochLength).To(Equal(DefaultCliqueEpochLength))
 	})
 
+	It("Should not default instant sealing clique block period", func() {
+		network := &Network{
+			Spec: NetworkSpec{
+				Consensus: ProofOfAuthority,
+				Genesis: &Genesis{
+					ChainID: 55555,
+					Clique: &Clique{
+						InstantSeal: true,
+					},
+				},
+				Nodes: []Node{
+					{
+						Name: "node-1",
+					},
+				},
+			},
+		}
+
+		network.Default()
+
+		Expect(network.Spec.Genesis.Clique.BlockPeriod).To(BeZero())
+		Expect(network.Spec.Genesis.Clique.EpochLength).To(Equal(DefaultCliqueEpochLength))
+	})
+
 	It("Should default network with ibft2 consensus", func() {
 		network := &Network{
 			Spec: NetworkSpec{
@@ -244,4 +428,114 @@ var _ = Describe("Ethereum defaulting", func() {
 		Expect(network.Spec.Genesis.IBFT2.FutureMessagesLimit).To(Equal(DefaultIBFT2FutureMessagesLimit))
 		Expect(network.Spec.Genesis.IBFT2.FutureMessagesMaxDistance).To(Equal(DefaultIBFT2FutureMessagesMaxDistance))
 	})
+
+	It("Should create node pools nodes", func() {
+		var replicas int32 = 3
+
+		network := &Network{
+			Spec: NetworkSpec{
+				Join: RinkebyNetwork,
+				Nodes: []Node{
+					{
+						Name:     "bootnode",
+						Bootnode: true,
+					},
+					// previously created pool node, scaled down
+					{
+						Name: "rpc-3",
+						Pool: "rpc",
+					},
+				},
+				NodePools: []NodePool{
+					{
+						Node: Node{
+							Name: "rpc",
+							RPC:  true,
+						},
+						Replicas: 1,
+					},
+				},
+				Replicas: &replicas,
+			},
+		}
+
+		network.Default()
+
+		Expect(network.Spec.NodePools[0].Replicas).To(Equal(replicas))
+		Expect(network.Spec.Nodes).To(HaveLen(4))
+		Expect(network.Spec.Nodes[0].Name).To(Equal("bootnode"))
+		for i, node := range network.Spec.Nodes[1:] {
+			Expect(node.Name).To(Equal(fmt.Sprintf("rpc-%d", i)))
+			Expect(node.Pool).To(Equal("rpc"))
+			Expect(node.RPC).To(BeTrue())
+			Expect(node.RPCPort).To(Equal(DefaultRPCPort))
+		}
+	})
+
+	It("Should default replicas from first node pool", func() {
+		network := &Network{
+			Spec: NetworkSpec{
+				Join: RinkebyNetwork,
+				NodePools: []NodePool{
+					{
+						Node: Node{
+							Name: "rpc",
+						},
+						Replicas: 2,
+					},
+				},
+			},
+		}
+
+		network.Default()
+
+		Expect(*network.Spec.Replicas).To(Equal(int32(2)))
+		Expect(network.Spec.Nodes).To(HaveLen(2))
+	})
+
+	It("Should default node storage from operator config", func() {
+		defer helpers.SetConfig(helpers.OperatorConfig{})
+		helpers.SetConfig(helpers.OperatorConfig{
+			StorageClass: "fast-ssd",
+			Resources: helpers.ResourcesConfig{
+				Storage: "200Gi",
+			},
+			StorageSizes: map[string]string{
+				"mainnet-full": "2Ti",
+				"mainnet":      "500Gi",
+			},
+		})
+
+		storage := "10Gi"
+		network := &Network{
+			Spec: NetworkSpec{
+				Join: MainNetwork,
+				Nodes: []Node{
+					{
+						Name:     "full",
+						SyncMode: FullSynchronization,
+					},
+					{
+						Name:     "fast",
+						SyncMode: FastSynchronization,
+					},
+					{
+						Name: "explicit",
+						Resources: &NodeResources{
+							Storage: storage,
+						},
+					},
+				},
+			},
+		}
+
+		network.Default()
+
+		Expect(network.Spec.Nodes[0].Resources.Storage).To(Equal("2Ti"))
+		Expect(network.Spec.Nodes[1].Resources.Storage).To(Equal("500Gi"))
+		Expect(network.Spec.Nodes[2].Resources.Storage).To(Equal(storage))
+		for _, node := range network.Spec.Nodes {
+			Expect(*node.Resources.StorageClass).To(Equal("fast-ssd"))
+		}
+	})
 })
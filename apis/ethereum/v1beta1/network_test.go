@@ -1,4 +1,4 @@
-package v1alpha1
+package v1beta1
 
 import (
 	"reflect"
@@ -84,10 +84,20 @@ func TestServiceName(t *testing.T) {
 	}
 }
 
+func TestConfigName(t *testing.T) {
+	node := network.Spec.Nodes[0]
+	expected := "test-network-node-1-config"
+	got := node.ConfigName(network.Name)
+
+	if got != expected {
+		t.Errorf("Expecting node config name to be %s got %s", expected, got)
+	}
+}
+
 func TestConfigmapName(t *testing.T) {
 	node := network.Spec.Nodes[0]
 	expected := "test-network-besu"
-	got := node.ConfigmapName(network.Name, node.Client)
+	got := network.ConfigmapName(node.Client)
 
 	if got != expected {
 		t.Errorf("Expecting bootnode to be %s got %s", expected, got)
@@ -107,3 +117,34 @@ func TestLabels(t *testing.T) {
 		t.Errorf("Expecting node labels to be %s got %s", expected, got)
 	}
 }
+
+func TestResourceLabels(t *testing.T) {
+	node := network.Spec.Nodes[0]
+	node.Miner = true
+	expected := map[string]string{
+		"name":      "node",
+		"instance":  "node-1",
+		"network":   "test-network",
+		"client":    "besu",
+		"consensus": "poa",
+		"miner":     "true",
+	}
+	got := node.ResourceLabels(network.Name, ProofOfAuthority)
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expecting node resource labels to be %s got %s", expected, got)
+	}
+}
+
+func TestConfigmapLabels(t *testing.T) {
+	expected := map[string]string{
+		"name":    "genesis",
+		"network": "test-network",
+		"client":  "geth",
+	}
+	got := network.ConfigmapLabels(GethClient)
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expecting configmap labels to be %s got %s", expected, got)
+	}
+}
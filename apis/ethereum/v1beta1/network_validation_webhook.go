@@ -0,0 +1,1446 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/kotalco/kotal/helpers"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-ethereum-kotal-io-v1beta1-network,mutating=false,failurePolicy=fail,groups=ethereum.kotal.io,resources=networks,versions=v1beta1,name=vnetwork.kb.io
+
+var _ webhook.Validator = &Network{}
+
+const (
+	// minSnapshotSchedule is the minimum interval between node data snapshots
+	minSnapshotSchedule = 15 * time.Minute
+	// minBackupSchedule is the minimum interval between node data backups
+	minBackupSchedule = time.Hour
+	// minIntegrityCheckSchedule is the minimum interval between node data integrity checks, node is stopped while checked
+	minIntegrityCheckSchedule = 24 * time.Hour
+)
+
+var (
+	// ChainByID is public chains indexed by ID
+	ChainByID = map[uint]string{
+		1:    MainNetwork,
+		3:    RopstenNetwork,
+		4:    RinkebyNetwork,
+		5:    GoerliNetwork,
+		6:    KottiNetwork,
+		61:   ClassicNetwork,
+		63:   MordorNetwork,
+		2018: DevNetwork,
+	}
+)
+
+// ValidateMissingBootnodes validates that at least one bootnode in the network
+func (r *Network) ValidateMissingBootnodes() *field.Error {
+	// it's fine for a network of 1 node to have no bootnodes
+	if len(r.Spec.Nodes) == 1 {
+		return nil
+	}
+
+	// pool nodes can't be bootnodes, nodes of networks of node pools only discover each other
+	// through public network bootnodes or bootnodes running outside the cluster
+	if r.Spec.Nodes[0].Pool != "" {
+		if !r.IsPrivate() || len(r.Spec.Bootnodes) != 0 {
+			return nil
+		}
+		msg := "must include a bootnode if private network has node pools only and no spec.bootnodes, pool nodes can't be bootnodes"
+		return field.Invalid(field.NewPath("spec").Child("nodes"), "", msg)
+	}
+
+	if !r.Spec.Nodes[0].IsBootnode() {
+		msg := "first node must be a bootnode if network has multiple nodes"
+		// private network nodes have no public bootnodes to discover each other
+		if r.IsPrivate() {
+			msg = "first node must be a bootnode if private network has multiple nodes, nodes can't discover each other otherwise"
+		}
+		return field.Invalid(field.NewPath("spec").Child("nodes").Index(0).Child("bootnode"), false, msg)
+	}
+
+	return nil
+}
+
+// ValidateNodeNameUniqeness validates that all node names are unique
+func (r *Network) ValidateNodeNameUniqeness() field.ErrorList {
+
+	var uniquenessErrors field.ErrorList
+	names := map[string]int{}
+	msg := "already used by spec.nodes[%d].name"
+	nodesPath := field.NewPath("spec").Child("nodes")
+
+	for i, node := range r.Spec.Nodes {
+		if j, exists := names[node.Name]; exists {
+			path := nodesPath.Index(i).Child("name")
+			err := field.Invalid(path, node.Name, fmt.Sprintf(msg, j))
+			uniquenessErrors = append(uniquenessErrors, err)
+		} else {
+			names[node.Name] = i
+		}
+	}
+	return uniquenessErrors
+}
+
+// ValidateNodeName validates node name is a dns label, short enough for node resources names
+// node internal service has the longest derived name, and service names can't exceed 63 characters
+// node jobs names are longer, but they're shortened by the controller to fit job-name pod label
+func (r *Network) ValidateNodeName(i int) field.ErrorList {
+	node := r.Spec.Nodes[i]
+	namePath := field.NewPath("spec").Child("nodes").Index(i).Child("name")
+	var nameErrors field.ErrorList
+
+	for _, msg := range validation.IsDNS1123Label(node.Name) {
+		nameErrors = append(nameErrors, field.Invalid(namePath, node.Name, msg))
+	}
+
+	if len(node.InternalServiceName(r.Name)) > validation.DNS1035LabelMaxLength {
+		max := validation.DNS1035LabelMaxLength - len(node.InternalServiceName(r.Name)) + len(node.Name)
+		msg := fmt.Sprintf("must be no more than %d characters, node resources names can't exceed %d characters", max, validation.DNS1035LabelMaxLength)
+		nameErrors = append(nameErrors, field.Invalid(namePath, node.Name, msg))
+	}
+
+	return nameErrors
+}
+
+// ValidateNode validates a single node
+func (r *Network) ValidateNode(i int) field.ErrorList {
+	node := r.Spec.Nodes[i]
+	nodePath := field.NewPath("spec").Child("nodes").Index(i)
+	var nodeErrors field.ErrorList
+
+	nodeErrors = append(nodeErrors, r.ValidateNodeName(i)...)
+
+	// validate nodekey is provided if node is bootnode
+	if node.IsBootnode() && node.Nodekey == "" {
+		err := field.Invalid(nodePath.Child("nodekey"), node.Nodekey, "must provide nodekey if bootnode is true")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate dedicated bootnodes only serve peers discovery
+	if node.IsDedicatedBootnode() {
+		disabled := []struct {
+			name    string
+			enabled bool
+		}{
+			{"rpc", node.RPC},
+			{"ws", node.WS},
+			{"graphql", node.GraphQL},
+			{"miner", node.Miner},
+			{"metrics", node.Metrics},
+		}
+		for _, d := range disabled {
+			if d.enabled {
+				err := field.Invalid(nodePath.Child(d.name), true, "must be false if role is bootnode")
+				nodeErrors = append(nodeErrors, err)
+			}
+		}
+		if node.Import != nil {
+			err := field.Invalid(nodePath.Child("role"), node.Role, "must not be bootnode if import is provided")
+			nodeErrors = append(nodeErrors, err)
+		}
+		if node.Keystore != nil {
+			err := field.Invalid(nodePath.Child("role"), node.Role, "must not be bootnode if keystore is provided")
+			nodeErrors = append(nodeErrors, err)
+		}
+		// dedicated bootnodes have no data
+		if node.Snapshots != nil {
+			err := field.Invalid(nodePath.Child("role"), node.Role, "must not be bootnode if snapshots is provided")
+			nodeErrors = append(nodeErrors, err)
+		}
+		if node.IntegrityCheck != nil {
+			err := field.Invalid(nodePath.Child("role"), node.Role, "must not be bootnode if integrityCheck is provided")
+			nodeErrors = append(nodeErrors, err)
+		}
+		if node.AutoExpand != nil {
+			err := field.Invalid(nodePath.Child("role"), node.Role, "must not be bootnode if autoExpand is provided")
+			nodeErrors = append(nodeErrors, err)
+		}
+		if node.Backup != nil {
+			err := field.Invalid(nodePath.Child("role"), node.Role, "must not be bootnode if backup is provided")
+			nodeErrors = append(nodeErrors, err)
+		}
+		if node.Restore != nil {
+			err := field.Invalid(nodePath.Child("role"), node.Role, "must not be bootnode if restore is provided")
+			nodeErrors = append(nodeErrors, err)
+		}
+	}
+
+	cpu := resource.MustParse(node.Resources.CPU)
+	cpuLimit := resource.MustParse(node.Resources.CPULimit)
+
+	// validate cpuLimit can't be less than cpu request
+	if cpuLimit.Cmp(cpu) == -1 {
+		err := field.Invalid(nodePath.Child("resources").Child("cpuLimit"), node.Resources.CPULimit, fmt.Sprintf("must be greater than or equal to cpu %s", string(node.Resources.CPU)))
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	memory := resource.MustParse(node.Resources.Memory)
+	memoryLimit := resource.MustParse(node.Resources.MemoryLimit)
+
+	// validate memoryLimit can't be less than memory request
+	if memoryLimit.Cmp(memory) == -1 {
+		err := field.Invalid(nodePath.Child("resources").Child("memoryLimit"), node.Resources.MemoryLimit, fmt.Sprintf("must be greater than or equal to memory %s", string(node.Resources.Memory)))
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate cache can't exceed memory limit
+	if node.Cache != 0 && int64(node.Cache)*1024*1024 >= memoryLimit.Value() {
+		err := field.Invalid(nodePath.Child("cache"), node.Cache, fmt.Sprintf("must be less than memory limit %s", node.Resources.MemoryLimit))
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate coinbase is provided if node is miner
+	if node.Miner && node.Coinbase == "" {
+		err := field.Invalid(nodePath.Child("coinbase"), "", "must provide coinbase if miner is true")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate coinbase can't be set if miner is not set explicitly as true
+	if node.Coinbase != "" && node.Miner == false {
+		err := field.Invalid(nodePath.Child("miner"), false, "must set miner to true if coinbase is provided")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate only geth client can import accounts
+	if node.Client != GethClient && node.Import != nil {
+		err := field.Invalid(nodePath.Child("client"), node.Client, "must be geth if import is provided")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate only geth client can unlock keystore accounts
+	if node.Client != GethClient && node.Keystore != nil {
+		err := field.Invalid(nodePath.Child("client"), node.Client, "must be geth if keystore is provided")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate besu options are provided to besu nodes only
+	if node.Client != BesuClient && node.Besu != nil {
+		err := field.Invalid(nodePath.Child("client"), node.Client, "must be besu if besu is provided")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate only geth client supports light sync mode
+	if node.Client != GethClient && node.SyncMode == LightSynchronization {
+		err := field.Invalid(nodePath.Child("client"), node.Client, "must be geth if syncMode is light")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate only geth client can serve light clients
+	if node.Client != GethClient && node.LightServe != 0 {
+		err := field.Invalid(nodePath.Child("client"), node.Client, "must be geth if lightServe is provided")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate light nodes can't serve other light nodes
+	if node.SyncMode == LightSynchronization && node.LightServe != 0 {
+		err := field.Invalid(nodePath.Child("syncMode"), node.SyncMode, "must not be light if lightServe is provided")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate advertised address is ip address
+	if node.AdvertisedAddress != "" && net.ParseIP(node.AdvertisedAddress) == nil {
+		err := field.Invalid(nodePath.Child("advertisedAddress"), node.AdvertisedAddress, "must be a valid ip address")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	nodeErrors = append(nodeErrors, validateNodePorts(&node, nodePath)...)
+
+	// validate web socket ingress exposes web socket server without sensitive apis
+	if node.WSIngressHost != "" {
+		if !node.WS {
+			err := field.Invalid(nodePath.Child("ws"), node.WS, "must be true if wsIngressHost is provided")
+			nodeErrors = append(nodeErrors, err)
+		}
+		if node.WithSensitiveWS() {
+			err := field.Invalid(nodePath.Child("wsAPI"), node.WSAPI, "must not include sensitive apis if wsIngressHost is provided")
+			nodeErrors = append(nodeErrors, err)
+		}
+		if node.Service != nil && node.Service.Type == NoneService {
+			err := field.Invalid(nodePath.Child("service").Child("type"), node.Service.Type, "must not be None if wsIngressHost is provided")
+			nodeErrors = append(nodeErrors, err)
+		}
+	}
+
+	// validate rpc proxy is used with rpc server
+	if node.RPCProxy != nil && !node.RPC {
+		err := field.Invalid(nodePath.Child("rpc"), node.RPC, "must be true if rpcProxy is provided")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate metrics exporter polls rpc server
+	if node.MetricsExporter != nil && !node.RPC {
+		err := field.Invalid(nodePath.Child("rpc"), node.RPC, "must be true if metricsExporter is provided")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate besu host allowlists are used with their servers
+	if node.Besu != nil {
+		if len(node.Besu.MetricsHostAllowlist) != 0 && !node.Metrics {
+			err := field.Invalid(nodePath.Child("metrics"), node.Metrics, "must be true if besu metricsHostAllowlist is provided")
+			nodeErrors = append(nodeErrors, err)
+		}
+		if len(node.Besu.GraphQLHostAllowlist) != 0 && !node.GraphQL {
+			err := field.Invalid(nodePath.Child("graphql"), node.GraphQL, "must be true if besu graphqlHostAllowlist is provided")
+			nodeErrors = append(nodeErrors, err)
+		}
+	}
+
+	if node.Service != nil {
+		nodeErrors = append(nodeErrors, validateNodeService(node.Service, nodePath.Child("service"))...)
+		// bootnodes enode urls are advertised using service ip
+		if node.IsBootnode() && node.Service.Type == NoneService {
+			err := field.Invalid(nodePath.Child("service").Child("type"), node.Service.Type, "must not be None if node is bootnode")
+			nodeErrors = append(nodeErrors, err)
+		}
+	}
+
+	nodeErrors = append(nodeErrors, apivalidation.ValidateAnnotations(node.ServiceAnnotations, nodePath.Child("serviceAnnotations"))...)
+
+	if node.StorageMemory && !node.WithEphemeralStorage() {
+		err := field.Invalid(nodePath.Child("storageMemory"), node.StorageMemory, "can only be used with ephemeral storage")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate node data directory is provided for hostPath and local storage only
+	if node.WithLocalStorage() && node.Local == nil {
+		err := field.Invalid(nodePath.Child("local"), "", fmt.Sprintf("must be provided if storage is %s", node.Storage))
+		nodeErrors = append(nodeErrors, err)
+	}
+	if node.Local != nil && !node.WithLocalStorage() {
+		err := field.Invalid(nodePath.Child("local"), "", "must be none if storage isn't hostPath or local")
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate node data is restored into persistent volume claim provisioned by csi driver
+	if node.Restore != nil && node.StorageMode() != PersistentStorage {
+		err := field.Invalid(nodePath.Child("restore"), "", fmt.Sprintf("must be none if storage is %s", node.Storage))
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate snapshots are taken of persistent storage, and not too often
+	if node.Snapshots != nil {
+		if node.StorageMode() != PersistentStorage {
+			err := field.Invalid(nodePath.Child("snapshots"), "", fmt.Sprintf("must be none if storage is %s", node.Storage))
+			nodeErrors = append(nodeErrors, err)
+		}
+		if node.Snapshots.Schedule.Duration < minSnapshotSchedule {
+			err := field.Invalid(nodePath.Child("snapshots").Child("schedule"), node.Snapshots.Schedule.Duration.String(), fmt.Sprintf("must be at least %s", minSnapshotSchedule))
+			nodeErrors = append(nodeErrors, err)
+		}
+	}
+
+	// validate expanded pvc is provisioned by csi driver, and can grow beyond node storage
+	if node.AutoExpand != nil {
+		if node.StorageMode() != PersistentStorage {
+			err := field.Invalid(nodePath.Child("autoExpand"), "", fmt.Sprintf("must be none if storage is %s", node.Storage))
+			nodeErrors = append(nodeErrors, err)
+		}
+		if max, err := resource.ParseQuantity(node.AutoExpand.Max); err != nil || max.Cmp(resource.MustParse(node.Resources.Storage)) <= 0 {
+			err := field.Invalid(nodePath.Child("autoExpand").Child("max"), node.AutoExpand.Max, fmt.Sprintf("must be greater than node storage %s", node.Resources.Storage))
+			nodeErrors = append(nodeErrors, err)
+		}
+	}
+
+	// validate checked data survives stopping node, and checks aren't run too often
+	if node.IntegrityCheck != nil {
+		if node.WithEphemeralStorage() {
+			err := field.Invalid(nodePath.Child("integrityCheck"), "", "must be none if storage is ephemeral")
+			nodeErrors = append(nodeErrors, err)
+		}
+		if schedule := node.IntegrityCheck.Schedule.Duration; schedule != 0 && schedule < minIntegrityCheckSchedule {
+			err := field.Invalid(nodePath.Child("integrityCheck").Child("schedule"), schedule.String(), fmt.Sprintf("must be at least %s", minIntegrityCheckSchedule))
+			nodeErrors = append(nodeErrors, err)
+		}
+	}
+
+	// validate backups aren't taken too often
+	if node.Backup != nil && node.Backup.Schedule.Duration < minBackupSchedule {
+		err := field.Invalid(nodePath.Child("backup").Child("schedule"), node.Backup.Schedule.Duration.String(), fmt.Sprintf("must be at least %s", minBackupSchedule))
+		nodeErrors = append(nodeErrors, err)
+	}
+
+	// validate sidecar containers names don't conflict with generated containers or each other
+	sidecars := map[string]bool{
+		"node":             true,
+		"rpc-proxy":        node.RPCProxy != nil,
+		"metrics-exporter": node.MetricsExporter != nil,
+		"backup":           node.Backup != nil,
+	}
+	for j, sidecar := range node.Sidecars {
+		if sidecars[sidecar.Name] {
+			err := field.Invalid(nodePath.Child("sidecars").Index(j).Child("name"), sidecar.Name, "already used by another node container")
+			nodeErrors = append(nodeErrors, err)
+		}
+		sidecars[sidecar.Name] = true
+	}
+
+	// validate init scripts names don't conflict with generated init containers or each other
+	initContainers := map[string]bool{"init-genesis": true, "import-account": true}
+	for j, script := range node.InitScripts {
+		if initContainers[script.Name] {
+			err := field.Invalid(nodePath.Child("initScripts").Index(j).Child("name"), script.Name, "already used by another node init container")
+			nodeErrors = append(nodeErrors, err)
+		}
+		initContainers[script.Name] = true
+	}
+
+	// validate console scripts are run by geth console against node http-rpc server
+	if len(node.ConsoleScripts) != 0 {
+		if node.Client != GethClient {
+			err := field.Invalid(nodePath.Child("client"), node.Client, "must be geth if consoleScripts is provided")
+			nodeErrors = append(nodeErrors, err)
+		}
+		if !node.RPC {
+			err := field.Invalid(nodePath.Child("rpc"), node.RPC, "must be true if consoleScripts is provided")
+			nodeErrors = append(nodeErrors, err)
+		}
+	}
+	consoleScripts := map[string]bool{}
+	for j, script := range node.ConsoleScripts {
+		if consoleScripts[script.Name] {
+			err := field.Invalid(nodePath.Child("consoleScripts").Index(j).Child("name"), script.Name, "already used by another console script")
+			nodeErrors = append(nodeErrors, err)
+		}
+		consoleScripts[script.Name] = true
+	}
+
+	// Validate geth node
+	if node.Client == GethClient {
+		nodeErrors = append(nodeErrors, r.ValidateGethNode(&node, i)...)
+	}
+
+	return nodeErrors
+}
+
+// validateNodeService validates load balancer settings are valid and used with load balancer services only
+func validateNodeService(service *NodeService, servicePath *field.Path) field.ErrorList {
+	var serviceErrors field.ErrorList
+	loadBalancer := service.Type == LoadBalancerService
+
+	if service.LoadBalancerIP != "" {
+		if !loadBalancer {
+			err := field.Invalid(servicePath.Child("type"), service.Type, "must be LoadBalancer if loadBalancerIP is provided")
+			serviceErrors = append(serviceErrors, err)
+		}
+		if net.ParseIP(service.LoadBalancerIP) == nil {
+			err := field.Invalid(servicePath.Child("loadBalancerIP"), service.LoadBalancerIP, "must be a valid ip address")
+			serviceErrors = append(serviceErrors, err)
+		}
+	}
+
+	if len(service.LoadBalancerSourceRanges) != 0 && !loadBalancer {
+		err := field.Invalid(servicePath.Child("type"), service.Type, "must be LoadBalancer if loadBalancerSourceRanges is provided")
+		serviceErrors = append(serviceErrors, err)
+	}
+
+	for i, cidr := range service.LoadBalancerSourceRanges {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			err := field.Invalid(servicePath.Child("loadBalancerSourceRanges").Index(i), cidr, "must be a valid cidr")
+			serviceErrors = append(serviceErrors, err)
+		}
+	}
+
+	return serviceErrors
+}
+
+// rpcProxyPort returns node rpc proxy port, or 0 if node has no rpc proxy
+func rpcProxyPort(node *Node) uint {
+	if node.RPCProxy == nil {
+		return 0
+	}
+	return node.RPCProxy.Port
+}
+
+// metricsExporterPort returns node metrics exporter port, or 0 if node has no metrics exporter
+func metricsExporterPort(node *Node) uint {
+	if node.MetricsExporter == nil {
+		return 0
+	}
+	return node.MetricsExporter.Port
+}
+
+// backupPort returns node backup status server port, or 0 if node has no backup
+func backupPort(node *Node) uint {
+	if node.Backup == nil {
+		return 0
+	}
+	return node.Backup.Port
+}
+
+// validateNodePorts validates that p2p port and enabled servers ports of a node are distinct
+// nodes don't use host network and each node has its own pod and service, so ports can't collide across nodes
+// except on load balancer ip shared by nodes, which is validated by ValidateSharedLoadBalancerPorts
+func validateNodePorts(node *Node, nodePath *field.Path) field.ErrorList {
+	var portsErrors field.ErrorList
+	// port number => field using it
+	used := map[uint]string{}
+
+	ports := []struct {
+		name    string
+		port    uint
+		enabled bool
+	}{
+		{"p2pPort", node.P2PPort, true},
+		{"rpcPort", node.RPCPort, node.RPC},
+		{"wsPort", node.WSPort, node.WS},
+		{"graphqlPort", node.GraphQLPort, node.GraphQL},
+		{"metricsPort", node.MetricsPort, node.Metrics},
+		{"rpcProxy.port", rpcProxyPort(node), node.RPC},
+		{"metricsExporter.port", metricsExporterPort(node), node.RPC},
+		{"backup.port", backupPort(node), true},
+	}
+
+	for _, p := range ports {
+		if !p.enabled || p.port == 0 {
+			continue
+		}
+		if name, exists := used[p.port]; exists {
+			err := field.Invalid(nodePath.Child(p.name), p.port, fmt.Sprintf("already used by %s", name))
+			portsErrors = append(portsErrors, err)
+			continue
+		}
+		used[p.port] = p.name
+	}
+
+	return portsErrors
+}
+
+// ValidateSharedLoadBalancerPorts validates that nodes requesting the same load balancer ip expose distinct ports
+// node service exposes p2p port, rpc port if rpc has no sensitive apis, and ws port if ws is exposed by ingress
+func (r *Network) ValidateSharedLoadBalancerPorts() field.ErrorList {
+	var portsErrors field.ErrorList
+	// load balancer ip => port number => node field using it
+	used := map[string]map[uint]string{}
+	nodesPath := field.NewPath("spec").Child("nodes")
+
+	for i := range r.Spec.Nodes {
+		node := &r.Spec.Nodes[i]
+		if node.Service == nil || node.Service.LoadBalancerIP == "" {
+			continue
+		}
+
+		ports := []struct {
+			name    string
+			port    uint
+			exposed bool
+		}{
+			{"p2pPort", node.P2PPort, true},
+			{"rpcPort", node.RPCPort, node.RPC && !node.WithSensitiveRPC()},
+			{"wsPort", node.WSPort, node.WS && node.WSIngressHost != ""},
+		}
+
+		ip := node.Service.LoadBalancerIP
+		if used[ip] == nil {
+			used[ip] = map[uint]string{}
+		}
+
+		for _, p := range ports {
+			if !p.exposed {
+				continue
+			}
+			path := nodesPath.Index(i).Child(p.name)
+			if name, exists := used[ip][p.port]; exists {
+				msg := fmt.Sprintf("already exposed on load balancer ip %s by %s", ip, name)
+				portsErrors = append(portsErrors, field.Invalid(path, p.port, msg))
+				continue
+			}
+			used[ip][p.port] = path.String()
+		}
+	}
+
+	return portsErrors
+}
+
+// ValidateGethNode validates a node with client geth
+func (r *Network) ValidateGethNode(node *Node, i int) field.ErrorList {
+	var gethErrors field.ErrorList
+	nodePath := field.NewPath("spec").Child("nodes").Index(i)
+
+	// validate geth supports only pow and poa
+	if r.Spec.Join == "" && r.Spec.Consensus != ProofOfWork && r.Spec.Consensus != ProofOfAuthority {
+		err := field.Invalid(nodePath.Child("client"), node.Client, fmt.Sprintf("client doesn't support %s consensus", r.Spec.Consensus))
+		gethErrors = append(gethErrors, err)
+	}
+
+	// validate geth doesn't support fixed difficulty ethash networks
+	if r.Spec.Join == "" && r.Spec.Consensus == ProofOfWork && r.Spec.Genesis.Ethash != nil && r.Spec.Genesis.Ethash.FixedDifficulty != nil {
+		err := field.Invalid(nodePath.Child("client"), node.Client, "client doesn't support fixed difficulty pow networks")
+		gethErrors = append(gethErrors, err)
+	}
+
+	// validate account must be imported if coinbase is provided
+	// keystore account address can't be validated, keystore is loaded from secret
+	if node.Coinbase != "" && node.Import == nil && node.Keystore == nil {
+		err := field.Invalid(nodePath.Child("import"), "", "must import coinbase account")
+		gethErrors = append(gethErrors, err)
+	}
+
+	// validate account is either imported or loaded from keystore
+	if node.Import != nil && node.Keystore != nil {
+		err := field.Invalid(nodePath.Child("keystore"), node.Keystore.SecretName, "must be none if import is provided")
+		gethErrors = append(gethErrors, err)
+	}
+
+	// validate imported account private key is valid and coinbase account is derived from it
+	if node.Coinbase != "" && node.Import != nil {
+		privateKey := node.Import.PrivateKey[2:]
+		address, err := helpers.DeriveAddress(string(privateKey))
+		if err != nil {
+			err := field.Invalid(nodePath.Child("import").Child("privatekey"), "<private key>", "invalid private key")
+			gethErrors = append(gethErrors, err)
+		}
+
+		if strings.ToLower(string(node.Coinbase)) != strings.ToLower(address) {
+			err := field.Invalid(nodePath.Child("import").Child("privatekey"), "<private key>", "private key doesn't correspond to the coinbase address")
+			gethErrors = append(gethErrors, err)
+		}
+	}
+
+	// validate rpc can't be enabled for node with imported account
+	if node.Import != nil && node.RPC {
+		err := field.Invalid(nodePath.Child("rpc"), node.RPC, "must be false if import is provided")
+		gethErrors = append(gethErrors, err)
+	}
+
+	// validate ws can't be enabled for node with imported account
+	if node.Import != nil && node.WS {
+		err := field.Invalid(nodePath.Child("ws"), node.WS, "must be false if import is provided")
+		gethErrors = append(gethErrors, err)
+	}
+
+	// validate graphql can't be enabled for node with imported account
+	if node.Import != nil && node.GraphQL {
+		err := field.Invalid(nodePath.Child("graphql"), node.GraphQL, "must be false if import is provided")
+		gethErrors = append(gethErrors, err)
+	}
+
+	return gethErrors
+}
+
+// ValidateNodes validate network nodes spec
+func (r *Network) ValidateNodes() field.ErrorList {
+	var allErrors field.ErrorList
+
+	for i := range r.Spec.Nodes {
+		allErrors = append(allErrors, r.ValidateNode(i)...)
+	}
+
+	allErrors = append(allErrors, r.ValidateNodeNameUniqeness()...)
+
+	allErrors = append(allErrors, r.ValidateSharedLoadBalancerPorts()...)
+
+	if err := r.ValidateMissingBootnodes(); err != nil {
+		allErrors = append(allErrors, err)
+	}
+
+	allErrors = append(allErrors, r.ValidateLightServers()...)
+
+	return allErrors
+}
+
+// ValidateNodePools validates node pools names are unique and pools nodes don't use node specific settings
+func (r *Network) ValidateNodePools() field.ErrorList {
+	var poolErrors field.ErrorList
+	names := map[string]int{}
+	poolsPath := field.NewPath("spec").Child("nodePools")
+	msg := "must be none in node pool"
+
+	for i, pool := range r.Spec.NodePools {
+		poolPath := poolsPath.Index(i)
+
+		if j, exists := names[pool.Name]; exists {
+			err := field.Invalid(poolPath.Child("name"), pool.Name, fmt.Sprintf("already used by spec.nodePools[%d].name", j))
+			poolErrors = append(poolErrors, err)
+		} else {
+			names[pool.Name] = i
+		}
+
+		// all pool nodes would share the same node key and addresses
+		if pool.IsBootnode() {
+			err := field.Invalid(poolPath.Child("bootnode"), true, "must be false in node pool")
+			poolErrors = append(poolErrors, err)
+		}
+
+		if pool.Nodekey != "" {
+			err := field.Invalid(poolPath.Child("nodekey"), pool.Nodekey, msg)
+			poolErrors = append(poolErrors, err)
+		}
+
+		if pool.DNSName != "" {
+			err := field.Invalid(poolPath.Child("dnsName"), pool.DNSName, msg)
+			poolErrors = append(poolErrors, err)
+		}
+
+		if pool.AdvertisedAddress != "" {
+			err := field.Invalid(poolPath.Child("advertisedAddress"), pool.AdvertisedAddress, msg)
+			poolErrors = append(poolErrors, err)
+		}
+
+		// all pool nodes would request the same load balancer ip for the same ports
+		if pool.Service != nil && pool.Service.LoadBalancerIP != "" {
+			err := field.Invalid(poolPath.Child("service").Child("loadBalancerIP"), pool.Service.LoadBalancerIP, msg)
+			poolErrors = append(poolErrors, err)
+		}
+	}
+
+	return poolErrors
+}
+
+// ValidateLightServers validates private networks with light nodes have at least one node serving light clients
+// public networks light nodes are served by public network nodes
+func (r *Network) ValidateLightServers() field.ErrorList {
+	var lightErrors field.ErrorList
+
+	if !r.IsPrivate() {
+		return nil
+	}
+
+	for _, node := range r.Spec.Nodes {
+		if node.LightServe != 0 {
+			return nil
+		}
+	}
+
+	for i, node := range r.Spec.Nodes {
+		if node.SyncMode == LightSynchronization {
+			path := field.NewPath("spec").Child("nodes").Index(i).Child("syncMode")
+			err := field.Invalid(path, node.SyncMode, "requires at least one node serving light clients")
+			lightErrors = append(lightErrors, err)
+		}
+	}
+
+	return lightErrors
+}
+
+// ValidateGenesis validates network genesis block spec
+func (r *Network) ValidateGenesis() field.ErrorList {
+
+	var allErrors field.ErrorList
+
+	// join: can't specifiy genesis while joining existing network
+	if r.Spec.Join != "" {
+		err := field.Invalid(field.NewPath("spec").Child("join"), r.Spec.Join, "must be none if spec.genesis is specified")
+		allErrors = append(allErrors, err)
+	}
+
+	if r.Spec.Genesis.Permissioning != nil {
+		allErrors = append(allErrors, r.ValidatePermissioning()...)
+	}
+
+	// external genesis is used verbatim, it can't be mixed with inline genesis
+	if r.Spec.Genesis.IsExternal() {
+		allErrors = append(allErrors, r.ValidateExternalGenesis()...)
+		return allErrors
+	}
+
+	// sha256: checksum is verified for external genesis only
+	if r.Spec.Genesis.SHA256 != "" {
+		err := field.Invalid(field.NewPath("spec").Child("genesis").Child("sha256"), r.Spec.Genesis.SHA256, "must be none if genesis is not loaded from config map or url")
+		allErrors = append(allErrors, err)
+	}
+
+	// chainId: must be specified for inline genesis
+	if r.Spec.Genesis.ChainID == 0 {
+		err := field.Invalid(field.NewPath("spec").Child("genesis").Child("chainId"), "", "must be specified if genesis is not loaded from config map or url")
+		allErrors = append(allErrors, err)
+	}
+
+	// don't use existing network chain id
+	if chain := ChainByID[r.Spec.Genesis.ChainID]; chain != "" {
+		err := field.Invalid(field.NewPath("spec").Child("genesis").Child("chainId"), fmt.Sprintf("%d", r.Spec.Genesis.ChainID), fmt.Sprintf("can't use chain id of %s network to avoid tx replay", chain))
+		allErrors = append(allErrors, err)
+	}
+
+	// ethash must be nil of consensus is not Pow
+	if r.Spec.Consensus != ProofOfWork && r.Spec.Genesis.Ethash != nil {
+		err := field.Invalid(field.NewPath("spec").Child("consensus"), r.Spec.Consensus, fmt.Sprintf("must be %s if spec.genesis.ethash is specified", ProofOfWork))
+		allErrors = append(allErrors, err)
+	}
+
+	// clique must be nil of consensus is not PoA
+	if r.Spec.Consensus != ProofOfAuthority && r.Spec.Genesis.Clique != nil {
+		err := field.Invalid(field.NewPath("spec").Child("consensus"), r.Spec.Consensus, fmt.Sprintf("must be %s if spec.genesis.clique is specified", ProofOfAuthority))
+		allErrors = append(allErrors, err)
+	}
+
+	// ibft2 must be nil of consensus is not ibft2
+	if r.Spec.Consensus != IstanbulBFT && r.Spec.Genesis.IBFT2 != nil {
+		err := field.Invalid(field.NewPath("spec").Child("consensus"), r.Spec.Consensus, fmt.Sprintf("must be %s if spec.genesis.ibft2 is specified", IstanbulBFT))
+		allErrors = append(allErrors, err)
+	}
+
+	// validate consensus engine parameters
+	allErrors = append(allErrors, r.ValidateConsensusEngine()...)
+
+	// validate forks order
+	allErrors = append(allErrors, r.ValidateForksOrder()...)
+	return allErrors
+}
+
+// ValidatePermissioning validates onchain permissioning is used by besu nodes only
+// ingress contracts must be deployed in generated genesis, external genesis is used verbatim
+func (r *Network) ValidatePermissioning() field.ErrorList {
+	var permissioningErrors field.ErrorList
+	permissioning := r.Spec.Genesis.Permissioning
+	permissioningPath := field.NewPath("spec").Child("genesis").Child("permissioning")
+
+	for i, node := range r.Spec.Nodes {
+		if node.Client != BesuClient {
+			err := field.Invalid(field.NewPath("spec").Child("nodes").Index(i).Child("client"), node.Client, "must be besu if spec.genesis.permissioning is specified")
+			permissioningErrors = append(permissioningErrors, err)
+		}
+	}
+
+	if r.Spec.Genesis.IsExternal() {
+		return permissioningErrors
+	}
+
+	// contracts code by lowercase address
+	code := map[string]bool{}
+	for _, account := range r.Spec.Genesis.Accounts {
+		code[strings.ToLower(string(account.Address))] = account.Code != ""
+	}
+
+	if permissioning.Nodes && !code[strings.ToLower(string(permissioning.NodesContractAddress))] {
+		err := field.Invalid(permissioningPath.Child("nodesContractAddress"), permissioning.NodesContractAddress, "must be a genesis account with nodes ingress contract code")
+		permissioningErrors = append(permissioningErrors, err)
+	}
+
+	if permissioning.Accounts && !code[strings.ToLower(string(permissioning.AccountsContractAddress))] {
+		err := field.Invalid(permissioningPath.Child("accountsContractAddress"), permissioning.AccountsContractAddress, "must be a genesis account with accounts ingress contract code")
+		permissioningErrors = append(permissioningErrors, err)
+	}
+
+	return permissioningErrors
+}
+
+// ValidateConsensusEngine validates genesis consensus engine parameters
+// clique signers and ibft2 validators are encoded in genesis extraData
+func (r *Network) ValidateConsensusEngine() field.ErrorList {
+	var engineErrors field.ErrorList
+	genesis := r.Spec.Genesis
+	genesisPath := field.NewPath("spec").Child("genesis")
+
+	// ethash: difficulty must be greater than zero
+	if r.Spec.Consensus == ProofOfWork {
+		difficulty, ok := new(big.Int).SetString(strings.TrimPrefix(strings.ToLower(string(genesis.Difficulty)), "0x"), 16)
+		if ok && difficulty.Sign() == 0 {
+			err := field.Invalid(genesisPath.Child("difficulty"), genesis.Difficulty, "must be greater than zero for proof of work networks")
+			engineErrors = append(engineErrors, err)
+		}
+		if genesis.Ethash != nil && genesis.Ethash.FixedDifficulty != nil && *genesis.Ethash.FixedDifficulty == 0 {
+			err := field.Invalid(genesisPath.Child("ethash").Child("fixedDifficulty"), "0", "must be greater than zero")
+			engineErrors = append(engineErrors, err)
+		}
+	}
+
+	// clique: at least one signer is required to seal blocks, signers must be unique
+	if r.Spec.Consensus == ProofOfAuthority && genesis.Clique != nil {
+		signersPath := genesisPath.Child("clique").Child("signers")
+		engineErrors = append(engineErrors, validateExtraDataAddresses(signersPath, genesis.Clique.Signers)...)
+
+		// clique: instant sealing (0 block period) is supported by geth only
+		if genesis.Clique.InstantSeal {
+			if genesis.Clique.BlockPeriod != 0 {
+				err := field.Invalid(genesisPath.Child("clique").Child("blockPeriod"), genesis.Clique.BlockPeriod, "must be none if instantSeal is true")
+				engineErrors = append(engineErrors, err)
+			}
+			for i, node := range r.Spec.Nodes {
+				if node.Client != GethClient {
+					err := field.Invalid(field.NewPath("spec").Child("nodes").Index(i).Child("client"), node.Client, "must be geth if clique instantSeal is true")
+					engineErrors = append(engineErrors, err)
+				}
+			}
+		}
+	}
+
+	// ibft2: at least one validator is required to seal blocks, validators must be unique
+	if r.Spec.Consensus == IstanbulBFT && genesis.IBFT2 != nil {
+		validatorsPath := genesisPath.Child("ibft2").Child("validators")
+		engineErrors = append(engineErrors, validateExtraDataAddresses(validatorsPath, genesis.IBFT2.Validators)...)
+	}
+
+	return engineErrors
+}
+
+// validateExtraDataAddresses validates signers or validators to be encoded in genesis extraData
+func validateExtraDataAddresses(path *field.Path, addresses []EthereumAddress) field.ErrorList {
+	var addressesErrors field.ErrorList
+
+	if len(addresses) == 0 {
+		err := field.Invalid(path, "", "at least one address is required")
+		addressesErrors = append(addressesErrors, err)
+	}
+
+	seen := map[string]bool{}
+	for i, address := range addresses {
+		lower := strings.ToLower(string(address))
+		if seen[lower] {
+			err := field.Invalid(path.Index(i), address, "duplicate address")
+			addressesErrors = append(addressesErrors, err)
+		}
+		seen[lower] = true
+	}
+
+	return addressesErrors
+}
+
+// ValidateChainIDUniqueness validates that no other network in the namespace uses the same chain id
+func (r *Network) ValidateChainIDUniqueness() field.ErrorList {
+	var uniquenessErrors field.ErrorList
+
+	// external genesis chain id is unknown to the operator
+	if networkReader == nil || r.Spec.Genesis == nil || r.Spec.Genesis.IsExternal() {
+		return uniquenessErrors
+	}
+
+	var networks NetworkList
+	if err := networkReader.List(context.Background(), &networks, client.InNamespace(r.Namespace)); err != nil {
+		networklog.Error(err, "unable to list networks")
+		uniquenessErrors = append(uniquenessErrors, field.InternalError(field.NewPath("spec").Child("genesis").Child("chainId"), err))
+		return uniquenessErrors
+	}
+
+	for _, network := range networks.Items {
+		if network.Name == r.Name || network.Spec.Genesis == nil {
+			continue
+		}
+		if network.Spec.Genesis.ChainID == r.Spec.Genesis.ChainID {
+			err := field.Invalid(field.NewPath("spec").Child("genesis").Child("chainId"), fmt.Sprintf("%d", r.Spec.Genesis.ChainID), fmt.Sprintf("already used by network %s", network.Name))
+			uniquenessErrors = append(uniquenessErrors, err)
+		}
+	}
+
+	return uniquenessErrors
+}
+
+// ValidateExternalGenesis validates genesis loaded from config map or url
+func (r *Network) ValidateExternalGenesis() field.ErrorList {
+	var externalErrors field.ErrorList
+	genesis := r.Spec.Genesis
+	genesisPath := field.NewPath("spec").Child("genesis")
+
+	// fromConfigMap and fromURL are mutually exclusive
+	if genesis.FromConfigMap != nil && genesis.FromURL != "" {
+		err := field.Invalid(genesisPath.Child("fromURL"), genesis.FromURL, "must be none if spec.genesis.fromConfigMap is specified")
+		externalErrors = append(externalErrors, err)
+	}
+
+	inline := genesis.ChainID != 0 ||
+		genesis.Accounts != nil ||
+		genesis.AccountsFromMnemonic != nil ||
+		genesis.Coinbase != "" ||
+		genesis.Difficulty != "" ||
+		genesis.MixHash != "" ||
+		genesis.Ethash != nil ||
+		genesis.Clique != nil ||
+		genesis.IBFT2 != nil ||
+		genesis.Forks != nil ||
+		genesis.GasLimit != "" ||
+		genesis.Nonce != "" ||
+		genesis.Timestamp != ""
+
+	if inline {
+		err := field.Invalid(genesisPath, "", "inline genesis fields must be none if genesis is loaded from config map or url")
+		externalErrors = append(externalErrors, err)
+	}
+
+	return externalErrors
+}
+
+// ValidateDevMode validates geth development mode network
+func (r *Network) ValidateDevMode() field.ErrorList {
+	var devErrors field.ErrorList
+	msg := "must be none if spec.dev is specified"
+
+	if r.Spec.Join != "" {
+		devErrors = append(devErrors, field.Invalid(field.NewPath("spec").Child("join"), r.Spec.Join, msg))
+	}
+
+	if r.Spec.Genesis != nil {
+		devErrors = append(devErrors, field.Invalid(field.NewPath("spec").Child("genesis"), "", msg))
+	}
+
+	if r.Spec.Consensus != "" {
+		devErrors = append(devErrors, field.Invalid(field.NewPath("spec").Child("consensus"), r.Spec.Consensus, msg))
+	}
+
+	// development network is a single node chain
+	if len(r.Spec.Nodes) != 1 {
+		err := field.Invalid(field.NewPath("spec").Child("nodes"), len(r.Spec.Nodes), "must have a single node if spec.dev is specified")
+		devErrors = append(devErrors, err)
+	}
+
+	for i, node := range r.Spec.Nodes {
+		if node.Client != GethClient {
+			err := field.Invalid(field.NewPath("spec").Child("nodes").Index(i).Child("client"), node.Client, "must be geth if spec.dev is specified")
+			devErrors = append(devErrors, err)
+		}
+	}
+
+	return devErrors
+}
+
+// ValidateJoinFrom validates network joining exported network doesn't specify imported settings
+func (r *Network) ValidateJoinFrom() field.ErrorList {
+	var joinFromErrors field.ErrorList
+	specPath := field.NewPath("spec")
+	msg := "must be none if spec.joinFrom is provided"
+
+	if r.Spec.Join != "" {
+		err := field.Invalid(specPath.Child("join"), r.Spec.Join, msg)
+		joinFromErrors = append(joinFromErrors, err)
+	}
+
+	if r.Spec.ID != 0 {
+		err := field.Invalid(specPath.Child("id"), fmt.Sprintf("%d", r.Spec.ID), msg)
+		joinFromErrors = append(joinFromErrors, err)
+	}
+
+	if r.Spec.Consensus != "" {
+		err := field.Invalid(specPath.Child("consensus"), r.Spec.Consensus, msg)
+		joinFromErrors = append(joinFromErrors, err)
+	}
+
+	if r.Spec.Genesis != nil {
+		err := field.Invalid(specPath.Child("genesis"), "", msg)
+		joinFromErrors = append(joinFromErrors, err)
+	}
+
+	if r.Spec.Dev != nil {
+		err := field.Invalid(specPath.Child("dev"), "", msg)
+		joinFromErrors = append(joinFromErrors, err)
+	}
+
+	return joinFromErrors
+}
+
+// ValidateFrom validates cloned network doesn't specify copied settings
+func (r *Network) ValidateFrom() field.ErrorList {
+	var fromErrors field.ErrorList
+	specPath := field.NewPath("spec")
+	msg := "must be none if spec.from is provided"
+
+	if r.Spec.From.Network == r.Name {
+		err := field.Invalid(specPath.Child("from").Child("network"), r.Spec.From.Network, "must be another network")
+		fromErrors = append(fromErrors, err)
+	}
+
+	if r.Spec.Join != "" {
+		err := field.Invalid(specPath.Child("join"), r.Spec.Join, msg)
+		fromErrors = append(fromErrors, err)
+	}
+
+	if r.Spec.JoinFrom != nil {
+		err := field.Invalid(specPath.Child("joinFrom"), "", msg)
+		fromErrors = append(fromErrors, err)
+	}
+
+	if r.Spec.Consensus != "" {
+		err := field.Invalid(specPath.Child("consensus"), r.Spec.Consensus, msg)
+		fromErrors = append(fromErrors, err)
+	}
+
+	if r.Spec.Genesis != nil {
+		err := field.Invalid(specPath.Child("genesis"), "", msg)
+		fromErrors = append(fromErrors, err)
+	}
+
+	if r.Spec.Dev != nil {
+		err := field.Invalid(specPath.Child("dev"), "", msg)
+		fromErrors = append(fromErrors, err)
+	}
+
+	return fromErrors
+}
+
+// ValidateFaucet validates faucet is deployed in private network and served by a node with rpc enabled
+func (r *Network) ValidateFaucet() field.ErrorList {
+	var faucetErrors field.ErrorList
+	faucetPath := field.NewPath("spec").Child("faucet")
+
+	if !r.IsPrivate() {
+		err := field.Invalid(faucetPath, "", "must be none if spec.genesis or spec.dev is none")
+		faucetErrors = append(faucetErrors, err)
+	}
+
+	for _, node := range r.Spec.Nodes {
+		if node.Name != r.Spec.Faucet.Node {
+			continue
+		}
+		if !node.RPC {
+			err := field.Invalid(faucetPath.Child("node"), r.Spec.Faucet.Node, "node must have rpc enabled")
+			faucetErrors = append(faucetErrors, err)
+		}
+		if node.Service != nil && node.Service.Type == NoneService {
+			err := field.Invalid(faucetPath.Child("node"), r.Spec.Faucet.Node, "node must have service")
+			faucetErrors = append(faucetErrors, err)
+		}
+		return faucetErrors
+	}
+
+	err := field.Invalid(faucetPath.Child("node"), r.Spec.Faucet.Node, "must be one of spec.nodes")
+	faucetErrors = append(faucetErrors, err)
+
+	return faucetErrors
+}
+
+// ValidateExplorer validates explorer indexes a full node with rpc enabled
+func (r *Network) ValidateExplorer() field.ErrorList {
+	var explorerErrors field.ErrorList
+	nodePath := field.NewPath("spec").Child("explorer").Child("node")
+
+	for _, node := range r.Spec.Nodes {
+		if node.Name != r.Spec.Explorer.Node {
+			continue
+		}
+		if !node.RPC {
+			err := field.Invalid(nodePath, r.Spec.Explorer.Node, "node must have rpc enabled")
+			explorerErrors = append(explorerErrors, err)
+		}
+		if node.SyncMode != FullSynchronization {
+			err := field.Invalid(nodePath, r.Spec.Explorer.Node, "node must be full sync node")
+			explorerErrors = append(explorerErrors, err)
+		}
+		if node.Service != nil && node.Service.Type == NoneService {
+			err := field.Invalid(nodePath, r.Spec.Explorer.Node, "node must have service")
+			explorerErrors = append(explorerErrors, err)
+		}
+		return explorerErrors
+	}
+
+	err := field.Invalid(nodePath, r.Spec.Explorer.Node, "must be one of spec.nodes")
+	explorerErrors = append(explorerErrors, err)
+
+	return explorerErrors
+}
+
+// ValidateForksOrder validates that forks are in correct order
+func (r *Network) ValidateForksOrder() field.ErrorList {
+	var orderErrors field.ErrorList
+	forks := r.Spec.Genesis.Forks
+
+	forkNames := []string{
+		"homestead",
+		"eip150",
+		"eip155",
+		"eip155",
+		"byzantium",
+		"constantinople",
+		"petersburg",
+		"istanbul",
+		"muirglacier",
+	}
+
+	milestones := []uint{
+		forks.Homestead,
+		forks.EIP150,
+		forks.EIP155,
+		forks.EIP155,
+		forks.Byzantium,
+		forks.Constantinople,
+		forks.Petersburg,
+		forks.Istanbul,
+		forks.MuirGlacier,
+	}
+
+	for i := 1; i < len(milestones); i++ {
+		if milestones[i] < milestones[i-1] {
+			path := field.NewPath("spec").Child("genesis").Child("forks").Child(forkNames[i])
+			msg := fmt.Sprintf("Fork %s can't be activated (at block %d) before fork %s (at block %d)", forkNames[i], milestones[i], forkNames[i-1], milestones[i-1])
+			orderErrors = append(orderErrors, field.Invalid(path, fmt.Sprintf("%d", milestones[i]), msg))
+		}
+	}
+
+	return orderErrors
+
+}
+
+// Validate is the shared validation between create and update
+func (r *Network) Validate() field.ErrorList {
+	var validateErrors field.ErrorList
+
+	// consensus: can't specify consensus while joining existing network
+	if r.Spec.Join != "" && r.Spec.Consensus != "" {
+		err := field.Invalid(field.NewPath("spec").Child("consensus"), r.Spec.Consensus, "must be none while joining a network")
+		validateErrors = append(validateErrors, err)
+	}
+
+	// genesis: must specify genesis if there's no network to join
+	if r.Spec.Join == "" && !r.IsPrivate() {
+		err := field.Invalid(field.NewPath("spec").Child("genesis"), "", "must be specified if spec.join is none")
+		validateErrors = append(validateErrors, err)
+	}
+
+	// id: must be provided if join is none
+	if r.Spec.Join == "" && r.Spec.ID == 0 && r.Spec.Dev == nil && r.Spec.JoinFrom == nil {
+		err := field.Invalid(field.NewPath("spec").Child("id"), "", "must be specified if spec.join is none")
+		validateErrors = append(validateErrors, err)
+	}
+
+	// id: must be none if join is provided
+	if r.Spec.Join != "" && r.Spec.ID != 0 {
+		err := field.Invalid(field.NewPath("spec").Child("id"), fmt.Sprintf("%d", r.Spec.ID), "must be none if spec.join is provided")
+		validateErrors = append(validateErrors, err)
+	}
+
+	// consensus: must be provided if genesis is provided
+	if r.Spec.Genesis != nil && r.Spec.Consensus == "" {
+		err := field.Invalid(field.NewPath("spec").Child("consensus"), "", "must be specified if spec.genesis is provided")
+		validateErrors = append(validateErrors, err)
+	}
+
+	// ttl: protected networks can't be deleted
+	if r.IsProtected() && r.Spec.TTLSecondsAfterCreation != nil {
+		err := field.Invalid(field.NewPath("spec").Child("ttlSecondsAfterCreation"), *r.Spec.TTLSecondsAfterCreation, "must be none if network is protected")
+		validateErrors = append(validateErrors, err)
+	}
+
+	// validate joining exported network
+	if r.Spec.JoinFrom != nil {
+		validateErrors = append(validateErrors, r.ValidateJoinFrom()...)
+	}
+
+	// validate cloned network
+	if r.Spec.From != nil {
+		validateErrors = append(validateErrors, r.ValidateFrom()...)
+	}
+
+	// validate non nil genesis
+	if r.Spec.Genesis != nil {
+		validateErrors = append(validateErrors, r.ValidateGenesis()...)
+	}
+
+	// validate development mode
+	if r.Spec.Dev != nil {
+		validateErrors = append(validateErrors, r.ValidateDevMode()...)
+	}
+
+	// validate faucet
+	if r.Spec.Faucet != nil {
+		validateErrors = append(validateErrors, r.ValidateFaucet()...)
+	}
+
+	// validate explorer
+	if r.Spec.Explorer != nil {
+		validateErrors = append(validateErrors, r.ValidateExplorer()...)
+	}
+
+	// validate node pools
+	if len(r.Spec.NodePools) != 0 {
+		validateErrors = append(validateErrors, r.ValidateNodePools()...)
+	}
+
+	// validate nodes
+	if len(r.Spec.Nodes) == 0 {
+		err := field.Invalid(field.NewPath("spec").Child("nodes"), "", "must have at least one node in spec.nodes or spec.nodePools")
+		validateErrors = append(validateErrors, err)
+	} else {
+		validateErrors = append(validateErrors, r.ValidateNodes()...)
+	}
+
+	return validateErrors
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *Network) ValidateCreate() error {
+	var allErrors field.ErrorList
+
+	networklog.Info("validate create", "name", r.Name)
+
+	// shared validation rules with update
+	allErrors = append(allErrors, r.Validate()...)
+
+	// chain id is immutable, it's enough to check its uniqueness on creation
+	allErrors = append(allErrors, r.ValidateChainIDUniqueness()...)
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *Network) ValidateUpdate(old runtime.Object) error {
+	var allErrors field.ErrorList
+
+	networklog.Info("validate update", "name", r.Name)
+
+	// shared validation rules with create
+	allErrors = append(allErrors, r.Validate()...)
+
+	oldNetwork := old.(*Network)
+
+	if oldNetwork.Spec.ID != r.Spec.ID {
+		err := field.Invalid(field.NewPath("spec").Child("id"), fmt.Sprintf("%d", r.Spec.ID), "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	if oldNetwork.Spec.Join != r.Spec.Join {
+		err := field.Invalid(field.NewPath("spec").Child("join"), r.Spec.Join, "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	if !reflect.DeepEqual(oldNetwork.Spec.JoinFrom, r.Spec.JoinFrom) {
+		err := field.Invalid(field.NewPath("spec").Child("joinFrom"), "", "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	if !reflect.DeepEqual(oldNetwork.Spec.From, r.Spec.From) {
+		err := field.Invalid(field.NewPath("spec").Child("from"), "", "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	if oldNetwork.Spec.Consensus != r.Spec.Consensus {
+		err := field.Invalid(field.NewPath("spec").Child("consensus"), r.Spec.Consensus, "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	// TODO: move to validate genesis
+	if !reflect.DeepEqual(r.Spec.Genesis, oldNetwork.Spec.Genesis) {
+		err := field.Invalid(field.NewPath("spec").Child("genesis"), "", "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	// maximum allowed nodes with different name
+	var maxDiff int
+	// all old nodes names
+	oldNodesNames := map[string]bool{}
+	// nodes count in the old network spec
+	oldNodesCount := len(oldNetwork.Spec.Nodes)
+	// nodes count in the new network spec
+	newNodesCount := len(r.Spec.Nodes)
+	// nodes with different names than the old spec
+	differentNodes := map[string]int{}
+
+	if newNodesCount > oldNodesCount {
+		maxDiff = newNodesCount - oldNodesCount
+	}
+
+	for _, node := range oldNetwork.Spec.Nodes {
+		oldNodesNames[node.Name] = true
+	}
+
+	for i, node := range r.Spec.Nodes {
+		if exists := oldNodesNames[node.Name]; !exists {
+			differentNodes[node.Name] = i
+		}
+	}
+
+	if len(differentNodes) > maxDiff {
+		for nodeName, i := range differentNodes {
+			err := field.Invalid(field.NewPath("spec").Child("nodes").Index(i).Child("name"), nodeName, "field is immutable")
+			allErrors = append(allErrors, err)
+		}
+	}
+
+	// service ip family can't be changed after service creation
+	oldIPFamilies := map[string]IPFamily{}
+	for _, node := range oldNetwork.Spec.Nodes {
+		oldIPFamilies[node.Name] = node.IPFamily
+	}
+
+	for i, node := range r.Spec.Nodes {
+		if family, exists := oldIPFamilies[node.Name]; exists && family != node.IPFamily {
+			err := field.Invalid(field.NewPath("spec").Child("nodes").Index(i).Child("ipFamily"), node.IPFamily, "field is immutable")
+			allErrors = append(allErrors, err)
+		}
+	}
+
+	// node data can't be moved between storage modes or kubernetes nodes directories
+	oldStorage := map[string]StorageMode{}
+	oldLocal := map[string]LocalStorage{}
+	for _, node := range oldNetwork.Spec.Nodes {
+		oldStorage[node.Name] = node.StorageMode()
+		if node.Local != nil {
+			oldLocal[node.Name] = *node.Local
+		}
+	}
+
+	for i, node := range r.Spec.Nodes {
+		if storage, exists := oldStorage[node.Name]; exists && storage != node.StorageMode() {
+			err := field.Invalid(field.NewPath("spec").Child("nodes").Index(i).Child("storage"), node.Storage, "field is immutable")
+			allErrors = append(allErrors, err)
+		}
+		if local, exists := oldLocal[node.Name]; exists && node.Local != nil && local != *node.Local {
+			err := field.Invalid(field.NewPath("spec").Child("nodes").Index(i).Child("local"), "", "field is immutable")
+			allErrors = append(allErrors, err)
+		}
+	}
+
+	// node data is restored only when node data pvc is created
+	oldRestoreSnapshots := map[string]string{}
+	for _, node := range oldNetwork.Spec.Nodes {
+		oldRestoreSnapshots[node.Name] = node.RestoreSnapshotName()
+	}
+
+	for i, node := range r.Spec.Nodes {
+		if snapshot, exists := oldRestoreSnapshots[node.Name]; exists && snapshot != node.RestoreSnapshotName() {
+			err := field.Invalid(field.NewPath("spec").Child("nodes").Index(i).Child("restore").Child("volumeSnapshotName"), node.RestoreSnapshotName(), "field is immutable")
+			allErrors = append(allErrors, err)
+		}
+	}
+
+	// besu data storage format is set when node database is created
+	oldDataStorageFormats := map[string]DataStorageFormat{}
+	for _, node := range oldNetwork.Spec.Nodes {
+		oldDataStorageFormats[node.Name] = node.DataStorageFormat()
+	}
+
+	for i, node := range r.Spec.Nodes {
+		if format, exists := oldDataStorageFormats[node.Name]; exists && format != node.DataStorageFormat() {
+			err := field.Invalid(field.NewPath("spec").Child("nodes").Index(i).Child("besu").Child("dataStorageFormat"), node.DataStorageFormat(), "field is immutable")
+			allErrors = append(allErrors, err)
+		}
+	}
+
+	// critical nodes of protected network can't be removed, unless protection is removed first
+	if oldNetwork.IsProtected() {
+		newNodesNames := map[string]bool{}
+		for _, node := range r.Spec.Nodes {
+			newNodesNames[node.Name] = true
+		}
+
+		for i, node := range oldNetwork.Spec.Nodes {
+			if node.IsCritical() && !newNodesNames[node.Name] {
+				err := field.Invalid(field.NewPath("spec").Child("nodes").Index(i), node.Name, "critical node can't be removed from protected network")
+				allErrors = append(allErrors, err)
+			}
+		}
+	}
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *Network) ValidateDelete() error {
+	networklog.Info("validate delete", "name", r.Name)
+
+	if r.IsProtected() {
+		return fmt.Errorf("network %s is protected, remove %s annotation to delete it", r.Name, ProtectedAnnotation)
+	}
+
+	return nil
+}
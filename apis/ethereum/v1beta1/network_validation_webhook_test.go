@@ -0,0 +1,2981 @@
+package v1beta1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Ethereum network validation", func() {
+
+	var (
+		networkID         uint = 77777
+		newNetworkID      uint = 8888
+		fixedDifficulty   uint = 1500
+		coinbase               = EthereumAddress("0xd2c21213027cbf4d46c16b55fa98e5252b048706")
+		privatekey             = PrivateKey("0x608e9b6f67c65e47531e08e8e501386dfae63a540fa3c48802c8aad854510b4e")
+		wrongPrivatekey        = PrivateKey("0x608e9b6f67c65e47531e08e8e501386dfae63a540fa3c48802c8aad854510b4f")
+		invalidPrivatekey      = PrivateKey("0x0000000000000000000000000000000000000000000000000000000000000000")
+	)
+
+	createCases := []struct {
+		Title   string
+		Network *Network
+		Errors  field.ErrorList
+	}{
+		{
+			Title: "network #1",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join:      RinkebyNetwork,
+					Consensus: ProofOfWork,
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.consensus",
+					BadValue: ProofOfWork,
+					Detail:   "must be none while joining a network",
+				},
+			},
+		},
+		{
+			Title: "network #2",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Genesis: &Genesis{
+						ChainID: 444,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.join",
+					BadValue: RinkebyNetwork,
+					Detail:   "must be none if spec.genesis is specified",
+				},
+			},
+		},
+		{
+			Title: "network #3",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.genesis",
+					BadValue: "",
+					Detail:   "must be specified if spec.join is none",
+				},
+			},
+		},
+		{
+			Title: "network #4",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					Genesis: &Genesis{
+						ChainID: 1,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.genesis.chainId",
+					BadValue: "1",
+					Detail:   "can't use chain id of mainnet network to avoid tx replay",
+				},
+			},
+		},
+		{
+			Title: "network #5",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfWork,
+					Genesis: &Genesis{
+						ChainID: 55555,
+						Clique:  &Clique{},
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.consensus",
+					BadValue: ProofOfWork,
+					Detail:   "must be poa if spec.genesis.clique is specified",
+				},
+			},
+		},
+		{
+			Title: "network #6",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfWork,
+					Genesis: &Genesis{
+						ChainID: 55555,
+						IBFT2:   &IBFT2{},
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.consensus",
+					BadValue: ProofOfWork,
+					Detail:   "must be ibft2 if spec.genesis.ibft2 is specified",
+				},
+			},
+		},
+		{
+			Title: "network #7",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: IstanbulBFT,
+					Genesis: &Genesis{
+						ChainID: 55555,
+						Ethash:  &Ethash{},
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.consensus",
+					BadValue: IstanbulBFT,
+					Detail:   "must be pow if spec.genesis.ethash is specified",
+				},
+			},
+		},
+		{
+			Title: "network #8",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: IstanbulBFT,
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+						{
+							Name: "node-2",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].bootnode",
+					BadValue: false,
+					Detail:   "first node must be a bootnode if private network has multiple nodes, nodes can't discover each other otherwise",
+				},
+			},
+		},
+		{
+			Title: "network #9",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: IstanbulBFT,
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[1].name",
+					BadValue: "node-1",
+					Detail:   "already used by spec.nodes[0].name",
+				},
+			},
+		},
+		{
+			Title: "network #10",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: IstanbulBFT,
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							Bootnode: true,
+						},
+						{
+							Name: "node-2",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].nodekey",
+					BadValue: "",
+					Detail:   "must provide nodekey if bootnode is true",
+				},
+			},
+		},
+		{
+			Title: "network #11",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: IstanbulBFT,
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Nodes: []Node{
+						{
+							Name:  "node-1",
+							Miner: true,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].coinbase",
+					BadValue: "",
+					Detail:   "must provide coinbase if miner is true",
+				},
+			},
+		},
+		{
+			Title: "network #12",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: IstanbulBFT,
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							Coinbase: EthereumAddress("0x676aEda2E67D24eb304cFf75A5190824831E3399"),
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].miner",
+					BadValue: false,
+					Detail:   "must set miner to true if coinbase is provided",
+				},
+			},
+		},
+		{
+			Title: "network #13",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.consensus",
+					BadValue: "",
+					Detail:   "must be specified if spec.genesis is provided",
+				},
+			},
+		},
+		{
+			Title: "network #14",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					Genesis: &Genesis{
+						ChainID: 55555,
+						Forks: &Forks{
+							EIP150:    1,
+							Homestead: 2,
+						},
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.genesis.forks.eip150",
+					BadValue: "1",
+					Detail:   "Fork eip150 can't be activated (at block 1) before fork homestead (at block 2)",
+				},
+			},
+		},
+		{
+			Title: "network #15",
+			Network: &Network{
+				Spec: NetworkSpec{
+					ID:   networkID,
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.id",
+					BadValue: fmt.Sprintf("%d", networkID),
+					Detail:   "must be none if spec.join is provided",
+				},
+			},
+		},
+		{
+			Title: "network #16",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfWork,
+					Genesis:   &Genesis{},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.id",
+					BadValue: "",
+					Detail:   "must be specified if spec.join is none",
+				},
+			},
+		},
+		{
+			Title: "network #17",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfWork,
+					ID:        networkID,
+					Genesis:   &Genesis{},
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							Client:   GethClient,
+							Miner:    true,
+							Coinbase: coinbase,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].import",
+					BadValue: "",
+					Detail:   "must import coinbase account",
+				},
+			},
+		},
+		{
+			Title: "network #18",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfWork,
+					ID:        networkID,
+					Genesis:   &Genesis{},
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							Client:   GethClient,
+							Miner:    true,
+							Coinbase: coinbase,
+							Import: &ImportedAccount{
+								PrivateKey: wrongPrivatekey,
+								Password:   "secret",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].import.privatekey",
+					BadValue: "<private key>",
+					Detail:   "private key doesn't correspond to the coinbase address",
+				},
+			},
+		},
+		{
+			Title: "network #19",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfWork,
+					ID:        networkID,
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							Miner:    true,
+							Coinbase: coinbase,
+							Import: &ImportedAccount{
+								PrivateKey: privatekey,
+								Password:   "secret",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].client",
+					BadValue: "besu",
+					Detail:   "must be geth if import is provided",
+				},
+			},
+		},
+		{
+			Title: "network #20",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: IstanbulBFT,
+					ID:        networkID,
+					Nodes: []Node{
+						{
+							Name:   "node-1",
+							Client: GethClient,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].client",
+					BadValue: "geth",
+					Detail:   "client doesn't support ibft2 consensus",
+				},
+			},
+		},
+		{
+			Title: "network #21",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					ID:        networkID,
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							Client:   GethClient,
+							RPC:      true,
+							Miner:    true,
+							Coinbase: coinbase,
+							Import: &ImportedAccount{
+								PrivateKey: privatekey,
+								Password:   "secret",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].rpc",
+					BadValue: true,
+					Detail:   "must be false if import is provided",
+				},
+			},
+		},
+		{
+			Title: "network #22",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					ID:        networkID,
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							Client:   GethClient,
+							WS:       true,
+							Miner:    true,
+							Coinbase: coinbase,
+							Import: &ImportedAccount{
+								PrivateKey: privatekey,
+								Password:   "secret",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].ws",
+					BadValue: true,
+					Detail:   "must be false if import is provided",
+				},
+			},
+		},
+		{
+			Title: "network #23",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					ID:        networkID,
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							Client:   GethClient,
+							GraphQL:  true,
+							Miner:    true,
+							Coinbase: coinbase,
+							Import: &ImportedAccount{
+								PrivateKey: privatekey,
+								Password:   "secret",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].graphql",
+					BadValue: true,
+					Detail:   "must be false if import is provided",
+				},
+			},
+		},
+		{
+			Title: "network #24",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfWork,
+					ID:        networkID,
+					Genesis: &Genesis{
+						Ethash: &Ethash{
+							FixedDifficulty: &fixedDifficulty,
+						},
+					},
+					Nodes: []Node{
+						{
+							Name:   "node-1",
+							Client: GethClient,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].client",
+					BadValue: "geth",
+					Detail:   "client doesn't support fixed difficulty pow networks",
+				},
+			},
+		},
+		{
+			Title: "network #25",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							Client:   BesuClient,
+							SyncMode: LightSynchronization,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].client",
+					BadValue: "besu",
+					Detail:   "must be geth if syncMode is light",
+				},
+			},
+		},
+		{
+			Title: "network #26",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:   "node-1",
+							Client: BesuClient,
+							Resources: &NodeResources{
+								CPU:      "2",
+								CPULimit: "1",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].resources.cpuLimit",
+					BadValue: "1",
+					Detail:   "must be greater than or equal to cpu 2",
+				},
+			},
+		},
+		{
+			Title: "network #27",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:   "node-1",
+							Client: BesuClient,
+							Resources: &NodeResources{
+								CPU:         "1",
+								CPULimit:    "2",
+								Memory:      "2Gi",
+								MemoryLimit: "1Gi",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].resources.memoryLimit",
+					BadValue: "1Gi",
+					Detail:   "must be greater than or equal to memory 2Gi",
+				},
+			},
+		},
+		{
+			Title: "network #28",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfWork,
+					ID:        networkID,
+					Genesis:   &Genesis{},
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							Client:   GethClient,
+							Miner:    true,
+							Coinbase: coinbase,
+							Import: &ImportedAccount{
+								PrivateKey: invalidPrivatekey,
+								Password:   "secret",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].import.privatekey",
+					BadValue: "<private key>",
+					Detail:   "private key doesn't correspond to the coinbase address",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].import.privatekey",
+					BadValue: "<private key>",
+					Detail:   "invalid private key",
+				},
+			},
+		},
+		{
+			Title: "network #29",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					ID:        networkID,
+					Genesis: &Genesis{
+						FromConfigMap: &GenesisConfigMapSource{
+							Name: "genesis",
+						},
+						FromURL: "https://example.com/genesis.json",
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.genesis.fromURL",
+					BadValue: "https://example.com/genesis.json",
+					Detail:   "must be none if spec.genesis.fromConfigMap is specified",
+				},
+			},
+		},
+		{
+			Title: "network #30",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					ID:        networkID,
+					Genesis: &Genesis{
+						ChainID: 4444,
+						FromURL: "https://example.com/genesis.json",
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.genesis",
+					BadValue: "",
+					Detail:   "inline genesis fields must be none if genesis is loaded from config map or url",
+				},
+			},
+		},
+		{
+			Title: "network #31",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					ID:        networkID,
+					Genesis:   &Genesis{},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.genesis.chainId",
+					BadValue: "",
+					Detail:   "must be specified if genesis is not loaded from config map or url",
+				},
+			},
+		},
+		{
+			Title: "network #32",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfWork,
+					ID:        networkID,
+					Genesis: &Genesis{
+						ChainID:    4444,
+						Difficulty: "0x0",
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.genesis.difficulty",
+					BadValue: HexString("0x0"),
+					Detail:   "must be greater than zero for proof of work networks",
+				},
+			},
+		},
+		{
+			Title: "network #33",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					ID:        networkID,
+					Genesis: &Genesis{
+						ChainID: 4444,
+						Clique: &Clique{
+							Signers: []EthereumAddress{
+								coinbase,
+								coinbase,
+							},
+						},
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.genesis.clique.signers[1]",
+					BadValue: coinbase,
+					Detail:   "duplicate address",
+				},
+			},
+		},
+		{
+			Title: "network #34",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: IstanbulBFT,
+					ID:        networkID,
+					Genesis: &Genesis{
+						ChainID: 4444,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.genesis.ibft2.validators",
+					BadValue: "",
+					Detail:   "at least one address is required",
+				},
+			},
+		},
+		{
+			Title: "network #35",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Dev:  &DevMode{},
+					Nodes: []Node{
+						{
+							Name:   "node-1",
+							Client: BesuClient,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.join",
+					BadValue: RinkebyNetwork,
+					Detail:   "must be none if spec.dev is specified",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].client",
+					BadValue: BesuClient,
+					Detail:   "must be geth if spec.dev is specified",
+				},
+			},
+		},
+		{
+			Title: "network #36",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Dev: &DevMode{},
+					Nodes: []Node{
+						{
+							Name:   "node-1",
+							Client: GethClient,
+						},
+						{
+							Name:   "node-2",
+							Client: GethClient,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes",
+					BadValue: 2,
+					Detail:   "must have a single node if spec.dev is specified",
+				},
+			},
+		},
+		{
+			Title: "network #37",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Faucet: &Faucet{
+						Node:                 "node-1",
+						PrivateKeySecretName: "faucet-key",
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.faucet",
+					BadValue: "",
+					Detail:   "must be none if spec.genesis or spec.dev is none",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.faucet.node",
+					BadValue: "node-1",
+					Detail:   "node must have rpc enabled",
+				},
+			},
+		},
+		{
+			Title: "network #38",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					ID:        networkID,
+					Genesis: &Genesis{
+						ChainID: 4444,
+					},
+					Faucet: &Faucet{
+						Node:                 "node-2",
+						PrivateKeySecretName: "faucet-key",
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+							RPC:  true,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.faucet.node",
+					BadValue: "node-2",
+					Detail:   "must be one of spec.nodes",
+				},
+			},
+		},
+		{
+			Title: "network #39",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Explorer: &Explorer{
+						Node: "node-1",
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.explorer.node",
+					BadValue: "node-1",
+					Detail:   "node must have rpc enabled",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.explorer.node",
+					BadValue: "node-1",
+					Detail:   "node must be full sync node",
+				},
+			},
+		},
+		{
+			Title: "network #40",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:        "node-1",
+							P2PPort:     30303,
+							RPC:         true,
+							RPCPort:     8545,
+							WS:          true,
+							WSPort:      8545,
+							GraphQL:     true,
+							GraphQLPort: 30303,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].wsPort",
+					BadValue: uint(8545),
+					Detail:   "already used by rpcPort",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].graphqlPort",
+					BadValue: uint(30303),
+					Detail:   "already used by p2pPort",
+				},
+			},
+		},
+		{
+			Title: "network #41",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name: "node-1",
+							Service: &NodeService{
+								Type:                     NodePortService,
+								LoadBalancerIP:           "10.0.0.300",
+								LoadBalancerSourceRanges: []string{"10.0.0.0/8", "192.168.1.1"},
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].service.type",
+					BadValue: NodePortService,
+					Detail:   "must be LoadBalancer if loadBalancerIP is provided",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].service.loadBalancerIP",
+					BadValue: "10.0.0.300",
+					Detail:   "must be a valid ip address",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].service.type",
+					BadValue: NodePortService,
+					Detail:   "must be LoadBalancer if loadBalancerSourceRanges is provided",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].service.loadBalancerSourceRanges[1]",
+					BadValue: "192.168.1.1",
+					Detail:   "must be a valid cidr",
+				},
+			},
+		},
+		{
+			Title: "network #42",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:    "node-1",
+							Role:    BootnodeRole,
+							RPC:     true,
+							Metrics: true,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].nodekey",
+					BadValue: "",
+					Detail:   "must provide nodekey if bootnode is true",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].rpc",
+					BadValue: true,
+					Detail:   "must be false if role is bootnode",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].metrics",
+					BadValue: true,
+					Detail:   "must be false if role is bootnode",
+				},
+			},
+		},
+		{
+			Title: "network #43",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Nodes: []Node{
+						{
+							Name:       "node-1",
+							Client:     BesuClient,
+							LightServe: 50,
+						},
+						{
+							Name:     "node-2",
+							Client:   GethClient,
+							SyncMode: LightSynchronization,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].client",
+					BadValue: BesuClient,
+					Detail:   "must be geth if lightServe is provided",
+				},
+			},
+		},
+		{
+			Title: "network #44",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							Client:   GethClient,
+							SyncMode: LightSynchronization,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].syncMode",
+					BadValue: LightSynchronization,
+					Detail:   "requires at least one node serving light clients",
+				},
+			},
+		},
+		{
+			Title: "network #45",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:  "node-1",
+							Cache: 8192,
+							Resources: &NodeResources{
+								MemoryLimit: "8Gi",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].cache",
+					BadValue: uint(8192),
+					Detail:   "must be less than memory limit 8Gi",
+				},
+			},
+		},
+		{
+			Title: "network #46",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:              "node-1",
+							AdvertisedAddress: "bootnode.example.com",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].advertisedAddress",
+					BadValue: "bootnode.example.com",
+					Detail:   "must be a valid ip address",
+				},
+			},
+		},
+		{
+			Title: "network #47",
+			Network: &Network{
+				Spec: NetworkSpec{
+					ID:        networkID,
+					Consensus: ProofOfAuthority,
+					JoinFrom: &JoinFrom{
+						ConfigMapName: "exported-network",
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.id",
+					BadValue: fmt.Sprintf("%d", networkID),
+					Detail:   "must be none if spec.joinFrom is provided",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.consensus",
+					BadValue: ProofOfAuthority,
+					Detail:   "must be none if spec.joinFrom is provided",
+				},
+			},
+		},
+		{
+			Title: "network #48",
+			Network: &Network{
+				Spec: NetworkSpec{
+					ID:        networkID,
+					Consensus: ProofOfWork,
+					Genesis: &Genesis{
+						ChainID: 55555,
+						SHA256:  "5c4e7b7a4d0e7c8a1b9f1e2d3c4b5a69788796a5b4c3d2e1f0a9b8c7d6e5f4a3",
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.genesis.sha256",
+					BadValue: "5c4e7b7a4d0e7c8a1b9f1e2d3c4b5a69788796a5b4c3d2e1f0a9b8c7d6e5f4a3",
+					Detail:   "must be none if genesis is not loaded from config map or url",
+				},
+			},
+		},
+		{
+			Title: "network #49",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:     "bootnode",
+							Bootnode: true,
+							Nodekey:  privatekey,
+						},
+					},
+					NodePools: []NodePool{
+						{
+							Node: Node{
+								Name:     "rpc",
+								Bootnode: true,
+								Nodekey:  privatekey,
+							},
+							Replicas: 2,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodePools[0].bootnode",
+					BadValue: true,
+					Detail:   "must be false in node pool",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodePools[0].nodekey",
+					BadValue: privatekey,
+					Detail:   "must be none in node pool",
+				},
+			},
+		},
+		{
+			Title: "network #50",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes",
+					BadValue: "",
+					Detail:   "must have at least one node in spec.nodes or spec.nodePools",
+				},
+			},
+		},
+		{
+			Title: "network #51",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name: "node-1",
+							Sidecars: []corev1.Container{
+								{
+									Name:  "node",
+									Image: "exporter:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].sidecars[0].name",
+					BadValue: "node",
+					Detail:   "already used by another node container",
+				},
+			},
+		},
+		{
+			Title: "network #52",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name: "node-1",
+							InitScripts: []InitScript{
+								{
+									Name:          "download",
+									ConfigMapName: "scripts",
+								},
+								{
+									Name:          "download",
+									ConfigMapName: "scripts",
+								},
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].initScripts[1].name",
+					BadValue: "download",
+					Detail:   "already used by another node init container",
+				},
+			},
+		},
+		{
+			Title: "network #53",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name: "node-1",
+							ServiceAnnotations: map[string]string{
+								"internal load balancer": "true",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].serviceAnnotations",
+					BadValue: "internal load balancer",
+					Detail:   validation.IsQualifiedName("internal load balancer")[0],
+				},
+			},
+		},
+		{
+			Title: "network #54",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:          "node-1",
+							StorageMemory: true,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].storageMemory",
+					BadValue: true,
+					Detail:   "can only be used with ephemeral storage",
+				},
+			},
+		},
+		{
+			Title: "network #55",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfWork,
+					Genesis: &Genesis{
+						ChainID: 55555,
+						Permissioning: &OnchainPermissioning{
+							Nodes: true,
+						},
+					},
+					Nodes: []Node{
+						{
+							Name:   "node-1",
+							Client: GethClient,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].client",
+					BadValue: GethClient,
+					Detail:   "must be besu if spec.genesis.permissioning is specified",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.genesis.permissioning.nodesContractAddress",
+					BadValue: DefaultNodesIngressContractAddress,
+					Detail:   "must be a genesis account with nodes ingress contract code",
+				},
+			},
+		},
+		{
+			Title: "network #56",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfWork,
+					Genesis: &Genesis{
+						ChainID: 55555,
+						Accounts: []Account{
+							{
+								Address: DefaultAccountsIngressContractAddress,
+								Balance: "0x0",
+							},
+						},
+						Permissioning: &OnchainPermissioning{
+							Accounts: true,
+						},
+					},
+					Nodes: []Node{
+						{
+							Name:   "node-1",
+							Client: BesuClient,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.genesis.permissioning.accountsContractAddress",
+					BadValue: DefaultAccountsIngressContractAddress,
+					Detail:   "must be a genesis account with accounts ingress contract code",
+				},
+			},
+		},
+		{
+			Title: "network #57",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							Client:   GethClient,
+							Coinbase: "0x2b3430337f12Ce89EaBC7b0d865F4253c7744c0d",
+							Import: &ImportedAccount{
+								PrivateKey: "0x5df5eff7ef9e4e82739b68a34c6b23608d79ee8daf3b598a01ffb0dd7aa3a2fd",
+								Password:   "secret",
+							},
+							Keystore: &KeystoreSecret{
+								SecretName: "signer-keystore",
+							},
+						},
+						{
+							Name:   "node-2",
+							Client: BesuClient,
+							Keystore: &KeystoreSecret{
+								SecretName: "signer-keystore",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].keystore",
+					BadValue: "signer-keystore",
+					Detail:   "must be none if import is provided",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[1].client",
+					BadValue: BesuClient,
+					Detail:   "must be geth if keystore is provided",
+				},
+			},
+		},
+		{
+			Title: "network #58",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Explorer: &Explorer{
+						Node: "node-2",
+					},
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							Client:   BesuClient,
+							Bootnode: true,
+							Service: &NodeService{
+								Type: NoneService,
+							},
+						},
+						{
+							Name:     "node-2",
+							Client:   BesuClient,
+							RPC:      true,
+							SyncMode: FullSynchronization,
+							Service: &NodeService{
+								Type: NoneService,
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].service.type",
+					BadValue: NoneService,
+					Detail:   "must not be None if node is bootnode",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.explorer.node",
+					BadValue: "node-2",
+					Detail:   "node must have service",
+				},
+			},
+		},
+		{
+			Title: "network #59",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							Client:   BesuClient,
+							RPCProxy: &RPCProxy{},
+						},
+						{
+							Name:    "node-2",
+							Client:  BesuClient,
+							RPC:     true,
+							RPCPort: 8555,
+							RPCProxy: &RPCProxy{
+								Port: 8555,
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].rpc",
+					BadValue: false,
+					Detail:   "must be true if rpcProxy is provided",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[1].rpcProxy.port",
+					BadValue: uint(8555),
+					Detail:   "already used by rpcPort",
+				},
+			},
+		},
+		{
+			Title: "network #60",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:          "node-1",
+							Client:        BesuClient,
+							WSIngressHost: "ws.example.com",
+						},
+						{
+							Name:          "node-2",
+							Client:        BesuClient,
+							WS:            true,
+							WSAPI:         []API{ETHAPI, AdminAPI},
+							WSIngressHost: "ws.example.com",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].ws",
+					BadValue: false,
+					Detail:   "must be true if wsIngressHost is provided",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[1].wsAPI",
+					BadValue: []API{ETHAPI, AdminAPI},
+					Detail:   "must not include sensitive apis if wsIngressHost is provided",
+				},
+			},
+		},
+		{
+			Title: "network #61",
+			Network: &Network{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ledger",
+				},
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name: "Node_1",
+						},
+						{
+							Name: strings.Repeat("a", 50),
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].name",
+					BadValue: "Node_1",
+					Detail:   validation.IsDNS1123Label("Node_1")[0],
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[1].name",
+					BadValue: strings.Repeat("a", 50),
+					Detail:   "must be no more than 47 characters, node resources names can't exceed 63 characters",
+				},
+			},
+		},
+		{
+			Title: "network #62",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:   "node-1",
+							Client: GethClient,
+							Besu: &BesuOptions{
+								DataStorageFormat: BonsaiDataStorage,
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].client",
+					BadValue: GethClient,
+					Detail:   "must be besu if besu is provided",
+				},
+			},
+		},
+		{
+			Title: "network #63",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:    "node-1",
+							Storage: EphemeralStorage,
+							Snapshots: &SnapshotPolicy{
+								Schedule: metav1.Duration{Duration: 5 * time.Minute},
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].snapshots",
+					BadValue: "",
+					Detail:   "must be none if storage is ephemeral",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].snapshots.schedule",
+					BadValue: "5m0s",
+					Detail:   "must be at least 15m0s",
+				},
+			},
+		},
+		{
+			Title: "network #64",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:    "node-1",
+							RPC:     true,
+							RPCPort: 8556,
+							Backup: &BackupPolicy{
+								Schedule:              metav1.Duration{Duration: 30 * time.Minute},
+								Provider:              S3BackupProvider,
+								Bucket:                "chain-backups",
+								CredentialsSecretName: "s3-credentials",
+							},
+							Sidecars: []corev1.Container{
+								{
+									Name: "backup",
+								},
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].backup.schedule",
+					BadValue: "30m0s",
+					Detail:   "must be at least 1h0m0s",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].backup.port",
+					BadValue: DefaultBackupPort,
+					Detail:   "already used by rpcPort",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].sidecars[0].name",
+					BadValue: "backup",
+					Detail:   "already used by another node container",
+				},
+			},
+		},
+		{
+			Title: "network #65",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:    "node-1",
+							Storage: EphemeralStorage,
+							Restore: &NodeRestore{
+								VolumeSnapshotName: "synced-node",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].restore",
+					BadValue: "",
+					Detail:   "must be none if storage is ephemeral",
+				},
+			},
+		},
+		{
+			Title: "network #66",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:            "node-1",
+							MetricsExporter: &MetricsExporter{},
+						},
+						{
+							Name:            "node-2",
+							RPC:             true,
+							RPCPort:         9090,
+							MetricsExporter: &MetricsExporter{},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].rpc",
+					BadValue: false,
+					Detail:   "must be true if metricsExporter is provided",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[1].metricsExporter.port",
+					BadValue: DefaultMetricsExporterPort,
+					Detail:   "already used by rpcPort",
+				},
+			},
+		},
+		{
+			Title: "network #67",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:    "node-1",
+							Storage: HostPathStorage,
+							Snapshots: &SnapshotPolicy{
+								Schedule: metav1.Duration{Duration: time.Hour},
+							},
+						},
+						{
+							Name: "node-2",
+							Local: &LocalStorage{
+								Path:     "/mnt/nvme0",
+								NodeName: "worker-1",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].local",
+					BadValue: "",
+					Detail:   "must be provided if storage is hostPath",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].snapshots",
+					BadValue: "",
+					Detail:   "must be none if storage is hostPath",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[1].local",
+					BadValue: "",
+					Detail:   "must be none if storage isn't hostPath or local",
+				},
+			},
+		},
+		{
+			Title: "network #68",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:    "node-1",
+							Storage: LocalVolumeStorage,
+							Restore: &NodeRestore{
+								VolumeSnapshotName: "synced-node",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].local",
+					BadValue: "",
+					Detail:   "must be provided if storage is local",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].restore",
+					BadValue: "",
+					Detail:   "must be none if storage is local",
+				},
+			},
+		},
+		{
+			Title: "network #69",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:    "node-1",
+							Storage: EphemeralStorage,
+							Resources: &NodeResources{
+								Storage: "500Gi",
+							},
+							AutoExpand: &AutoExpandPolicy{
+								Max: "500Gi",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].autoExpand",
+					BadValue: "",
+					Detail:   "must be none if storage is ephemeral",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].autoExpand.max",
+					BadValue: "500Gi",
+					Detail:   "must be greater than node storage 500Gi",
+				},
+			},
+		},
+		{
+			Title: "network #70",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:    "node-1",
+							Storage: EphemeralStorage,
+							IntegrityCheck: &IntegrityCheckPolicy{
+								Schedule: metav1.Duration{Duration: time.Hour},
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].integrityCheck",
+					BadValue: "",
+					Detail:   "must be none if storage is ephemeral",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].integrityCheck.schedule",
+					BadValue: "1h0m0s",
+					Detail:   "must be at least 24h0m0s",
+				},
+			},
+		},
+		{
+			Title: "network #71",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:   "node-1",
+							Client: BesuClient,
+							Besu: &BesuOptions{
+								MetricsHostAllowlist: []string{"prometheus.monitoring"},
+								GraphQLHostAllowlist: []string{"dapp.example.com"},
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].metrics",
+					BadValue: false,
+					Detail:   "must be true if besu metricsHostAllowlist is provided",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].graphql",
+					BadValue: false,
+					Detail:   "must be true if besu graphqlHostAllowlist is provided",
+				},
+			},
+		},
+		{
+			Title: "network #72",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					Genesis: &Genesis{
+						ChainID: 55555,
+						Clique: &Clique{
+							PoA: PoA{
+								BlockPeriod: 5,
+							},
+							Signers:     []EthereumAddress{"0xd2c21213027cbf4d46c16b55fa98e5252b048706"},
+							InstantSeal: true,
+						},
+					},
+					Nodes: []Node{
+						{
+							Name:   "node-1",
+							Client: BesuClient,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.genesis.clique.blockPeriod",
+					BadValue: uint(5),
+					Detail:   "must be none if instantSeal is true",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].client",
+					BadValue: BesuClient,
+					Detail:   "must be geth if clique instantSeal is true",
+				},
+			},
+		},
+		{
+			Title: "network #73",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:   "node-1",
+							Client: BesuClient,
+							ConsoleScripts: []ConsoleScript{
+								{
+									Name:   "add-peer",
+									Script: `admin.addPeer("enode://...")`,
+								},
+								{
+									Name:   "add-peer",
+									Script: "miner.setGasPrice(1)",
+								},
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].client",
+					BadValue: BesuClient,
+					Detail:   "must be geth if consoleScripts is provided",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].rpc",
+					BadValue: false,
+					Detail:   "must be true if consoleScripts is provided",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].consoleScripts[1].name",
+					BadValue: "add-peer",
+					Detail:   "already used by another console script",
+				},
+			},
+		},
+		{
+			Title: "network #74",
+			Network: &Network{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "consortium",
+				},
+				Spec: NetworkSpec{
+					ID:        8888,
+					Consensus: ProofOfAuthority,
+					From: &NetworkSource{
+						Network: "consortium",
+						ChainID: 8888,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.from.network",
+					BadValue: "consortium",
+					Detail:   "must be another network",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.consensus",
+					BadValue: ProofOfAuthority,
+					Detail:   "must be none if spec.from is provided",
+				},
+			},
+		},
+		{
+			Title: "network #75",
+			Network: &Network{
+				Spec: NetworkSpec{
+					ID:        8888,
+					Consensus: ProofOfAuthority,
+					Genesis: &Genesis{
+						ChainID: 8888,
+					},
+					NodePools: []NodePool{
+						{
+							Node: Node{
+								Name: "rpc",
+							},
+							Replicas: 2,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes",
+					BadValue: "",
+					Detail:   "must include a bootnode if private network has node pools only and no spec.bootnodes, pool nodes can't be bootnodes",
+				},
+			},
+		},
+		{
+			Title: "network #76",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name: "node-1",
+							Service: &NodeService{
+								Type:           LoadBalancerService,
+								LoadBalancerIP: "10.0.0.10",
+							},
+						},
+						{
+							Name: "node-2",
+							Service: &NodeService{
+								Type:           LoadBalancerService,
+								LoadBalancerIP: "10.0.0.10",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[1].p2pPort",
+					BadValue: uint(30303),
+					Detail:   "already exposed on load balancer ip 10.0.0.10 by spec.nodes[0].p2pPort",
+				},
+			},
+		},
+		{
+			Title: "network #77",
+			Network: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					NodePools: []NodePool{
+						{
+							Node: Node{
+								Name: "rpc",
+								Service: &NodeService{
+									Type:           LoadBalancerService,
+									LoadBalancerIP: "10.0.0.10",
+								},
+							},
+							Replicas: 1,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodePools[0].service.loadBalancerIP",
+					BadValue: "10.0.0.10",
+					Detail:   "must be none in node pool",
+				},
+			},
+		},
+	}
+
+	// errorsToCauses converts field error list into array of status cause
+	errorsToCauses := func(errs field.ErrorList) []metav1.StatusCause {
+		causes := make([]metav1.StatusCause, 0, len(errs))
+
+		for i := range errs {
+			err := errs[i]
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseType(err.Type),
+				Message: err.ErrorBody(),
+				Field:   err.Field,
+			})
+		}
+
+		return causes
+	}
+
+	updateCases := []struct {
+		Title      string
+		OldNetwork *Network
+		NewNetwork *Network
+		Errors     field.ErrorList
+	}{
+		{
+			Title: "network #1",
+			OldNetwork: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			NewNetwork: &Network{
+				Spec: NetworkSpec{
+					Join: RopstenNetwork,
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.join",
+					BadValue: RopstenNetwork,
+					Detail:   "field is immutable",
+				},
+			},
+		},
+		{
+			Title: "network #2",
+			OldNetwork: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			NewNetwork: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfWork,
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.consensus",
+					BadValue: ProofOfWork,
+					Detail:   "field is immutable",
+				},
+			},
+		},
+		{
+			Title: "network #3",
+			OldNetwork: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			NewNetwork: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					Genesis: &Genesis{
+						ChainID: 4444,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.genesis",
+					BadValue: "",
+					Detail:   "field is immutable",
+				},
+			},
+		},
+		{
+			Title: "network #4",
+			OldNetwork: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			NewNetwork: &Network{
+				Spec: NetworkSpec{
+					Consensus: ProofOfAuthority,
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-2",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].name",
+					BadValue: "node-2",
+					Detail:   "field is immutable",
+				},
+			},
+		},
+		{
+			Title: "network #5",
+			OldNetwork: &Network{
+				Spec: NetworkSpec{
+					ID:        networkID,
+					Consensus: ProofOfAuthority,
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			NewNetwork: &Network{
+				Spec: NetworkSpec{
+					ID:        newNetworkID,
+					Consensus: ProofOfAuthority,
+					Genesis: &Genesis{
+						ChainID: 55555,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.id",
+					BadValue: fmt.Sprintf("%d", newNetworkID),
+					Detail:   "field is immutable",
+				},
+			},
+		},
+		{
+			Title: "network #6",
+			OldNetwork: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			NewNetwork: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							IPFamily: IPv6Family,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].ipFamily",
+					BadValue: IPv6Family,
+					Detail:   "field is immutable",
+				},
+			},
+		},
+		{
+			Title: "network #7",
+			OldNetwork: &Network{
+				Spec: NetworkSpec{
+					JoinFrom: &JoinFrom{
+						ConfigMapName: "exported-network",
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			NewNetwork: &Network{
+				Spec: NetworkSpec{
+					JoinFrom: &JoinFrom{
+						ConfigMapName: "another-exported-network",
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.joinFrom",
+					BadValue: "",
+					Detail:   "field is immutable",
+				},
+			},
+		},
+		{
+			Title: "network #8",
+			OldNetwork: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			NewNetwork: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:    "node-1",
+							Storage: EphemeralStorage,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].storage",
+					BadValue: EphemeralStorage,
+					Detail:   "field is immutable",
+				},
+			},
+		},
+		{
+			Title: "network #9",
+			OldNetwork: &Network{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						ProtectedAnnotation: "true",
+					},
+				},
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:     "node-1",
+							Bootnode: true,
+						},
+						{
+							Name: "node-2",
+						},
+					},
+				},
+			},
+			NewNetwork: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name: "node-2",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0]",
+					BadValue: "node-1",
+					Detail:   "critical node can't be removed from protected network",
+				},
+			},
+		},
+		{
+			Title: "network #10",
+			OldNetwork: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:   "node-1",
+							Client: BesuClient,
+							Besu: &BesuOptions{
+								DataStorageFormat: ForestDataStorage,
+							},
+						},
+					},
+				},
+			},
+			NewNetwork: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:   "node-1",
+							Client: BesuClient,
+							Besu: &BesuOptions{
+								DataStorageFormat: BonsaiDataStorage,
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].besu.dataStorageFormat",
+					BadValue: BonsaiDataStorage,
+					Detail:   "field is immutable",
+				},
+			},
+		},
+		{
+			Title: "network #11",
+			OldNetwork: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			NewNetwork: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name: "node-1",
+							Restore: &NodeRestore{
+								VolumeSnapshotName: "synced-node",
+							},
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].restore.volumeSnapshotName",
+					BadValue: "synced-node",
+					Detail:   "field is immutable",
+				},
+			},
+		},
+		{
+			Title: "network #12",
+			OldNetwork: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:    "node-1",
+							Storage: HostPathStorage,
+							Local: &LocalStorage{
+								Path:     "/mnt/nvme0",
+								NodeName: "worker-1",
+							},
+						},
+						{
+							Name: "node-2",
+						},
+					},
+				},
+			},
+			NewNetwork: &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{
+							Name:    "node-1",
+							Storage: HostPathStorage,
+							Local: &LocalStorage{
+								Path:     "/mnt/nvme0",
+								NodeName: "worker-2",
+							},
+						},
+						{
+							Name:    "node-2",
+							Storage: EphemeralStorage,
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[0].local",
+					BadValue: "",
+					Detail:   "field is immutable",
+				},
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.nodes[1].storage",
+					BadValue: EphemeralStorage,
+					Detail:   "field is immutable",
+				},
+			},
+		},
+		{
+			Title: "network #13",
+			OldNetwork: &Network{
+				Spec: NetworkSpec{
+					ID: 8888,
+					From: &NetworkSource{
+						Network: "consortium",
+						ChainID: 8888,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			NewNetwork: &Network{
+				Spec: NetworkSpec{
+					ID: 8888,
+					From: &NetworkSource{
+						Network: "consortium",
+						ChainID: 9999,
+					},
+					Nodes: []Node{
+						{
+							Name: "node-1",
+						},
+					},
+				},
+			},
+			Errors: field.ErrorList{
+				{
+					Type:     field.ErrorTypeInvalid,
+					Field:    "spec.from",
+					BadValue: "",
+					Detail:   "field is immutable",
+				},
+			},
+		},
+	}
+
+	Context("While creating network", func() {
+		for _, c := range createCases {
+			func() {
+				cc := c
+				It(fmt.Sprintf("Should validate %s", cc.Title), func() {
+					cc.Network.Default()
+					err := cc.Network.ValidateCreate()
+
+					errStatus := err.(*errors.StatusError)
+
+					causes := errorsToCauses(cc.Errors)
+
+					Expect(errStatus.ErrStatus.Details.Causes).To(ContainElements(causes))
+				})
+			}()
+		}
+
+		It("Should admit public network of node pools only", func() {
+			network := &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					NodePools: []NodePool{
+						{
+							Node: Node{
+								Name: "rpc",
+								RPC:  true,
+							},
+							Replicas: 3,
+						},
+					},
+				},
+			}
+
+			network.Default()
+			Expect(network.ValidateCreate()).To(Succeed())
+		})
+
+		It("Should admit private network of node pools only with bootnodes", func() {
+			network := &Network{
+				Spec: NetworkSpec{
+					ID:        8888,
+					Consensus: ProofOfAuthority,
+					Genesis: &Genesis{
+						ChainID: 8888,
+						Clique: &Clique{
+							Signers: []EthereumAddress{"0xd2c21213027cbf4d46c16b55fa98e5252b048706"},
+						},
+					},
+					Bootnodes: []Enode{"enode://2281549869465d98e90cebc45e1d6834a01465a990add7bcf07a49287e7e66b50ca27f9c70a46190cef7ad746dd5d5b6b9dfee0c9954104c8e9bd0d42758ec58@bootnode.example.com:30303"},
+					NodePools: []NodePool{
+						{
+							Node: Node{
+								Name: "rpc",
+								RPC:  true,
+							},
+							Replicas: 3,
+						},
+					},
+				},
+			}
+
+			network.Default()
+			Expect(network.ValidateCreate()).To(Succeed())
+		})
+
+		It("Should reject public network whose first node isn't a bootnode", func() {
+			network := &Network{
+				Spec: NetworkSpec{
+					Join: RinkebyNetwork,
+					Nodes: []Node{
+						{Name: "node-1"},
+						{Name: "node-2"},
+					},
+				},
+			}
+
+			network.Default()
+			err := network.ValidateCreate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("first node must be a bootnode if network has multiple nodes"))
+		})
+	})
+
+	Context("While creating network with used chain id", func() {
+		existing := &Network{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "existing",
+				Namespace: "default",
+			},
+			Spec: NetworkSpec{
+				Consensus: ProofOfAuthority,
+				ID:        networkID,
+				Genesis: &Genesis{
+					ChainID: 4444,
+				},
+			},
+		}
+
+		It("Should reject duplicate chain id in the same namespace", func() {
+			scheme := runtime.NewScheme()
+			Expect(AddToScheme(scheme)).To(Succeed())
+			networkReader = fake.NewFakeClientWithScheme(scheme, existing)
+			defer func() { networkReader = nil }()
+
+			network := &Network{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "new",
+					Namespace: "default",
+				},
+				Spec: NetworkSpec{
+					Genesis: &Genesis{
+						ChainID: 4444,
+					},
+				},
+			}
+
+			Expect(network.ValidateChainIDUniqueness()).To(ContainElement(&field.Error{
+				Type:     field.ErrorTypeInvalid,
+				Field:    "spec.genesis.chainId",
+				BadValue: "4444",
+				Detail:   "already used by network existing",
+			}))
+		})
+	})
+
+	Context("While deleting network", func() {
+		It("Should reject deleting protected network", func() {
+			network := &Network{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "protected",
+					Annotations: map[string]string{
+						ProtectedAnnotation: "true",
+					},
+				},
+			}
+
+			Expect(network.ValidateDelete()).NotTo(Succeed())
+
+			network.Annotations[ProtectedAnnotation] = "false"
+			Expect(network.ValidateDelete()).To(Succeed())
+		})
+	})
+
+	Context("While updating network", func() {
+		for _, c := range updateCases {
+			func() {
+				cc := c
+				It(fmt.Sprintf("Should validate %s", cc.Title), func() {
+					cc.NewNetwork.Default()
+					err := cc.NewNetwork.ValidateUpdate(cc.OldNetwork)
+
+					errStatus := err.(*errors.StatusError)
+
+					causes := errorsToCauses(cc.Errors)
+
+					Expect(errStatus.ErrStatus.Details.Causes).To(ContainElements(causes))
+				})
+			}()
+		}
+	})
+
+})
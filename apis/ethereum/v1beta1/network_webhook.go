@@ -1,15 +1,21 @@
-package v1alpha1
+package v1beta1
 
 import (
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // log is for logging in this package.
 var networklog = logf.Log.WithName("network-resource")
 
+// networkReader is used by validation webhook to read existing networks
+// it's nil if webhook is not setup with a manager, e.g. in unit tests
+var networkReader client.Reader
+
 // SetupWebhookWithManager sets up the webook with a given controller manager
 func (r *Network) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	networkReader = mgr.GetClient()
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
@@ -0,0 +1,813 @@
+package v1beta1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//Node is the specification of the node
+type Node struct {
+	// Client is ethereum client running on the node
+	Client EthereumClient `json:"client,omitempty"`
+
+	// Name is the node name
+	Name string `json:"name"`
+
+	// import is account to import
+	Import *ImportedAccount `json:"import,omitempty"`
+
+	// Keystore is signer account keystore and password loaded from secret, used instead of import
+	// keystore account is unlocked on every node start, so signers keep sealing blocks after restarts
+	Keystore *KeystoreSecret `json:"keystore,omitempty"`
+
+	// Bootnode is whether node is bootnode or no
+	Bootnode bool `json:"bootnode,omitempty"`
+
+	// Role is node role, dedicated bootnodes only serve peers discovery
+	Role NodeRole `json:"role,omitempty"`
+
+	// Nodekey is the node private key
+	Nodekey PrivateKey `json:"nodekey,omitempty"`
+
+	// P2PPort is port used for peer to peer communication
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=30303
+	P2PPort uint `json:"p2pPort,omitempty"`
+
+	// AdvertisedAddress is the external ip address advertised to peers instead of pod ip
+	// it's load balancer ip for nodes behind load balancers
+	AdvertisedAddress string `json:"advertisedAddress,omitempty"`
+
+	// IPFamily is node service ip family, cluster default is used if not provided
+	IPFamily IPFamily `json:"ipFamily,omitempty"`
+
+	// DNSName is node dns name published by external-dns and used instead of ip in node enode url
+	// +kubebuilder:validation:Pattern="^([a-z0-9]([-a-z0-9]*[a-z0-9])?\\.)+[a-z]{2,}$"
+	DNSName string `json:"dnsName,omitempty"`
+
+	// SyncMode is the node synchronization mode
+	SyncMode SynchronizationMode `json:"syncMode,omitempty"`
+
+	// LightServe is the maximum percentage of time allowed for serving light clients requests
+	// +kubebuilder:validation:Maximum=100
+	LightServe uint `json:"lightServe,omitempty"`
+
+	// Cache is memory (in megabytes) allocated to client internal caching
+	// geth cache defaults to a quarter of memory limit, besu database cache defaults to client default
+	Cache uint `json:"cache,omitempty"`
+
+	// Besu is hyperledger besu specific options
+	Besu *BesuOptions `json:"besu,omitempty"`
+
+	// Miner is whether node is mining/validating blocks or no
+	Miner bool `json:"miner,omitempty"`
+
+	// Logging is logging verboisty level
+	Logging VerbosityLevel `json:"logging,omitempty"`
+
+	// LogFormat is logging format
+	LogFormat LogFormat `json:"logFormat,omitempty"`
+
+	// Coinbase is the account to which mining rewards are paid
+	Coinbase EthereumAddress `json:"coinbase,omitempty"`
+
+	// Hosts is a list of hostnames to to whitelist for RPC access
+	Hosts []string `json:"hosts,omitempty"`
+
+	// CORSDomains is the domains from which to accept cross origin requests
+	CORSDomains []string `json:"corsDomains,omitempty"`
+
+	// RPC is whether HTTP-RPC server is enabled or not
+	RPC bool `json:"rpc,omitempty"`
+
+	// RPCHost is HTTP-RPC server host address
+	RPCHost string `json:"rpcHost,omitempty"`
+
+	// RPCPort is HTTP-RPC server listening port
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	RPCPort uint `json:"rpcPort,omitempty"`
+
+	// RPCAPI is a list of rpc services to enable
+	RPCAPI []API `json:"rpcAPI,omitempty"`
+
+	// RPCProxy is proxy sidecar filtering and rate limiting requests to HTTP-RPC server exposed by node service
+	RPCProxy *RPCProxy `json:"rpcProxy,omitempty"`
+
+	// WS is whether web socket server is enabled or not
+	WS bool `json:"ws,omitempty"`
+
+	// WSHost is HTTP-WS server host address
+	WSHost string `json:"wsHost,omitempty"`
+
+	// WSPort is the web socket server listening port
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	WSPort uint `json:"wsPort,omitempty"`
+
+	// WSAPI is a list of WS services to enable
+	WSAPI []API `json:"wsAPI,omitempty"`
+
+	// WSIngressHost is the host of ingress exposing web socket server, ingress is created only if host is specified
+	WSIngressHost string `json:"wsIngressHost,omitempty"`
+
+	// GraphQL is whether GraphQL server is enabled or not
+	GraphQL bool `json:"graphql,omitempty"`
+
+	// GraphQLHost is GraphQL server host address
+	GraphQLHost string `json:"graphqlHost,omitempty"`
+
+	// GraphQLPort is the GraphQL server listening port
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	GraphQLPort uint `json:"graphqlPort,omitempty"`
+
+	// Metrics is whether node metrics exporter is enabled or not
+	Metrics bool `json:"metrics,omitempty"`
+
+	// MetricsHost is metrics exporter host address
+	MetricsHost string `json:"metricsHost,omitempty"`
+
+	// MetricsPort is metrics exporter listening port
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	MetricsPort uint `json:"metricsPort,omitempty"`
+
+	// MetricsExporter is chain metrics exporter sidecar polling node http-rpc server
+	// it exposes standardized chain metrics, for clients and modes without native prometheus metrics
+	MetricsExporter *MetricsExporter `json:"metricsExporter,omitempty"`
+
+	// Resources is node compute and storage resources
+	Resources *NodeResources `json:"resources,omitempty"`
+
+	// Architecture is the cpu architecture node pod is scheduled on, images built for it are used
+	// node pod can be scheduled on any architecture supported by its images if empty
+	Architecture Architecture `json:"architecture,omitempty"`
+
+	// Service is node service exposure configuration
+	Service *NodeService `json:"service,omitempty"`
+
+	// ServiceAnnotations is annotations added to node service, like cloud provider load balancer annotations
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+
+	// ExtraArgs is extra client arguments appended to the generated arguments
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+
+	// Env is extra environment variables set in the client container
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Sidecars is extra containers added to node pod, like exporters or log shippers
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+
+	// SidecarsMountData is whether node data volume is mounted read-only in sidecar containers
+	// data volume is mounted at the same path as the client container
+	SidecarsMountData bool `json:"sidecarsMountData,omitempty"`
+
+	// InitScripts is shell scripts run in order before node client starts
+	InitScripts []InitScript `json:"initScripts,omitempty"`
+
+	// ConsoleScripts is JavaScript run once against geth node console by jobs, like admin.addPeer or miner.setGasPrice calls
+	ConsoleScripts []ConsoleScript `json:"consoleScripts,omitempty"`
+
+	// Storage is node data storage, persistent volume claim is used if not provided
+	// ephemeral storage is removed with node pod, it's meant for throwaway dev networks
+	Storage StorageMode `json:"storage,omitempty"`
+
+	// StorageMemory is whether ephemeral storage is memory backed or no
+	// memory backed storage counts against node memory limit
+	StorageMemory bool `json:"storageMemory,omitempty"`
+
+	// Local is kubernetes node directory node data is kept in, it's required by hostPath and local storage
+	Local *LocalStorage `json:"local,omitempty"`
+
+	// Restore is the source node data is restored from when node is created
+	Restore *NodeRestore `json:"restore,omitempty"`
+
+	// Snapshots is node data periodic volume snapshots policy
+	Snapshots *SnapshotPolicy `json:"snapshots,omitempty"`
+
+	// AutoExpand is node data pvc automatic expansion policy, pvc storage class must allow volume expansion
+	AutoExpand *AutoExpandPolicy `json:"autoExpand,omitempty"`
+
+	// IntegrityCheck is node data periodic integrity check policy
+	IntegrityCheck *IntegrityCheckPolicy `json:"integrityCheck,omitempty"`
+
+	// Backup is node data periodic backup to object storage
+	Backup *BackupPolicy `json:"backup,omitempty"`
+
+	// Pool is the name of node pool this node is created from, it's set by the operator
+	Pool string `json:"pool,omitempty"`
+}
+
+// InitScript is shell script loaded from config map and run as node init container
+// it can download files, warm caches or fix permissions of restored data
+type InitScript struct {
+	// Name is init script container name
+	Name string `json:"name"`
+
+	// ConfigMapName is the name of config map in network namespace holding the script
+	ConfigMapName string `json:"configMapName"`
+
+	// Key is config map key holding the script
+	Key string `json:"key,omitempty"`
+
+	// Image is init script container image, node client image is used if not provided
+	Image string `json:"image,omitempty"`
+}
+
+// ConsoleScript is JavaScript evaluated by geth console attached to node http-rpc server
+// script is run once by a job, and run again if changed
+type ConsoleScript struct {
+	// Name is console script name, it's part of the script job name
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	// +kubebuilder:validation:MaxLength=20
+	Name string `json:"name"`
+
+	// Script is JavaScript evaluated by geth console
+	Script string `json:"script"`
+}
+
+// NodePool is a group of identical nodes created from the same node spec
+// pool nodes are named after the pool name with numeric suffix, like rpc-0, rpc-1 and so on
+type NodePool struct {
+	// Node is pool nodes spec, node name is the pool name
+	Node `json:",inline"`
+
+	// Replicas is the number of pool nodes
+	// +kubebuilder:validation:Minimum=0
+	Replicas int32 `json:"replicas"`
+}
+
+// IsBootnode is whether node is bootnode or no
+func (n *Node) IsBootnode() bool {
+	return n.Bootnode || n.IsDedicatedBootnode()
+}
+
+// IsDedicatedBootnode is whether node is discovery only bootnode or no
+func (n *Node) IsDedicatedBootnode() bool {
+	return n.Role == BootnodeRole
+}
+
+// IsCritical is whether removing the node can partition or halt the network
+// bootnodes and block producers (pow miners, clique signers and ibft2 validators) are critical nodes
+func (n *Node) IsCritical() bool {
+	return n.IsBootnode() || n.Miner
+}
+
+// WithEphemeralStorage is whether node data is kept in ephemeral storage instead of persistent volume claim
+func (n *Node) WithEphemeralStorage() bool {
+	return n.Storage == EphemeralStorage
+}
+
+// WithHostPathStorage is whether node data is kept in kubernetes node directory instead of persistent volume claim
+func (n *Node) WithHostPathStorage() bool {
+	return n.Storage == HostPathStorage
+}
+
+// WithLocalVolume is whether node data is kept in local persistent volume generated by the operator
+func (n *Node) WithLocalVolume() bool {
+	return n.Storage == LocalVolumeStorage
+}
+
+// WithLocalStorage is whether node data is kept in kubernetes node directory, node pod is pinned to that kubernetes node
+func (n *Node) WithLocalStorage() bool {
+	return n.WithHostPathStorage() || n.WithLocalVolume()
+}
+
+// WithPersistentVolumeClaim is whether node data is kept in persistent volume claim
+func (n *Node) WithPersistentVolumeClaim() bool {
+	return n.StorageMode() == PersistentStorage || n.WithLocalVolume()
+}
+
+// StorageMode returns node data storage mode, persistent storage is used if not provided
+func (n *Node) StorageMode() StorageMode {
+	if n.Storage == "" {
+		return PersistentStorage
+	}
+	return n.Storage
+}
+
+// WithNodekey is whether node is configured with private key
+func (n *Node) WithNodekey() bool {
+	return n.Nodekey != ""
+}
+
+// WithService is whether node is exposed by service
+func (n *Node) WithService() bool {
+	if n.Service == nil || n.Service.Type == "" {
+		return n.IsBootnode()
+	}
+	return n.Service.Type != NoneService
+}
+
+// WithSensitiveRPC is whether node http-rpc server serves sensitive apis
+func (n *Node) WithSensitiveRPC() bool {
+	return n.RPC && withSensitiveAPI(n.RPCAPI)
+}
+
+// WithSensitiveWS is whether node web socket server serves sensitive apis
+func (n *Node) WithSensitiveWS() bool {
+	return n.WS && withSensitiveAPI(n.WSAPI)
+}
+
+// ServesPublicRPC is whether node http-rpc server can be load balanced by network rpc service
+// miners are kept out of load balanced requests, and sensitive apis are never exposed by network rpc service
+func (n *Node) ServesPublicRPC() bool {
+	return n.RPC && !n.Miner && !n.WithSensitiveRPC()
+}
+
+// WithInternalService is whether node sensitive apis are exposed through internal service
+func (n *Node) WithInternalService() bool {
+	return n.WithSensitiveRPC() || n.WithSensitiveWS()
+}
+
+// RestoreSnapshotName returns the name of volume snapshot node data is restored from, or empty string if not provided
+func (n *Node) RestoreSnapshotName() string {
+	if n.Restore == nil {
+		return ""
+	}
+	return n.Restore.VolumeSnapshotName
+}
+
+// DataStorageFormat returns node besu data storage format, or empty string if not provided
+func (n *Node) DataStorageFormat() DataStorageFormat {
+	if n.Besu == nil {
+		return ""
+	}
+	return n.Besu.DataStorageFormat
+}
+
+// DeploymentName returns name to be used by node deployment
+func (n *Node) DeploymentName(network string) string {
+	return fmt.Sprintf("%s-%s", network, n.Name)
+}
+
+// PVCName returns name to be used by node pvc
+func (n *Node) PVCName(network string) string {
+	return n.DeploymentName(network) // same as deployment name
+}
+
+// LocalPVName returns name to be used by node local persistent volume
+// persistent volumes are cluster scoped, that's why namespace is part of the name
+func (n *Node) LocalPVName(namespace, network string) string {
+	return fmt.Sprintf("%s-%s-%s", namespace, network, n.Name)
+}
+
+// SecretName returns name to be used by node secret
+func (n *Node) SecretName(network string) string {
+	return n.DeploymentName(network) // same as deployment name
+}
+
+// ConfigName returns name to be used by node configuration file configmap
+func (n *Node) ConfigName(network string) string {
+	return fmt.Sprintf("%s-%s-config", network, n.Name)
+}
+
+// ServiceName returns name to be used by node service
+func (n *Node) ServiceName(network string) string {
+	return n.DeploymentName(network) // same as deployment name
+}
+
+// InternalServiceName returns name to be used by node internal service
+func (n *Node) InternalServiceName(network string) string {
+	return fmt.Sprintf("%s-%s-internal", network, n.Name)
+}
+
+// Labels to be used by node resources
+func (n *Node) Labels(network string) map[string]string {
+	labels := map[string]string{
+		"name":     "node",
+		"instance": n.Name,
+		"network":  network,
+	}
+	// node pool nodes are selected by scale subresource selector
+	if n.Pool != "" {
+		labels["pool"] = n.Pool
+	}
+	return labels
+}
+
+// ResourceLabels to be used by node resources and pods metadata, node labels with node client, network consensus and node roles
+// they're never used by selectors, so node roles can change without recreating node resources
+func (n *Node) ResourceLabels(network string, consensus ConsensusAlgorithm) map[string]string {
+	labels := n.Labels(network)
+	labels["client"] = string(n.Client)
+	if consensus != "" {
+		labels["consensus"] = string(consensus)
+	}
+	if n.IsBootnode() {
+		labels["bootnode"] = "true"
+	}
+	if n.Miner {
+		labels["miner"] = "true"
+	}
+	if n.RPC {
+		labels["rpc"] = "true"
+	}
+	return labels
+}
+
+// NodeTemplate is network wide node settings inherited by every node
+// nodes can override template settings field by field
+type NodeTemplate struct {
+	// Client is ethereum client running on the nodes
+	Client EthereumClient `json:"client,omitempty"`
+
+	// SyncMode is the nodes synchronization mode
+	SyncMode SynchronizationMode `json:"syncMode,omitempty"`
+
+	// Logging is logging verboisty level
+	Logging VerbosityLevel `json:"logging,omitempty"`
+
+	// LogFormat is logging format
+	LogFormat LogFormat `json:"logFormat,omitempty"`
+
+	// Resources is nodes compute and storage resources
+	Resources *NodeResources `json:"resources,omitempty"`
+
+	// Architecture is the cpu architecture nodes pods are scheduled on
+	Architecture Architecture `json:"architecture,omitempty"`
+}
+
+// NodeResources is node compute and storage resources
+type NodeResources struct {
+	// CPU is cpu cores the node requires
+	// +kubebuilder:validation:Pattern="^[1-9][0-9]*m?$"
+	CPU string `json:"cpu,omitempty"`
+	// CPULimit is cpu cores the node is limited to
+	// +kubebuilder:validation:Pattern="^[1-9][0-9]*m?$"
+	CPULimit string `json:"cpuLimit,omitempty"`
+	// Memory is memmory requirements
+	// +kubebuilder:validation:Pattern="^[1-9][0-9]*[KMGTPE]i$"
+	Memory string `json:"memory,omitempty"`
+	// MemoryLimit is cpu cores the node is limited to
+	// +kubebuilder:validation:Pattern="^[1-9][0-9]*[KMGTPE]i$"
+	MemoryLimit string `json:"memoryLimit,omitempty"`
+	// Storage is disk space storage requirements
+	// +kubebuilder:validation:Pattern="^[1-9][0-9]*[KMGTPE]i$"
+	Storage string `json:"storage,omitempty"`
+	// StorageClass is the volume storage class
+	StorageClass *string `json:"storageClass,omitempty"`
+}
+
+// RPCProxy is HTTP-RPC server proxy configuration
+type RPCProxy struct {
+	// Port is the proxy listening port
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port uint `json:"port,omitempty"`
+	// AllowedMethods is a list of allowed json-rpc methods, all methods are allowed if empty
+	AllowedMethods []string `json:"allowedMethods,omitempty"`
+	// RateLimit is the number of requests allowed per second per client ip, 0 disables rate limiting
+	// +kubebuilder:validation:Minimum=0
+	RateLimit int `json:"rateLimit,omitempty"`
+	// RateLimitBurst is the number of requests allowed per client ip in a single burst
+	// +kubebuilder:validation:Minimum=1
+	RateLimitBurst int `json:"rateLimitBurst,omitempty"`
+	// MaxBatchSize is the maximum number of requests in a json-rpc batch
+	// +kubebuilder:validation:Minimum=1
+	MaxBatchSize int `json:"maxBatchSize,omitempty"`
+}
+
+// NodeRestore is the source node data is restored from
+type NodeRestore struct {
+	// VolumeSnapshotName is the name of csi volume snapshot in network namespace used as node data pvc data source
+	VolumeSnapshotName string `json:"volumeSnapshotName"`
+}
+
+// SnapshotPolicy is node data persistent volume claim periodic csi volume snapshots policy
+type SnapshotPolicy struct {
+	// Schedule is the interval between snapshots, like 6h or 24h
+	Schedule metav1.Duration `json:"schedule"`
+	// Retention is the number of snapshots kept, older snapshots are deleted
+	// +kubebuilder:validation:Minimum=1
+	Retention int `json:"retention,omitempty"`
+	// VolumeSnapshotClassName is the volume snapshot class used by snapshots, default class is used if not provided
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// IntegrityCheckPolicy is node data integrity check policy
+// node is stopped while client specific check job verifies its data, like geth snapshot verify-state
+// checks are also requested on demand by changing network kotal.io/integrity-check annotation
+type IntegrityCheckPolicy struct {
+	// Schedule is the interval between checks, like 168h, checks are run on demand only if not provided
+	Schedule metav1.Duration `json:"schedule,omitempty"`
+}
+
+// AutoExpandPolicy is node data persistent volume claim expansion policy
+// pvc is expanded according to kubelet volume stats before chain data fills the disk
+type AutoExpandPolicy struct {
+	// Threshold is the used disk space percentage pvc is expanded at
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	Threshold int `json:"threshold,omitempty"`
+	// Increment is the storage added to pvc on every expansion
+	// +kubebuilder:validation:Pattern="^[1-9][0-9]*[KMGTPE]i$"
+	Increment string `json:"increment,omitempty"`
+	// Max is the storage pvc isn't expanded beyond
+	// +kubebuilder:validation:Pattern="^[1-9][0-9]*[KMGTPE]i$"
+	Max string `json:"max"`
+}
+
+// BackupPolicy is node data periodic incremental backup to s3 compatible object storage
+type BackupPolicy struct {
+	// Schedule is the interval between backups, like 24h
+	Schedule metav1.Duration `json:"schedule"`
+	// Provider is object storage provider
+	Provider BackupProvider `json:"provider"`
+	// Endpoint is object storage endpoint, provider endpoint is used if not provided
+	// +kubebuilder:validation:Pattern="^https?://"
+	Endpoint string `json:"endpoint,omitempty"`
+	// Region is object storage region
+	Region string `json:"region,omitempty"`
+	// Bucket is object storage bucket
+	Bucket string `json:"bucket"`
+	// Prefix is backups objects key prefix, namespace, network and node names are used if not provided
+	Prefix string `json:"prefix,omitempty"`
+	// CredentialsSecretName is the name of secret holding accessKeyId and secretAccessKey keys
+	// google cloud storage hmac keys are used as access key id and secret access key
+	CredentialsSecretName string `json:"credentialsSecretName"`
+	// FullEvery is how often all files are archived, only files changed since previous backup are archived otherwise
+	// +kubebuilder:validation:Minimum=1
+	FullEvery int `json:"fullEvery,omitempty"`
+	// PauseNode is whether node is paused while its data is archived, so backups are consistent
+	PauseNode bool `json:"pauseNode,omitempty"`
+	// Port is backup status server port, last backup result is served on it
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port uint `json:"port,omitempty"`
+}
+
+// BackupProvider is object storage provider
+// +kubebuilder:validation:Enum=s3;gcs
+type BackupProvider string
+
+const (
+	// S3BackupProvider is amazon s3 or s3 compatible object storage
+	S3BackupProvider BackupProvider = "s3"
+	// GCSBackupProvider is google cloud storage
+	GCSBackupProvider BackupProvider = "gcs"
+)
+
+// backup credentials secret keys
+const (
+	// BackupAccessKeyIDKey is the backup credentials secret key holding access key id
+	BackupAccessKeyIDKey = "accessKeyId"
+	// BackupSecretAccessKeyKey is the backup credentials secret key holding secret access key
+	BackupSecretAccessKeyKey = "secretAccessKey"
+)
+
+// MetricsExporter is chain metrics exporter sidecar configuration
+type MetricsExporter struct {
+	// Port is exporter metrics listening port
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port uint `json:"port,omitempty"`
+}
+
+// NodeRole is node role
+// +kubebuilder:validation:Enum=bootnode
+type NodeRole string
+
+const (
+	// BootnodeRole is discovery only bootnode running geth bootnode tool
+	BootnodeRole NodeRole = "bootnode"
+)
+
+// NodeService is node service exposure configuration
+type NodeService struct {
+	// Type is node service type
+	// bootnodes are exposed by ClusterIP service by default, other nodes aren't exposed unless type is provided
+	Type ServiceType `json:"type,omitempty"`
+	// LoadBalancerIP is static ip requested from cloud provider load balancer
+	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+	// LoadBalancerSourceRanges is client ip ranges allowed to access load balancer
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+}
+
+// ServiceType is node service type
+// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer;None
+type ServiceType string
+
+const (
+	// ClusterIPService exposes node inside the cluster only
+	ClusterIPService ServiceType = "ClusterIP"
+	// NodePortService exposes node on a static port of every cluster node
+	NodePortService ServiceType = "NodePort"
+	// LoadBalancerService exposes node using cloud provider load balancer
+	LoadBalancerService ServiceType = "LoadBalancer"
+	// NoneService doesn't expose node, no service is created for the node
+	NoneService ServiceType = "None"
+)
+
+// IPFamily is node service ip family
+// +kubebuilder:validation:Enum=IPv4;IPv6
+type IPFamily string
+
+const (
+	// IPv4Family is IPv4 ip family
+	IPv4Family IPFamily = "IPv4"
+	// IPv6Family is IPv6 ip family
+	IPv6Family IPFamily = "IPv6"
+)
+
+// StorageMode is node data storage mode
+// +kubebuilder:validation:Enum=persistent;ephemeral;hostPath;local
+type StorageMode string
+
+const (
+	// PersistentStorage keeps node data in persistent volume claim
+	PersistentStorage StorageMode = "persistent"
+	// EphemeralStorage keeps node data in empty dir volume removed with node pod
+	EphemeralStorage StorageMode = "ephemeral"
+	// HostPathStorage keeps node data in kubernetes node directory, node pod is pinned to that kubernetes node
+	// it's meant for bare-metal clusters with dedicated drives per node
+	HostPathStorage StorageMode = "hostPath"
+	// LocalVolumeStorage keeps node data in local persistent volume bound to node persistent volume claim
+	// local volume is pinned to kubernetes node holding the directory, so is node pod
+	LocalVolumeStorage StorageMode = "local"
+)
+
+// LocalStorage is kubernetes node directory node data is kept in
+type LocalStorage struct {
+	// Path is the absolute directory path on kubernetes node
+	// it's created if missing by hostPath storage, it must exist for local storage
+	// +kubebuilder:validation:Pattern="^/.+"
+	Path string `json:"path"`
+	// NodeName is kubernetes node name node pod is pinned to
+	NodeName string `json:"nodeName"`
+}
+
+// SynchronizationMode is the node synchronization mode
+// +kubebuilder:validation:Enum=fast;full;light
+type SynchronizationMode string
+
+const (
+	//FastSynchronization is the fast synchronization mode
+	FastSynchronization SynchronizationMode = "fast"
+
+	//LightSynchronization is the light synchronization mode
+	LightSynchronization SynchronizationMode = "light"
+
+	//FullSynchronization is the fast (non-archival) synchronization mode
+	FullSynchronization SynchronizationMode = "full"
+)
+
+// VerbosityLevel is logging verbosity levels
+// +kubebuilder:validation:Enum=off;fatal;error;warn;info;debug;trace;all
+type VerbosityLevel string
+
+const (
+	// NoLogs outputs no logs
+	NoLogs VerbosityLevel = "off"
+	// FatalLogs outputs only fatal logs
+	FatalLogs VerbosityLevel = "fatal"
+	// ErrorLogs outputs only error logs
+	ErrorLogs VerbosityLevel = "error"
+	// WarnLogs outputs only warning logs
+	WarnLogs VerbosityLevel = "warn"
+	// InfoLogs outputs only informational logs
+	InfoLogs VerbosityLevel = "info"
+	// DebugLogs outputs only debugging logs
+	DebugLogs VerbosityLevel = "debug"
+	// TraceLogs outputs only tracing logs
+	TraceLogs VerbosityLevel = "trace"
+	// AllLogs outputs only all logs
+	AllLogs VerbosityLevel = "all"
+)
+
+// LogFormat is logging format
+// +kubebuilder:validation:Enum=plain;json
+type LogFormat string
+
+const (
+	// PlainLogFormat is plain text logging format
+	PlainLogFormat LogFormat = "plain"
+	// JSONLogFormat is json logging format
+	JSONLogFormat LogFormat = "json"
+)
+
+// API is RPC API to be exposed by RPC or web socket server
+// +kubebuilder:validation:Enum=admin;clique;debug;eea;eth;ibft;miner;net;perm;plugins;priv;txpool;web3
+type API string
+
+const (
+	// AdminAPI is administration API
+	AdminAPI API = "admin"
+
+	// CliqueAPI is clique (Proof of Authority consensus) API
+	CliqueAPI API = "clique"
+
+	// DebugAPI is debugging API
+	DebugAPI API = "debug"
+
+	// EEAAPI is EEA (Enterprise Ethereum Alliance) API
+	EEAAPI API = "eea"
+
+	// ETHAPI is ethereum API
+	ETHAPI API = "eth"
+
+	// IBFTAPI is IBFT consensus API
+	IBFTAPI API = "ibft"
+
+	// MinerAPI is miner API
+	MinerAPI API = "miner"
+
+	// NetworkAPI is network API
+	NetworkAPI API = "net"
+
+	// PermissionAPI is permission API
+	PermissionAPI API = "perm"
+
+	// PluginsAPI is plugins API
+	PluginsAPI API = "plugins"
+
+	// PrivacyAPI is besu privacy API
+	PrivacyAPI API = "priv"
+
+	// TransactionPoolAPI is transaction pool API
+	TransactionPoolAPI API = "txpool"
+
+	// Web3API is web3 API
+	Web3API API = "web3"
+)
+
+// SensitiveAPIs are the apis exposed through node internal service only, never through node public service
+var SensitiveAPIs = []API{AdminAPI, DebugAPI}
+
+// withSensitiveAPI is whether any of given apis is sensitive
+func withSensitiveAPI(apis []API) bool {
+	for _, api := range apis {
+		for _, sensitive := range SensitiveAPIs {
+			if api == sensitive {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// EthereumClient is the ethereum client running on a given node
+// +kubebuilder:validation:Enum=besu;geth
+type EthereumClient string
+
+const (
+	// BesuClient is hyperledger besu ethereum client
+	BesuClient EthereumClient = "besu"
+	// GethClient is go ethereum client
+	GethClient EthereumClient = "geth"
+)
+
+// Architecture is kubernetes node cpu architecture
+// +kubebuilder:validation:Enum=amd64;arm64
+type Architecture string
+
+const (
+	// AMD64Architecture is x86-64 architecture
+	AMD64Architecture Architecture = "amd64"
+	// ARM64Architecture is arm64 architecture, like aws graviton
+	ARM64Architecture Architecture = "arm64"
+)
+
+// BesuOptions is hyperledger besu specific node options
+type BesuOptions struct {
+	// DataStorageFormat is besu database storage format, it can't be changed after node creation
+	// it's supported by besu 21.7.0 or later
+	DataStorageFormat DataStorageFormat `json:"dataStorageFormat,omitempty"`
+	// MetricsHostAllowlist is hostnames allowed to scrape node metrics, defaults to node pod ip and services dns names
+	MetricsHostAllowlist []string `json:"metricsHostAllowlist,omitempty"`
+	// GraphQLHostAllowlist is hostnames allowed to access GraphQL server, defaults to node pod ip and services dns names
+	GraphQLHostAllowlist []string `json:"graphqlHostAllowlist,omitempty"`
+}
+
+// DataStorageFormat is besu database storage format
+// +kubebuilder:validation:Enum=BONSAI;FOREST
+type DataStorageFormat string
+
+const (
+	// BonsaiDataStorage keeps flat world state, pruning old states uses much less disk
+	BonsaiDataStorage DataStorageFormat = "BONSAI"
+	// ForestDataStorage keeps full world state trie
+	ForestDataStorage DataStorageFormat = "FOREST"
+)
+
+// KeystoreSecret is account encrypted json keystore and its password loaded from secret in network namespace
+type KeystoreSecret struct {
+	// SecretName is the name of secret holding keystore and password
+	SecretName string `json:"secretName"`
+	// KeystoreKey is the secret key holding account encrypted json keystore
+	KeystoreKey string `json:"keystoreKey,omitempty"`
+	// PasswordKey is the secret key holding keystore password
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// ImportedAccount is account derived from private key
+type ImportedAccount struct {
+	// Privatekey is the account private key
+	PrivateKey `json:"privatekey"`
+	// Password is the password used to encrypt account private key
+	Password string `json:"password"`
+}
@@ -0,0 +1,109 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeTaskType is node client administration task
+// +kubebuilder:validation:Enum=export-chain;import-chain;db-compact;snapshot-prune
+type NodeTaskType string
+
+const (
+	// ExportChainTask exports node blocks into chain file
+	ExportChainTask NodeTaskType = "export-chain"
+	// ImportChainTask imports blocks from chain file
+	ImportChainTask NodeTaskType = "import-chain"
+	// CompactDatabaseTask compacts node database, it's supported by geth only
+	CompactDatabaseTask NodeTaskType = "db-compact"
+	// PruneSnapshotTask prunes stale state using state snapshot, it's supported by geth only
+	PruneSnapshotTask NodeTaskType = "snapshot-prune"
+)
+
+// NodeTaskPhase is node task execution phase
+type NodeTaskPhase string
+
+const (
+	// NodeTaskPending means task is waiting for node to be stopped or task job to start
+	NodeTaskPending NodeTaskPhase = "Pending"
+	// NodeTaskRunning means task job is running
+	NodeTaskRunning NodeTaskPhase = "Running"
+	// NodeTaskSucceeded means task job completed successfully
+	NodeTaskSucceeded NodeTaskPhase = "Succeeded"
+	// NodeTaskFailed means task job failed or task can't be run
+	NodeTaskFailed NodeTaskPhase = "Failed"
+)
+
+// NodeTaskSpec defines the desired state of NodeTask
+type NodeTaskSpec struct {
+	// Network is name of the network the node belongs to
+	Network string `json:"network"`
+
+	// Node is name of the network node the task is run against
+	Node string `json:"node"`
+
+	// Task is client administration task
+	Task NodeTaskType `json:"task"`
+
+	// File is chain file path relative to node data directory, it's used by export-chain and import-chain tasks
+	File string `json:"file,omitempty"`
+
+	// Pause is whether node is stopped while task runs
+	// clients lock their database, so tasks writing or reading node database require node to be stopped
+	Pause bool `json:"pause,omitempty"`
+}
+
+// NodeTaskStatus defines the observed state of NodeTask
+type NodeTaskStatus struct {
+	// Phase is task execution phase
+	Phase NodeTaskPhase `json:"phase,omitempty"`
+
+	// StartTime is when task job has been created
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when task job completed or failed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message is why task is pending or failed
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=etask,categories=kotal;ethereum
+
+// NodeTask is the Schema for the nodetasks API
+// +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
+// +kubebuilder:printcolumn:name="Node",type=string,JSONPath=".spec.node"
+// +kubebuilder:printcolumn:name="Task",type=string,JSONPath=".spec.task"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+type NodeTask struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeTaskSpec   `json:"spec,omitempty"`
+	Status NodeTaskStatus `json:"status,omitempty"`
+}
+
+// IsCompleted is whether task job completed or task failed
+func (t *NodeTask) IsCompleted() bool {
+	return t.Status.Phase == NodeTaskSucceeded || t.Status.Phase == NodeTaskFailed
+}
+
+// PausesNode is whether task stops its node, node is started again once task is completed
+func (t *NodeTask) PausesNode() bool {
+	return t.Spec.Pause && !t.IsCompleted()
+}
+
+// +kubebuilder:object:root=true
+
+// NodeTaskList contains a list of NodeTask
+type NodeTaskList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeTask `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeTask{}, &NodeTaskList{})
+}
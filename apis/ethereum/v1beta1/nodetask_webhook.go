@@ -0,0 +1,90 @@
+package v1beta1
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var nodetasklog = logf.Log.WithName("nodetask-resource")
+
+// SetupWebhookWithManager registers webhook to be started wth the given manager
+func (t *NodeTask) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(t).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-ethereum-kotal-io-v1beta1-nodetask,mutating=false,failurePolicy=fail,groups=ethereum.kotal.io,resources=nodetasks,versions=v1beta1,name=vnodetask.kb.io
+
+var _ webhook.Validator = &NodeTask{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (t *NodeTask) ValidateCreate() error {
+	var allErrors field.ErrorList
+
+	nodetasklog.Info("validate create", "name", t.Name)
+
+	filePath := field.NewPath("spec").Child("file")
+
+	// validate chain file is provided to chain export and import tasks only
+	if t.Spec.Task == ExportChainTask || t.Spec.Task == ImportChainTask {
+		if t.Spec.File == "" {
+			err := field.Invalid(filePath, "", fmt.Sprintf("must be provided if task is %s", t.Spec.Task))
+			allErrors = append(allErrors, err)
+		}
+	} else if t.Spec.File != "" {
+		err := field.Invalid(filePath, t.Spec.File, fmt.Sprintf("must be none if task is %s", t.Spec.Task))
+		allErrors = append(allErrors, err)
+	}
+
+	// validate chain file is kept in node data directory
+	if file := t.Spec.File; file != "" && (filepath.IsAbs(file) || strings.HasPrefix(filepath.Clean(file), "..")) {
+		err := field.Invalid(filePath, file, "must be relative to node data directory")
+		allErrors = append(allErrors, err)
+	}
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{}, t.Name, allErrors)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (t *NodeTask) ValidateUpdate(old runtime.Object) error {
+	var allErrors field.ErrorList
+
+	nodetasklog.Info("validate update", "name", t.Name)
+
+	oldTask := old.(*NodeTask)
+
+	// task is run once, it's re-run by creating another task
+	if !reflect.DeepEqual(oldTask.Spec, t.Spec) {
+		err := field.Invalid(field.NewPath("spec"), "", "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{}, t.Name, allErrors)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (t *NodeTask) ValidateDelete() error {
+	nodetasklog.Info("validate delete", "name", t.Name)
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package v1beta1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNodeTaskValidateCreate(t *testing.T) {
+	cases := []struct {
+		title string
+		spec  NodeTaskSpec
+		err   string
+	}{
+		{
+			title: "chain export without file",
+			spec:  NodeTaskSpec{Network: "my-network", Node: "node-1", Task: ExportChainTask},
+			err:   "must be provided if task is export-chain",
+		},
+		{
+			title: "database compaction with file",
+			spec:  NodeTaskSpec{Network: "my-network", Node: "node-1", Task: CompactDatabaseTask, File: "chain.rlp"},
+			err:   "must be none if task is db-compact",
+		},
+		{
+			title: "chain import from absolute file",
+			spec:  NodeTaskSpec{Network: "my-network", Node: "node-1", Task: ImportChainTask, File: "/etc/chain.rlp"},
+			err:   "must be relative to node data directory",
+		},
+		{
+			title: "chain import from file outside node data directory",
+			spec:  NodeTaskSpec{Network: "my-network", Node: "node-1", Task: ImportChainTask, File: "../chain.rlp"},
+			err:   "must be relative to node data directory",
+		},
+		{
+			title: "chain export into node data directory",
+			spec:  NodeTaskSpec{Network: "my-network", Node: "node-1", Task: ExportChainTask, File: "exports/chain.rlp", Pause: true},
+		},
+	}
+
+	for _, c := range cases {
+		task := &NodeTask{Spec: c.spec}
+		err := task.ValidateCreate()
+		if c.err == "" {
+			if err != nil {
+				t.Errorf("%s: expecting no error got %s", c.title, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s: expecting error %q got %v", c.title, c.err, err)
+		}
+	}
+}
+
+func TestNodeTaskValidateUpdate(t *testing.T) {
+	old := &NodeTask{Spec: NodeTaskSpec{Network: "my-network", Node: "node-1", Task: CompactDatabaseTask}}
+
+	task := old.DeepCopy()
+	task.Status.Phase = NodeTaskRunning
+	if err := task.ValidateUpdate(old); err != nil {
+		t.Errorf("Expecting status update to be valid got %s", err)
+	}
+
+	task.Spec.Pause = true
+	if err := task.ValidateUpdate(old); err == nil || !strings.Contains(err.Error(), "field is immutable") {
+		t.Errorf("Expecting spec update to be rejected got %v", err)
+	}
+}
@@ -0,0 +1,1178 @@
+// +build !ignore_autogenerated
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Account) DeepCopyInto(out *Account) {
+	*out = *in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = make(map[HexString]HexString, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Account.
+func (in *Account) DeepCopy() *Account {
+	if in == nil {
+		return nil
+	}
+	out := new(Account)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoExpandPolicy) DeepCopyInto(out *AutoExpandPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoExpandPolicy.
+func (in *AutoExpandPolicy) DeepCopy() *AutoExpandPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoExpandPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupPolicy) DeepCopyInto(out *BackupPolicy) {
+	*out = *in
+	out.Schedule = in.Schedule
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPolicy.
+func (in *BackupPolicy) DeepCopy() *BackupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
+func (in *BackupStatus) DeepCopy() *BackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BesuOptions) DeepCopyInto(out *BesuOptions) {
+	*out = *in
+	if in.MetricsHostAllowlist != nil {
+		in, out := &in.MetricsHostAllowlist, &out.MetricsHostAllowlist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GraphQLHostAllowlist != nil {
+		in, out := &in.GraphQLHostAllowlist, &out.GraphQLHostAllowlist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BesuOptions.
+func (in *BesuOptions) DeepCopy() *BesuOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(BesuOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Clique) DeepCopyInto(out *Clique) {
+	*out = *in
+	out.PoA = in.PoA
+	if in.Signers != nil {
+		in, out := &in.Signers, &out.Signers
+		*out = make([]EthereumAddress, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Clique.
+func (in *Clique) DeepCopy() *Clique {
+	if in == nil {
+		return nil
+	}
+	out := new(Clique)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsoleScript) DeepCopyInto(out *ConsoleScript) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsoleScript.
+func (in *ConsoleScript) DeepCopy() *ConsoleScript {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsoleScript)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsoleScriptStatus) DeepCopyInto(out *ConsoleScriptStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsoleScriptStatus.
+func (in *ConsoleScriptStatus) DeepCopy() *ConsoleScriptStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsoleScriptStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevMode) DeepCopyInto(out *DevMode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevMode.
+func (in *DevMode) DeepCopy() *DevMode {
+	if in == nil {
+		return nil
+	}
+	out := new(DevMode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ethash) DeepCopyInto(out *Ethash) {
+	*out = *in
+	if in.FixedDifficulty != nil {
+		in, out := &in.FixedDifficulty, &out.FixedDifficulty
+		*out = new(uint)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ethash.
+func (in *Ethash) DeepCopy() *Ethash {
+	if in == nil {
+		return nil
+	}
+	out := new(Ethash)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ethstats) DeepCopyInto(out *Ethstats) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ethstats.
+func (in *Ethstats) DeepCopy() *Ethstats {
+	if in == nil {
+		return nil
+	}
+	out := new(Ethstats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Explorer) DeepCopyInto(out *Explorer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Explorer.
+func (in *Explorer) DeepCopy() *Explorer {
+	if in == nil {
+		return nil
+	}
+	out := new(Explorer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Faucet) DeepCopyInto(out *Faucet) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Faucet.
+func (in *Faucet) DeepCopy() *Faucet {
+	if in == nil {
+		return nil
+	}
+	out := new(Faucet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Forks) DeepCopyInto(out *Forks) {
+	*out = *in
+	if in.DAO != nil {
+		in, out := &in.DAO, &out.DAO
+		*out = new(uint)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Forks.
+func (in *Forks) DeepCopy() *Forks {
+	if in == nil {
+		return nil
+	}
+	out := new(Forks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Genesis) DeepCopyInto(out *Genesis) {
+	*out = *in
+	if in.FromConfigMap != nil {
+		in, out := &in.FromConfigMap, &out.FromConfigMap
+		*out = new(GenesisConfigMapSource)
+		**out = **in
+	}
+	if in.Accounts != nil {
+		in, out := &in.Accounts, &out.Accounts
+		*out = make([]Account, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AccountsFromMnemonic != nil {
+		in, out := &in.AccountsFromMnemonic, &out.AccountsFromMnemonic
+		*out = new(MnemonicAccounts)
+		**out = **in
+	}
+	if in.Permissioning != nil {
+		in, out := &in.Permissioning, &out.Permissioning
+		*out = new(OnchainPermissioning)
+		**out = **in
+	}
+	if in.Ethash != nil {
+		in, out := &in.Ethash, &out.Ethash
+		*out = new(Ethash)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Clique != nil {
+		in, out := &in.Clique, &out.Clique
+		*out = new(Clique)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IBFT2 != nil {
+		in, out := &in.IBFT2, &out.IBFT2
+		*out = new(IBFT2)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Forks != nil {
+		in, out := &in.Forks, &out.Forks
+		*out = new(Forks)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Genesis.
+func (in *Genesis) DeepCopy() *Genesis {
+	if in == nil {
+		return nil
+	}
+	out := new(Genesis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenesisConfigMapSource) DeepCopyInto(out *GenesisConfigMapSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenesisConfigMapSource.
+func (in *GenesisConfigMapSource) DeepCopy() *GenesisConfigMapSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GenesisConfigMapSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IBFT2) DeepCopyInto(out *IBFT2) {
+	*out = *in
+	out.PoA = in.PoA
+	if in.Validators != nil {
+		in, out := &in.Validators, &out.Validators
+		*out = make([]EthereumAddress, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IBFT2.
+func (in *IBFT2) DeepCopy() *IBFT2 {
+	if in == nil {
+		return nil
+	}
+	out := new(IBFT2)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportedAccount) DeepCopyInto(out *ImportedAccount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportedAccount.
+func (in *ImportedAccount) DeepCopy() *ImportedAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportedAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InitScript) DeepCopyInto(out *InitScript) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitScript.
+func (in *InitScript) DeepCopy() *InitScript {
+	if in == nil {
+		return nil
+	}
+	out := new(InitScript)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrityCheckPolicy) DeepCopyInto(out *IntegrityCheckPolicy) {
+	*out = *in
+	out.Schedule = in.Schedule
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrityCheckPolicy.
+func (in *IntegrityCheckPolicy) DeepCopy() *IntegrityCheckPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrityCheckPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrityCheckStatus) DeepCopyInto(out *IntegrityCheckStatus) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrityCheckStatus.
+func (in *IntegrityCheckStatus) DeepCopy() *IntegrityCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrityCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JoinFrom) DeepCopyInto(out *JoinFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JoinFrom.
+func (in *JoinFrom) DeepCopy() *JoinFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(JoinFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeystoreSecret) DeepCopyInto(out *KeystoreSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeystoreSecret.
+func (in *KeystoreSecret) DeepCopy() *KeystoreSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(KeystoreSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalStorage) DeepCopyInto(out *LocalStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalStorage.
+func (in *LocalStorage) DeepCopy() *LocalStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsExporter) DeepCopyInto(out *MetricsExporter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsExporter.
+func (in *MetricsExporter) DeepCopy() *MetricsExporter {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsExporter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MnemonicAccounts) DeepCopyInto(out *MnemonicAccounts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MnemonicAccounts.
+func (in *MnemonicAccounts) DeepCopy() *MnemonicAccounts {
+	if in == nil {
+		return nil
+	}
+	out := new(MnemonicAccounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
+func (in *Monitoring) DeepCopy() *Monitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Monitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Network) DeepCopyInto(out *Network) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Network.
+func (in *Network) DeepCopy() *Network {
+	if in == nil {
+		return nil
+	}
+	out := new(Network)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Network) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkList) DeepCopyInto(out *NetworkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Network, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkList.
+func (in *NetworkList) DeepCopy() *NetworkList {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSource) DeepCopyInto(out *NetworkSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSource.
+func (in *NetworkSource) DeepCopy() *NetworkSource {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+	*out = *in
+	if in.Genesis != nil {
+		in, out := &in.Genesis, &out.Genesis
+		*out = new(Genesis)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeTemplate != nil {
+		in, out := &in.NodeTemplate, &out.NodeTemplate
+		*out = new(NodeTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]Node, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]NodePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.JoinFrom != nil {
+		in, out := &in.JoinFrom, &out.JoinFrom
+		*out = new(JoinFrom)
+		**out = **in
+	}
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = new(NetworkSource)
+		**out = **in
+	}
+	if in.Bootnodes != nil {
+		in, out := &in.Bootnodes, &out.Bootnodes
+		*out = make([]Enode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(Monitoring)
+		**out = **in
+	}
+	if in.Dev != nil {
+		in, out := &in.Dev, &out.Dev
+		*out = new(DevMode)
+		**out = **in
+	}
+	if in.Faucet != nil {
+		in, out := &in.Faucet, &out.Faucet
+		*out = new(Faucet)
+		**out = **in
+	}
+	if in.Explorer != nil {
+		in, out := &in.Explorer, &out.Explorer
+		*out = new(Explorer)
+		**out = **in
+	}
+	if in.Ethstats != nil {
+		in, out := &in.Ethstats, &out.Ethstats
+		*out = new(Ethstats)
+		**out = **in
+	}
+	if in.TTLSecondsAfterCreation != nil {
+		in, out := &in.TTLSecondsAfterCreation, &out.TTLSecondsAfterCreation
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
+func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkStatus) DeepCopyInto(out *NetworkStatus) {
+	*out = *in
+	if in.Bootnodes != nil {
+		in, out := &in.Bootnodes, &out.Bootnodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SpecHashes != nil {
+		in, out := &in.SpecHashes, &out.SpecHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]SpecChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStatus.
+func (in *NetworkStatus) DeepCopy() *NetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Node) DeepCopyInto(out *Node) {
+	*out = *in
+	if in.Import != nil {
+		in, out := &in.Import, &out.Import
+		*out = new(ImportedAccount)
+		**out = **in
+	}
+	if in.Keystore != nil {
+		in, out := &in.Keystore, &out.Keystore
+		*out = new(KeystoreSecret)
+		**out = **in
+	}
+	if in.Besu != nil {
+		in, out := &in.Besu, &out.Besu
+		*out = new(BesuOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CORSDomains != nil {
+		in, out := &in.CORSDomains, &out.CORSDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RPCAPI != nil {
+		in, out := &in.RPCAPI, &out.RPCAPI
+		*out = make([]API, len(*in))
+		copy(*out, *in)
+	}
+	if in.RPCProxy != nil {
+		in, out := &in.RPCProxy, &out.RPCProxy
+		*out = new(RPCProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WSAPI != nil {
+		in, out := &in.WSAPI, &out.WSAPI
+		*out = make([]API, len(*in))
+		copy(*out, *in)
+	}
+	if in.MetricsExporter != nil {
+		in, out := &in.MetricsExporter, &out.MetricsExporter
+		*out = new(MetricsExporter)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(NodeResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(NodeService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitScripts != nil {
+		in, out := &in.InitScripts, &out.InitScripts
+		*out = make([]InitScript, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConsoleScripts != nil {
+		in, out := &in.ConsoleScripts, &out.ConsoleScripts
+		*out = make([]ConsoleScript, len(*in))
+		copy(*out, *in)
+	}
+	if in.Local != nil {
+		in, out := &in.Local, &out.Local
+		*out = new(LocalStorage)
+		**out = **in
+	}
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(NodeRestore)
+		**out = **in
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = new(SnapshotPolicy)
+		**out = **in
+	}
+	if in.AutoExpand != nil {
+		in, out := &in.AutoExpand, &out.AutoExpand
+		*out = new(AutoExpandPolicy)
+		**out = **in
+	}
+	if in.IntegrityCheck != nil {
+		in, out := &in.IntegrityCheck, &out.IntegrityCheck
+		*out = new(IntegrityCheckPolicy)
+		**out = **in
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
+func (in *Node) DeepCopy() *Node {
+	if in == nil {
+		return nil
+	}
+	out := new(Node)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePool) DeepCopyInto(out *NodePool) {
+	*out = *in
+	in.Node.DeepCopyInto(&out.Node)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePool.
+func (in *NodePool) DeepCopy() *NodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeResources) DeepCopyInto(out *NodeResources) {
+	*out = *in
+	if in.StorageClass != nil {
+		in, out := &in.StorageClass, &out.StorageClass
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeResources.
+func (in *NodeResources) DeepCopy() *NodeResources {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeRestore) DeepCopyInto(out *NodeRestore) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRestore.
+func (in *NodeRestore) DeepCopy() *NodeRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeService) DeepCopyInto(out *NodeService) {
+	*out = *in
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeService.
+func (in *NodeService) DeepCopy() *NodeService {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
+	*out = *in
+	if in.NoPeersSince != nil {
+		in, out := &in.NoPeersSince, &out.NoPeersSince
+		*out = (*in).DeepCopy()
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IntegrityCheck != nil {
+		in, out := &in.IntegrityCheck, &out.IntegrityCheck
+		*out = new(IntegrityCheckStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConsoleScripts != nil {
+		in, out := &in.ConsoleScripts, &out.ConsoleScripts
+		*out = make([]ConsoleScriptStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatus.
+func (in *NodeStatus) DeepCopy() *NodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTask) DeepCopyInto(out *NodeTask) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTask.
+func (in *NodeTask) DeepCopy() *NodeTask {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeTask) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTaskList) DeepCopyInto(out *NodeTaskList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTaskList.
+func (in *NodeTaskList) DeepCopy() *NodeTaskList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTaskList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeTaskList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTaskSpec) DeepCopyInto(out *NodeTaskSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTaskSpec.
+func (in *NodeTaskSpec) DeepCopy() *NodeTaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTaskStatus) DeepCopyInto(out *NodeTaskStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTaskStatus.
+func (in *NodeTaskStatus) DeepCopy() *NodeTaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTemplate) DeepCopyInto(out *NodeTemplate) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(NodeResources)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplate.
+func (in *NodeTemplate) DeepCopy() *NodeTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnchainPermissioning) DeepCopyInto(out *OnchainPermissioning) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnchainPermissioning.
+func (in *OnchainPermissioning) DeepCopy() *OnchainPermissioning {
+	if in == nil {
+		return nil
+	}
+	out := new(OnchainPermissioning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoA) DeepCopyInto(out *PoA) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoA.
+func (in *PoA) DeepCopy() *PoA {
+	if in == nil {
+		return nil
+	}
+	out := new(PoA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RPCProxy) DeepCopyInto(out *RPCProxy) {
+	*out = *in
+	if in.AllowedMethods != nil {
+		in, out := &in.AllowedMethods, &out.AllowedMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RPCProxy.
+func (in *RPCProxy) DeepCopy() *RPCProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(RPCProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotPolicy) DeepCopyInto(out *SnapshotPolicy) {
+	*out = *in
+	out.Schedule = in.Schedule
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotPolicy.
+func (in *SnapshotPolicy) DeepCopy() *SnapshotPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpecChange) DeepCopyInto(out *SpecChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecChange.
+func (in *SpecChange) DeepCopy() *SpecChange {
+	if in == nil {
+		return nil
+	}
+	out := new(SpecChange)
+	in.DeepCopyInto(out)
+	return out
+}
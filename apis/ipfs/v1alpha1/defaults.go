@@ -1,5 +1,14 @@
 package v1alpha1
 
+import "time"
+
+const (
+	// DefaultBootstrap is the default node bootstrap mode
+	DefaultBootstrap = PublicBootstrap
+	// DefaultDatastore is the default node repo datastore
+	DefaultDatastore = FlatFSDatastore
+)
+
 // Resources
 const (
 	// DefaultNodeCPURequest is the cpu requested by ipfs node
@@ -15,3 +24,13 @@ const (
 	// DefaultNodeStorageRequest is the Storage requested by ipfs node
 	DefaultNodeStorageRequest = "10Gi"
 )
+
+// IPNS records
+const (
+	// DefaultIPNSKey is the default key ipns records are published under
+	DefaultIPNSKey = "self"
+	// DefaultIPNSLifetime is the default ipns record lifetime
+	DefaultIPNSLifetime = 24 * time.Hour
+	// DefaultIPNSRepublishInterval is the default ipns record republish interval
+	DefaultIPNSRepublishInterval = 12 * time.Hour
+)
@@ -0,0 +1,65 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IPNSRecordSpec defines the desired state of IPNSRecord
+type IPNSRecordSpec struct {
+	// Peer is name of ipfs peer publishing the record
+	Peer string `json:"peer"`
+	// CID is content identifier published under the key
+	// +kubebuilder:validation:MinLength=1
+	CID string `json:"cid"`
+	// Key is name of peer key the record is published under, self or name of a Key added to the peer
+	Key string `json:"key,omitempty"`
+	// Lifetime is how long the published record is valid
+	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+	// RepublishInterval is how often the record is republished
+	RepublishInterval *metav1.Duration `json:"republishInterval,omitempty"`
+}
+
+// IPNSRecordStatus defines the observed state of IPNSRecord
+type IPNSRecordStatus struct {
+	// Name is ipns name the record is published under
+	Name string `json:"name,omitempty"`
+	// Value is the published ipfs path
+	Value string `json:"value,omitempty"`
+	// LastPublished is the last time the record has been published
+	LastPublished *metav1.Time `json:"lastPublished,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ipns,categories=kotal;ipfs
+
+// IPNSRecord is the Schema for the ipnsrecords API
+// +kubebuilder:printcolumn:name="Peer",type=string,JSONPath=".spec.peer"
+// +kubebuilder:printcolumn:name="Name",type=string,JSONPath=".status.name"
+// +kubebuilder:printcolumn:name="Value",type=string,JSONPath=".status.value"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+type IPNSRecord struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IPNSRecordSpec   `json:"spec,omitempty"`
+	Status IPNSRecordStatus `json:"status,omitempty"`
+}
+
+// Path returns ipfs path published by this record
+func (r *IPNSRecord) Path() string {
+	return "/ipfs/" + r.Spec.CID
+}
+
+// +kubebuilder:object:root=true
+
+// IPNSRecordList contains a list of IPNSRecord
+type IPNSRecordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IPNSRecord `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IPNSRecord{}, &IPNSRecordList{})
+}
@@ -0,0 +1,106 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var ipnsrecordlog = logf.Log.WithName("ipnsrecord-resource")
+
+// SetupWebhookWithManager registers webhook to be started wth the given manager
+func (r *IPNSRecord) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-ipfs-kotal-io-v1alpha1-ipnsrecord,mutating=true,failurePolicy=fail,groups=ipfs.kotal.io,resources=ipnsrecords,verbs=create;update,versions=v1alpha1,name=mipnsrecord.kb.io
+
+var _ webhook.Defaulter = &IPNSRecord{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *IPNSRecord) Default() {
+	ipnsrecordlog.Info("default", "name", r.Name)
+
+	if r.Spec.Key == "" {
+		r.Spec.Key = DefaultIPNSKey
+	}
+
+	if r.Spec.Lifetime == nil {
+		r.Spec.Lifetime = &metav1.Duration{Duration: DefaultIPNSLifetime}
+	}
+
+	if r.Spec.RepublishInterval == nil {
+		r.Spec.RepublishInterval = &metav1.Duration{Duration: DefaultIPNSRepublishInterval}
+	}
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-ipfs-kotal-io-v1alpha1-ipnsrecord,mutating=false,failurePolicy=fail,groups=ipfs.kotal.io,resources=ipnsrecords,versions=v1alpha1,name=vipnsrecord.kb.io
+
+var _ webhook.Validator = &IPNSRecord{}
+
+// Validate is the shared validation between create and update
+func (r *IPNSRecord) Validate() field.ErrorList {
+	var allErrors field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if r.Spec.RepublishInterval.Duration <= 0 {
+		err := field.Invalid(specPath.Child("republishInterval"), r.Spec.RepublishInterval.Duration.String(), "must be greater than zero")
+		allErrors = append(allErrors, err)
+	}
+
+	// record must be republished before it expires
+	if r.Spec.RepublishInterval.Duration >= r.Spec.Lifetime.Duration {
+		msg := fmt.Sprintf("must be less than lifetime %s", r.Spec.Lifetime.Duration)
+		err := field.Invalid(specPath.Child("republishInterval"), r.Spec.RepublishInterval.Duration.String(), msg)
+		allErrors = append(allErrors, err)
+	}
+
+	return allErrors
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *IPNSRecord) ValidateCreate() error {
+	var allErrors field.ErrorList
+
+	ipnsrecordlog.Info("validate create", "name", r.Name)
+
+	allErrors = append(allErrors, r.Validate()...)
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *IPNSRecord) ValidateUpdate(old runtime.Object) error {
+	var allErrors field.ErrorList
+
+	ipnsrecordlog.Info("validate update", "name", r.Name)
+
+	allErrors = append(allErrors, r.Validate()...)
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{}, r.Name, allErrors)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *IPNSRecord) ValidateDelete() error {
+	ipnsrecordlog.Info("validate delete", "name", r.Name)
+
+	return nil
+}
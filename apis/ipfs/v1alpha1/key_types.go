@@ -0,0 +1,53 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KeySpec defines the desired state of Key
+type KeySpec struct {
+	// Peer is name of ipfs peer the key is added to
+	Peer string `json:"peer"`
+}
+
+// KeyStatus defines the observed state of Key
+type KeyStatus struct {
+	// ID is key peer ID, which is the ipns name records are published under
+	ID string `json:"id,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ikey,categories=kotal;ipfs
+
+// Key is the Schema for the keys API
+// +kubebuilder:printcolumn:name="Peer",type=string,JSONPath=".spec.peer"
+// +kubebuilder:printcolumn:name="ID",type=string,JSONPath=".status.id"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+type Key struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeySpec   `json:"spec,omitempty"`
+	Status KeyStatus `json:"status,omitempty"`
+}
+
+// SecretName returns name of the secret holding the key
+func (k *Key) SecretName() string {
+	return fmt.Sprintf("%s-ipfs-key", k.Name)
+}
+
+// +kubebuilder:object:root=true
+
+// KeyList contains a list of Key
+type KeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Key `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Key{}, &KeyList{})
+}
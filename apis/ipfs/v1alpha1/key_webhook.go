@@ -0,0 +1,71 @@
+package v1alpha1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var keylog = logf.Log.WithName("key-resource")
+
+// SetupWebhookWithManager registers webhook to be started wth the given manager
+func (k *Key) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(k).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-ipfs-kotal-io-v1alpha1-key,mutating=false,failurePolicy=fail,groups=ipfs.kotal.io,resources=keys,versions=v1alpha1,name=vkey.kb.io
+
+var _ webhook.Validator = &Key{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (k *Key) ValidateCreate() error {
+	var allErrors field.ErrorList
+
+	keylog.Info("validate create", "name", k.Name)
+
+	// self is reserved for peer identity key
+	if k.Name == DefaultIPNSKey {
+		err := field.Invalid(field.NewPath("metadata").Child("name"), k.Name, "is reserved for peer identity key")
+		allErrors = append(allErrors, err)
+	}
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{}, k.Name, allErrors)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (k *Key) ValidateUpdate(old runtime.Object) error {
+	var allErrors field.ErrorList
+
+	keylog.Info("validate update", "name", k.Name)
+
+	oldKey := old.(*Key)
+
+	if oldKey.Spec.Peer != k.Spec.Peer {
+		err := field.Invalid(field.NewPath("spec").Child("peer"), k.Spec.Peer, "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{}, k.Name, allErrors)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (k *Key) ValidateDelete() error {
+	keylog.Info("validate delete", "name", k.Name)
+
+	return nil
+}
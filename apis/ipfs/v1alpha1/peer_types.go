@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PeerSpec defines the desired state of Peer
+type PeerSpec struct {
+	// ID is peer ID
+	ID string `json:"id"`
+	// PrivateKey is peer private key
+	PrivateKey string `json:"privateKey"`
+	// Datastore is peer repo datastore backend, chosen once when the repo is initialized
+	Datastore Datastore `json:"datastore,omitempty"`
+	// Profiles is a list of profiles to apply
+	Profiles []Profile `json:"profiles,omitempty"`
+	// Bootstrap is how peer bootstrap list is populated
+	Bootstrap BootstrapMode `json:"bootstrap,omitempty"`
+	// BootstrapPeers is bootstrap peers multiaddresses used if bootstrap is custom
+	BootstrapPeers []string `json:"bootstrapPeers,omitempty"`
+	// PeeringPeers is peers the peer keeps protected connections with
+	PeeringPeers []PeeringPeer `json:"peeringPeers,omitempty"`
+	// Announce is swarm multiaddresses the peer announces to the network instead of its listening addresses
+	Announce []string `json:"announce,omitempty"`
+	// NoAnnounce is swarm multiaddresses the peer doesn't announce to the network
+	NoAnnounce []string `json:"noAnnounce,omitempty"`
+	// RelayClient is whether the peer uses circuit relays when it isn't publicly reachable
+	RelayClient bool `json:"relayClient,omitempty"`
+	// RelayService is whether the peer acts as a circuit relay for other peers
+	RelayService bool `json:"relayService,omitempty"`
+	// AutoNATService is whether the peer helps other peers detect if they're behind nat
+	AutoNATService bool `json:"autoNATService,omitempty"`
+	// Gateway is peer http gateway configuration
+	Gateway *Gateway `json:"gateway,omitempty"`
+	// Metrics is whether peer api (which serves prometheus metrics) is reachable from the cluster or no
+	Metrics bool `json:"metrics,omitempty"`
+	// IPFamily is peer service ip family, cluster default is used if not provided
+	IPFamily IPFamily `json:"ipFamily,omitempty"`
+	// ExtraArgs is extra ipfs daemon arguments
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+	// Env is extra environment variables set in the ipfs daemon container
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// Resources is peer compute and storage resources
+	Resources *NodeResources `json:"resources,omitempty"`
+}
+
+// PeerStatus defines the observed state of Peer
+type PeerStatus struct {
+	// ServiceIP is peer service cluster ip
+	ServiceIP string `json:"serviceIP,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ipeer,categories=kotal;ipfs
+
+// Peer is the Schema for the peers API
+// +kubebuilder:printcolumn:name="ID",type=string,JSONPath=".spec.id"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+type Peer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PeerSpec   `json:"spec,omitempty"`
+	Status PeerStatus `json:"status,omitempty"`
+}
+
+// Node returns ipfs node spec of this peer
+func (p *Peer) Node() *Node {
+	return &Node{
+		Name:           p.Name,
+		ID:             p.Spec.ID,
+		PrivateKey:     p.Spec.PrivateKey,
+		Datastore:      p.Spec.Datastore,
+		Profiles:       p.Spec.Profiles,
+		Bootstrap:      p.Spec.Bootstrap,
+		BootstrapPeers: p.Spec.BootstrapPeers,
+		PeeringPeers:   p.Spec.PeeringPeers,
+		Announce:       p.Spec.Announce,
+		NoAnnounce:     p.Spec.NoAnnounce,
+		RelayClient:    p.Spec.RelayClient,
+		RelayService:   p.Spec.RelayService,
+		AutoNATService: p.Spec.AutoNATService,
+		Gateway:        p.Spec.Gateway,
+		Metrics:        p.Spec.Metrics,
+		IPFamily:       p.Spec.IPFamily,
+		ExtraArgs:      p.Spec.ExtraArgs,
+		Env:            p.Spec.Env,
+		Resources:      p.Spec.Resources,
+	}
+}
+
+// Labels to be used by peer resources
+func (p *Peer) Labels() map[string]string {
+	return map[string]string{
+		"name":     "node",
+		"instance": p.Name,
+		"peer":     p.Name,
+	}
+}
+
+// +kubebuilder:object:root=true
+
+// PeerList contains a list of Peer
+type PeerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Peer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Peer{}, &PeerList{})
+}
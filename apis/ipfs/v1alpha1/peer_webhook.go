@@ -0,0 +1,112 @@
+package v1alpha1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var peerlog = logf.Log.WithName("peer-resource")
+
+// SetupWebhookWithManager registers webhook to be started wth the given manager
+func (p *Peer) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(p).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-ipfs-kotal-io-v1alpha1-peer,mutating=true,failurePolicy=fail,groups=ipfs.kotal.io,resources=peers,verbs=create;update,versions=v1alpha1,name=mpeer.kb.io
+
+var _ webhook.Defaulter = &Peer{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (p *Peer) Default() {
+	peerlog.Info("default", "name", p.Name)
+
+	if p.Spec.Bootstrap == "" {
+		p.Spec.Bootstrap = DefaultBootstrap
+	}
+
+	if p.Spec.Datastore == "" {
+		p.Spec.Datastore = DefaultDatastore
+	}
+
+	if p.Spec.Resources == nil {
+		p.Spec.Resources = &NodeResources{}
+	}
+
+	defaultNodeResources(p.Spec.Resources)
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-ipfs-kotal-io-v1alpha1-peer,mutating=false,failurePolicy=fail,groups=ipfs.kotal.io,resources=peers,versions=v1alpha1,name=vpeer.kb.io
+
+var _ webhook.Validator = &Peer{}
+
+// Validate is the shared validation between create and update
+func (p *Peer) Validate() field.ErrorList {
+	var allErrors field.ErrorList
+	specPath := field.NewPath("spec")
+
+	allErrors = append(allErrors, validateNodeBootstrap(p.Spec.Bootstrap, p.Spec.BootstrapPeers, specPath)...)
+	allErrors = append(allErrors, validateNodePeering(p.Spec.ID, p.Spec.PeeringPeers, specPath)...)
+	allErrors = append(allErrors, validateNodeGateway(p.Spec.Gateway, specPath)...)
+	allErrors = append(allErrors, validateNodeResources(p.Spec.Resources, specPath.Child("resources"))...)
+
+	return allErrors
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (p *Peer) ValidateCreate() error {
+	var allErrors field.ErrorList
+
+	peerlog.Info("validate create", "name", p.Name)
+
+	allErrors = append(allErrors, p.Validate()...)
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{}, p.Name, allErrors)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (p *Peer) ValidateUpdate(old runtime.Object) error {
+	var allErrors field.ErrorList
+
+	peerlog.Info("validate update", "name", p.Name)
+
+	allErrors = append(allErrors, p.Validate()...)
+
+	oldPeer := old.(*Peer)
+
+	// service ip family can't be changed after service creation
+	if oldPeer.Spec.IPFamily != p.Spec.IPFamily {
+		err := field.Invalid(field.NewPath("spec").Child("ipFamily"), p.Spec.IPFamily, "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	// datastore is chosen when the repo is initialized, unset datastore predates defaulting
+	if oldPeer.Spec.Datastore != "" && oldPeer.Spec.Datastore != p.Spec.Datastore {
+		err := field.Invalid(field.NewPath("spec").Child("datastore"), p.Spec.Datastore, "field is immutable")
+		allErrors = append(allErrors, err)
+	}
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{}, p.Name, allErrors)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (p *Peer) ValidateDelete() error {
+	peerlog.Info("validate delete", "name", p.Name)
+
+	return nil
+}
@@ -2,6 +2,9 @@ package v1alpha1
 
 import (
 	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -10,6 +13,14 @@ type SwarmSpec struct {
 	// Nodes is swarm nodes
 	// +kubebuilder:validation:MinItems=1
 	Nodes []Node `json:"nodes"`
+	// Monitoring is swarm monitoring configuration
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+}
+
+// Monitoring is swarm monitoring configuration
+type Monitoring struct {
+	// Dashboards is whether to generate grafana dashboards configmap or no
+	Dashboards bool `json:"dashboards,omitempty"`
 }
 
 // Node is ipfs node
@@ -20,16 +31,58 @@ type Node struct {
 	ID string `json:"id"`
 	// PrivateKey is node private key
 	PrivateKey string `json:"privateKey"`
+	// Datastore is node repo datastore backend, chosen once when the repo is initialized
+	Datastore Datastore `json:"datastore,omitempty"`
 	// Profiles is a list of profiles to apply
 	Profiles []Profile `json:"profiles,omitempty"`
+	// Bootstrap is how node bootstrap list is populated
+	Bootstrap BootstrapMode `json:"bootstrap,omitempty"`
+	// BootstrapPeers is bootstrap peers multiaddresses used if bootstrap is custom
+	BootstrapPeers []string `json:"bootstrapPeers,omitempty"`
+	// PeeringPeers is external peers the node keeps protected connections with, in addition to other swarm nodes
+	PeeringPeers []PeeringPeer `json:"peeringPeers,omitempty"`
+	// Announce is swarm multiaddresses the node announces to the network instead of its listening addresses
+	Announce []string `json:"announce,omitempty"`
+	// NoAnnounce is swarm multiaddresses the node doesn't announce to the network
+	NoAnnounce []string `json:"noAnnounce,omitempty"`
+	// RelayClient is whether the node uses circuit relays when it isn't publicly reachable
+	RelayClient bool `json:"relayClient,omitempty"`
+	// RelayService is whether the node acts as a circuit relay for other peers
+	RelayService bool `json:"relayService,omitempty"`
+	// AutoNATService is whether the node helps other peers detect if they're behind nat
+	AutoNATService bool `json:"autoNATService,omitempty"`
+	// Gateway is node http gateway configuration
+	Gateway *Gateway `json:"gateway,omitempty"`
+	// Metrics is whether node api (which serves prometheus metrics) is reachable from the cluster or no
+	Metrics bool `json:"metrics,omitempty"`
+	// IPFamily is node service ip family, cluster default is used if not provided
+	IPFamily IPFamily `json:"ipFamily,omitempty"`
+	// ExtraArgs is extra ipfs daemon arguments
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+	// Env is extra environment variables set in the ipfs daemon container
+	Env []corev1.EnvVar `json:"env,omitempty"`
 	// Resources is node compute and storage resources
 	Resources *NodeResources `json:"resources,omitempty"`
 }
 
 // SwarmAddress returns node swarm address
 func (n *Node) SwarmAddress(ip string) string {
+	protocol := "ip4"
+	if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() == nil {
+		protocol = "ip6"
+	}
 	// TODO: replace hardcoded 4001 port with node swarm port
-	return fmt.Sprintf("/ip4/%s/tcp/4001/p2p/%s", ip, n.ID)
+	return fmt.Sprintf("/%s/%s/tcp/4001/p2p/%s", protocol, ip, n.ID)
+}
+
+// PeeringAddress returns node swarm address resolved from its service dns name, it doesn't change if service is recreated
+func (n *Node) PeeringAddress(swarm, namespace string) string {
+	protocol := "dns4"
+	if n.IPFamily == IPv6Family {
+		protocol = "dns6"
+	}
+	// TODO: replace hardcoded 4001 port with node swarm port
+	return fmt.Sprintf("/%s/%s.%s.svc/tcp/4001", protocol, n.ServiceName(swarm), namespace)
 }
 
 // DeploymentName returns name to be used by node deployment
@@ -61,6 +114,38 @@ func (n *Node) Labels(swarm string) map[string]string {
 	}
 }
 
+// PeeringPeer is peer the node keeps protected connection with, reconnecting if connection is lost
+type PeeringPeer struct {
+	// ID is peer ID
+	ID string `json:"id"`
+	// Addrs is peer swarm multiaddresses, peer addresses are looked up in the dht if not provided
+	Addrs []string `json:"addrs,omitempty"`
+}
+
+// Gateway is ipfs http gateway configuration
+type Gateway struct {
+	// PathPrefixes is list of path prefixes the gateway is allowed to be served under
+	PathPrefixes []string `json:"pathPrefixes,omitempty"`
+	// NoFetch is whether gateway serves local content only without fetching it from the network
+	NoFetch bool `json:"noFetch,omitempty"`
+	// Writable is whether gateway accepts writes (POST, PUT and DELETE)
+	Writable bool `json:"writable,omitempty"`
+	// PublicGateways is public gateways configuration per hostname
+	PublicGateways []PublicGateway `json:"publicGateways,omitempty"`
+}
+
+// PublicGateway is public gateway configuration for a single hostname
+type PublicGateway struct {
+	// Hostname is gateway hostname
+	Hostname string `json:"hostname"`
+	// Paths is list of content paths served on this hostname
+	Paths []string `json:"paths,omitempty"`
+	// UseSubdomains is whether content is served from subdomains like {cid}.ipfs.{hostname}
+	UseSubdomains bool `json:"useSubdomains,omitempty"`
+	// NoDNSLink is whether dnslink lookup is disabled for this hostname
+	NoDNSLink bool `json:"noDNSLink,omitempty"`
+}
+
 // NodeResources is node compute and storage resources
 type NodeResources struct {
 	// CPU is cpu cores the node requires
@@ -80,6 +165,41 @@ type NodeResources struct {
 	Storage string `json:"storage,omitempty"`
 }
 
+// IPFamily is node service ip family
+// +kubebuilder:validation:Enum=IPv4;IPv6
+type IPFamily string
+
+const (
+	// IPv4Family is IPv4 ip family
+	IPv4Family IPFamily = "IPv4"
+	// IPv6Family is IPv6 ip family
+	IPv6Family IPFamily = "IPv6"
+)
+
+// Datastore is ipfs repo datastore backend
+// +kubebuilder:validation:Enum=flatfs;badger
+type Datastore string
+
+const (
+	// FlatFSDatastore stores blocks as files in the file system
+	FlatFSDatastore Datastore = "flatfs"
+	// BadgerDatastore stores blocks in badger key value store
+	BadgerDatastore Datastore = "badger"
+)
+
+// BootstrapMode is how node bootstrap list is populated
+// +kubebuilder:validation:Enum=public;none;custom
+type BootstrapMode string
+
+const (
+	// PublicBootstrap keeps default public ipfs network bootstrap peers
+	PublicBootstrap BootstrapMode = "public"
+	// NoBootstrap clears default bootstrap peers
+	NoBootstrap BootstrapMode = "none"
+	// CustomBootstrap replaces default bootstrap peers with bootstrap peers from spec
+	CustomBootstrap BootstrapMode = "custom"
+)
+
 // Profile is ipfs configuration
 // +kubebuilder:validation:Enum=server;randomports;default-datastore;local-discovery;test;default-networking;flatfs;badgerds;lowpower
 type Profile string
@@ -88,13 +208,52 @@ type Profile string
 type SwarmStatus struct {
 	// NodesCount is number of nodes in this swarm
 	NodesCount int `json:"nodesCount,omitempty"`
+	// ReadyNodes is number of nodes with ready pods
+	ReadyNodes int `json:"readyNodes,omitempty"`
+	// Nodes is swarm nodes observed state
+	Nodes []NodeStatus `json:"nodes,omitempty"`
+	// ObservedGeneration is the most recent swarm generation recorded in history
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// SpecHashes is hashes of observed spec fields, used to summarize changed fields
+	SpecHashes map[string]string `json:"specHashes,omitempty"`
+	// History is the most recent spec changes, newest first
+	History []SpecChange `json:"history,omitempty"`
+}
+
+// SpecChange is observed spec change
+type SpecChange struct {
+	// Generation is the observed generation
+	Generation int64 `json:"generation"`
+	// Time is when the change was observed
+	Time metav1.Time `json:"time"`
+	// Manager is the field manager that changed the spec, like kubectl
+	Manager string `json:"manager,omitempty"`
+	// Changes is summary of changed spec fields
+	Changes []string `json:"changes,omitempty"`
+}
+
+// NodeStatus is ipfs node observed state
+type NodeStatus struct {
+	// Name is node name
+	Name string `json:"name"`
+	// ID is node peer ID
+	ID string `json:"id,omitempty"`
+	// SwarmAddress is node swarm multiaddress used by other swarm nodes to connect to it
+	SwarmAddress string `json:"swarmAddress,omitempty"`
+	// Ready is whether node pod is ready or no
+	Ready bool `json:"ready,omitempty"`
+	// Peers is number of peers connected to the node, reported for ready nodes
+	Peers int `json:"peers,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=iswarm,categories=kotal;ipfs
 
 // Swarm is the Schema for the swarms API
 // +kubebuilder:printcolumn:name="Nodes",type=integer,JSONPath=".status.nodesCount"
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=".status.readyNodes"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
 type Swarm struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -103,6 +262,11 @@ type Swarm struct {
 	Status SwarmStatus `json:"status,omitempty"`
 }
 
+// DashboardsConfigName returns name to be used by grafana dashboards config map
+func (s *Swarm) DashboardsConfigName() string {
+	return fmt.Sprintf("%s-dashboards", s.Name)
+}
+
 // +kubebuilder:object:root=true
 
 // SwarmList contains a list of Swarm
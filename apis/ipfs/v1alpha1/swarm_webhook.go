@@ -10,6 +10,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/kotalco/kotal/helpers"
 )
 
 // log is for logging in this package.
@@ -37,28 +39,69 @@ func (s *Swarm) Default() {
 
 // DefaultNode defaults a single ipfs node spec
 func (s *Swarm) DefaultNode(node *Node) {
+	if node.Bootstrap == "" {
+		node.Bootstrap = DefaultBootstrap
+	}
+
+	if node.Datastore == "" {
+		node.Datastore = DefaultDatastore
+	}
+
 	if node.Resources == nil {
 		node.Resources = &NodeResources{}
 	}
 
-	if node.Resources.CPU == "" {
-		node.Resources.CPU = DefaultNodeCPURequest
+	defaultNodeResources(node.Resources)
+}
+
+// defaultNodeResources defaults ipfs node compute and storage resources
+func defaultNodeResources(resources *NodeResources) {
+	// operator config resources take precedence over built-in defaults
+	config := helpers.Config().Resources
+
+	if resources.CPU == "" {
+		resources.CPU = config.CPU
+	}
+
+	if resources.CPULimit == "" {
+		resources.CPULimit = config.CPULimit
+	}
+
+	if resources.Memory == "" {
+		resources.Memory = config.Memory
+	}
+
+	if resources.MemoryLimit == "" {
+		resources.MemoryLimit = config.MemoryLimit
+	}
+
+	// operator config ipfs storage size takes precedence over operator config resources
+	if resources.Storage == "" {
+		resources.Storage = helpers.StorageSize("ipfs")
 	}
 
-	if node.Resources.CPULimit == "" {
-		node.Resources.CPULimit = DefaultNodeCPULimit
+	if resources.Storage == "" {
+		resources.Storage = config.Storage
 	}
 
-	if node.Resources.Memory == "" {
-		node.Resources.Memory = DefaultNodeMemoryRequest
+	if resources.CPU == "" {
+		resources.CPU = DefaultNodeCPURequest
 	}
 
-	if node.Resources.MemoryLimit == "" {
-		node.Resources.MemoryLimit = DefaultNodeMemoryLimit
+	if resources.CPULimit == "" {
+		resources.CPULimit = DefaultNodeCPULimit
 	}
 
-	if node.Resources.Storage == "" {
-		node.Resources.Storage = DefaultNodeStorageRequest
+	if resources.Memory == "" {
+		resources.Memory = DefaultNodeMemoryRequest
+	}
+
+	if resources.MemoryLimit == "" {
+		resources.MemoryLimit = DefaultNodeMemoryLimit
+	}
+
+	if resources.Storage == "" {
+		resources.Storage = DefaultNodeStorageRequest
 	}
 }
 
@@ -92,27 +135,103 @@ func (s *Swarm) ValidateNode(i int) field.ErrorList {
 	node := s.Spec.Nodes[i]
 	nodePath := field.NewPath("spec").Child("nodes").Index(i)
 
-	cpu := resource.MustParse(node.Resources.CPU)
-	cpuLimit := resource.MustParse(node.Resources.CPULimit)
+	nodeErrors = append(nodeErrors, validateNodeBootstrap(node.Bootstrap, node.BootstrapPeers, nodePath)...)
+	nodeErrors = append(nodeErrors, validateNodePeering(node.ID, node.PeeringPeers, nodePath)...)
+	nodeErrors = append(nodeErrors, validateNodeGateway(node.Gateway, nodePath)...)
+	nodeErrors = append(nodeErrors, validateNodeResources(node.Resources, nodePath.Child("resources"))...)
+
+	return nodeErrors
+}
+
+// validateNodeBootstrap validates bootstrap peers are provided if and only if bootstrap is custom
+func validateNodeBootstrap(bootstrap BootstrapMode, peers []string, nodePath *field.Path) field.ErrorList {
+	var bootstrapErrors field.ErrorList
+
+	if bootstrap == CustomBootstrap && len(peers) == 0 {
+		err := field.Invalid(nodePath.Child("bootstrapPeers"), peers, "must be provided if bootstrap is custom")
+		bootstrapErrors = append(bootstrapErrors, err)
+	}
+
+	if bootstrap != CustomBootstrap && len(peers) != 0 {
+		err := field.Invalid(nodePath.Child("bootstrapPeers"), peers, "must be empty if bootstrap is not custom")
+		bootstrapErrors = append(bootstrapErrors, err)
+	}
+
+	return bootstrapErrors
+}
+
+// validateNodePeering validates peering peers are unique and other than the node itself
+func validateNodePeering(id string, peers []PeeringPeer, nodePath *field.Path) field.ErrorList {
+	var peeringErrors field.ErrorList
+	ids := map[string]bool{}
+
+	for i, peer := range peers {
+		idPath := nodePath.Child("peeringPeers").Index(i).Child("id")
+
+		if peer.ID == id {
+			err := field.Invalid(idPath, peer.ID, "must be another peer")
+			peeringErrors = append(peeringErrors, err)
+		}
+
+		if ids[peer.ID] {
+			err := field.Invalid(idPath, peer.ID, "already used by another peering peer")
+			peeringErrors = append(peeringErrors, err)
+		}
+		ids[peer.ID] = true
+	}
+
+	return peeringErrors
+}
+
+// validateNodeGateway validates public gateways hostnames are unique
+func validateNodeGateway(gateway *Gateway, nodePath *field.Path) field.ErrorList {
+	var gatewayErrors field.ErrorList
+
+	if gateway == nil {
+		return gatewayErrors
+	}
+
+	hostnames := map[string]int{}
+	gatewaysPath := nodePath.Child("gateway").Child("publicGateways")
+
+	for i, public := range gateway.PublicGateways {
+		if j, exists := hostnames[public.Hostname]; exists {
+			msg := fmt.Sprintf("already used by %s", gatewaysPath.Index(j).Child("hostname"))
+			err := field.Invalid(gatewaysPath.Index(i).Child("hostname"), public.Hostname, msg)
+			gatewayErrors = append(gatewayErrors, err)
+		} else {
+			hostnames[public.Hostname] = i
+		}
+	}
+
+	return gatewayErrors
+}
+
+// validateNodeResources validates ipfs node compute resources limits against requests
+func validateNodeResources(resources *NodeResources, resourcesPath *field.Path) field.ErrorList {
+	var resourcesErrors field.ErrorList
+
+	cpu := resource.MustParse(resources.CPU)
+	cpuLimit := resource.MustParse(resources.CPULimit)
 
 	// validate cpuLimit can't be less than cpu request
 	if cpuLimit.Cmp(cpu) == -1 {
-		msg := fmt.Sprintf("must be greater than or equal to cpu %s", string(node.Resources.CPU))
-		err := field.Invalid(nodePath.Child("resources").Child("cpuLimit"), node.Resources.CPULimit, msg)
-		nodeErrors = append(nodeErrors, err)
+		msg := fmt.Sprintf("must be greater than or equal to cpu %s", string(resources.CPU))
+		err := field.Invalid(resourcesPath.Child("cpuLimit"), resources.CPULimit, msg)
+		resourcesErrors = append(resourcesErrors, err)
 	}
 
-	memory := resource.MustParse(node.Resources.Memory)
-	memoryLimit := resource.MustParse(node.Resources.MemoryLimit)
+	memory := resource.MustParse(resources.Memory)
+	memoryLimit := resource.MustParse(resources.MemoryLimit)
 
 	// validate memoryLimit can't be less than memory request
 	if memoryLimit.Cmp(memory) == -1 {
-		msg := fmt.Sprintf("must be greater than or equal to memory %s", string(node.Resources.Memory))
-		err := field.Invalid(nodePath.Child("resources").Child("memoryLimit"), node.Resources.MemoryLimit, msg)
-		nodeErrors = append(nodeErrors, err)
+		msg := fmt.Sprintf("must be greater than or equal to memory %s", string(resources.Memory))
+		err := field.Invalid(resourcesPath.Child("memoryLimit"), resources.MemoryLimit, msg)
+		resourcesErrors = append(resourcesErrors, err)
 	}
 
-	return nodeErrors
+	return resourcesErrors
 }
 
 // Validate is the shared validation between create and update
@@ -151,6 +270,34 @@ func (s *Swarm) ValidateUpdate(old runtime.Object) error {
 
 	allErrors = append(allErrors, s.Validate()...)
 
+	oldSwarm := old.(*Swarm)
+
+	oldNodes := map[string]Node{}
+	for _, node := range oldSwarm.Spec.Nodes {
+		oldNodes[node.Name] = node
+	}
+
+	for i, node := range s.Spec.Nodes {
+		oldNode, exists := oldNodes[node.Name]
+		if !exists {
+			continue
+		}
+
+		nodePath := field.NewPath("spec").Child("nodes").Index(i)
+
+		// service ip family can't be changed after service creation
+		if oldNode.IPFamily != node.IPFamily {
+			err := field.Invalid(nodePath.Child("ipFamily"), node.IPFamily, "field is immutable")
+			allErrors = append(allErrors, err)
+		}
+
+		// datastore is chosen when the repo is initialized, unset datastore predates defaulting
+		if oldNode.Datastore != "" && oldNode.Datastore != node.Datastore {
+			err := field.Invalid(nodePath.Child("datastore"), node.Datastore, "field is immutable")
+			allErrors = append(allErrors, err)
+		}
+	}
+
 	if len(allErrors) == 0 {
 		return nil
 	}
@@ -0,0 +1,101 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ManifestName is the archive entry listing all data directory files at backup time
+// restoring incremental archives removes files missing from the manifest
+const ManifestName = ".kotal-backup-manifest"
+
+// writeArchive writes gzipped tar of data directory files modified after since, zero since archives all files
+// archived is the number of archived files, manifest entry isn't counted
+func writeArchive(w io.Writer, dir string, since time.Time) (archived int, err error) {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	files := []string{}
+
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// files removed while walking, like compacted database tables, are skipped
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil || name == "." {
+			return err
+		}
+		name = filepath.ToSlash(name)
+
+		// sockets and other special files can't be archived
+		if !info.Mode().IsRegular() && !info.IsDir() {
+			return nil
+		}
+
+		files = append(files, name)
+
+		if info.IsDir() || !info.ModTime().After(since) {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = name
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		// file might grow while it's archived, only the size in the header is copied
+		if _, err := io.CopyN(tw, file, info.Size()); err != nil {
+			return err
+		}
+
+		archived++
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	manifest := strings.Join(files, "\n")
+	header := &tar.Header{
+		Name:    ManifestName,
+		Mode:    0644,
+		Size:    int64(len(manifest)),
+		ModTime: time.Now(),
+	}
+	if err = tw.WriteHeader(header); err != nil {
+		return
+	}
+	if _, err = io.WriteString(tw, manifest); err != nil {
+		return
+	}
+
+	if err = tw.Close(); err != nil {
+		return
+	}
+
+	err = gz.Close()
+	return
+}
@@ -0,0 +1,264 @@
+// Package backup contains node data backup run as ethereum node sidecar
+// node data directory is periodically archived and uploaded to s3 compatible object storage, last backup result is served over http
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Command is the operator binary command running backup sidecar
+const Command = "backup"
+
+// stateName is the object holding backups state, relative to backups prefix
+const stateName = "backup-state.json"
+
+// object storage credentials environment variables
+const (
+	// EnvAccessKeyID is the environment variable holding object storage access key id
+	EnvAccessKeyID = "AWS_ACCESS_KEY_ID"
+	// EnvSecretAccessKey is the environment variable holding object storage secret access key
+	EnvSecretAccessKey = "AWS_SECRET_ACCESS_KEY"
+)
+
+// Options is backup options
+type Options struct {
+	// DataDir is node data directory
+	DataDir string
+	// Endpoint is s3 compatible object storage endpoint
+	Endpoint string
+	// Region is object storage region
+	Region string
+	// Bucket is object storage bucket
+	Bucket string
+	// Prefix is backups objects key prefix
+	Prefix string
+	// AccessKeyID is object storage access key id
+	AccessKeyID string
+	// SecretAccessKey is object storage secret access key
+	SecretAccessKey string
+	// Interval is the interval between backups
+	Interval time.Duration
+	// FullEvery is how often all files are archived, every FullEvery backup is a full backup
+	FullEvery int
+	// PauseProcess is node process command name paused while archiving data, node isn't paused if empty
+	PauseProcess string
+	// Listen is backup status server listening address
+	Listen string
+}
+
+// Result is backup result
+type Result struct {
+	// Time is when backup started
+	Time time.Time `json:"time"`
+	// Object is uploaded backup object key
+	Object string `json:"object,omitempty"`
+	// Full is whether all files are archived, or only files changed since previous backup
+	Full bool `json:"full,omitempty"`
+	// Files is the number of archived files
+	Files int `json:"files,omitempty"`
+	// Error is why backup failed
+	Error string `json:"error,omitempty"`
+}
+
+// state is backups state kept in object storage, so sidecar restarts don't cause full backups
+type state struct {
+	// Last is when last successful backup started
+	Last time.Time `json:"last"`
+	// SinceFull is the number of incremental backups since last full backup
+	SinceFull int `json:"sinceFull"`
+}
+
+// Backup is node data backup
+type Backup struct {
+	options Options
+	storage *storage
+
+	mu     sync.Mutex
+	result *Result
+}
+
+// New creates a new node data backup
+func New(options Options) (*Backup, error) {
+	storage, err := newStorage(options.Endpoint, options.Region, options.Bucket, options.AccessKeyID, options.SecretAccessKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Backup{
+		options: options,
+		storage: storage,
+	}, nil
+}
+
+// loadState loads backups state from object storage
+func (b *Backup) loadState() (s state, err error) {
+	content, found, err := b.storage.get(path.Join(b.options.Prefix, stateName))
+	if err != nil || !found {
+		return
+	}
+	err = json.Unmarshal(content, &s)
+	return
+}
+
+// saveState saves backups state to object storage
+func (b *Backup) saveState(s state) error {
+	content, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return b.storage.put(path.Join(b.options.Prefix, stateName), content)
+}
+
+// Run archives and uploads node data, only files changed since last backup are archived unless full backup is due
+func (b *Backup) Run(now time.Time) Result {
+	result := Result{Time: now}
+
+	if err := b.run(&result); err != nil {
+		result.Object = ""
+		result.Error = err.Error()
+	}
+
+	b.mu.Lock()
+	b.result = &result
+	b.mu.Unlock()
+
+	return result
+}
+
+// run archives and uploads node data, recording backup in result
+func (b *Backup) run(result *Result) error {
+	s, err := b.loadState()
+	if err != nil {
+		return err
+	}
+
+	result.Full = s.Last.IsZero() || s.SinceFull+1 >= b.options.FullEvery
+
+	since := s.Last
+	kind := "incremental"
+	if result.Full {
+		since = time.Time{}
+		kind = "full"
+	}
+
+	result.Object = path.Join(b.options.Prefix, fmt.Sprintf("%s-%s.tar.gz", result.Time.UTC().Format("20060102T150405Z"), kind))
+
+	// node is paused, so it doesn't write to its database while it's archived
+	if b.options.PauseProcess != "" {
+		if err := signalProcess(b.options.PauseProcess, syscall.SIGSTOP); err != nil {
+			return err
+		}
+		defer signalProcess(b.options.PauseProcess, syscall.SIGCONT)
+	}
+
+	reader, writer := io.Pipe()
+	archived := make(chan int, 1)
+
+	go func() {
+		files, err := writeArchive(writer, b.options.DataDir, since)
+		writer.CloseWithError(err)
+		archived <- files
+	}()
+
+	err = b.storage.upload(result.Object, reader)
+	// archive writer is unblocked if upload failed
+	reader.CloseWithError(err)
+	result.Files = <-archived
+
+	if err != nil {
+		return err
+	}
+
+	s.Last = result.Time
+	if result.Full {
+		s.SinceFull = 0
+	} else {
+		s.SinceFull++
+	}
+
+	return b.saveState(s)
+}
+
+// ServeHTTP serves last backup result
+func (b *Backup) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	result := b.result
+	b.mu.Unlock()
+
+	if result == nil {
+		http.Error(w, "no backup yet", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// Run parses backup command line arguments and backs up node data periodically
+func Run(args []string) error {
+	options := Options{
+		AccessKeyID:     os.Getenv(EnvAccessKeyID),
+		SecretAccessKey: os.Getenv(EnvSecretAccessKey),
+	}
+
+	flags := flag.NewFlagSet(Command, flag.ContinueOnError)
+	flags.StringVar(&options.DataDir, "data-dir", "/mnt/data", "Node data directory.")
+	flags.StringVar(&options.Endpoint, "endpoint", "", "S3 compatible object storage endpoint.")
+	flags.StringVar(&options.Region, "region", "us-east-1", "Object storage region.")
+	flags.StringVar(&options.Bucket, "bucket", "", "Object storage bucket.")
+	flags.StringVar(&options.Prefix, "prefix", "", "Backups objects key prefix.")
+	flags.DurationVar(&options.Interval, "interval", 24*time.Hour, "Interval between backups.")
+	flags.IntVar(&options.FullEvery, "full-every", 7, "Every n-th backup archives all files.")
+	flags.StringVar(&options.PauseProcess, "pause-process", "", "Node process name paused while archiving data.")
+	flags.StringVar(&options.Listen, "listen", ":8556", "The address backup status server listens on.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if options.Endpoint == "" || options.Bucket == "" {
+		return errors.New("endpoint and bucket are required")
+	}
+
+	if options.AccessKeyID == "" || options.SecretAccessKey == "" {
+		return fmt.Errorf("%s and %s environment variables are required", EnvAccessKeyID, EnvSecretAccessKey)
+	}
+
+	backup, err := New(options)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		log.Fatal(http.ListenAndServe(options.Listen, backup))
+	}()
+
+	// first backup is due one interval after the last successful backup
+	var wait time.Duration
+	if s, err := backup.loadState(); err == nil && !s.Last.IsZero() {
+		wait = time.Until(s.Last.Add(options.Interval))
+	}
+
+	for {
+		time.Sleep(wait)
+
+		result := backup.Run(time.Now())
+		if result.Error != "" {
+			log.Printf("backup failed: %s", result.Error)
+		} else {
+			log.Printf("backup %s uploaded, %d files archived", result.Object, result.Files)
+		}
+
+		wait = options.Interval
+	}
+}
@@ -0,0 +1,168 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeStorage is in memory s3 compatible object storage supporting multipart uploads
+type fakeStorage struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	parts   map[string][]byte
+}
+
+func (f *fakeStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	body, _ := ioutil.ReadAll(r.Body)
+	key := r.URL.Path
+	query := r.URL.Query()
+
+	switch {
+	case r.Method == http.MethodPost && query.Get("uploads") == "" && strings.Contains(r.URL.RawQuery, "uploads"):
+		w.Write([]byte("<InitiateMultipartUploadResult><UploadId>upload-1</UploadId></InitiateMultipartUploadResult>"))
+	case r.Method == http.MethodPut && query.Get("partNumber") != "":
+		f.parts[key] = append(f.parts[key], body...)
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodPost && query.Get("uploadId") != "":
+		f.objects[key] = f.parts[key]
+		delete(f.parts, key)
+	case r.Method == http.MethodPut:
+		f.objects[key] = body
+	case r.Method == http.MethodGet:
+		content, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(content)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// archiveEntries returns gzipped tar archive entries content by name
+func archiveEntries(t *testing.T, archive []byte) map[string]string {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("Expecting gzipped archive got %s", err)
+	}
+
+	entries := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			break
+		}
+		content, _ := ioutil.ReadAll(tr)
+		entries[header.Name] = string(content)
+	}
+
+	return entries
+}
+
+// newTestDataDir creates data directory with old and new chain data files
+func newTestDataDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	os.MkdirAll(filepath.Join(dir, "chaindata"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "chaindata", "000001.ldb"), []byte("old"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "chaindata", "000002.ldb"), []byte("new"), 0644)
+
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(filepath.Join(dir, "chaindata", "000001.ldb"), old, old)
+
+	return dir
+}
+
+func TestWriteArchive(t *testing.T) {
+	dir := newTestDataDir(t)
+	defer os.RemoveAll(dir)
+
+	var archive bytes.Buffer
+	archived, err := writeArchive(&archive, dir, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	entries := archiveEntries(t, archive.Bytes())
+
+	if archived != 1 || entries["chaindata/000002.ldb"] != "new" {
+		t.Errorf("Expecting only files modified since last backup to be archived got %v", entries)
+	}
+	if _, ok := entries["chaindata/000001.ldb"]; ok {
+		t.Errorf("Expecting unmodified files not to be archived")
+	}
+	if manifest := entries[ManifestName]; !strings.Contains(manifest, "chaindata/000001.ldb") {
+		t.Errorf("Expecting manifest to list all files got %s", manifest)
+	}
+}
+
+func TestBackupRun(t *testing.T) {
+	dir := newTestDataDir(t)
+	defer os.RemoveAll(dir)
+
+	fake := &fakeStorage{objects: map[string][]byte{}, parts: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	backup, err := New(Options{
+		DataDir:         dir,
+		Endpoint:        server.URL,
+		Region:          "us-east-1",
+		Bucket:          "backups",
+		Prefix:          "default/goerli/node-1",
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+		FullEvery:       7,
+	})
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	first := backup.Run(time.Now())
+	if first.Error != "" || !first.Full || first.Files != 2 {
+		t.Fatalf("Expecting first backup to be full got %+v", first)
+	}
+
+	second := backup.Run(time.Now().Add(time.Second))
+	if second.Error != "" || second.Full || second.Files != 0 {
+		t.Fatalf("Expecting second backup to be incremental got %+v", second)
+	}
+
+	object := fake.objects["/backups/"+first.Object]
+	if entries := archiveEntries(t, object); entries["chaindata/000001.ldb"] != "old" {
+		t.Errorf("Expecting full backup to be uploaded got %v", entries)
+	}
+
+	if state := string(fake.objects["/backups/default/goerli/node-1/"+stateName]); !strings.Contains(state, `"sinceFull":1`) {
+		t.Errorf("Expecting backups state to be saved got %s", state)
+	}
+
+	rec := httptest.NewRecorder()
+	backup.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !strings.Contains(rec.Body.String(), second.Object) {
+		t.Errorf("Expecting last backup result to be served got %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,43 @@
+package backup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// signalProcess sends signal to processes with the given command name
+// node processes are visible to the sidecar if pod shares its process namespace
+func signalProcess(name string, signal syscall.Signal) error {
+	entries, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return err
+	}
+
+	found := false
+
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		comm, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+		if err != nil || strings.TrimSpace(string(comm)) != name {
+			continue
+		}
+
+		if err := syscall.Kill(pid, signal); err != nil {
+			return err
+		}
+		found = true
+	}
+
+	if !found {
+		return fmt.Errorf("no %s process found", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// partSize is the size of multipart upload parts, s3 allows up to 10000 parts per object
+const partSize = 64 * 1024 * 1024
+
+// storage is s3 compatible object storage client, requests are signed using aws signature version 4
+// google cloud storage is accessed through its s3 compatible xml api using hmac keys
+type storage struct {
+	endpoint        *url.URL
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	client          *http.Client
+}
+
+// initiateMultipartUploadResult is s3 initiate multipart upload response
+type initiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+// completedPart is s3 multipart upload uploaded part
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// completeMultipartUpload is s3 complete multipart upload request
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+// newStorage creates a new object storage client
+func newStorage(endpoint, region, bucket, accessKeyID, secretAccessKey string) (*storage, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint url %s", endpoint)
+	}
+
+	return &storage{
+		endpoint:        u,
+		region:          region,
+		bucket:          bucket,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		client:          &http.Client{Timeout: 10 * time.Minute},
+	}, nil
+}
+
+// escape escapes query parameter as required by aws signature version 4
+func escape(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
+
+// canonicalQuery returns query parameters sorted by name
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	params := make([]string, 0, len(keys))
+	for _, key := range keys {
+		params = append(params, fmt.Sprintf("%s=%s", escape(key), escape(query.Get(key))))
+	}
+
+	return strings.Join(params, "&")
+}
+
+// hmacSHA256 returns hmac of data using key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// sign signs request using aws signature version 4
+func (s *storage) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := fmt.Sprintf("host:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n", req.URL.Host, payloadHash, amzDate)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, s.region)
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(hash[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", s.accessKeyID, scope, signedHeaders, signature))
+}
+
+// do sends signed request for object key, objects are addressed using path style urls
+// response body is returned, error is returned if response status isn't 2xx
+func (s *storage) do(method, key string, query url.Values, body []byte) (*http.Response, []byte, error) {
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hash := sha256.Sum256(body)
+	s.sign(req, hex.EncodeToString(hash[:]), time.Now())
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res, resBody, fmt.Errorf("%s %s failed with status %d: %s", method, key, res.StatusCode, resBody)
+	}
+
+	return res, resBody, nil
+}
+
+// get returns object content, found is false if object doesn't exist
+func (s *storage) get(key string) (content []byte, found bool, err error) {
+	res, body, err := s.do(http.MethodGet, key, nil, nil)
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return body, true, nil
+}
+
+// put uploads small object in a single request
+func (s *storage) put(key string, content []byte) error {
+	_, _, err := s.do(http.MethodPut, key, nil, content)
+	return err
+}
+
+// upload streams reader content to object using multipart upload, upload is aborted on failure
+func (s *storage) upload(key string, r io.Reader) (err error) {
+	_, body, err := s.do(http.MethodPost, key, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return err
+	}
+
+	initiated := initiateMultipartUploadResult{}
+	if err = xml.Unmarshal(body, &initiated); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			s.do(http.MethodDelete, key, url.Values{"uploadId": {initiated.UploadID}}, nil)
+		}
+	}()
+
+	completed := completeMultipartUpload{}
+	buffer := make([]byte, partSize)
+
+	for number := 1; ; number++ {
+		n, readErr := io.ReadFull(r, buffer)
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			return readErr
+		}
+
+		// at least one part is uploaded, even if it's empty
+		if n == 0 && number > 1 {
+			break
+		}
+
+		query := url.Values{
+			"partNumber": {fmt.Sprintf("%d", number)},
+			"uploadId":   {initiated.UploadID},
+		}
+
+		res, _, err := s.do(http.MethodPut, key, query, buffer[:n])
+		if err != nil {
+			return err
+		}
+
+		completed.Parts = append(completed.Parts, completedPart{PartNumber: number, ETag: res.Header.Get("ETag")})
+
+		if readErr != nil {
+			break
+		}
+	}
+
+	content, err := xml.Marshal(completed)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = s.do(http.MethodPost, key, url.Values{"uploadId": {initiated.UploadID}}, content)
+	return err
+}
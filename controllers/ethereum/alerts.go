@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"fmt"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// PrometheusRuleGVK is prometheus operator PrometheusRule group version kind
+var PrometheusRuleGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PrometheusRule",
+}
+
+// alert is a prometheus alerting rule
+type alert struct {
+	name    string
+	client  ethereumv1beta1.EthereumClient
+	expr    string
+	period  string
+	summary string
+}
+
+// toRule converts alert into prometheus rule
+func (a alert) toRule(network *ethereumv1beta1.Network) map[string]interface{} {
+	labels := map[string]interface{}{
+		"severity": "warning",
+		"network":  network.Name,
+	}
+
+	if a.client != "" {
+		labels["client"] = string(a.client)
+	}
+
+	return map[string]interface{}{
+		"alert":  a.name,
+		"expr":   a.expr,
+		"for":    a.period,
+		"labels": labels,
+		"annotations": map[string]interface{}{
+			"summary": a.summary,
+		},
+	}
+}
+
+// generateAlertRules generates prometheus alerting rules for network nodes
+// node down, block height stalled, low peer count and disk nearly full alerts
+func generateAlertRules(network *ethereumv1beta1.Network) []interface{} {
+	// select metrics of network node pods only
+	selector := fmt.Sprintf(`namespace="%s",pod=~"%s-.*"`, network.Namespace, network.Name)
+	pvcSelector := fmt.Sprintf(`namespace="%s",persistentvolumeclaim=~"%s-.*"`, network.Namespace, network.Name)
+
+	alerts := []alert{
+		{
+			name:    "NodeDown",
+			expr:    fmt.Sprintf("up{%s} == 0", selector),
+			period:  "5m",
+			summary: "node {{ $labels.pod }} is down",
+		},
+		{
+			name:    "DiskNearlyFull",
+			expr:    fmt.Sprintf("kubelet_volume_stats_available_bytes{%s} / kubelet_volume_stats_capacity_bytes{%s} < 0.1", pvcSelector, pvcSelector),
+			period:  "5m",
+			summary: "node data volume {{ $labels.persistentvolumeclaim }} is more than 90% full",
+		},
+	}
+
+	clients := map[ethereumv1beta1.EthereumClient]bool{}
+	for _, node := range network.Spec.Nodes {
+		clients[node.Client] = true
+	}
+
+	// client specific alerts
+	for _, client := range []ethereumv1beta1.EthereumClient{ethereumv1beta1.BesuClient, ethereumv1beta1.GethClient} {
+		if !clients[client] {
+			continue
+		}
+
+		var height, peers string
+
+		switch client {
+		case ethereumv1beta1.BesuClient:
+			height, peers = "ethereum_blockchain_height", "ethereum_peer_count"
+		case ethereumv1beta1.GethClient:
+			height, peers = "chain_head_block", "p2p_peers"
+		}
+
+		alerts = append(alerts,
+			alert{
+				name:    "BlockHeightStalled",
+				client:  client,
+				expr:    fmt.Sprintf("increase(%s{%s}[10m]) == 0", height, selector),
+				period:  "5m",
+				summary: "node {{ $labels.pod }} block height didn't increase in the last 10 minutes",
+			},
+			alert{
+				name:    "LowPeerCount",
+				client:  client,
+				expr:    fmt.Sprintf("%s{%s} < 1", peers, selector),
+				period:  "10m",
+				summary: "node {{ $labels.pod }} has no peers",
+			},
+		)
+	}
+
+	rules := []interface{}{}
+	for _, a := range alerts {
+		rules = append(rules, a.toRule(network))
+	}
+
+	return rules
+}
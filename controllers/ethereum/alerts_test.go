@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"testing"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGenerateAlertRules(t *testing.T) {
+	network := &ethereumv1beta1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-network",
+			Namespace: "default",
+		},
+		Spec: ethereumv1beta1.NetworkSpec{
+			Nodes: []ethereumv1beta1.Node{
+				{
+					Name:   "node-1",
+					Client: ethereumv1beta1.BesuClient,
+				},
+				{
+					Name:   "node-2",
+					Client: ethereumv1beta1.BesuClient,
+				},
+			},
+		},
+	}
+
+	rules := generateAlertRules(network)
+
+	// node down, disk nearly full, besu block height stalled and besu low peer count
+	if len(rules) != 4 {
+		t.Errorf("Expecting 4 alerting rules got %d", len(rules))
+	}
+
+	// rules must be valid unstructured content
+	rule := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	if err := unstructured.SetNestedSlice(rule.Object, rules, "spec", "groups"); err != nil {
+		t.Errorf("Expecting alerting rules to be valid unstructured content got %s", err)
+	}
+}
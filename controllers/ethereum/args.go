@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// joinValues returns comma separated values with surrounding spaces, empty and duplicate values removed
+// values order is kept as given in the spec, so the same spec always results in the same argument
+func joinValues(values []string) string {
+	seen := map[string]bool{}
+	canonical := []string{}
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		canonical = append(canonical, value)
+	}
+
+	return strings.Join(canonical, ",")
+}
+
+// joinAPIs returns comma separated apis, see joinValues
+func joinAPIs(apis []ethereumv1beta1.API) string {
+	values := []string{}
+	for _, api := range apis {
+		values = append(values, string(api))
+	}
+	return joinValues(values)
+}
+
+// withDNSName returns whether any of the enode urls uses dns name instead of ip
+func withDNSName(enodeURLs []string) bool {
+	for _, enodeURL := range enodeURLs {
+		u, err := url.Parse(enodeURL)
+		if err != nil {
+			continue
+		}
+		if net.ParseIP(u.Hostname()) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeBootnodes returns in-cluster bootnodes enode urls followed by network external bootnodes
+// duplicate enode urls are removed
+func nodeBootnodes(network *ethereumv1beta1.Network, bootnodes []string) []string {
+	seen := map[string]bool{}
+	enodeURLs := []string{}
+
+	for _, enodeURL := range bootnodes {
+		if !seen[enodeURL] {
+			seen[enodeURL] = true
+			enodeURLs = append(enodeURLs, enodeURL)
+		}
+	}
+
+	for _, enode := range network.Spec.Bootnodes {
+		enodeURL := string(enode)
+		if !seen[enodeURL] {
+			seen[enodeURL] = true
+			enodeURLs = append(enodeURLs, enodeURL)
+		}
+	}
+
+	return enodeURLs
+}
@@ -0,0 +1,575 @@
+package controllers
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestJoinValues(t *testing.T) {
+	expected := "eth,web3,net"
+	got := joinValues([]string{"eth", " web3", "", "eth", "net", "web3 "})
+
+	if got != expected {
+		t.Errorf("Expecting joined values to be %s got %s", expected, got)
+	}
+}
+
+func argsTestNetwork(client ethereumv1beta1.EthereumClient) *ethereumv1beta1.Network {
+	network := &ethereumv1beta1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-network",
+			Namespace: "default",
+		},
+		Spec: ethereumv1beta1.NetworkSpec{
+			Join: ethereumv1beta1.RinkebyNetwork,
+			Nodes: []ethereumv1beta1.Node{
+				{
+					Name:    "node-1",
+					Client:  client,
+					Nodekey: "0x608e9b6f67c65e47531e08e8e501386dfae63a540fa3c48802c8aad854510b4e",
+					RPC:     true,
+					RPCAPI: []ethereumv1beta1.API{
+						ethereumv1beta1.ETHAPI,
+						ethereumv1beta1.Web3API,
+					},
+					Hosts:       []string{"whitelisted.example.com"},
+					CORSDomains: []string{"cors.example.com"},
+					Metrics:     true,
+				},
+			},
+		},
+	}
+	network.Default()
+	return network
+}
+
+func TestGetArgsIsDeterministic(t *testing.T) {
+	bootnodes := []string{"enode://a@10.0.0.1:30303", "enode://b@10.0.0.2:30303"}
+
+	for _, name := range []ethereumv1beta1.EthereumClient{ethereumv1beta1.GethClient, ethereumv1beta1.BesuClient} {
+		network := argsTestNetwork(name)
+		node := &network.Spec.Nodes[0]
+		client, err := NewEthereumClient(name)
+		if err != nil {
+			t.Fatalf("Expecting no error got %s", err)
+		}
+
+		first := client.GetArgs(node, network, bootnodes)
+		for i := 0; i < 10; i++ {
+			if got := client.GetArgs(node, network, bootnodes); !reflect.DeepEqual(got, first) {
+				t.Fatalf("Expecting %s args to be %v got %v", name, first, got)
+			}
+		}
+	}
+}
+
+func TestSpecNodeDeploymentIsIdempotent(t *testing.T) {
+	r := &NetworkReconciler{}
+
+	for _, name := range []ethereumv1beta1.EthereumClient{ethereumv1beta1.GethClient, ethereumv1beta1.BesuClient} {
+		network := argsTestNetwork(name)
+		node := &network.Spec.Nodes[0]
+		client, err := NewEthereumClient(name)
+		if err != nil {
+			t.Fatalf("Expecting no error got %s", err)
+		}
+
+		spec := func(dep *appsv1.Deployment) {
+			args := client.GetArgs(node, network, nil)
+			volumes := createNodeVolumes(node, network)
+			mounts := createNodeVolumeMounts(node, network)
+			affinity := r.getNodeAffinity(network)
+			r.specNodeDeployment(dep, node, network, args, volumes, mounts, affinity)
+		}
+
+		dep := &appsv1.Deployment{}
+		spec(dep)
+		// deployment as read back from the api server
+		existing := dep.DeepCopy()
+		spec(dep)
+
+		if !equality.Semantic.DeepEqual(existing, dep) {
+			t.Errorf("Expecting %s node deployment spec to be unchanged", name)
+		}
+	}
+}
+
+func TestWithDNSName(t *testing.T) {
+	cases := []struct {
+		enodeURLs []string
+		expected  bool
+	}{
+		{[]string{"enode://a@10.0.0.1:30303"}, false},
+		{[]string{"enode://a@[fd00::1]:30303"}, false},
+		{[]string{"enode://a@10.0.0.1:30303", "enode://b@bootnode.example.com:30303"}, true},
+	}
+
+	for _, c := range cases {
+		if got := withDNSName(c.enodeURLs); got != c.expected {
+			t.Errorf("Expecting %v to use dns name to be %v got %v", c.enodeURLs, c.expected, got)
+		}
+	}
+}
+
+func TestNodeBootnodes(t *testing.T) {
+	external := "enode://" + strings.Repeat("b", 128) + "@bootnode.example.com:30303"
+	inCluster := []string{"enode://a@10.0.0.1:30303"}
+
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	network.Spec.Bootnodes = []ethereumv1beta1.Enode{
+		ethereumv1beta1.Enode(external),
+		ethereumv1beta1.Enode(inCluster[0]),
+	}
+
+	expected := []string{inCluster[0], external}
+	if got := nodeBootnodes(network, inCluster); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expecting bootnodes to be %v got %v", expected, got)
+	}
+
+	if len(inCluster) != 1 {
+		t.Errorf("Expecting in-cluster bootnodes to be unchanged got %v", inCluster)
+	}
+}
+
+func TestSpecNodeDeploymentEnv(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	network.Spec.Ethstats = &ethereumv1beta1.Ethstats{}
+	node := &network.Spec.Nodes[0]
+	node.Env = []corev1.EnvVar{{Name: "GODEBUG", Value: "madvdontneed=1"}}
+
+	dep := &appsv1.Deployment{}
+	r.specNodeDeployment(dep, node, network, nil, nil, nil, nil)
+	container := dep.Spec.Template.Spec.Containers[0]
+
+	if got := container.Env[len(container.Env)-1]; got != node.Env[0] {
+		t.Errorf("Expecting env %v to be appended got %v", node.Env[0], got)
+	}
+}
+
+func TestSpecDedicatedBootnodeDeployment(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.BesuClient)
+	node := &network.Spec.Nodes[0]
+	node.Role = ethereumv1beta1.BootnodeRole
+	node.RPC = false
+	node.Metrics = false
+
+	volumes := createNodeVolumes(node, network)
+	mounts := createNodeVolumeMounts(node, network)
+
+	dep := &appsv1.Deployment{}
+	r.specNodeDeployment(dep, node, network, nil, volumes, mounts, nil)
+	container := dep.Spec.Template.Spec.Containers[0]
+
+	if container.Image != BootnodeImage("") {
+		t.Errorf("Expecting bootnode image to be %s got %s", BootnodeImage(""), container.Image)
+	}
+	if !reflect.DeepEqual(container.Command, []string{"bootnode"}) {
+		t.Errorf("Expecting bootnode command got %v", container.Command)
+	}
+	if len(volumes) != 1 || volumes[0].Name != "secrets" {
+		t.Errorf("Expecting bootnode to mount its secrets only got %v", volumes)
+	}
+}
+
+func TestSpecNodeDeploymentSidecars(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	node.Sidecars = []corev1.Container{{Name: "exporter", Image: "exporter:latest"}}
+	node.SidecarsMountData = true
+
+	dep := &appsv1.Deployment{}
+	r.specNodeDeployment(dep, node, network, nil, nil, nil, nil)
+	containers := dep.Spec.Template.Spec.Containers
+
+	if len(containers) != 2 || containers[1].Name != "exporter" {
+		t.Fatalf("Expecting exporter sidecar to be appended got %v", containers)
+	}
+
+	expected := []corev1.VolumeMount{{Name: "data", MountPath: PathBlockchainData, ReadOnly: true}}
+	if !reflect.DeepEqual(containers[1].VolumeMounts, expected) {
+		t.Errorf("Expecting sidecar volume mounts to be %v got %v", expected, containers[1].VolumeMounts)
+	}
+
+	if len(node.Sidecars[0].VolumeMounts) != 0 {
+		t.Errorf("Expecting node sidecars spec to be unchanged got %v", node.Sidecars[0].VolumeMounts)
+	}
+}
+
+func TestSpecNodeDeploymentMetricsExporter(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	node.Metrics = false
+	node.MetricsExporter = &ethereumv1beta1.MetricsExporter{}
+	network.Default()
+
+	dep := &appsv1.Deployment{}
+	r.specNodeDeployment(dep, node, network, nil, nil, nil, nil)
+	containers := dep.Spec.Template.Spec.Containers
+
+	if len(containers) != 2 || containers[1].Name != "metrics-exporter" {
+		t.Fatalf("Expecting metrics exporter sidecar to be appended got %v", containers)
+	}
+
+	args := strings.Join(containers[1].Args, " ")
+	if expected := MetricsExporterExecutionURL + " http://127.0.0.1:8545"; !strings.Contains(args, expected) {
+		t.Errorf("Expecting metrics exporter args to contain %s got %s", expected, args)
+	}
+
+	annotations := dep.Spec.Template.Annotations
+	if annotations["prometheus.io/port"] != "9090" || annotations["prometheus.io/path"] != MetricsExporterPath {
+		t.Errorf("Expecting prometheus to scrape metrics exporter got %v", annotations)
+	}
+}
+
+func TestSpecNodeDeploymentRoleLabels(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+
+	dep := &appsv1.Deployment{}
+	r.specNodeDeployment(dep, node, network, nil, nil, nil, nil)
+
+	labels := dep.Spec.Template.Labels
+	if labels["client"] != "geth" || labels["rpc"] != "true" {
+		t.Errorf("Expecting pod labels to include client and roles got %v", labels)
+	}
+	if _, exists := dep.Spec.Selector.MatchLabels["rpc"]; exists {
+		t.Errorf("Expecting roles not to be selected, deployment selector is immutable")
+	}
+}
+
+func TestSpecNodeDeploymentArchitecture(t *testing.T) {
+	os.Setenv(EnvGethImage+"_ARM64", "kotalco/geth:arm64")
+	defer os.Unsetenv(EnvGethImage + "_ARM64")
+
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	node.Architecture = ethereumv1beta1.ARM64Architecture
+
+	dep := &appsv1.Deployment{}
+	r.specNodeDeployment(dep, node, network, nil, nil, nil, nil)
+	pod := dep.Spec.Template.Spec
+
+	if image := pod.Containers[0].Image; image != "kotalco/geth:arm64" {
+		t.Errorf("Expecting arm64 geth image got %s", image)
+	}
+
+	if pod.Affinity == nil || pod.Affinity.NodeAffinity == nil {
+		t.Fatalf("Expecting node pod to require arm64 kubernetes nodes")
+	}
+	requirement := pod.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0]
+	if requirement.Key != "kubernetes.io/arch" || requirement.Values[0] != "arm64" {
+		t.Errorf("Expecting kubernetes.io/arch in arm64 requirement got %v", requirement)
+	}
+}
+
+func TestSpecNodeDeploymentHostPath(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	node.Storage = ethereumv1beta1.HostPathStorage
+	node.Local = &ethereumv1beta1.LocalStorage{Path: "/mnt/nvme0", NodeName: "worker-1"}
+
+	volumes := createNodeVolumes(node, network)
+	data := volumes[len(volumes)-1]
+	if data.HostPath == nil || data.HostPath.Path != "/mnt/nvme0" {
+		t.Fatalf("Expecting node data to be kept in kubernetes node directory got %v", data.VolumeSource)
+	}
+
+	dep := &appsv1.Deployment{}
+	r.specNodeDeployment(dep, node, network, nil, volumes, nil, nil)
+	pod := dep.Spec.Template.Spec
+
+	if pod.Affinity == nil || pod.Affinity.NodeAffinity == nil {
+		t.Fatalf("Expecting node pod to be pinned to kubernetes node")
+	}
+	requirement := pod.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0]
+	if requirement.Key != "kubernetes.io/hostname" || requirement.Values[0] != "worker-1" {
+		t.Errorf("Expecting kubernetes.io/hostname in worker-1 requirement got %v", requirement)
+	}
+	if dep.Spec.Strategy.Type != appsv1.RecreateDeploymentStrategyType {
+		t.Errorf("Expecting node pod to be recreated got %s", dep.Spec.Strategy.Type)
+	}
+}
+
+func TestSpecNodeDeploymentInitScripts(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	network.Spec.Genesis = &ethereumv1beta1.Genesis{}
+	node := &network.Spec.Nodes[0]
+	node.InitScripts = []ethereumv1beta1.InitScript{
+		{Name: "fix-permissions", ConfigMapName: "scripts", Key: "fix.sh"},
+		{Name: "download", ConfigMapName: "scripts", Key: "download.sh", Image: "curlimages/curl"},
+	}
+
+	volumes := createNodeVolumes(node, network)
+	mounts := createNodeVolumeMounts(node, network)
+
+	dep := &appsv1.Deployment{}
+	r.specNodeDeployment(dep, node, network, nil, volumes, mounts, nil)
+	initContainers := dep.Spec.Template.Spec.InitContainers
+
+	names := []string{}
+	for _, container := range initContainers {
+		names = append(names, container.Name)
+	}
+	if expected := []string{"fix-permissions", "download", "init-genesis"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Expecting init containers %v got %v", expected, names)
+	}
+
+	if initContainers[0].Image != GethImage("") || initContainers[1].Image != "curlimages/curl" {
+		t.Errorf("Expecting init scripts images to be %s and curlimages/curl got %s and %s", GethImage(""), initContainers[0].Image, initContainers[1].Image)
+	}
+
+	if expected := []string{"/mnt/init-scripts/fix-permissions/fix.sh"}; !reflect.DeepEqual(initContainers[0].Args, expected) {
+		t.Errorf("Expecting init script args %v got %v", expected, initContainers[0].Args)
+	}
+
+	if got := len(initContainers[0].VolumeMounts); got != len(mounts)+1 {
+		t.Errorf("Expecting init script to mount node volumes and script volume got %d mounts", got)
+	}
+
+	found := false
+	for _, volume := range volumes {
+		if volume.Name == "init-script-download" && volume.ConfigMap.Name == "scripts" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expecting init script volume to be created got %v", volumes)
+	}
+}
+
+func TestGethLightServeArgs(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	node.LightServe = 50
+
+	args := (&GethClient{}).GetArgs(node, network, nil)
+
+	for i, arg := range args {
+		if arg == GethLightServe {
+			if args[i+1] != "50" {
+				t.Errorf("Expecting light serve to be 50 got %s", args[i+1])
+			}
+			return
+		}
+	}
+	t.Errorf("Expecting %s argument in %v", GethLightServe, args)
+}
+
+func TestGethCache(t *testing.T) {
+	node := &ethereumv1beta1.Node{
+		Resources: &ethereumv1beta1.NodeResources{
+			MemoryLimit: "16Gi",
+		},
+	}
+
+	if got := gethCache(node); got != 4096 {
+		t.Errorf("Expecting default cache to be 4096 got %d", got)
+	}
+
+	node.Cache = 2048
+	if got := gethCache(node); got != 2048 {
+		t.Errorf("Expecting cache to be 2048 got %d", got)
+	}
+}
+
+func TestAdvertisedAddressArgs(t *testing.T) {
+	cases := []struct {
+		client   ethereumv1beta1.EthereumClient
+		expected []string
+	}{
+		{ethereumv1beta1.GethClient, []string{GethNat, "extip:34.1.2.3"}},
+		{ethereumv1beta1.BesuClient, []string{BesuNatMethod, "NONE", BesuP2PHost, "34.1.2.3"}},
+	}
+
+	for _, c := range cases {
+		network := argsTestNetwork(c.client)
+		node := &network.Spec.Nodes[0]
+		node.AdvertisedAddress = "34.1.2.3"
+		client, _ := NewEthereumClient(c.client)
+		args := strings.Join(client.GetArgs(node, network, nil), " ")
+
+		if expected := strings.Join(c.expected, " "); !strings.Contains(args, expected) {
+			t.Errorf("Expecting %s args to contain %s got %s", c.client, expected, args)
+		}
+	}
+}
+
+func TestLoggingArgFromVerbosity(t *testing.T) {
+	cases := []struct {
+		client   ethereumv1beta1.EthereumClient
+		level    ethereumv1beta1.VerbosityLevel
+		expected string
+	}{
+		{ethereumv1beta1.GethClient, ethereumv1beta1.NoLogs, "0"},
+		{ethereumv1beta1.GethClient, ethereumv1beta1.WarnLogs, "2"},
+		{ethereumv1beta1.GethClient, ethereumv1beta1.AllLogs, "5"},
+		{ethereumv1beta1.BesuClient, ethereumv1beta1.NoLogs, "OFF"},
+		{ethereumv1beta1.BesuClient, ethereumv1beta1.DebugLogs, "DEBUG"},
+	}
+
+	for _, c := range cases {
+		client, _ := NewEthereumClient(c.client)
+		if got := client.LoggingArgFromVerbosity(c.level); got != c.expected {
+			t.Errorf("Expecting %s %s logging to be %s got %s", c.client, c.level, c.expected, got)
+		}
+	}
+}
+
+func TestCreateNodeVolumesEphemeralStorage(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	node.Storage = ethereumv1beta1.EphemeralStorage
+	node.StorageMemory = true
+	node.Resources = &ethereumv1beta1.NodeResources{Storage: "2Gi"}
+
+	volumes := createNodeVolumes(node, network)
+	data := volumes[len(volumes)-1]
+
+	if data.Name != "data" || data.EmptyDir == nil || data.PersistentVolumeClaim != nil {
+		t.Fatalf("Expecting data volume to be empty dir got %v", data)
+	}
+	if data.EmptyDir.Medium != corev1.StorageMediumMemory {
+		t.Errorf("Expecting empty dir to be memory backed got %q", data.EmptyDir.Medium)
+	}
+	if size := data.EmptyDir.SizeLimit.String(); size != "2Gi" {
+		t.Errorf("Expecting empty dir size limit to be 2Gi got %s", size)
+	}
+}
+
+func TestBesuPermissioningArgs(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.BesuClient)
+	network.Spec.Genesis = &ethereumv1beta1.Genesis{
+		Permissioning: &ethereumv1beta1.OnchainPermissioning{
+			Nodes:                true,
+			NodesContractAddress: ethereumv1beta1.DefaultNodesIngressContractAddress,
+		},
+	}
+	node := &network.Spec.Nodes[0]
+
+	args := strings.Join((&BesuClient{}).GetArgs(node, network, nil), " ")
+
+	expected := strings.Join([]string{
+		BesuPermissionsNodesContractEnabled,
+		BesuPermissionsNodesContractAddress, string(ethereumv1beta1.DefaultNodesIngressContractAddress),
+	}, " ")
+	if !strings.Contains(args, expected) {
+		t.Errorf("Expecting besu args to contain %s got %s", expected, args)
+	}
+	if strings.Contains(args, BesuPermissionsAccountsContractEnabled) {
+		t.Errorf("Expecting accounts permissioning to be disabled got %s", args)
+	}
+}
+
+func TestBesuPrivacyAPIArgs(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.BesuClient)
+	node := &network.Spec.Nodes[0]
+	// api value admitted by crd enum
+	node.RPCAPI = []ethereumv1beta1.API{"eth", "priv"}
+
+	if node.RPCAPI[1] != ethereumv1beta1.PrivacyAPI {
+		t.Fatalf("Expecting priv api to be privacy api")
+	}
+
+	args := strings.Join((&BesuClient{}).GetArgs(node, network, nil), " ")
+
+	if expected := BesuRPCHTTPAPI + " eth,priv"; !strings.Contains(args, expected) {
+		t.Errorf("Expecting besu args to contain %s got %s", expected, args)
+	}
+}
+
+func TestBesuDataStorageFormatArgs(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.BesuClient)
+	node := &network.Spec.Nodes[0]
+
+	if args := (&BesuClient{}).GetArgs(node, network, nil); strings.Contains(strings.Join(args, " "), BesuDataStorageFormat) {
+		t.Errorf("Expecting besu default data storage format got %s", args)
+	}
+
+	node.Besu = &ethereumv1beta1.BesuOptions{DataStorageFormat: ethereumv1beta1.BonsaiDataStorage}
+
+	args := strings.Join((&BesuClient{}).GetArgs(node, network, nil), " ")
+
+	if expected := BesuDataStorageFormat + " BONSAI"; !strings.Contains(args, expected) {
+		t.Errorf("Expecting besu args to contain %s got %s", expected, args)
+	}
+}
+
+func TestBesuHostAllowlistArgs(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.BesuClient)
+	node := &network.Spec.Nodes[0]
+	node.GraphQL = true
+
+	args := strings.Join((&BesuClient{}).GetArgs(node, network, nil), " ")
+
+	if expected := BesuMetricsHostAllowlist + " localhost,127.0.0.1,$(POD_IP),my-network-node-1,my-network-node-1.default,"; !strings.Contains(args, expected) {
+		t.Errorf("Expecting besu args to contain %s got %s", expected, args)
+	}
+	if expected := "my-network-node-1-internal.default.svc.cluster.local"; !strings.Contains(args, expected) {
+		t.Errorf("Expecting besu args to contain %s got %s", expected, args)
+	}
+
+	node.Besu = &ethereumv1beta1.BesuOptions{
+		MetricsHostAllowlist: []string{"prometheus.monitoring"},
+		GraphQLHostAllowlist: []string{"dapp.example.com"},
+	}
+
+	args = strings.Join((&BesuClient{}).GetArgs(node, network, nil), " ")
+
+	for _, expected := range []string{BesuMetricsHostAllowlist + " prometheus.monitoring", BesuGraphQLHTTPHostAllowlist + " dapp.example.com"} {
+		if !strings.Contains(args, expected) {
+			t.Errorf("Expecting besu args to contain %s got %s", expected, args)
+		}
+	}
+
+	dep := &appsv1.Deployment{}
+	(&NetworkReconciler{}).specNodeDeployment(dep, node, network, nil, nil, nil, nil)
+	if env := dep.Spec.Template.Spec.Containers[0].Env; len(env) == 0 || env[0].Name != EnvPodIP {
+		t.Errorf("Expecting node pod ip to be exposed to besu container got %v", env)
+	}
+}
+
+func TestGethKeystoreArgs(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	node.Coinbase = "0x2b3430337f12Ce89EaBC7b0d865F4253c7744c0d"
+	node.Keystore = &ethereumv1beta1.KeystoreSecret{SecretName: "signer-keystore"}
+	network.Default()
+
+	args := strings.Join((&GethClient{}).GetArgs(node, network, nil), " ")
+
+	expected := strings.Join([]string{
+		GethKeyStore, PathKeystore + "/keystore",
+		GethMinerCoinbase, string(node.Coinbase),
+		GethUnlock, string(node.Coinbase),
+		GethPassword, PathKeystore + "/account.password",
+		GethAllowInsecureUnlock,
+	}, " ")
+	if !strings.Contains(args, expected) {
+		t.Errorf("Expecting geth args to contain %s got %s", expected, args)
+	}
+
+	volume := createNodeVolumes(node, network)[1]
+	if volume.Name != "keystore" || volume.Secret == nil || volume.Secret.SecretName != "signer-keystore" {
+		t.Fatalf("Expecting keystore secret volume got %v", volume)
+	}
+	if key := volume.Secret.Items[0].Key; key != ethereumv1beta1.DefaultKeystoreSecretKey {
+		t.Errorf("Expecting keystore key to be defaulted to %s got %s", ethereumv1beta1.DefaultKeystoreSecretKey, key)
+	}
+}
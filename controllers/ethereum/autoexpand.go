@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// VolumeUsage is persistent volume claim disk usage reported by kubelet
+type VolumeUsage struct {
+	UsedBytes     uint64 `json:"usedBytes"`
+	CapacityBytes uint64 `json:"capacityBytes"`
+}
+
+// VolumeStats gets persistent volume claims disk usage
+type VolumeStats interface {
+	// PVCUsage returns disk usage of persistent volume claims mounted by pods running on kubernetes node
+	// usage is keyed by persistent volume claim namespace/name
+	PVCUsage(nodeName string) (map[string]VolumeUsage, error)
+}
+
+// kubeletStatsSummary is the subset of kubelet stats summary holding pods volumes usage
+type kubeletStatsSummary struct {
+	Pods []struct {
+		Volume []struct {
+			VolumeUsage
+			PVCRef *struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"pvcRef,omitempty"`
+		} `json:"volume,omitempty"`
+	} `json:"pods"`
+}
+
+// kubeletVolumeStats gets persistent volume claims disk usage from kubelet stats summary through api server node proxy
+type kubeletVolumeStats struct {
+	client rest.Interface
+}
+
+// NewKubeletVolumeStats creates volume stats getter reading kubelet stats summary
+func NewKubeletVolumeStats(config *rest.Config) (VolumeStats, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &kubeletVolumeStats{client: clientset.CoreV1().RESTClient()}, nil
+}
+
+// PVCUsage returns disk usage of persistent volume claims mounted by pods running on kubernetes node
+func (k *kubeletVolumeStats) PVCUsage(nodeName string) (map[string]VolumeUsage, error) {
+	content, err := k.client.Get().Resource("nodes").Name(nodeName).SubResource("proxy").Suffix("stats/summary").DoRaw(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return parseKubeletPVCUsage(content)
+}
+
+// parseKubeletPVCUsage parses persistent volume claims disk usage from kubelet stats summary
+func parseKubeletPVCUsage(content []byte) (map[string]VolumeUsage, error) {
+	summary := kubeletStatsSummary{}
+	if err := json.Unmarshal(content, &summary); err != nil {
+		return nil, err
+	}
+
+	usage := map[string]VolumeUsage{}
+	for _, pod := range summary.Pods {
+		for _, volume := range pod.Volume {
+			if volume.PVCRef == nil {
+				continue
+			}
+			usage[fmt.Sprintf("%s/%s", volume.PVCRef.Namespace, volume.PVCRef.Name)] = volume.VolumeUsage
+		}
+	}
+
+	return usage, nil
+}
+
+// expandedStorage returns node data pvc storage after expansion, expand is false if pvc isn't due for expansion
+// pvc is expanded by the policy increment up to the policy max once used space reaches the policy threshold
+func expandedStorage(policy *ethereumv1beta1.AutoExpandPolicy, current resource.Quantity, usage VolumeUsage) (expanded resource.Quantity, expand bool) {
+	if usage.CapacityBytes == 0 || usage.UsedBytes*100 < uint64(policy.Threshold)*usage.CapacityBytes {
+		return current, false
+	}
+
+	expanded = current.DeepCopy()
+	expanded.Add(resource.MustParse(policy.Increment))
+
+	if max := resource.MustParse(policy.Max); expanded.Cmp(max) > 0 {
+		expanded = max
+	}
+
+	return expanded, expanded.Cmp(current) > 0
+}
+
+// expandNodesVolumes expands nodes data pvcs running out of disk space according to nodes auto expand policy
+// kubelet volume stats of node pod kubernetes node are used, failures are logged and retried on next refresh
+func (r *NetworkReconciler) expandNodesVolumes(network *ethereumv1beta1.Network) {
+	// kubelet stats of each kubernetes node are fetched once
+	usageByNode := map[string]map[string]VolumeUsage{}
+
+	for i := range network.Spec.Nodes {
+		node := &network.Spec.Nodes[i]
+		if node.AutoExpand == nil || node.IsDedicatedBootnode() || node.StorageMode() != ethereumv1beta1.PersistentStorage {
+			continue
+		}
+
+		var pods corev1.PodList
+		if err := r.Client.List(context.Background(), &pods, client.InNamespace(network.Namespace), client.MatchingLabels(node.Labels(network.Name))); err != nil {
+			r.Log.Error(err, "unable to list node pods")
+			continue
+		}
+
+		nodeName := ""
+		for _, pod := range pods.Items {
+			if pod.Status.Phase == corev1.PodRunning && pod.Spec.NodeName != "" {
+				nodeName = pod.Spec.NodeName
+				break
+			}
+		}
+		if nodeName == "" {
+			continue
+		}
+
+		if _, fetched := usageByNode[nodeName]; !fetched {
+			usage, err := r.VolumeStats.PVCUsage(nodeName)
+			if err != nil {
+				r.Log.Error(err, "unable to get kubelet volume stats", "node", nodeName)
+			}
+			usageByNode[nodeName] = usage
+		}
+
+		usage, found := usageByNode[nodeName][fmt.Sprintf("%s/%s", network.Namespace, node.PVCName(network.Name))]
+		if !found {
+			continue
+		}
+
+		var pvc corev1.PersistentVolumeClaim
+		key := types.NamespacedName{Name: node.PVCName(network.Name), Namespace: network.Namespace}
+		if err := r.Client.Get(context.Background(), key, &pvc); err != nil {
+			r.Log.Error(err, "unable to get node data pvc")
+			continue
+		}
+
+		// previous expansion is still in progress
+		current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		if capacity := pvc.Status.Capacity[corev1.ResourceStorage]; capacity.Cmp(current) < 0 {
+			continue
+		}
+
+		expanded, expand := expandedStorage(node.AutoExpand, current, usage)
+		if !expand {
+			if usage.UsedBytes*100 >= uint64(node.AutoExpand.Threshold)*usage.CapacityBytes {
+				r.Recorder.Eventf(network, corev1.EventTypeWarning, "VolumeExpansionLimitReached", "node %s data volume is %d%% used and can't be expanded beyond %s", node.Name, usage.UsedBytes*100/usage.CapacityBytes, node.AutoExpand.Max)
+			}
+			continue
+		}
+
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = expanded
+		if err := r.Client.Update(context.Background(), &pvc); err != nil {
+			r.Log.Error(err, "unable to expand node data pvc")
+			continue
+		}
+
+		r.Recorder.Eventf(network, corev1.EventTypeNormal, "VolumeExpanded", "node %s data volume expanded from %s to %s", node.Name, current.String(), expanded.String())
+	}
+}
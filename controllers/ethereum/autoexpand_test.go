@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// fakeVolumeStats returns the same volumes usage for all kubernetes nodes
+type fakeVolumeStats map[string]VolumeUsage
+
+func (f fakeVolumeStats) PVCUsage(nodeName string) (map[string]VolumeUsage, error) {
+	return f, nil
+}
+
+func TestParseKubeletPVCUsage(t *testing.T) {
+	summary := `{"node":{"nodeName":"worker-1"},"pods":[{"podRef":{"name":"goerli-node-1-abc","namespace":"default"},"volume":[
+		{"name":"data","capacityBytes":1000,"usedBytes":850,"pvcRef":{"name":"goerli-node-1","namespace":"default"}},
+		{"name":"config","capacityBytes":10,"usedBytes":1}
+	]}]}`
+
+	usage, err := parseKubeletPVCUsage([]byte(summary))
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if len(usage) != 1 || usage["default/goerli-node-1"].UsedBytes != 850 {
+		t.Errorf("Expecting only pvcs usage to be parsed got %v", usage)
+	}
+}
+
+func TestExpandedStorage(t *testing.T) {
+	policy := &ethereumv1beta1.AutoExpandPolicy{Threshold: 80, Increment: "100Gi", Max: "250Gi"}
+	current := resource.MustParse("100Gi")
+
+	if _, expand := expandedStorage(policy, current, VolumeUsage{UsedBytes: 79, CapacityBytes: 100}); expand {
+		t.Errorf("Expecting pvc below threshold not to be expanded")
+	}
+
+	expanded, expand := expandedStorage(policy, current, VolumeUsage{UsedBytes: 80, CapacityBytes: 100})
+	if !expand || expanded.String() != "200Gi" {
+		t.Errorf("Expecting pvc to be expanded to 200Gi got %s", expanded.String())
+	}
+
+	expanded, _ = expandedStorage(policy, expanded, VolumeUsage{UsedBytes: 90, CapacityBytes: 100})
+	if expanded.String() != "250Gi" {
+		t.Errorf("Expecting pvc expansion to be capped at 250Gi got %s", expanded.String())
+	}
+
+	if _, expand := expandedStorage(policy, expanded, VolumeUsage{UsedBytes: 90, CapacityBytes: 100}); expand {
+		t.Errorf("Expecting pvc at max storage not to be expanded")
+	}
+}
+
+func TestExpandNodesVolumes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = ethereumv1beta1.AddToScheme(scheme)
+
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	network.Namespace = "default"
+	node := &network.Spec.Nodes[0]
+	node.AutoExpand = &ethereumv1beta1.AutoExpandPolicy{Threshold: 80, Increment: "100Gi", Max: "1Ti"}
+
+	storage := resource.MustParse("100Gi")
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: node.PVCName(network.Name), Namespace: "default"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: storage}},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{Capacity: corev1.ResourceList{corev1.ResourceStorage: storage}},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1-pod", Namespace: "default", Labels: node.Labels(network.Name)},
+		Spec:       corev1.PodSpec{NodeName: "worker-1"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	recorder := record.NewFakeRecorder(1)
+	r := &NetworkReconciler{
+		Client:   fake.NewFakeClientWithScheme(scheme, pvc, pod),
+		Log:      ctrl.Log,
+		Recorder: recorder,
+		VolumeStats: fakeVolumeStats{
+			"default/" + node.PVCName(network.Name): {UsedBytes: 90, CapacityBytes: 100},
+		},
+	}
+
+	r.expandNodesVolumes(network)
+
+	expanded := &corev1.PersistentVolumeClaim{}
+	if err := r.Client.Get(context.Background(), types.NamespacedName{Name: pvc.Name, Namespace: "default"}, expanded); err != nil {
+		t.Fatal(err)
+	}
+	if requested := expanded.Spec.Resources.Requests[corev1.ResourceStorage]; requested.String() != "200Gi" {
+		t.Errorf("Expecting node data pvc to be expanded to 200Gi got %s", requested.String())
+	}
+	if event := <-recorder.Events; !strings.Contains(event, "VolumeExpanded") {
+		t.Errorf("Expecting volume expanded event got %s", event)
+	}
+
+	// expansion in progress, pvc capacity is still 100Gi
+	r.expandNodesVolumes(network)
+
+	if err := r.Client.Get(context.Background(), types.NamespacedName{Name: pvc.Name, Namespace: "default"}, expanded); err != nil {
+		t.Fatal(err)
+	}
+	if requested := expanded.Spec.Resources.Requests[corev1.ResourceStorage]; requested.String() != "200Gi" {
+		t.Errorf("Expecting node data pvc not to be expanded while resizing got %s", requested.String())
+	}
+}
@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	"github.com/kotalco/kotal/backup"
+)
+
+// backupPauseProcess returns node client process name paused by backup sidecar
+func backupPauseProcess(client ethereumv1beta1.EthereumClient) string {
+	// besu runs on the jvm
+	if client == ethereumv1beta1.BesuClient {
+		return "java"
+	}
+	return string(client)
+}
+
+// backupPrefix returns node backups objects key prefix
+func backupPrefix(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) string {
+	if node.Backup.Prefix != "" {
+		return node.Backup.Prefix
+	}
+	return path.Join(network.Namespace, network.Name, node.Name)
+}
+
+// nodeBackup returns backup sidecar container uploading node data to object storage
+// sidecar runs as root, so it can read node data and pause node process owned by any user
+func nodeBackup(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) corev1.Container {
+	policy := node.Backup
+
+	args := []string{
+		backup.Command,
+		BackupDataDir, PathBlockchainData,
+		BackupEndpoint, policy.Endpoint,
+		BackupRegion, policy.Region,
+		BackupBucket, policy.Bucket,
+		BackupPrefix, backupPrefix(node, network),
+		BackupInterval, policy.Schedule.Duration.String(),
+		BackupFullEvery, fmt.Sprintf("%d", policy.FullEvery),
+		BackupListen, fmt.Sprintf(":%d", policy.Port),
+	}
+
+	if policy.PauseNode {
+		args = append(args, BackupPauseProcess, backupPauseProcess(node.Client))
+	}
+
+	secretKeyRef := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: policy.CredentialsSecretName},
+				Key:                  key,
+			},
+		}
+	}
+
+	root := int64(0)
+
+	return corev1.Container{
+		Name:    "backup",
+		Image:   BackupImage(node.Architecture),
+		Command: []string{"/manager"},
+		Args:    args,
+		Env: []corev1.EnvVar{
+			{Name: backup.EnvAccessKeyID, ValueFrom: secretKeyRef(ethereumv1beta1.BackupAccessKeyIDKey)},
+			{Name: backup.EnvSecretAccessKey, ValueFrom: secretKeyRef(ethereumv1beta1.BackupSecretAccessKeyKey)},
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "backup",
+				ContainerPort: int32(policy.Port),
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "data",
+				MountPath: PathBlockchainData,
+				ReadOnly:  true,
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser: &root,
+		},
+	}
+}
+
+// getBackupStatus returns last backup result served by node backup sidecar
+func getBackupStatus(url string) (*ethereumv1beta1.BackupStatus, error) {
+	resp, err := rpcClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// no backup has been taken yet
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("backup status returned status code %d", resp.StatusCode)
+	}
+
+	status := &ethereumv1beta1.BackupStatus{}
+	if err := json.NewDecoder(resp.Body).Decode(status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// updateNodesBackupStatus records nodes last backup result in nodes status
+// an event is recorded whenever a new backup fails
+func (r *NetworkReconciler) updateNodesBackupStatus(network *ethereumv1beta1.Network) {
+	log := r.Log.WithName("backup status")
+
+	for i := range network.Status.Nodes {
+		status := &network.Status.Nodes[i]
+		node := network.Spec.Nodes[i]
+
+		if node.Backup == nil {
+			status.Backup = nil
+			continue
+		}
+
+		ip, err := r.getNodePodIP(&node, network)
+		if err != nil {
+			log.Info(fmt.Sprintf("unable to get node (%s) pod ip: %s", node.Name, err))
+			continue
+		}
+
+		backupStatus, err := getBackupStatus(fmt.Sprintf("http://%s:%d", ip, node.Backup.Port))
+		if err != nil {
+			log.Info(fmt.Sprintf("unable to get node (%s) backup status: %s", node.Name, err))
+			continue
+		}
+		if backupStatus == nil {
+			continue
+		}
+
+		if backupStatus.Error != "" && (status.Backup == nil || !status.Backup.Time.Equal(&backupStatus.Time)) {
+			r.Recorder.Eventf(network, corev1.EventTypeWarning, "BackupFailed", "node %s backup failed: %s", node.Name, backupStatus.Error)
+		}
+
+		status.Backup = backupStatus
+	}
+}
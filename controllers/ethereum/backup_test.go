@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestSpecNodeDeploymentBackup(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.BesuClient)
+	network.Name = "goerli"
+	network.Namespace = "chains"
+	node := &network.Spec.Nodes[0]
+	node.Backup = &ethereumv1beta1.BackupPolicy{
+		Schedule:              metav1.Duration{Duration: 24 * time.Hour},
+		Provider:              ethereumv1beta1.GCSBackupProvider,
+		Bucket:                "chain-backups",
+		CredentialsSecretName: "gcs-hmac",
+		PauseNode:             true,
+	}
+	network.Default()
+
+	dep := &appsv1.Deployment{}
+	r.specNodeDeployment(dep, node, network, nil, nil, nil, nil)
+	containers := dep.Spec.Template.Spec.Containers
+
+	if len(containers) != 2 || containers[1].Name != "backup" {
+		t.Fatalf("Expecting backup sidecar to be appended got %v", containers)
+	}
+
+	args := strings.Join(containers[1].Args, " ")
+	expected := strings.Join([]string{
+		BackupEndpoint, ethereumv1beta1.DefaultGCSEndpoint,
+		BackupRegion, ethereumv1beta1.DefaultGCSRegion,
+		BackupBucket, "chain-backups",
+		BackupPrefix, "chains/goerli/node-1",
+		BackupInterval, "24h0m0s",
+	}, " ")
+	if !strings.Contains(args, expected) {
+		t.Errorf("Expecting backup args to contain %s got %s", expected, args)
+	}
+	if !strings.HasSuffix(args, BackupPauseProcess+" java") {
+		t.Errorf("Expecting besu jvm process to be paused got %s", args)
+	}
+
+	if env := containers[1].Env[0]; env.ValueFrom.SecretKeyRef.Name != "gcs-hmac" {
+		t.Errorf("Expecting credentials to be loaded from gcs-hmac secret got %v", env)
+	}
+
+	if share := dep.Spec.Template.Spec.ShareProcessNamespace; share == nil || !*share {
+		t.Errorf("Expecting pod to share process namespace")
+	}
+}
+
+func TestGetBackupStatus(t *testing.T) {
+	result := `{"time":"2020-10-01T00:00:00Z","object":"chains/goerli/node-1/20201001T000000Z-full.tar.gz","full":true,"files":120}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(result))
+	}))
+	defer server.Close()
+
+	status, err := getBackupStatus(server.URL)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	if !status.Full || status.Files != 120 || status.Time.Year() != 2020 {
+		t.Errorf("Expecting backup result to be decoded got %+v", status)
+	}
+
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+
+	if status, err := getBackupStatus(notFound.URL); status != nil || err != nil {
+		t.Errorf("Expecting no backup status before first backup got %v %v", status, err)
+	}
+}
@@ -5,26 +5,32 @@ import (
 	"fmt"
 	"strings"
 
-	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
 )
 
 // BesuClient is Hyperledger Besu client
 type BesuClient struct{}
 
 // LoggingArgFromVerbosity returns logging argument from node verbosity level
-func (b *BesuClient) LoggingArgFromVerbosity(level ethereumv1alpha1.VerbosityLevel) string {
+func (b *BesuClient) LoggingArgFromVerbosity(level ethereumv1beta1.VerbosityLevel) string {
 	return strings.ToUpper(string(level))
 }
 
 // GetArgs returns command line arguments required for client run
-func (b *BesuClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alpha1.Network, bootnodes []string) (args []string) {
+func (b *BesuClient) GetArgs(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network, bootnodes []string) (args []string) {
 
 	// appendArg appends argument with optional value to the arguments array
 	appendArg := func(arg ...string) {
 		args = append(args, arg...)
 	}
 
-	appendArg(BesuNatMethod, "KUBERNETES")
+	// advertised address replaces nat detection
+	if node.AdvertisedAddress != "" {
+		appendArg(BesuNatMethod, "NONE")
+		appendArg(BesuP2PHost, node.AdvertisedAddress)
+	} else {
+		appendArg(BesuNatMethod, "KUBERNETES")
+	}
 
 	appendArg(BesuLogging, b.LoggingArgFromVerbosity(node.Logging))
 
@@ -53,12 +59,25 @@ func (b *BesuClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alp
 	if len(bootnodes) != 0 {
 		commaSeperatedBootnodes := strings.Join(bootnodes, ",")
 		appendArg(BesuBootnodes, commaSeperatedBootnodes)
+		// besu doesn't resolve dns names in enode urls by default
+		if withDNSName(bootnodes) {
+			appendArg(BesuDNSEnabled, "true")
+		}
 	}
 
 	if node.SyncMode != "" {
 		appendArg(BesuSyncMode, string(node.SyncMode))
 	}
 
+	if node.Cache != 0 {
+		appendArg(BesuRocksDBCacheCapacity, fmt.Sprintf("%d", uint64(node.Cache)*1024*1024))
+	}
+
+	// format is immutable, so it always matches the format node database was created with
+	if format := node.DataStorageFormat(); format != "" {
+		appendArg(BesuDataStorageFormat, string(format))
+	}
+
 	if node.Miner {
 		appendArg(BesuMinerEnabled)
 	}
@@ -80,12 +99,7 @@ func (b *BesuClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alp
 	}
 
 	if len(node.RPCAPI) != 0 {
-		apis := []string{}
-		for _, api := range node.RPCAPI {
-			apis = append(apis, string(api))
-		}
-		commaSeperatedAPIs := strings.Join(apis, ",")
-		appendArg(BesuRPCHTTPAPI, commaSeperatedAPIs)
+		appendArg(BesuRPCHTTPAPI, joinAPIs(node.RPCAPI))
 	}
 
 	if node.WS {
@@ -101,12 +115,7 @@ func (b *BesuClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alp
 	}
 
 	if len(node.WSAPI) != 0 {
-		apis := []string{}
-		for _, api := range node.WSAPI {
-			apis = append(apis, string(api))
-		}
-		commaSeperatedAPIs := strings.Join(apis, ",")
-		appendArg(BesuRPCWSAPI, commaSeperatedAPIs)
+		appendArg(BesuRPCWSAPI, joinAPIs(node.WSAPI))
 	}
 
 	if node.GraphQL {
@@ -122,12 +131,12 @@ func (b *BesuClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alp
 	}
 
 	if len(node.Hosts) != 0 {
-		commaSeperatedHosts := strings.Join(node.Hosts, ",")
+		commaSeperatedHosts := joinValues(node.Hosts)
 		appendArg(BesuHostWhitelist, commaSeperatedHosts)
 	}
 
 	if len(node.CORSDomains) != 0 {
-		commaSeperatedDomains := strings.Join(node.CORSDomains, ",")
+		commaSeperatedDomains := joinValues(node.CORSDomains)
 		if node.RPC {
 			appendArg(BesuRPCHTTPCorsOrigins, commaSeperatedDomains)
 		}
@@ -136,11 +145,62 @@ func (b *BesuClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alp
 		}
 	}
 
+	var metricsHosts, graphqlHosts []string
+	if node.Besu != nil {
+		metricsHosts = node.Besu.MetricsHostAllowlist
+		graphqlHosts = node.Besu.GraphQLHostAllowlist
+	}
+
+	if node.GraphQL {
+		appendArg(BesuGraphQLHTTPHostAllowlist, besuHostAllowlist(graphqlHosts, node, network))
+	}
+
+	if node.Metrics {
+		appendArg(BesuMetricsEnabled)
+		appendArg(BesuMetricsHost, node.MetricsHost)
+		appendArg(BesuMetricsPort, fmt.Sprintf("%d", node.MetricsPort))
+		appendArg(BesuMetricsHostAllowlist, besuHostAllowlist(metricsHosts, node, network))
+	}
+
+	// ingress contracts are deployed in genesis block
+	if network.Spec.Genesis != nil && network.Spec.Genesis.Permissioning != nil {
+		permissioning := network.Spec.Genesis.Permissioning
+		if permissioning.Nodes {
+			appendArg(BesuPermissionsNodesContractEnabled)
+			appendArg(BesuPermissionsNodesContractAddress, string(permissioning.NodesContractAddress))
+		}
+		if permissioning.Accounts {
+			appendArg(BesuPermissionsAccountsContractEnabled)
+			appendArg(BesuPermissionsAccountsContractAddress, string(permissioning.AccountsContractAddress))
+		}
+	}
+
 	return args
 }
 
+// besuHostAllowlist returns comma separated hosts allowed to access besu metrics or GraphQL server
+// node pod ip and node services dns names are allowed if no hosts are provided, so prometheus and in-cluster clients aren't rejected
+func besuHostAllowlist(hosts []string, node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) string {
+	if len(hosts) != 0 {
+		return joinValues(hosts)
+	}
+
+	// pod ip is expanded by kubernetes from node container environment, so it's passed on the command line
+	hosts = []string{"localhost", "127.0.0.1", fmt.Sprintf("$(%s)", EnvPodIP)}
+	for _, svc := range []string{node.ServiceName(network.Name), node.InternalServiceName(network.Name)} {
+		hosts = append(hosts,
+			svc,
+			fmt.Sprintf("%s.%s", svc, network.Namespace),
+			fmt.Sprintf("%s.%s.svc", svc, network.Namespace),
+			fmt.Sprintf("%s.%s.svc.cluster.local", svc, network.Namespace),
+		)
+	}
+
+	return joinValues(hosts)
+}
+
 // GetGenesisFile returns genesis config parameter
-func (b *BesuClient) GetGenesisFile(genesis *ethereumv1alpha1.Genesis, consensus ethereumv1alpha1.ConsensusAlgorithm) (content string, err error) {
+func (b *BesuClient) GetGenesisFile(genesis *ethereumv1beta1.Genesis, consensus ethereumv1beta1.ConsensusAlgorithm) (content string, err error) {
 	mixHash := genesis.MixHash
 	nonce := genesis.Nonce
 	difficulty := genesis.Difficulty
@@ -149,7 +209,7 @@ func (b *BesuClient) GetGenesisFile(genesis *ethereumv1alpha1.Genesis, consensus
 	var extraData, engine string
 
 	// ethash PoW settings
-	if consensus == ethereumv1alpha1.ProofOfWork {
+	if consensus == ethereumv1beta1.ProofOfWork {
 		consensusConfig = map[string]uint{}
 
 		if genesis.Ethash.FixedDifficulty != nil {
@@ -160,7 +220,7 @@ func (b *BesuClient) GetGenesisFile(genesis *ethereumv1alpha1.Genesis, consensus
 	}
 
 	// clique PoA settings
-	if consensus == ethereumv1alpha1.ProofOfAuthority {
+	if consensus == ethereumv1beta1.ProofOfAuthority {
 		consensusConfig = map[string]uint{
 			"blockperiodseconds": genesis.Clique.BlockPeriod,
 			"epochlength":        genesis.Clique.EpochLength,
@@ -170,7 +230,7 @@ func (b *BesuClient) GetGenesisFile(genesis *ethereumv1alpha1.Genesis, consensus
 	}
 
 	// clique ibft2 settings
-	if consensus == ethereumv1alpha1.IstanbulBFT {
+	if consensus == ethereumv1beta1.IstanbulBFT {
 
 		consensusConfig = map[string]uint{
 			"blockperiodseconds":        genesis.IBFT2.BlockPeriod,
@@ -219,7 +279,7 @@ func (b *BesuClient) GetGenesisFile(genesis *ethereumv1alpha1.Genesis, consensus
 	result["mixHash"] = mixHash
 	result["extraData"] = extraData
 
-	alloc := map[ethereumv1alpha1.EthereumAddress]interface{}{}
+	alloc := map[ethereumv1beta1.EthereumAddress]interface{}{}
 	for _, account := range genesis.Accounts {
 		m := map[string]interface{}{
 			"balance": account.Balance,
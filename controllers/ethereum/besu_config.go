@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// besuNumericOptions is besu options with numeric values
+var besuNumericOptions = map[string]bool{
+	BesuNetworkID:            true,
+	BesuP2PPort:              true,
+	BesuRPCHTTPPort:          true,
+	BesuRPCWSPort:            true,
+	BesuGraphQLHTTPPort:      true,
+	BesuMetricsPort:          true,
+	BesuRocksDBCacheCapacity: true,
+}
+
+// besuListOptions is besu options with comma separated list values
+var besuListOptions = map[string]bool{
+	BesuBootnodes:                true,
+	BesuRPCHTTPAPI:               true,
+	BesuRPCWSAPI:                 true,
+	BesuRPCHTTPCorsOrigins:       true,
+	BesuGraphQLHTTPCorsOrigins:   true,
+	BesuGraphQLHTTPHostAllowlist: true,
+	BesuHostWhitelist:            true,
+	BesuMetricsHostAllowlist:     true,
+}
+
+// splitBesuArgs splits besu arguments into arguments rendered in toml configuration file and command line arguments
+// options referencing container environment variables are kept on the command line, kubernetes doesn't expand them in files
+func splitBesuArgs(args []string) (configArgs, commandArgs []string) {
+	for i := 0; i < len(args); i++ {
+		option := []string{args[i]}
+		if i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+			i++
+			option = append(option, args[i])
+		}
+
+		if len(option) == 2 && strings.Contains(option[1], "$(") {
+			commandArgs = append(commandArgs, option...)
+			continue
+		}
+		configArgs = append(configArgs, option...)
+	}
+
+	return
+}
+
+// generateBesuConfig renders besu command line arguments as toml configuration file
+// options without values are flags set to true, options order is kept
+func generateBesuConfig(args []string) (string, error) {
+	var config strings.Builder
+
+	for i := 0; i < len(args); i++ {
+		option := args[i]
+		if !strings.HasPrefix(option, "--") {
+			return "", fmt.Errorf("expecting besu option got value %s", option)
+		}
+		key := strings.TrimPrefix(option, "--")
+
+		// flag without value
+		if i+1 == len(args) || strings.HasPrefix(args[i+1], "--") {
+			fmt.Fprintf(&config, "%s=true\n", key)
+			continue
+		}
+
+		i++
+		value := args[i]
+
+		switch {
+		case value == "true" || value == "false":
+			fmt.Fprintf(&config, "%s=%s\n", key, value)
+		case besuNumericOptions[option]:
+			if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+				return "", fmt.Errorf("expecting numeric value for besu option %s got %s", option, value)
+			}
+			fmt.Fprintf(&config, "%s=%s\n", key, value)
+		case besuListOptions[option]:
+			items := []string{}
+			for _, item := range strings.Split(value, ",") {
+				items = append(items, strconv.Quote(item))
+			}
+			fmt.Fprintf(&config, "%s=[%s]\n", key, strings.Join(items, ","))
+		default:
+			fmt.Fprintf(&config, "%s=%s\n", key, strconv.Quote(value))
+		}
+	}
+
+	return config.String(), nil
+}
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestGenerateBesuConfig(t *testing.T) {
+	args := []string{
+		BesuNatMethod, "KUBERNETES",
+		BesuNetworkID, "7777",
+		BesuBootnodes, "enode://a@10.0.0.1:30303,enode://b@10.0.0.2:30303",
+		BesuRPCHTTPEnabled,
+		BesuRPCHTTPAPI, "eth,net",
+		BesuDNSEnabled, "true",
+	}
+
+	expected := `nat-method="KUBERNETES"
+network-id=7777
+bootnodes=["enode://a@10.0.0.1:30303","enode://b@10.0.0.2:30303"]
+rpc-http-enabled=true
+rpc-http-api=["eth","net"]
+Xdns-enabled=true
+`
+
+	got, err := generateBesuConfig(args)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	if got != expected {
+		t.Errorf("Expecting besu config to be:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestGenerateBesuConfigHostAllowlists(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.BesuClient)
+	node := &network.Spec.Nodes[0]
+	node.GraphQL = true
+	node.Besu = &ethereumv1beta1.BesuOptions{
+		MetricsHostAllowlist: []string{"prometheus.monitoring", "10.0.0.1"},
+		GraphQLHostAllowlist: []string{"dapp.example.com"},
+	}
+
+	config, err := generateBesuConfig((&BesuClient{}).GetArgs(node, network, nil))
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	for _, expected := range []string{
+		`metrics-host-allowlist=["prometheus.monitoring","10.0.0.1"]`,
+		`graphql-http-host-allowlist=["dapp.example.com"]`,
+	} {
+		if !strings.Contains(config, expected+"\n") {
+			t.Errorf("Expecting besu config to contain %s got:\n%s", expected, config)
+		}
+	}
+}
+
+func TestSplitBesuArgs(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.BesuClient)
+	node := &network.Spec.Nodes[0]
+
+	configArgs, commandArgs := splitBesuArgs((&BesuClient{}).GetArgs(node, network, nil))
+
+	if len(commandArgs) != 2 || commandArgs[0] != BesuMetricsHostAllowlist || !strings.Contains(commandArgs[1], "$(POD_IP)") {
+		t.Errorf("Expecting metrics host allowlist with pod ip to be kept on the command line got %v", commandArgs)
+	}
+
+	config, err := generateBesuConfig(configArgs)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if strings.Contains(config, "$(") || !strings.Contains(config, "metrics-enabled=true") {
+		t.Errorf("Expecting besu config without environment variables references got:\n%s", config)
+	}
+}
+
+func TestGenerateBesuConfigInvalidArgs(t *testing.T) {
+	cases := [][]string{
+		{"KUBERNETES"},
+		{BesuP2PPort, "port"},
+	}
+
+	for _, args := range cases {
+		if _, err := generateBesuConfig(args); err == nil {
+			t.Errorf("Expecting error for args %v", args)
+		}
+	}
+}
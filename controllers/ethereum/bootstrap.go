@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// network bootstrap config map keys
+const (
+	// BootstrapGenesisKey is the bootstrap key holding genesis.json used by network nodes
+	BootstrapGenesisKey = "genesis.json"
+	// BootstrapStaticNodesKey is the bootstrap key holding static-nodes.json of network nodes enode urls
+	BootstrapStaticNodesKey = "static-nodes.json"
+	// BootstrapBootnodesKey is the bootstrap key holding comma separated bootnodes enode urls
+	BootstrapBootnodesKey = "bootnodes"
+)
+
+// reconcileBootstrap creates or updates config map holding files external nodes need to join private network
+// unlike network export, it's consumed by nodes running outside kubernetes, so genesis is rendered as genesis.json
+func (r *NetworkReconciler) reconcileBootstrap(network *ethereumv1beta1.Network) error {
+	// only networks created, imported or cloned from genesis are bootstrapped
+	if network.Spec.Genesis == nil {
+		return nil
+	}
+
+	genesis, err := r.clientGenesis(bootstrapClient(network), network)
+	if err != nil {
+		return err
+	}
+
+	configmap := &corev1.ConfigMap{}
+	configmap.Name = network.BootstrapName()
+	configmap.Namespace = network.Namespace
+
+	_, err = ctrl.CreateOrUpdate(context.Background(), r.Client, configmap, func() error {
+		if err := ctrl.SetControllerReference(network, configmap, r.Scheme); err != nil {
+			r.Log.Error(err, "Unable to set controller reference on network bootstrap configmap")
+			return err
+		}
+
+		return specBootstrapConfigmap(configmap, network, genesis)
+	})
+
+	return err
+}
+
+// bootstrapClient returns client genesis.json is rendered for, it's the client of the first node using genesis
+func bootstrapClient(network *ethereumv1beta1.Network) ethereumv1beta1.EthereumClient {
+	for i := range network.Spec.Nodes {
+		if node := &network.Spec.Nodes[i]; !node.IsDedicatedBootnode() {
+			return node.Client
+		}
+	}
+	return network.Spec.Nodes[0].Client
+}
+
+// specBootstrapConfigmap updates network bootstrap config map spec
+func specBootstrapConfigmap(configmap *corev1.ConfigMap, network *ethereumv1beta1.Network, genesis string) error {
+	// nodes enode urls are published once nodes services addresses are assigned
+	staticNodes := []string{}
+	for _, status := range network.Status.Nodes {
+		if status.EnodeURL != "" {
+			staticNodes = append(staticNodes, status.EnodeURL)
+		}
+	}
+
+	staticNodesJSON, err := json.Marshal(staticNodes)
+	if err != nil {
+		return err
+	}
+
+	configmap.ObjectMeta.Labels = network.BootstrapLabels()
+	configmap.Data = map[string]string{
+		BootstrapGenesisKey:     genesis,
+		BootstrapStaticNodesKey: string(staticNodesJSON),
+		BootstrapBootnodesKey:   strings.Join(nodeBootnodes(network, network.Status.Bootnodes), ","),
+	}
+
+	return nil
+}
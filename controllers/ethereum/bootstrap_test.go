@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestReconcileBootstrap(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = ethereumv1beta1.AddToScheme(scheme)
+
+	network := &ethereumv1beta1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "consortium",
+			Namespace: "default",
+		},
+		Spec: ethereumv1beta1.NetworkSpec{
+			ID:        7777,
+			Consensus: ethereumv1beta1.ProofOfAuthority,
+			Genesis: &ethereumv1beta1.Genesis{
+				ChainID: 7777,
+				Clique: &ethereumv1beta1.Clique{
+					Signers: []ethereumv1beta1.EthereumAddress{"0xd2c21213027cbf4d46c16b55fa98e5252b048706"},
+				},
+			},
+			Bootnodes: []ethereumv1beta1.Enode{"enode://b@bootnode.example.com:30303"},
+			Nodes: []ethereumv1beta1.Node{
+				{Name: "node-1", Bootnode: true},
+				{Name: "node-2"},
+			},
+		},
+		Status: ethereumv1beta1.NetworkStatus{
+			Bootnodes: []string{"enode://a@10.0.0.1:30303"},
+			Nodes: []ethereumv1beta1.NodeStatus{
+				{Name: "node-1", EnodeURL: "enode://a@10.0.0.1:30303"},
+				{Name: "node-2"},
+			},
+		},
+	}
+	network.Default()
+
+	r := &NetworkReconciler{
+		Client: fake.NewFakeClientWithScheme(scheme, network),
+		Log:    ctrl.Log,
+		Scheme: scheme,
+	}
+
+	if err := r.reconcileBootstrap(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	configmap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: "consortium-bootstrap", Namespace: "default"}
+	if err := r.Client.Get(context.Background(), key, configmap); err != nil {
+		t.Fatalf("Expecting bootstrap config map to be created got %s", err)
+	}
+
+	if genesis := configmap.Data[BootstrapGenesisKey]; !strings.Contains(genesis, `"chainId": 7777`) && !strings.Contains(genesis, `"chainId":7777`) {
+		t.Errorf("Expecting genesis.json with chain id 7777 got %s", genesis)
+	}
+
+	if staticNodes := configmap.Data[BootstrapStaticNodesKey]; staticNodes != `["enode://a@10.0.0.1:30303"]` {
+		t.Errorf("Expecting static nodes with published enode urls only got %s", staticNodes)
+	}
+
+	if bootnodes := configmap.Data[BootstrapBootnodesKey]; bootnodes != "enode://a@10.0.0.1:30303,enode://b@bootnode.example.com:30303" {
+		t.Errorf("Expecting in-cluster and external bootnodes got %s", bootnodes)
+	}
+
+	// public networks aren't bootstrapped
+	network = argsTestNetwork(ethereumv1beta1.GethClient)
+	if err := r.reconcileBootstrap(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	key.Name = network.BootstrapName()
+	if err := r.Client.Get(context.Background(), key, configmap); err == nil {
+		t.Errorf("Expecting public network bootstrap config map not to be created")
+	}
+}
@@ -3,18 +3,18 @@ package controllers
 import (
 	"fmt"
 
-	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
 )
 
 // EthereumClient is Ethereum client
 type EthereumClient interface {
-	GetArgs(*ethereumv1alpha1.Node, *ethereumv1alpha1.Network, []string) []string
-	GetGenesisFile(*ethereumv1alpha1.Genesis, ethereumv1alpha1.ConsensusAlgorithm) (string, error)
-	LoggingArgFromVerbosity(ethereumv1alpha1.VerbosityLevel) string
+	GetArgs(*ethereumv1beta1.Node, *ethereumv1beta1.Network, []string) []string
+	GetGenesisFile(*ethereumv1beta1.Genesis, ethereumv1beta1.ConsensusAlgorithm) (string, error)
+	LoggingArgFromVerbosity(ethereumv1beta1.VerbosityLevel) string
 }
 
 // NewEthereumClient returns an Ethereum client instance
-func NewEthereumClient(name ethereumv1alpha1.EthereumClient) (EthereumClient, error) {
+func NewEthereumClient(name ethereumv1beta1.EthereumClient) (EthereumClient, error) {
 	switch name {
 	case "besu":
 		return &BesuClient{}, nil
@@ -3,34 +3,35 @@ package controllers
 import (
 	"fmt"
 
-	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("Ethereum client arguments", func() {
 
 	var bootnodes []string
-	besuClient, _ := NewEthereumClient(ethereumv1alpha1.BesuClient)
-	gethClient, _ := NewEthereumClient(ethereumv1alpha1.GethClient)
+	besuClient, _ := NewEthereumClient(ethereumv1beta1.BesuClient)
+	gethClient, _ := NewEthereumClient(ethereumv1beta1.GethClient)
 	rinkeby := "rinkeby"
 	bootnode := "enode://publickey@ip:port"
-	coinbase := ethereumv1alpha1.EthereumAddress("0x5A0b54D5dc17e0AadC383d2db43B0a0D3E029c4c")
-	nodekey := ethereumv1alpha1.PrivateKey("0x608e9b6f67c65e47531e08e8e501386dfae63a540fa3c48802c8aad854510b4e")
+	coinbase := ethereumv1beta1.EthereumAddress("0x5A0b54D5dc17e0AadC383d2db43B0a0D3E029c4c")
+	nodekey := ethereumv1beta1.PrivateKey("0x608e9b6f67c65e47531e08e8e501386dfae63a540fa3c48802c8aad854510b4e")
 
 	cases := []struct {
 		title     string
 		bootnodes []string
-		network   *ethereumv1alpha1.Network
+		network   *ethereumv1beta1.Network
 		result    []string
 	}{
 		{
 			"node joining rinkeby",
 			bootnodes,
-			&ethereumv1alpha1.Network{
-				Spec: ethereumv1alpha1.NetworkSpec{
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
 					Join: rinkeby,
-					Nodes: []ethereumv1alpha1.Node{
+					Nodes: []ethereumv1beta1.Node{
 						{
 							Name: "node-1",
 						},
@@ -44,19 +45,19 @@ var _ = Describe("Ethereum client arguments", func() {
 				BesuDataPath,
 				PathBlockchainData,
 				BesuLogging,
-				besuClient.LoggingArgFromVerbosity(ethereumv1alpha1.DefaultLogging),
+				besuClient.LoggingArgFromVerbosity(ethereumv1beta1.DefaultLogging),
 			},
 		},
 		{
 			"geth bootnode joining rinkeby",
 			bootnodes,
-			&ethereumv1alpha1.Network{
-				Spec: ethereumv1alpha1.NetworkSpec{
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
 					Join: rinkeby,
-					Nodes: []ethereumv1alpha1.Node{
+					Nodes: []ethereumv1beta1.Node{
 						{
 							Name:     "node-1",
-							Client:   ethereumv1alpha1.GethClient,
+							Client:   ethereumv1beta1.GethClient,
 							Bootnode: true,
 							Nodekey:  nodekey,
 						},
@@ -69,21 +70,21 @@ var _ = Describe("Ethereum client arguments", func() {
 				GethDataDir,
 				PathBlockchainData,
 				GethLogging,
-				gethClient.LoggingArgFromVerbosity(ethereumv1alpha1.DefaultLogging),
+				gethClient.LoggingArgFromVerbosity(ethereumv1beta1.DefaultLogging),
 			},
 		},
 		{
 			"bootnode joining rinkeby",
 			bootnodes,
-			&ethereumv1alpha1.Network{
-				Spec: ethereumv1alpha1.NetworkSpec{
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
 					Join: rinkeby,
-					Nodes: []ethereumv1alpha1.Node{
+					Nodes: []ethereumv1beta1.Node{
 						{
 							Name:     "node-1",
 							Bootnode: true,
 							Nodekey:  nodekey,
-							Logging:  ethereumv1alpha1.NoLogs,
+							Logging:  ethereumv1beta1.NoLogs,
 						},
 					},
 				},
@@ -96,22 +97,22 @@ var _ = Describe("Ethereum client arguments", func() {
 				BesuDataPath,
 				PathBlockchainData,
 				BesuLogging,
-				besuClient.LoggingArgFromVerbosity(ethereumv1alpha1.NoLogs),
+				besuClient.LoggingArgFromVerbosity(ethereumv1beta1.NoLogs),
 			},
 		},
 		{
 			"geth bootnode joining rinkeby",
 			bootnodes,
-			&ethereumv1alpha1.Network{
-				Spec: ethereumv1alpha1.NetworkSpec{
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
 					Join: rinkeby,
-					Nodes: []ethereumv1alpha1.Node{
+					Nodes: []ethereumv1beta1.Node{
 						{
 							Name:     "node-1",
-							Client:   ethereumv1alpha1.GethClient,
+							Client:   ethereumv1beta1.GethClient,
 							Bootnode: true,
 							Nodekey:  nodekey,
-							Logging:  ethereumv1alpha1.NoLogs,
+							Logging:  ethereumv1beta1.NoLogs,
 						},
 					},
 				},
@@ -122,22 +123,22 @@ var _ = Describe("Ethereum client arguments", func() {
 				GethDataDir,
 				PathBlockchainData,
 				GethLogging,
-				gethClient.LoggingArgFromVerbosity(ethereumv1alpha1.NoLogs),
+				gethClient.LoggingArgFromVerbosity(ethereumv1beta1.NoLogs),
 			},
 		},
 		{
 			"bootnode joining rinkeby with rpc",
 			bootnodes,
-			&ethereumv1alpha1.Network{
-				Spec: ethereumv1alpha1.NetworkSpec{
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
 					Join: rinkeby,
-					Nodes: []ethereumv1alpha1.Node{
+					Nodes: []ethereumv1beta1.Node{
 						{
 							Name:     "node-1",
 							Bootnode: true,
 							Nodekey:  nodekey,
 							RPC:      true,
-							Logging:  ethereumv1alpha1.FatalLogs,
+							Logging:  ethereumv1beta1.FatalLogs,
 						},
 					},
 				},
@@ -151,23 +152,23 @@ var _ = Describe("Ethereum client arguments", func() {
 				PathBlockchainData,
 				BesuRPCHTTPEnabled,
 				BesuLogging,
-				besuClient.LoggingArgFromVerbosity(ethereumv1alpha1.FatalLogs),
+				besuClient.LoggingArgFromVerbosity(ethereumv1beta1.FatalLogs),
 			},
 		},
 		{
 			"geth bootnode joining rinkeby with rpc",
 			bootnodes,
-			&ethereumv1alpha1.Network{
-				Spec: ethereumv1alpha1.NetworkSpec{
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
 					Join: rinkeby,
-					Nodes: []ethereumv1alpha1.Node{
+					Nodes: []ethereumv1beta1.Node{
 						{
 							Name:     "node-1",
-							Client:   ethereumv1alpha1.GethClient,
+							Client:   ethereumv1beta1.GethClient,
 							Bootnode: true,
 							Nodekey:  nodekey,
 							RPC:      true,
-							Logging:  ethereumv1alpha1.FatalLogs,
+							Logging:  ethereumv1beta1.FatalLogs,
 						},
 					},
 				},
@@ -179,28 +180,28 @@ var _ = Describe("Ethereum client arguments", func() {
 				PathBlockchainData,
 				GethRPCHTTPEnabled,
 				GethLogging,
-				gethClient.LoggingArgFromVerbosity(ethereumv1alpha1.FatalLogs),
+				gethClient.LoggingArgFromVerbosity(ethereumv1beta1.FatalLogs),
 			},
 		},
 		{
 			"bootnode joining rinkeby with rpc settings",
 			bootnodes,
-			&ethereumv1alpha1.Network{
-				Spec: ethereumv1alpha1.NetworkSpec{
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
 					Join: rinkeby,
-					Nodes: []ethereumv1alpha1.Node{
+					Nodes: []ethereumv1beta1.Node{
 						{
 							Name:     "node-1",
 							Bootnode: true,
 							Nodekey:  nodekey,
 							RPC:      true,
 							RPCPort:  8599,
-							RPCAPI: []ethereumv1alpha1.API{
-								ethereumv1alpha1.ETHAPI,
-								ethereumv1alpha1.Web3API,
-								ethereumv1alpha1.NetworkAPI,
+							RPCAPI: []ethereumv1beta1.API{
+								ethereumv1beta1.ETHAPI,
+								ethereumv1beta1.Web3API,
+								ethereumv1beta1.NetworkAPI,
 							},
-							Logging: ethereumv1alpha1.ErrorLogs,
+							Logging: ethereumv1beta1.ErrorLogs,
 						},
 					},
 				},
@@ -218,29 +219,29 @@ var _ = Describe("Ethereum client arguments", func() {
 				BesuRPCHTTPAPI,
 				"eth,web3,net",
 				BesuLogging,
-				besuClient.LoggingArgFromVerbosity(ethereumv1alpha1.ErrorLogs),
+				besuClient.LoggingArgFromVerbosity(ethereumv1beta1.ErrorLogs),
 			},
 		},
 		{
 			"geth bootnode joining rinkeby with rpc settings",
 			bootnodes,
-			&ethereumv1alpha1.Network{
-				Spec: ethereumv1alpha1.NetworkSpec{
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
 					Join: rinkeby,
-					Nodes: []ethereumv1alpha1.Node{
+					Nodes: []ethereumv1beta1.Node{
 						{
 							Name:     "node-1",
-							Client:   ethereumv1alpha1.GethClient,
+							Client:   ethereumv1beta1.GethClient,
 							Bootnode: true,
 							Nodekey:  nodekey,
 							RPC:      true,
 							RPCPort:  8599,
-							RPCAPI: []ethereumv1alpha1.API{
-								ethereumv1alpha1.ETHAPI,
-								ethereumv1alpha1.Web3API,
-								ethereumv1alpha1.NetworkAPI,
+							RPCAPI: []ethereumv1beta1.API{
+								ethereumv1beta1.ETHAPI,
+								ethereumv1beta1.Web3API,
+								ethereumv1beta1.NetworkAPI,
 							},
-							Logging: ethereumv1alpha1.ErrorLogs,
+							Logging: ethereumv1beta1.ErrorLogs,
 						},
 					},
 				},
@@ -256,16 +257,16 @@ var _ = Describe("Ethereum client arguments", func() {
 				GethRPCHTTPAPI,
 				"eth,web3,net",
 				GethLogging,
-				gethClient.LoggingArgFromVerbosity(ethereumv1alpha1.ErrorLogs),
+				gethClient.LoggingArgFromVerbosity(ethereumv1beta1.ErrorLogs),
 			},
 		},
 		{
 			"bootnode joining rinkeby with rpc, ws settings",
 			bootnodes,
-			&ethereumv1alpha1.Network{
-				Spec: ethereumv1alpha1.NetworkSpec{
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
 					Join: rinkeby,
-					Nodes: []ethereumv1alpha1.Node{
+					Nodes: []ethereumv1beta1.Node{
 						{
 							Name:     "node-1",
 							Bootnode: true,
@@ -273,19 +274,19 @@ var _ = Describe("Ethereum client arguments", func() {
 							RPC:      true,
 							RPCHost:  "0.0.0.0",
 							RPCPort:  8599,
-							RPCAPI: []ethereumv1alpha1.API{
-								ethereumv1alpha1.ETHAPI,
-								ethereumv1alpha1.Web3API,
-								ethereumv1alpha1.NetworkAPI,
+							RPCAPI: []ethereumv1beta1.API{
+								ethereumv1beta1.ETHAPI,
+								ethereumv1beta1.Web3API,
+								ethereumv1beta1.NetworkAPI,
 							},
 							WS:     true,
 							WSHost: "127.0.0.1",
 							WSPort: 8588,
-							WSAPI: []ethereumv1alpha1.API{
-								ethereumv1alpha1.Web3API,
-								ethereumv1alpha1.ETHAPI,
+							WSAPI: []ethereumv1beta1.API{
+								ethereumv1beta1.Web3API,
+								ethereumv1beta1.ETHAPI,
 							},
-							Logging: ethereumv1alpha1.WarnLogs,
+							Logging: ethereumv1beta1.WarnLogs,
 						},
 					},
 				},
@@ -312,37 +313,37 @@ var _ = Describe("Ethereum client arguments", func() {
 				BesuRPCWSAPI,
 				"web3,eth",
 				BesuLogging,
-				besuClient.LoggingArgFromVerbosity(ethereumv1alpha1.WarnLogs),
+				besuClient.LoggingArgFromVerbosity(ethereumv1beta1.WarnLogs),
 			},
 		},
 		{
 			"geth bootnode joining rinkeby with rpc, ws settings",
 			bootnodes,
-			&ethereumv1alpha1.Network{
-				Spec: ethereumv1alpha1.NetworkSpec{
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
 					Join: rinkeby,
-					Nodes: []ethereumv1alpha1.Node{
+					Nodes: []ethereumv1beta1.Node{
 						{
 							Name:     "node-1",
-							Client:   ethereumv1alpha1.GethClient,
+							Client:   ethereumv1beta1.GethClient,
 							Bootnode: true,
 							Nodekey:  nodekey,
 							RPC:      true,
 							RPCHost:  "0.0.0.0",
 							RPCPort:  8599,
-							RPCAPI: []ethereumv1alpha1.API{
-								ethereumv1alpha1.ETHAPI,
-								ethereumv1alpha1.Web3API,
-								ethereumv1alpha1.NetworkAPI,
+							RPCAPI: []ethereumv1beta1.API{
+								ethereumv1beta1.ETHAPI,
+								ethereumv1beta1.Web3API,
+								ethereumv1beta1.NetworkAPI,
 							},
 							WS:     true,
 							WSHost: "127.0.0.1",
 							WSPort: 8588,
-							WSAPI: []ethereumv1alpha1.API{
-								ethereumv1alpha1.Web3API,
-								ethereumv1alpha1.ETHAPI,
+							WSAPI: []ethereumv1beta1.API{
+								ethereumv1beta1.Web3API,
+								ethereumv1beta1.ETHAPI,
 							},
-							Logging: ethereumv1alpha1.WarnLogs,
+							Logging: ethereumv1beta1.WarnLogs,
 						},
 					},
 				},
@@ -367,16 +368,16 @@ var _ = Describe("Ethereum client arguments", func() {
 				GethRPCWSAPI,
 				"web3,eth",
 				GethLogging,
-				gethClient.LoggingArgFromVerbosity(ethereumv1alpha1.WarnLogs),
+				gethClient.LoggingArgFromVerbosity(ethereumv1beta1.WarnLogs),
 			},
 		},
 		{
 			"bootnode joining rinkeby with rpc, ws, graphql settings and cors domains",
 			bootnodes,
-			&ethereumv1alpha1.Network{
-				Spec: ethereumv1alpha1.NetworkSpec{
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
 					Join: rinkeby,
-					Nodes: []ethereumv1alpha1.Node{
+					Nodes: []ethereumv1beta1.Node{
 						{
 							Name:     "node-1",
 							Bootnode: true,
@@ -384,23 +385,23 @@ var _ = Describe("Ethereum client arguments", func() {
 							RPC:      true,
 							RPCHost:  "0.0.0.0",
 							RPCPort:  8599,
-							RPCAPI: []ethereumv1alpha1.API{
-								ethereumv1alpha1.ETHAPI,
-								ethereumv1alpha1.Web3API,
-								ethereumv1alpha1.NetworkAPI,
+							RPCAPI: []ethereumv1beta1.API{
+								ethereumv1beta1.ETHAPI,
+								ethereumv1beta1.Web3API,
+								ethereumv1beta1.NetworkAPI,
 							},
 							CORSDomains: []string{"cors.example.com"},
 							WS:          true,
 							WSHost:      "127.0.0.1",
 							WSPort:      8588,
-							WSAPI: []ethereumv1alpha1.API{
-								ethereumv1alpha1.Web3API,
-								ethereumv1alpha1.ETHAPI,
+							WSAPI: []ethereumv1beta1.API{
+								ethereumv1beta1.Web3API,
+								ethereumv1beta1.ETHAPI,
 							},
 							GraphQL:     true,
 							GraphQLHost: "127.0.0.2",
 							GraphQLPort: 8511,
-							Logging:     ethereumv1alpha1.InfoLogs,
+							Logging:     ethereumv1beta1.InfoLogs,
 						},
 					},
 				},
@@ -435,41 +436,41 @@ var _ = Describe("Ethereum client arguments", func() {
 				BesuGraphQLHTTPCorsOrigins,
 				"cors.example.com",
 				BesuLogging,
-				besuClient.LoggingArgFromVerbosity(ethereumv1alpha1.InfoLogs),
+				besuClient.LoggingArgFromVerbosity(ethereumv1beta1.InfoLogs),
 			},
 		},
 		{
 			"geth bootnode joining rinkeby with rpc, ws, graphql settings and cors domains",
 			bootnodes,
-			&ethereumv1alpha1.Network{
-				Spec: ethereumv1alpha1.NetworkSpec{
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
 					Join: rinkeby,
-					Nodes: []ethereumv1alpha1.Node{
+					Nodes: []ethereumv1beta1.Node{
 						{
 							Name:     "node-1",
-							Client:   ethereumv1alpha1.GethClient,
+							Client:   ethereumv1beta1.GethClient,
 							Bootnode: true,
 							Nodekey:  nodekey,
 							RPC:      true,
 							RPCHost:  "0.0.0.0",
 							RPCPort:  8599,
-							RPCAPI: []ethereumv1alpha1.API{
-								ethereumv1alpha1.ETHAPI,
-								ethereumv1alpha1.Web3API,
-								ethereumv1alpha1.NetworkAPI,
+							RPCAPI: []ethereumv1beta1.API{
+								ethereumv1beta1.ETHAPI,
+								ethereumv1beta1.Web3API,
+								ethereumv1beta1.NetworkAPI,
 							},
 							CORSDomains: []string{"cors.example.com"},
 							WS:          true,
 							WSHost:      "127.0.0.1",
 							WSPort:      8588,
-							WSAPI: []ethereumv1alpha1.API{
-								ethereumv1alpha1.Web3API,
-								ethereumv1alpha1.ETHAPI,
+							WSAPI: []ethereumv1beta1.API{
+								ethereumv1beta1.Web3API,
+								ethereumv1beta1.ETHAPI,
 							},
 							GraphQL:     true,
 							GraphQLHost: "127.0.0.2",
 							GraphQLPort: 8511,
-							Logging:     ethereumv1alpha1.InfoLogs,
+							Logging:     ethereumv1beta1.InfoLogs,
 						},
 					},
 				},
@@ -502,22 +503,22 @@ var _ = Describe("Ethereum client arguments", func() {
 				GethGraphQLHTTPCorsOrigins,
 				"cors.example.com",
 				GethLogging,
-				gethClient.LoggingArgFromVerbosity(ethereumv1alpha1.InfoLogs),
+				gethClient.LoggingArgFromVerbosity(ethereumv1beta1.InfoLogs),
 			},
 		},
 		{
 			"miner node of private network that connects to bootnode",
 			[]string{bootnode},
-			&ethereumv1alpha1.Network{
-				Spec: ethereumv1alpha1.NetworkSpec{
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
 					ID:      8888,
-					Genesis: &ethereumv1alpha1.Genesis{},
-					Nodes: []ethereumv1alpha1.Node{
+					Genesis: &ethereumv1beta1.Genesis{},
+					Nodes: []ethereumv1beta1.Node{
 						{
 							Name:     "node-1",
 							Miner:    true,
 							Coinbase: coinbase,
-							Logging:  ethereumv1alpha1.DebugLogs,
+							Logging:  ethereumv1beta1.DebugLogs,
 						},
 					},
 				},
@@ -534,23 +535,23 @@ var _ = Describe("Ethereum client arguments", func() {
 				BesuMinerCoinbase,
 				string(coinbase),
 				BesuLogging,
-				besuClient.LoggingArgFromVerbosity(ethereumv1alpha1.DebugLogs),
+				besuClient.LoggingArgFromVerbosity(ethereumv1beta1.DebugLogs),
 			},
 		},
 		{
 			"geth miner node of private network that connects to bootnode",
 			[]string{bootnode},
-			&ethereumv1alpha1.Network{
-				Spec: ethereumv1alpha1.NetworkSpec{
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
 					ID:      7777,
-					Genesis: &ethereumv1alpha1.Genesis{},
-					Nodes: []ethereumv1alpha1.Node{
+					Genesis: &ethereumv1beta1.Genesis{},
+					Nodes: []ethereumv1beta1.Node{
 						{
 							Name:     "node-1",
-							Client:   ethereumv1alpha1.GethClient,
+							Client:   ethereumv1beta1.GethClient,
 							Miner:    true,
 							Coinbase: coinbase,
-							Logging:  ethereumv1alpha1.DebugLogs,
+							Logging:  ethereumv1beta1.DebugLogs,
 						},
 					},
 				},
@@ -566,7 +567,124 @@ var _ = Describe("Ethereum client arguments", func() {
 				GethMinerCoinbase,
 				string(coinbase),
 				GethLogging,
-				gethClient.LoggingArgFromVerbosity(ethereumv1alpha1.DebugLogs),
+				gethClient.LoggingArgFromVerbosity(ethereumv1beta1.DebugLogs),
+			},
+		},
+		{
+			"besu node joining rinkeby with metrics",
+			bootnodes,
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
+					Join: rinkeby,
+					Nodes: []ethereumv1beta1.Node{
+						{
+							Name:        "node-1",
+							Metrics:     true,
+							MetricsPort: 9999,
+						},
+					},
+				},
+			},
+			[]string{
+				BesuMetricsEnabled,
+				BesuMetricsHost,
+				ethereumv1beta1.DefaultHost,
+				BesuMetricsPort,
+				"9999",
+			},
+		},
+		{
+			"geth node joining rinkeby with metrics",
+			bootnodes,
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
+					Join: rinkeby,
+					Nodes: []ethereumv1beta1.Node{
+						{
+							Name:    "node-1",
+							Client:  ethereumv1beta1.GethClient,
+							Metrics: true,
+						},
+					},
+				},
+			},
+			[]string{
+				GethMetricsEnabled,
+				GethPprofEnabled,
+				GethPprofHost,
+				ethereumv1beta1.DefaultHost,
+				GethPprofPort,
+				fmt.Sprintf("%d", ethereumv1beta1.DefaultMetricsPort),
+			},
+		},
+		{
+			"geth node joining rinkeby with json logs",
+			bootnodes,
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
+					Join: rinkeby,
+					Nodes: []ethereumv1beta1.Node{
+						{
+							Name:      "node-1",
+							Client:    ethereumv1beta1.GethClient,
+							LogFormat: ethereumv1beta1.JSONLogFormat,
+						},
+					},
+				},
+			},
+			[]string{
+				GethLogJSON,
+			},
+		},
+		{
+			"geth node of development network",
+			bootnodes,
+			&ethereumv1beta1.Network{
+				Spec: ethereumv1beta1.NetworkSpec{
+					Dev: &ethereumv1beta1.DevMode{
+						Period: 5,
+					},
+					Nodes: []ethereumv1beta1.Node{
+						{
+							Name:   "node-1",
+							Client: ethereumv1beta1.GethClient,
+							Import: &ethereumv1beta1.ImportedAccount{
+								PrivateKey: nodekey,
+								Password:   "secret",
+							},
+						},
+					},
+				},
+			},
+			[]string{
+				GethDev,
+				GethDevPeriod,
+				"5",
+				GethPassword,
+				fmt.Sprintf("%s/account.password", PathSecrets),
+			},
+		},
+		{
+			"geth node joining rinkeby reporting to ethstats",
+			bootnodes,
+			&ethereumv1beta1.Network{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-network",
+				},
+				Spec: ethereumv1beta1.NetworkSpec{
+					Join:     rinkeby,
+					Ethstats: &ethereumv1beta1.Ethstats{},
+					Nodes: []ethereumv1beta1.Node{
+						{
+							Name:   "node-1",
+							Client: ethereumv1beta1.GethClient,
+						},
+					},
+				},
+			},
+			[]string{
+				GethEthstats,
+				fmt.Sprintf("node-1:$(%s)@my-network-ethstats:80", EnvEthstatsSecret),
 			},
 		},
 	}
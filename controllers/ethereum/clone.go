@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// cloneNetwork copies consensus and genesis of the cloned network into network spec
+// nodes data is restored from cloned network nodes volume snapshots if requested
+// network spec is updated in memory only, it's not persisted
+func (r *NetworkReconciler) cloneNetwork(network *ethereumv1beta1.Network) error {
+	if network.Spec.From == nil {
+		return nil
+	}
+
+	var source ethereumv1beta1.Network
+
+	key := types.NamespacedName{
+		Name:      network.Spec.From.Network,
+		Namespace: network.Namespace,
+	}
+
+	if err := r.Client.Get(context.Background(), key, &source); err != nil {
+		r.Log.Error(err, "unable to get cloned network")
+		return err
+	}
+
+	if err := cloneNetworkFrom(network, &source); err != nil {
+		r.Log.Error(err, "unable to clone network")
+		return err
+	}
+
+	if !network.Spec.From.Snapshots {
+		return nil
+	}
+
+	return r.restoreClonedNodes(network, &source)
+}
+
+// cloneNetworkFrom copies consensus and genesis of source network under clone chain id
+func cloneNetworkFrom(network, source *ethereumv1beta1.Network) error {
+	if source.Spec.Genesis == nil {
+		return fmt.Errorf("network %s isn't created from genesis", source.Name)
+	}
+
+	// genesis.json loaded verbatim has its own chain id
+	if source.Spec.Genesis.FromConfigMap != nil || source.Spec.Genesis.FromURL != "" {
+		return fmt.Errorf("network %s genesis is loaded verbatim, it can't be copied under another chain id", source.Name)
+	}
+
+	if source.Spec.Genesis.ChainID == network.Spec.From.ChainID {
+		return fmt.Errorf("chain id must be different from network %s chain id %d", source.Name, source.Spec.Genesis.ChainID)
+	}
+
+	// clone nodes mustn't peer with cloned network nodes
+	if source.Spec.ID == network.Spec.ID {
+		return fmt.Errorf("network id must be different from network %s id %d", source.Name, source.Spec.ID)
+	}
+
+	network.Spec.Consensus = source.Spec.Consensus
+	network.Spec.Genesis = source.Spec.Genesis.DeepCopy()
+	network.Spec.Genesis.ChainID = network.Spec.From.ChainID
+
+	return nil
+}
+
+// restoreClonedNodes restores nodes data from the latest ready volume snapshot of cloned network node with the same name
+// node data pvc data source is set on creation only, so nodes data is restored once
+func (r *NetworkReconciler) restoreClonedNodes(network, source *ethereumv1beta1.Network) error {
+	for i := range network.Spec.Nodes {
+		node := &network.Spec.Nodes[i]
+
+		if node.Restore != nil || node.IsDedicatedBootnode() || node.StorageMode() != ethereumv1beta1.PersistentStorage {
+			continue
+		}
+
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(VolumeSnapshotListGVK)
+
+		// cloned network node is matched by name only, clone node may belong to another pool
+		sourceNode := &ethereumv1beta1.Node{Name: node.Name}
+		matchingLabels := client.MatchingLabels(sourceNode.Labels(source.Name))
+		inNamespace := client.InNamespace(network.Namespace)
+
+		err := r.Client.List(context.Background(), list, matchingLabels, inNamespace)
+		// csi snapshot custom resources are not installed
+		if meta.IsNoMatchError(err) {
+			r.Log.Error(err, "csi snapshot custom resources must be installed to restore cloned nodes data")
+			return nil
+		}
+		if err != nil {
+			r.Log.Error(err, "unable to list cloned node volume snapshots")
+			return err
+		}
+
+		if name := latestReadySnapshot(list.Items); name != "" {
+			node.Restore = &ethereumv1beta1.NodeRestore{VolumeSnapshotName: name}
+		}
+	}
+
+	return nil
+}
+
+// latestReadySnapshot returns name of the newest snapshot ready to be used, or empty string if there's none
+func latestReadySnapshot(snapshots []unstructured.Unstructured) string {
+	sortSnapshots(snapshots)
+
+	for _, snapshot := range snapshots {
+		if ready, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse"); ready {
+			return snapshot.GetName()
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestCloneNetworkFrom(t *testing.T) {
+	source := &ethereumv1beta1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "consortium",
+			Namespace: "default",
+		},
+		Spec: ethereumv1beta1.NetworkSpec{
+			ID:        7777,
+			Consensus: ethereumv1beta1.ProofOfAuthority,
+			Genesis: &ethereumv1beta1.Genesis{
+				ChainID: 7777,
+				Accounts: []ethereumv1beta1.Account{
+					{Address: "0x48c5F25a884116d58A6287B72C9b069F936C9489", Balance: "0xffffffffffffffff"},
+				},
+				Clique: &ethereumv1beta1.Clique{
+					Signers: []ethereumv1beta1.EthereumAddress{"0xd2c21213027cbf4d46c16b55fa98e5252b048706"},
+				},
+			},
+		},
+	}
+
+	network := &ethereumv1beta1.Network{
+		Spec: ethereumv1beta1.NetworkSpec{
+			ID: 8888,
+			From: &ethereumv1beta1.NetworkSource{
+				Network: "consortium",
+				ChainID: 8888,
+			},
+		},
+	}
+
+	if err := cloneNetworkFrom(network, source); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	if network.Spec.Consensus != source.Spec.Consensus {
+		t.Errorf("Expecting consensus %s got %s", source.Spec.Consensus, network.Spec.Consensus)
+	}
+
+	if network.Spec.Genesis.ChainID != 8888 || len(network.Spec.Genesis.Accounts) != 1 {
+		t.Errorf("Expecting genesis funded accounts to be copied under chain id 8888 got %+v", network.Spec.Genesis)
+	}
+
+	if source.Spec.Genesis.ChainID != 7777 {
+		t.Errorf("Expecting cloned network genesis not to be changed")
+	}
+
+	// same chain id
+	network.Spec.From.ChainID = 7777
+	if err := cloneNetworkFrom(network, source); err == nil {
+		t.Error("Expecting error cloning network under the same chain id")
+	}
+
+	// genesis loaded verbatim
+	network.Spec.From.ChainID = 8888
+	source.Spec.Genesis.FromURL = "https://example.com/genesis.json"
+	if err := cloneNetworkFrom(network, source); err == nil {
+		t.Error("Expecting error cloning network with genesis loaded verbatim")
+	}
+}
+
+func TestLatestReadySnapshot(t *testing.T) {
+	snapshot := func(name string, age time.Duration, ready bool) unstructured.Unstructured {
+		s := unstructured.Unstructured{Object: map[string]interface{}{}}
+		s.SetName(name)
+		s.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-age)))
+		_ = unstructured.SetNestedField(s.Object, ready, "status", "readyToUse")
+		return s
+	}
+
+	snapshots := []unstructured.Unstructured{
+		snapshot("oldest", 3*time.Hour, true),
+		snapshot("newest", time.Hour, false),
+		snapshot("older", 2*time.Hour, true),
+	}
+
+	if name := latestReadySnapshot(snapshots); name != "older" {
+		t.Errorf("Expecting newest ready snapshot older got %s", name)
+	}
+
+	if name := latestReadySnapshot(nil); name != "" {
+		t.Errorf("Expecting no snapshot got %s", name)
+	}
+}
@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	"github.com/kotalco/kotal/helpers"
+)
+
+// consoleScriptAttempts is the number of times console script job is retried, node might still be starting
+const consoleScriptAttempts = 3
+
+// consoleScriptJobName returns name of node console script job
+func consoleScriptJobName(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network, script string) string {
+	return boundedJobName(fmt.Sprintf("%s-script-%s", node.DeploymentName(network.Name), script))
+}
+
+// consoleScriptChecksum returns checksum of console script, changed script is run again
+func consoleScriptChecksum(script *ethereumv1beta1.ConsoleScript) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(script.Script)))
+}
+
+// specConsoleScriptJob updates node console script job spec
+// geth console is attached to node http-rpc server, console output is written to container termination message
+func specConsoleScriptJob(job *batchv1.Job, script *ethereumv1beta1.ConsoleScript, node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) {
+	labels := node.ResourceLabels(network.Name, network.Spec.Consensus)
+	labels[ConsoleScriptLabel] = script.Name
+
+	backoffLimit := int32(consoleScriptAttempts)
+	endpoint := fmt.Sprintf("http://%s:%d", nodeRPCService(node, network), node.RPCPort)
+
+	job.ObjectMeta.Labels = labels
+	job.ObjectMeta.Annotations = map[string]string{
+		ConsoleScriptChecksumAnnotation: consoleScriptChecksum(script),
+	}
+	job.Spec = batchv1.JobSpec{
+		BackoffLimit: &backoffLimit,
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				Containers: []corev1.Container{
+					{
+						Name: "console",
+						// console talks to node over http-rpc, it doesn't need node architecture
+						Image:   GethImage(""),
+						Command: []string{"/bin/sh", "-c"},
+						Args: []string{
+							fmt.Sprintf(`output=$(geth attach --exec "$%s" %s 2>&1); code=$?; echo "$output" | tee /dev/termination-log; exit $code`, EnvConsoleScript, endpoint),
+						},
+						Env: []corev1.EnvVar{
+							{
+								Name:  EnvConsoleScript,
+								Value: script.Script,
+							},
+						},
+					},
+				},
+				ImagePullSecrets: helpers.ImagePullSecrets(),
+			},
+		},
+	}
+}
+
+// reconcileConsoleScripts runs nodes console scripts that haven't run yet or changed, and records completed scripts results
+// jobs of scripts removed from node spec or changed are deleted
+func (r *NetworkReconciler) reconcileConsoleScripts(network *ethereumv1beta1.Network) error {
+	for i := range network.Spec.Nodes {
+		node := &network.Spec.Nodes[i]
+		status := nodeStatus(network, node.Name)
+		if status == nil {
+			continue
+		}
+
+		var jobs batchv1.JobList
+		if err := r.Client.List(context.Background(), &jobs, client.InNamespace(network.Namespace), client.MatchingLabels(node.Labels(network.Name)), client.HasLabels{ConsoleScriptLabel}); err != nil {
+			r.Log.Error(err, "unable to list node console script jobs")
+			return err
+		}
+
+		checksums := map[string]string{}
+		for j := range node.ConsoleScripts {
+			checksums[node.ConsoleScripts[j].Name] = consoleScriptChecksum(&node.ConsoleScripts[j])
+		}
+
+		existing := map[string]*batchv1.Job{}
+		for j := range jobs.Items {
+			job := &jobs.Items[j]
+			name := job.Labels[ConsoleScriptLabel]
+			if checksum, desired := checksums[name]; !desired || job.Annotations[ConsoleScriptChecksumAnnotation] != checksum {
+				propagation := metav1.DeletePropagationBackground
+				if err := r.Client.Delete(context.Background(), job, &client.DeleteOptions{PropagationPolicy: &propagation}); err != nil && !apierrors.IsNotFound(err) {
+					r.Log.Error(err, "unable to delete node console script job")
+					return err
+				}
+				continue
+			}
+			existing[name] = job
+		}
+
+		completed := map[string]ethereumv1beta1.ConsoleScriptStatus{}
+		for _, result := range status.ConsoleScripts {
+			completed[result.Name] = result
+		}
+
+		results := []ethereumv1beta1.ConsoleScriptStatus{}
+		for j := range node.ConsoleScripts {
+			script := &node.ConsoleScripts[j]
+
+			if result, found := completed[script.Name]; found && result.Checksum == checksums[script.Name] {
+				results = append(results, result)
+				continue
+			}
+
+			// script hasn't run yet, or it changed and its previous job is still being deleted
+			job, found := existing[script.Name]
+			if !found {
+				if err := r.createConsoleScriptJob(script, node, network); err != nil {
+					return err
+				}
+				continue
+			}
+
+			result, done, err := r.consoleScriptResult(job)
+			if err != nil {
+				return err
+			}
+			if !done {
+				continue
+			}
+
+			result.Name = script.Name
+			result.Checksum = checksums[script.Name]
+			results = append(results, result)
+
+			if result.Succeeded {
+				r.Recorder.Eventf(network, corev1.EventTypeNormal, "ConsoleScriptSucceeded", "node %s console script %s succeeded", node.Name, script.Name)
+			} else {
+				r.Recorder.Eventf(network, corev1.EventTypeWarning, "ConsoleScriptFailed", "node %s console script %s failed", node.Name, script.Name)
+			}
+		}
+
+		if len(results) == 0 {
+			results = nil
+		}
+		status.ConsoleScripts = results
+	}
+
+	return nil
+}
+
+// createConsoleScriptJob creates node console script job, job of changed script might still be deleting
+func (r *NetworkReconciler) createConsoleScriptJob(script *ethereumv1beta1.ConsoleScript, node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) error {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      consoleScriptJobName(node, network, script.Name),
+			Namespace: network.Namespace,
+		},
+	}
+
+	specConsoleScriptJob(job, script, node, network)
+
+	if err := ctrl.SetControllerReference(network, job, r.Scheme); err != nil {
+		return err
+	}
+
+	if err := r.Client.Create(context.Background(), job); err != nil && !apierrors.IsAlreadyExists(err) {
+		r.Log.Error(err, "unable to create node console script job")
+		return err
+	}
+
+	return nil
+}
+
+// consoleScriptResult returns completed console script job result, output is read from job last pod termination message
+// done is false if job is still running
+func (r *NetworkReconciler) consoleScriptResult(job *batchv1.Job) (result ethereumv1beta1.ConsoleScriptStatus, done bool, err error) {
+	completed := false
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		if condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed {
+			completed = true
+			result.Time = condition.LastTransitionTime
+			result.Succeeded = condition.Type == batchv1.JobComplete
+		}
+	}
+	if !completed {
+		return
+	}
+
+	var pods corev1.PodList
+	if err = r.Client.List(context.Background(), &pods, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		r.Log.Error(err, "unable to list node console script job pods")
+		return
+	}
+
+	var last *corev1.ContainerStateTerminated
+	for _, pod := range pods.Items {
+		for _, container := range pod.Status.ContainerStatuses {
+			if terminated := container.State.Terminated; terminated != nil && (last == nil || last.FinishedAt.Before(&terminated.FinishedAt)) {
+				last = terminated
+			}
+		}
+	}
+	if last != nil {
+		result.Output = last.Message
+	}
+
+	done = true
+
+	return
+}
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestSpecConsoleScriptJob(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	script := &ethereumv1beta1.ConsoleScript{Name: "gas-price", Script: "miner.setGasPrice(1)"}
+
+	job := &batchv1.Job{}
+	specConsoleScriptJob(job, script, node, network)
+
+	container := job.Spec.Template.Spec.Containers[0]
+	if expected := "geth attach --exec \"$CONSOLE_SCRIPT\" http://my-network-node-1:8545"; !strings.Contains(container.Args[0], expected) {
+		t.Errorf("Expecting console to be attached to node http-rpc server got %s", container.Args[0])
+	}
+	if container.Env[0].Value != script.Script {
+		t.Errorf("Expecting console script to be passed in environment got %v", container.Env)
+	}
+	if job.Labels[ConsoleScriptLabel] != "gas-price" || job.Annotations[ConsoleScriptChecksumAnnotation] != consoleScriptChecksum(script) {
+		t.Errorf("Expecting job to be labeled with console script name and checksum got %v %v", job.Labels, job.Annotations)
+	}
+}
+
+func TestConsoleScriptJobName(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+
+	if name := consoleScriptJobName(node, network, "gas-price"); name != "my-network-node-1-script-gas-price" {
+		t.Errorf("Expecting job name my-network-node-1-script-gas-price got %s", name)
+	}
+
+	// longest network and node names validation allows
+	network.Name = strings.Repeat("n", 30)
+	node.Name = strings.Repeat("a", 63-len(node.InternalServiceName(network.Name))+len(node.Name))
+	name := consoleScriptJobName(node, network, "gas-price")
+	if len(name) > 63 {
+		t.Errorf("Expecting job name to be shortened to 63 characters got %s", name)
+	}
+
+	if consoleScriptJobName(node, network, "gas-limit") == name {
+		t.Errorf("Expecting node scripts to have different job names")
+	}
+}
+
+func TestReconcileConsoleScripts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = ethereumv1beta1.AddToScheme(scheme)
+
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	node.ConsoleScripts = []ethereumv1beta1.ConsoleScript{{Name: "gas-price", Script: "miner.setGasPrice(1)"}}
+	network.Status.Nodes = []ethereumv1beta1.NodeStatus{{Name: node.Name}}
+
+	recorder := record.NewFakeRecorder(10)
+	r := &NetworkReconciler{
+		Client:   fake.NewFakeClientWithScheme(scheme, network),
+		Log:      ctrl.Log,
+		Scheme:   scheme,
+		Recorder: recorder,
+	}
+
+	if err := r.reconcileConsoleScripts(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	job := &batchv1.Job{}
+	key := types.NamespacedName{Name: consoleScriptJobName(node, network, "gas-price"), Namespace: network.Namespace}
+	if err := r.Client.Get(context.Background(), key, job); err != nil {
+		t.Fatalf("Expecting console script job to be created got %s", err)
+	}
+
+	// script job completed
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if err := r.Client.Status().Update(context.Background(), job); err != nil {
+		t.Fatal(err)
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name + "-abcde", Namespace: key.Namespace, Labels: map[string]string{"job-name": key.Name}},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "console", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: "true\n"}}},
+			},
+		},
+	}
+	if err := r.Client.Create(context.Background(), pod); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.reconcileConsoleScripts(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	results := network.Status.Nodes[0].ConsoleScripts
+	if len(results) != 1 || !results[0].Succeeded || results[0].Output != "true\n" {
+		t.Fatalf("Expecting console script output to be recorded got %+v", results)
+	}
+	if event := <-recorder.Events; !strings.Contains(event, "ConsoleScriptSucceeded") {
+		t.Errorf("Expecting console script succeeded event got %s", event)
+	}
+
+	// changed script is run again
+	node.ConsoleScripts[0].Script = "miner.setGasPrice(2)"
+	if err := r.reconcileConsoleScripts(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if err := r.Client.Get(context.Background(), key, job); err != nil {
+		t.Fatal(err)
+	}
+	if job.Annotations[ConsoleScriptChecksumAnnotation] != consoleScriptChecksum(&node.ConsoleScripts[0]) || len(network.Status.Nodes[0].ConsoleScripts) != 0 {
+		t.Errorf("Expecting changed console script to be run again")
+	}
+
+	// removed script job is deleted
+	node.ConsoleScripts = nil
+	if err := r.reconcileConsoleScripts(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if err := r.Client.Get(context.Background(), key, job); !apierrors.IsNotFound(err) {
+		t.Errorf("Expecting removed console script job to be deleted got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"fmt"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	"github.com/kotalco/kotal/helpers"
+)
+
+// GrafanaDashboardLabel is the label used by grafana sidecar to discover dashboards configmaps
+const GrafanaDashboardLabel = "grafana_dashboard"
+
+// generateDashboard generates grafana dashboard for network nodes running the given client
+func generateDashboard(network *ethereumv1beta1.Network, client ethereumv1beta1.EthereumClient) (string, error) {
+	var panels []helpers.DashboardPanel
+	// select metrics of network node pods only
+	selector := fmt.Sprintf(`namespace="%s",pod=~"%s-.*"`, network.Namespace, network.Name)
+
+	switch client {
+	case ethereumv1beta1.BesuClient:
+		panels = []helpers.DashboardPanel{
+			{Title: "Block Height", Query: fmt.Sprintf("ethereum_blockchain_height{%s}", selector), Legend: "{{pod}}"},
+			{Title: "Peers", Query: fmt.Sprintf("ethereum_peer_count{%s}", selector), Legend: "{{pod}}"},
+			{Title: "Pending Transactions", Query: fmt.Sprintf("besu_transaction_pool_transactions{%s}", selector), Legend: "{{pod}}"},
+			{Title: "Memory", Query: fmt.Sprintf("jvm_memory_bytes_used{%s,area=\"heap\"}", selector), Legend: "{{pod}}"},
+		}
+	case ethereumv1beta1.GethClient:
+		panels = []helpers.DashboardPanel{
+			{Title: "Block Height", Query: fmt.Sprintf("chain_head_block{%s}", selector), Legend: "{{pod}}"},
+			{Title: "Peers", Query: fmt.Sprintf("p2p_peers{%s}", selector), Legend: "{{pod}}"},
+			{Title: "Pending Transactions", Query: fmt.Sprintf("txpool_pending{%s}", selector), Legend: "{{pod}}"},
+			{Title: "Memory", Query: fmt.Sprintf("system_memory_used{%s}", selector), Legend: "{{pod}}"},
+		}
+	default:
+		return "", fmt.Errorf("Client %s is not supported", client)
+	}
+
+	title := fmt.Sprintf("%s/%s (%s)", network.Namespace, network.Name, client)
+
+	return helpers.GenerateDashboard(title, panels)
+}
@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGenerateDashboard(t *testing.T) {
+	network := &ethereumv1beta1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-network",
+			Namespace: "default",
+		},
+	}
+
+	for _, client := range []ethereumv1beta1.EthereumClient{ethereumv1beta1.BesuClient, ethereumv1beta1.GethClient} {
+		dashboard, err := generateDashboard(network, client)
+		if err != nil {
+			t.Fatalf("Expecting no error generating %s dashboard got %s", client, err)
+		}
+
+		model := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(dashboard), &model); err != nil {
+			t.Errorf("Expecting %s dashboard to be valid json got %s", client, err)
+		}
+
+		if !strings.Contains(dashboard, `pod=~\"my-network-.*\"`) {
+			t.Errorf("Expecting %s dashboard queries to select network pods", client)
+		}
+	}
+
+	if _, err := generateDashboard(network, "parity"); err == nil {
+		t.Errorf("Expecting error generating dashboard for unsupported client")
+	}
+}
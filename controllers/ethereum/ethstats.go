@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	"github.com/kotalco/kotal/helpers"
+)
+
+const (
+	// EthstatsPort is the port ethstats server listens on
+	EthstatsPort = 3000
+	// EthstatsSecretKey is the secret key holding the secret nodes use to report to ethstats server
+	EthstatsSecretKey = "secret"
+	// EnvEthstatsSecret is the environment variable holding ethstats secret
+	// it's expanded by kubernetes in node and ethstats server containers arguments
+	EnvEthstatsSecret = "WS_SECRET"
+)
+
+// ethstatsURL returns the url node reports its stats to, secret is expanded from container environment
+func ethstatsURL(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) string {
+	return fmt.Sprintf("%s:$(%s)@%s:80", node.Name, EnvEthstatsSecret, network.EthstatsName())
+}
+
+// ethstatsSecretEnv returns environment variable holding ethstats secret
+func ethstatsSecretEnv(network *ethereumv1beta1.Network) corev1.EnvVar {
+	return secretEnv(EnvEthstatsSecret, network.EthstatsName(), EthstatsSecretKey)
+}
+
+// reconcileEthstats creates ethstats server and its generated secret if ethstats is specified
+// deletes ethstats resources if ethstats is removed from network spec
+func (r *NetworkReconciler) reconcileEthstats(network *ethereumv1beta1.Network) error {
+	meta := metav1.ObjectMeta{
+		Name:      network.EthstatsName(),
+		Namespace: network.Namespace,
+	}
+
+	secret := &corev1.Secret{ObjectMeta: meta}
+	dep := &appsv1.Deployment{ObjectMeta: meta}
+	svc := &corev1.Service{ObjectMeta: meta}
+	ingress := &networkingv1beta1.Ingress{ObjectMeta: meta}
+
+	if network.Spec.Ethstats == nil {
+		for _, obj := range []runtime.Object{ingress, svc, dep, secret} {
+			if err := r.Client.Delete(context.Background(), obj); err != nil && !apierrors.IsNotFound(err) {
+				r.Log.Error(err, "unable to delete ethstats resource")
+				return err
+			}
+		}
+		return nil
+	}
+
+	mutators := []struct {
+		obj    runtime.Object
+		meta   metav1.Object
+		mutate func() error
+	}{
+		{secret, secret, func() error {
+			return specGeneratedSecret(secret, map[string]int{EthstatsSecretKey: 16})
+		}},
+		{dep, dep, func() error { r.specEthstatsDeployment(dep, network); return nil }},
+		{svc, svc, func() error { specHTTPService(svc, network.EthstatsLabels(), EthstatsPort); return nil }},
+	}
+
+	for _, m := range mutators {
+		m := m
+		if _, err := ctrl.CreateOrUpdate(context.Background(), r.Client, m.obj, func() error {
+			if err := ctrl.SetControllerReference(network, m.meta, r.Scheme); err != nil {
+				return err
+			}
+			return m.mutate()
+		}); err != nil {
+			r.Log.Error(err, "unable to reconcile ethstats resource")
+			return err
+		}
+	}
+
+	// dashboard is updated over web socket connections
+	return r.reconcileIngress(network, network.EthstatsLabels(), network.Spec.Ethstats.Host, network.EthstatsName(), 80, true)
+}
+
+// specEthstatsDeployment updates ethstats server deployment spec
+func (r *NetworkReconciler) specEthstatsDeployment(dep *appsv1.Deployment, network *ethereumv1beta1.Network) {
+	labels := network.EthstatsLabels()
+
+	dep.ObjectMeta.Labels = labels
+	dep.Spec = appsv1.DeploymentSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: labels,
+		},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: labels,
+			},
+			Spec: corev1.PodSpec{
+				ImagePullSecrets: helpers.ImagePullSecrets(),
+				Containers: []corev1.Container{
+					{
+						Name:  "ethstats",
+						Image: EthstatsImage(),
+						Env: []corev1.EnvVar{
+							ethstatsSecretEnv(network),
+						},
+						Ports: []corev1.ContainerPort{
+							{
+								Name:          "http",
+								ContainerPort: EthstatsPort,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,301 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	"github.com/kotalco/kotal/helpers"
+)
+
+const (
+	// ExplorerPort is the port blockscout serves http on
+	ExplorerPort = 4000
+	// ExplorerDatabasePort is the port explorer postgres database listens on
+	ExplorerDatabasePort = 5432
+	// ExplorerDatabase is explorer postgres database name
+	ExplorerDatabase = "blockscout"
+	// ExplorerDatabasePasswordKey is the secret key holding explorer database password
+	ExplorerDatabasePasswordKey = "db-password"
+	// ExplorerDatabaseURLKey is the external database secret key holding explorer database url
+	ExplorerDatabaseURLKey = "database-url"
+	// ExplorerSecretKeyBaseKey is the secret key holding blockscout secret key base
+	ExplorerSecretKeyBaseKey = "secret-key-base"
+	// PathExplorerDatabaseData is explorer postgres database data path
+	PathExplorerDatabaseData = "/var/lib/postgresql/data"
+)
+
+// explorerStartScript creates and migrates blockscout database then starts blockscout
+const explorerStartScript = `bin/blockscout eval "Elixir.Explorer.ReleaseTasks.create_and_migrate()" && bin/blockscout start`
+
+// reconcileExplorer creates blockscout explorer and its postgres database if explorer is specified
+// deletes explorer resources if explorer is removed from network spec
+func (r *NetworkReconciler) reconcileExplorer(network *ethereumv1beta1.Network) error {
+	explorerMeta := metav1.ObjectMeta{
+		Name:      network.ExplorerName(),
+		Namespace: network.Namespace,
+	}
+	dbMeta := metav1.ObjectMeta{
+		Name:      network.ExplorerDatabaseName(),
+		Namespace: network.Namespace,
+	}
+
+	secret := &corev1.Secret{ObjectMeta: explorerMeta}
+	dep := &appsv1.Deployment{ObjectMeta: explorerMeta}
+	svc := &corev1.Service{ObjectMeta: explorerMeta}
+	ingress := &networkingv1beta1.Ingress{ObjectMeta: explorerMeta}
+	dbPVC := &corev1.PersistentVolumeClaim{ObjectMeta: dbMeta}
+	dbDep := &appsv1.Deployment{ObjectMeta: dbMeta}
+	dbSvc := &corev1.Service{ObjectMeta: dbMeta}
+
+	if network.Spec.Explorer == nil {
+		for _, obj := range []runtime.Object{ingress, svc, dep, dbSvc, dbDep, dbPVC, secret} {
+			if err := r.Client.Delete(context.Background(), obj); err != nil && !apierrors.IsNotFound(err) {
+				r.Log.Error(err, "unable to delete explorer resource")
+				return err
+			}
+		}
+		return nil
+	}
+
+	node, err := findNode(network, network.Spec.Explorer.Node)
+	if err != nil {
+		return err
+	}
+
+	// explorer reaches node rpc server through node service
+	if _, err = r.reconcileNodeService(node, network); err != nil {
+		return err
+	}
+	if err = r.reconcileNodeInternalService(node, network); err != nil {
+		return err
+	}
+
+	provider := fmt.Sprintf("http://%s:%d", nodeRPCService(node, network), node.RPCPort)
+
+	type mutator struct {
+		obj    runtime.Object
+		meta   metav1.Object
+		mutate func() error
+	}
+
+	mutators := []mutator{
+		{secret, secret, func() error {
+			return specGeneratedSecret(secret, map[string]int{
+				ExplorerDatabasePasswordKey: 16,
+				ExplorerSecretKeyBaseKey:    64,
+			})
+		}},
+	}
+
+	if network.Spec.Explorer.DatabaseSecretName == "" {
+		mutators = append(mutators,
+			mutator{dbPVC, dbPVC, func() error { r.specExplorerDatabasePVC(dbPVC, network); return nil }},
+			mutator{dbDep, dbDep, func() error { r.specExplorerDatabaseDeployment(dbDep, network); return nil }},
+			mutator{dbSvc, dbSvc, func() error { r.specExplorerDatabaseService(dbSvc, network); return nil }},
+		)
+	} else {
+		// external database is used instead of the provisioned one
+		// database pvc is kept so switching back doesn't lose indexed data
+		for _, obj := range []runtime.Object{dbSvc, dbDep} {
+			if err := r.Client.Delete(context.Background(), obj); err != nil && !apierrors.IsNotFound(err) {
+				r.Log.Error(err, "unable to delete explorer database resource")
+				return err
+			}
+		}
+	}
+
+	mutators = append(mutators,
+		mutator{dep, dep, func() error { r.specExplorerDeployment(dep, network, node, provider); return nil }},
+		mutator{svc, svc, func() error { specHTTPService(svc, network.ExplorerLabels(), ExplorerPort); return nil }},
+	)
+
+	for _, m := range mutators {
+		m := m
+		if _, err := ctrl.CreateOrUpdate(context.Background(), r.Client, m.obj, func() error {
+			if err := ctrl.SetControllerReference(network, m.meta, r.Scheme); err != nil {
+				return err
+			}
+			return m.mutate()
+		}); err != nil {
+			r.Log.Error(err, "unable to reconcile explorer resource")
+			return err
+		}
+	}
+
+	return r.reconcileIngress(network, network.ExplorerLabels(), network.Spec.Explorer.Host, network.ExplorerName(), 80, false)
+}
+
+// specExplorerDatabasePVC updates explorer postgres database pvc spec
+func (r *NetworkReconciler) specExplorerDatabasePVC(pvc *corev1.PersistentVolumeClaim, network *ethereumv1beta1.Network) {
+	pvc.ObjectMeta.Labels = network.ExplorerDatabaseLabels()
+	// pvc spec is immutable after creation except for storage request
+	if pvc.CreationTimestamp.IsZero() {
+		pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{
+			corev1.ReadWriteOnce,
+		}
+		pvc.Spec.StorageClassName = helpers.StorageClass(nil)
+	}
+	pvc.Spec.Resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceStorage: resource.MustParse(network.Spec.Explorer.Storage),
+		},
+	}
+}
+
+// secretEnv returns environment variable loaded from secret key
+func secretEnv(name, secret, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secret,
+				},
+				Key: key,
+			},
+		},
+	}
+}
+
+// specExplorerDatabaseDeployment updates explorer postgres database deployment spec
+func (r *NetworkReconciler) specExplorerDatabaseDeployment(dep *appsv1.Deployment, network *ethereumv1beta1.Network) {
+	labels := network.ExplorerDatabaseLabels()
+
+	dep.ObjectMeta.Labels = labels
+	dep.Spec = appsv1.DeploymentSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: labels,
+		},
+		// database data volume can't be mounted by more than one pod
+		Strategy: appsv1.DeploymentStrategy{
+			Type: appsv1.RecreateDeploymentStrategyType,
+		},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: labels,
+			},
+			Spec: corev1.PodSpec{
+				ImagePullSecrets: helpers.ImagePullSecrets(),
+				Containers: []corev1.Container{
+					{
+						Name:  "postgres",
+						Image: PostgresImage(),
+						Env: []corev1.EnvVar{
+							secretEnv("POSTGRES_PASSWORD", network.ExplorerName(), ExplorerDatabasePasswordKey),
+							{
+								Name:  "POSTGRES_DB",
+								Value: ExplorerDatabase,
+							},
+							{
+								// volume root might contain lost+found directory
+								Name:  "PGDATA",
+								Value: fmt.Sprintf("%s/pgdata", PathExplorerDatabaseData),
+							},
+						},
+						Ports: []corev1.ContainerPort{
+							{
+								Name:          "postgres",
+								ContainerPort: ExplorerDatabasePort,
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{
+								Name:      "data",
+								MountPath: PathExplorerDatabaseData,
+							},
+						},
+					},
+				},
+				Volumes: []corev1.Volume{
+					{
+						Name: "data",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: network.ExplorerDatabaseName(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// specExplorerDatabaseService updates explorer postgres database service spec
+func (r *NetworkReconciler) specExplorerDatabaseService(svc *corev1.Service, network *ethereumv1beta1.Network) {
+	labels := network.ExplorerDatabaseLabels()
+
+	svc.ObjectMeta.Labels = labels
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "postgres",
+			Port:       ExplorerDatabasePort,
+			TargetPort: intstr.FromInt(ExplorerDatabasePort),
+			Protocol:   corev1.ProtocolTCP,
+		},
+	}
+	svc.Spec.Selector = labels
+}
+
+// specExplorerDeployment updates blockscout explorer deployment spec
+func (r *NetworkReconciler) specExplorerDeployment(dep *appsv1.Deployment, network *ethereumv1beta1.Network, node *ethereumv1beta1.Node, provider string) {
+	labels := network.ExplorerLabels()
+	// database password is expanded by kubernetes from the previously defined environment variable
+	databaseURL := corev1.EnvVar{
+		Name:  "DATABASE_URL",
+		Value: fmt.Sprintf("postgresql://postgres:$(DATABASE_PASSWORD)@%s:%d/%s", network.ExplorerDatabaseName(), ExplorerDatabasePort, ExplorerDatabase),
+	}
+	if secret := network.Spec.Explorer.DatabaseSecretName; secret != "" {
+		databaseURL = secretEnv("DATABASE_URL", secret, ExplorerDatabaseURLKey)
+	}
+
+	dep.ObjectMeta.Labels = labels
+	dep.Spec = appsv1.DeploymentSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: labels,
+		},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: labels,
+			},
+			Spec: corev1.PodSpec{
+				ImagePullSecrets: helpers.ImagePullSecrets(),
+				Containers: []corev1.Container{
+					{
+						Name:    "blockscout",
+						Image:   BlockscoutImage(),
+						Command: []string{"/bin/sh", "-c"},
+						Args:    []string{explorerStartScript},
+						Env: []corev1.EnvVar{
+							secretEnv("DATABASE_PASSWORD", network.ExplorerName(), ExplorerDatabasePasswordKey),
+							secretEnv("SECRET_KEY_BASE", network.ExplorerName(), ExplorerSecretKeyBaseKey),
+							databaseURL,
+							{Name: "ETHEREUM_JSONRPC_VARIANT", Value: string(node.Client)},
+							{Name: "ETHEREUM_JSONRPC_HTTP_URL", Value: provider},
+							{Name: "ETHEREUM_JSONRPC_TRACE_URL", Value: provider},
+							{Name: "COIN", Value: network.Spec.Explorer.Coin},
+							{Name: "PORT", Value: fmt.Sprintf("%d", ExplorerPort)},
+						},
+						Ports: []corev1.ContainerPort{
+							{
+								Name:          "http",
+								ContainerPort: ExplorerPort,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
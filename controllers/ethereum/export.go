@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// network export config map keys
+const (
+	// ExportNetworkIDKey is the export key holding network id
+	ExportNetworkIDKey = "network-id"
+	// ExportConsensusKey is the export key holding network consensus algorithm
+	ExportConsensusKey = "consensus"
+	// ExportGenesisKey is the export key holding network genesis specification
+	ExportGenesisKey = "genesis"
+	// ExportBootnodesKey is the export key holding comma separated bootnodes enode urls
+	ExportBootnodesKey = "bootnodes"
+)
+
+// reconcileExport creates or updates config map exporting private network bootstrap information
+// network id, consensus, genesis spec and bootnodes are exported, so the network can be joined from other clusters
+func (r *NetworkReconciler) reconcileExport(network *ethereumv1beta1.Network) error {
+	// only networks created from genesis are exported
+	if network.Spec.Genesis == nil {
+		return nil
+	}
+
+	configmap := &corev1.ConfigMap{}
+	configmap.Name = network.ExportName()
+	configmap.Namespace = network.Namespace
+
+	_, err := ctrl.CreateOrUpdate(context.Background(), r.Client, configmap, func() error {
+		if err := ctrl.SetControllerReference(network, configmap, r.Scheme); err != nil {
+			r.Log.Error(err, "Unable to set controller reference on network export configmap")
+			return err
+		}
+
+		return specExportConfigmap(configmap, network)
+	})
+
+	return err
+}
+
+// specExportConfigmap updates network export config map spec
+func specExportConfigmap(configmap *corev1.ConfigMap, network *ethereumv1beta1.Network) error {
+	// accounts derived from mnemonic are exported, not the mnemonic secret reference
+	spec := network.Spec.Genesis.DeepCopy()
+	spec.AccountsFromMnemonic = nil
+
+	genesis, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	configmap.ObjectMeta.Labels = network.ExportLabels()
+	configmap.Data = map[string]string{
+		ExportNetworkIDKey: fmt.Sprintf("%d", network.Spec.ID),
+		ExportConsensusKey: string(network.Spec.Consensus),
+		ExportGenesisKey:   string(genesis),
+		ExportBootnodesKey: strings.Join(nodeBootnodes(network, network.Status.Bootnodes), ","),
+	}
+
+	return nil
+}
+
+// importNetwork loads exported network bootstrap information into network spec
+// network spec is updated in memory only, it's not persisted
+func (r *NetworkReconciler) importNetwork(network *ethereumv1beta1.Network) error {
+	if network.Spec.JoinFrom == nil {
+		return nil
+	}
+
+	var configmap corev1.ConfigMap
+
+	key := types.NamespacedName{
+		Name:      network.Spec.JoinFrom.ConfigMapName,
+		Namespace: network.Namespace,
+	}
+
+	if err := r.Client.Get(context.Background(), key, &configmap); err != nil {
+		r.Log.Error(err, "unable to get exported network config map")
+		return err
+	}
+
+	return importNetworkFrom(network, &configmap)
+}
+
+// importNetworkFrom loads network id, consensus, genesis and bootnodes from export config map
+func importNetworkFrom(network *ethereumv1beta1.Network, configmap *corev1.ConfigMap) error {
+	for _, key := range []string{ExportNetworkIDKey, ExportConsensusKey, ExportGenesisKey} {
+		if _, ok := configmap.Data[key]; !ok {
+			return fmt.Errorf("config map %s has no key %s", configmap.Name, key)
+		}
+	}
+
+	id, err := strconv.ParseUint(configmap.Data[ExportNetworkIDKey], 10, 0)
+	if err != nil {
+		return fmt.Errorf("config map %s has invalid network id: %w", configmap.Name, err)
+	}
+
+	genesis := &ethereumv1beta1.Genesis{}
+	if err := json.Unmarshal([]byte(configmap.Data[ExportGenesisKey]), genesis); err != nil {
+		return fmt.Errorf("config map %s has invalid genesis: %w", configmap.Name, err)
+	}
+
+	network.Spec.ID = uint(id)
+	network.Spec.Consensus = ethereumv1beta1.ConsensusAlgorithm(configmap.Data[ExportConsensusKey])
+	network.Spec.Genesis = genesis
+
+	for _, enodeURL := range strings.Split(configmap.Data[ExportBootnodesKey], ",") {
+		if enodeURL = strings.TrimSpace(enodeURL); enodeURL != "" {
+			network.Spec.Bootnodes = append(network.Spec.Bootnodes, ethereumv1beta1.Enode(enodeURL))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestImportExportedNetwork(t *testing.T) {
+	exported := &ethereumv1beta1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "exported",
+			Namespace: "default",
+		},
+		Spec: ethereumv1beta1.NetworkSpec{
+			ID:        7777,
+			Consensus: ethereumv1beta1.ProofOfAuthority,
+			Genesis: &ethereumv1beta1.Genesis{
+				ChainID: 7777,
+				Clique: &ethereumv1beta1.Clique{
+					Signers: []ethereumv1beta1.EthereumAddress{"0xd2c21213027cbf4d46c16b55fa98e5252b048706"},
+				},
+			},
+			Bootnodes: []ethereumv1beta1.Enode{"enode://b@bootnode.example.com:30303"},
+			Nodes: []ethereumv1beta1.Node{
+				{Name: "node-1"},
+			},
+		},
+		Status: ethereumv1beta1.NetworkStatus{
+			Bootnodes: []string{"enode://a@10.0.0.1:30303"},
+		},
+	}
+	exported.Default()
+
+	configmap := &corev1.ConfigMap{}
+	if err := specExportConfigmap(configmap, exported); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	network := &ethereumv1beta1.Network{
+		Spec: ethereumv1beta1.NetworkSpec{
+			JoinFrom: &ethereumv1beta1.JoinFrom{
+				ConfigMapName: exported.ExportName(),
+			},
+		},
+	}
+
+	if err := importNetworkFrom(network, configmap); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	if network.Spec.ID != exported.Spec.ID || network.Spec.Consensus != exported.Spec.Consensus {
+		t.Errorf("Expecting network id %d and consensus %s got %d and %s", exported.Spec.ID, exported.Spec.Consensus, network.Spec.ID, network.Spec.Consensus)
+	}
+
+	if !reflect.DeepEqual(network.Spec.Genesis, exported.Spec.Genesis) {
+		t.Errorf("Expecting genesis %+v got %+v", exported.Spec.Genesis, network.Spec.Genesis)
+	}
+
+	expected := []ethereumv1beta1.Enode{"enode://a@10.0.0.1:30303", "enode://b@bootnode.example.com:30303"}
+	if !reflect.DeepEqual(network.Spec.Bootnodes, expected) {
+		t.Errorf("Expecting bootnodes %v got %v", expected, network.Spec.Bootnodes)
+	}
+}
+
+func TestImportMissingExportKey(t *testing.T) {
+	configmap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "exported-export"},
+		Data: map[string]string{
+			ExportNetworkIDKey: "7777",
+		},
+	}
+
+	if err := importNetworkFrom(&ethereumv1beta1.Network{}, configmap); err == nil {
+		t.Error("Expecting error importing network from incomplete export")
+	}
+}
@@ -5,12 +5,12 @@ import (
 	"encoding/hex"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
-	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
 	"strings"
 )
 
 // createExtraDataFromSigners creates extraDta genesis field value from initial signers
-func createExtraDataFromSigners(signers []ethereumv1alpha1.EthereumAddress) string {
+func createExtraDataFromSigners(signers []ethereumv1beta1.EthereumAddress) string {
 	extraData := "0x"
 	// vanity data
 	extraData += strings.Repeat("00", 32)
@@ -25,7 +25,7 @@ func createExtraDataFromSigners(signers []ethereumv1alpha1.EthereumAddress) stri
 }
 
 // createExtraDataFromValidators creates extraDta genesis field value from initial validators
-func createExtraDataFromValidators(validators []ethereumv1alpha1.EthereumAddress) (string, error) {
+func createExtraDataFromValidators(validators []ethereumv1beta1.EthereumAddress) (string, error) {
 	data := []interface{}{}
 	extraData := "0x"
 
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	"github.com/kotalco/kotal/helpers"
+)
+
+const (
+	// FaucetPort is the port faucet serves http on
+	FaucetPort = 8080
+	// FaucetPrivateKeySecretKey is the secret key holding faucet funding account private key
+	FaucetPrivateKeySecretKey = "privatekey"
+	// EnvFaucetPrivateKey is the environment variable used by faucet to load funding account private key
+	EnvFaucetPrivateKey = "PRIVATE_KEY"
+)
+
+// reconcileFaucet creates faucet deployment, service and ingress if faucet is specified
+// deletes faucet resources if faucet is removed from network spec
+func (r *NetworkReconciler) reconcileFaucet(network *ethereumv1beta1.Network) error {
+	meta := metav1.ObjectMeta{
+		Name:      network.FaucetName(),
+		Namespace: network.Namespace,
+	}
+
+	dep := &appsv1.Deployment{ObjectMeta: meta}
+	svc := &corev1.Service{ObjectMeta: meta}
+	ingress := &networkingv1beta1.Ingress{ObjectMeta: meta}
+
+	if network.Spec.Faucet == nil {
+		for _, obj := range []runtime.Object{dep, svc, ingress} {
+			if err := r.Client.Delete(context.Background(), obj); err != nil && !apierrors.IsNotFound(err) {
+				r.Log.Error(err, "unable to delete faucet resource")
+				return err
+			}
+		}
+		return nil
+	}
+
+	node, err := findNode(network, network.Spec.Faucet.Node)
+	if err != nil {
+		return err
+	}
+
+	// faucet reaches node rpc server through node service
+	if _, err = r.reconcileNodeService(node, network); err != nil {
+		return err
+	}
+	if err = r.reconcileNodeInternalService(node, network); err != nil {
+		return err
+	}
+
+	provider := fmt.Sprintf("http://%s:%d", nodeRPCService(node, network), node.RPCPort)
+
+	if _, err = ctrl.CreateOrUpdate(context.Background(), r.Client, dep, func() error {
+		if err := ctrl.SetControllerReference(network, dep, r.Scheme); err != nil {
+			return err
+		}
+		r.specFaucetDeployment(dep, network, provider)
+		return nil
+	}); err != nil {
+		r.Log.Error(err, "unable to reconcile faucet deployment")
+		return err
+	}
+
+	if _, err = ctrl.CreateOrUpdate(context.Background(), r.Client, svc, func() error {
+		if err := ctrl.SetControllerReference(network, svc, r.Scheme); err != nil {
+			return err
+		}
+		specHTTPService(svc, network.FaucetLabels(), FaucetPort)
+		return nil
+	}); err != nil {
+		r.Log.Error(err, "unable to reconcile faucet service")
+		return err
+	}
+
+	return r.reconcileIngress(network, network.FaucetLabels(), network.Spec.Faucet.Host, network.FaucetName(), 80, false)
+}
+
+// specFaucetDeployment updates faucet deployment spec
+func (r *NetworkReconciler) specFaucetDeployment(dep *appsv1.Deployment, network *ethereumv1beta1.Network, provider string) {
+	labels := network.FaucetLabels()
+	faucet := network.Spec.Faucet
+
+	dep.ObjectMeta.Labels = labels
+	dep.Spec = appsv1.DeploymentSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: labels,
+		},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: labels,
+			},
+			Spec: corev1.PodSpec{
+				ImagePullSecrets: helpers.ImagePullSecrets(),
+				Containers: []corev1.Container{
+					{
+						Name:  "faucet",
+						Image: FaucetImage(),
+						Args: []string{
+							FaucetHTTPPort, fmt.Sprintf("%d", FaucetPort),
+							FaucetProvider, provider,
+							FaucetAmount, fmt.Sprintf("%d", faucet.Amount),
+							FaucetInterval, fmt.Sprintf("%d", faucet.Interval),
+						},
+						Env: []corev1.EnvVar{
+							{
+								Name: EnvFaucetPrivateKey,
+								ValueFrom: &corev1.EnvVarSource{
+									SecretKeyRef: &corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: faucet.PrivateKeySecretName,
+										},
+										Key: FaucetPrivateKeySecretKey,
+									},
+								},
+							},
+						},
+						Ports: []corev1.ContainerPort{
+							{
+								Name:          "http",
+								ContainerPort: FaucetPort,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// genesisClient is the http client used to download external genesis
+var genesisClient = &http.Client{
+	Timeout: 10 * time.Second,
+}
+
+// downloadGenesis downloads genesis from url
+func downloadGenesis(url string) (string, error) {
+	resp, err := genesisClient.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s downloading genesis from %s", resp.Status, url)
+	}
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// getExternalGenesis returns genesis loaded from config map or url
+func (r *NetworkReconciler) getExternalGenesis(network *ethereumv1beta1.Network) (string, error) {
+	genesis := network.Spec.Genesis
+
+	if genesis.FromURL != "" {
+		content, err := downloadGenesis(genesis.FromURL)
+		if err != nil {
+			return "", err
+		}
+		return content, verifyGenesisChecksum(content, genesis.SHA256)
+	}
+
+	var configmap corev1.ConfigMap
+
+	key := types.NamespacedName{
+		Name:      genesis.FromConfigMap.Name,
+		Namespace: network.Namespace,
+	}
+
+	if err := r.Client.Get(context.Background(), key, &configmap); err != nil {
+		r.Log.Error(err, "unable to get genesis config map")
+		return "", err
+	}
+
+	content, ok := configmap.Data[genesis.FromConfigMap.Key]
+	if !ok {
+		return "", fmt.Errorf("config map %s has no key %s", key.Name, genesis.FromConfigMap.Key)
+	}
+
+	return content, verifyGenesisChecksum(content, genesis.SHA256)
+}
+
+// verifyGenesisChecksum verifies genesis content matches expected sha256 checksum if provided
+func verifyGenesisChecksum(content, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+
+	sum := sha256.Sum256([]byte(content))
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, checksum) {
+		return fmt.Errorf("genesis sha256 checksum %s doesn't match expected checksum %s", actual, checksum)
+	}
+
+	return nil
+}
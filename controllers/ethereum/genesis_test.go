@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDownloadGenesis(t *testing.T) {
+	expected := `{"config":{"chainId":4444}}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, expected)
+	}))
+	defer server.Close()
+
+	genesis, err := downloadGenesis(server.URL)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if genesis != expected {
+		t.Errorf("Expecting genesis %s got %s", expected, genesis)
+	}
+}
+
+func TestDownloadMissingGenesis(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	if _, err := downloadGenesis(server.URL); err == nil {
+		t.Error("Expecting error downloading missing genesis")
+	}
+}
+
+func TestVerifyGenesisChecksum(t *testing.T) {
+	genesis := `{"config":{"chainId":4444}}`
+	sum := sha256.Sum256([]byte(genesis))
+	checksum := hex.EncodeToString(sum[:])
+
+	if err := verifyGenesisChecksum(genesis, ""); err != nil {
+		t.Errorf("Expecting no error without checksum got %s", err)
+	}
+
+	if err := verifyGenesisChecksum(genesis, strings.ToUpper(checksum)); err != nil {
+		t.Errorf("Expecting no error with matching checksum got %s", err)
+	}
+
+	if err := verifyGenesisChecksum(`{"config":{"chainId":5555}}`, checksum); err == nil {
+		t.Error("Expecting error with mismatching checksum")
+	}
+}
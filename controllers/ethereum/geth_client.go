@@ -5,30 +5,32 @@ import (
 	"fmt"
 	"strings"
 
-	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
 )
 
 // GethClient is Go-Ethereum client
 type GethClient struct{}
 
 // LoggingArgFromVerbosity returns logging argument from node verbosity level
-func (g *GethClient) LoggingArgFromVerbosity(level ethereumv1alpha1.VerbosityLevel) string {
-	levels := map[ethereumv1alpha1.VerbosityLevel]string{
-		ethereumv1alpha1.NoLogs:    "0",
-		ethereumv1alpha1.FatalLogs: "1",
-		ethereumv1alpha1.ErrorLogs: "1",
-		ethereumv1alpha1.WarnLogs:  "2",
-		ethereumv1alpha1.InfoLogs:  "3",
-		ethereumv1alpha1.DebugLogs: "4",
-		ethereumv1alpha1.TraceLogs: "5",
-		ethereumv1alpha1.AllLogs:   "5",
+func (g *GethClient) LoggingArgFromVerbosity(level ethereumv1beta1.VerbosityLevel) string {
+	levels := map[ethereumv1beta1.VerbosityLevel]string{
+		ethereumv1beta1.NoLogs:    "0",
+		ethereumv1beta1.FatalLogs: "1",
+		ethereumv1beta1.ErrorLogs: "1",
+		ethereumv1beta1.WarnLogs:  "2",
+		ethereumv1beta1.InfoLogs:  "3",
+		ethereumv1beta1.DebugLogs: "4",
+		ethereumv1beta1.TraceLogs: "5",
+		ethereumv1beta1.AllLogs:   "5",
 	}
 
 	return levels[level]
 }
 
 // GetArgs returns command line arguments required for client run
-func (g *GethClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alpha1.Network, bootnodes []string) (args []string) {
+func (g *GethClient) GetArgs(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network, bootnodes []string) (args []string) {
 	// appendArg appends argument with optional value to the arguments array
 	appendArg := func(arg ...string) {
 		args = append(args, arg...)
@@ -38,6 +40,14 @@ func (g *GethClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alp
 
 	appendArg(GethLogging, g.LoggingArgFromVerbosity(node.Logging))
 
+	if node.LogFormat == ethereumv1beta1.JSONLogFormat {
+		appendArg(GethLogJSON)
+	}
+
+	if node.AdvertisedAddress != "" {
+		appendArg(GethNat, fmt.Sprintf("extip:%s", node.AdvertisedAddress))
+	}
+
 	if network.Spec.ID != 0 {
 		appendArg(GethNetworkID, fmt.Sprintf("%d", network.Spec.ID))
 	}
@@ -48,7 +58,7 @@ func (g *GethClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alp
 
 	appendArg(GethDataDir, PathBlockchainData)
 
-	if network.Spec.Join != "" && network.Spec.Join != ethereumv1alpha1.MainNetwork {
+	if network.Spec.Join != "" && network.Spec.Join != ethereumv1beta1.MainNetwork {
 		appendArg(fmt.Sprintf("--%s", network.Spec.Join))
 	}
 
@@ -65,6 +75,33 @@ func (g *GethClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alp
 		appendArg(GethSyncMode, string(node.SyncMode))
 	}
 
+	if node.LightServe != 0 {
+		appendArg(GethLightServe, fmt.Sprintf("%d", node.LightServe))
+	}
+
+	// geth sizes default cache from host memory instead of container memory limit
+	if cache := gethCache(node); cache != 0 {
+		appendArg(GethCache, fmt.Sprintf("%d", cache))
+	}
+
+	// development mode uses imported account as pre-funded developer account
+	if network.Spec.Dev != nil {
+		appendArg(GethDev)
+		appendArg(GethDevPeriod, fmt.Sprintf("%d", network.Spec.Dev.Period))
+		// password is already provided if coinbase is unlocked
+		if node.Import != nil && node.Coinbase == "" {
+			appendArg(GethPassword, fmt.Sprintf("%s/account.password", PathSecrets))
+		}
+		if node.Keystore != nil && node.Coinbase == "" {
+			appendArg(GethPassword, fmt.Sprintf("%s/account.password", PathKeystore))
+		}
+	}
+
+	// keystore is loaded from secret instead of imported account
+	if node.Keystore != nil {
+		appendArg(GethKeyStore, fmt.Sprintf("%s/keystore", PathKeystore))
+	}
+
 	if node.Miner {
 		appendArg(GethMinerEnabled)
 	}
@@ -72,7 +109,15 @@ func (g *GethClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alp
 	if node.Coinbase != "" {
 		appendArg(GethMinerCoinbase, string(node.Coinbase))
 		appendArg(GethUnlock, string(node.Coinbase))
-		appendArg(GethPassword, fmt.Sprintf("%s/account.password", PathSecrets))
+		if node.Keystore != nil {
+			appendArg(GethPassword, fmt.Sprintf("%s/account.password", PathKeystore))
+			// geth refuses to unlock accounts if http apis are exposed
+			if node.RPC || node.WS || node.GraphQL {
+				appendArg(GethAllowInsecureUnlock)
+			}
+		} else {
+			appendArg(GethPassword, fmt.Sprintf("%s/account.password", PathSecrets))
+		}
 	}
 
 	if node.RPC {
@@ -88,12 +133,7 @@ func (g *GethClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alp
 	}
 
 	if len(node.RPCAPI) != 0 {
-		apis := []string{}
-		for _, api := range node.RPCAPI {
-			apis = append(apis, string(api))
-		}
-		commaSeperatedAPIs := strings.Join(apis, ",")
-		appendArg(GethRPCHTTPAPI, commaSeperatedAPIs)
+		appendArg(GethRPCHTTPAPI, joinAPIs(node.RPCAPI))
 	}
 
 	if node.WS {
@@ -109,12 +149,7 @@ func (g *GethClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alp
 	}
 
 	if len(node.WSAPI) != 0 {
-		apis := []string{}
-		for _, api := range node.WSAPI {
-			apis = append(apis, string(api))
-		}
-		commaSeperatedAPIs := strings.Join(apis, ",")
-		appendArg(GethRPCWSAPI, commaSeperatedAPIs)
+		appendArg(GethRPCWSAPI, joinAPIs(node.WSAPI))
 	}
 
 	if node.GraphQL {
@@ -130,7 +165,7 @@ func (g *GethClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alp
 	}
 
 	if len(node.Hosts) != 0 {
-		commaSeperatedHosts := strings.Join(node.Hosts, ",")
+		commaSeperatedHosts := joinValues(node.Hosts)
 		if node.RPC {
 			appendArg(GethRPCHostWhitelist, commaSeperatedHosts)
 		}
@@ -140,7 +175,7 @@ func (g *GethClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alp
 	}
 
 	if len(node.CORSDomains) != 0 {
-		commaSeperatedDomains := strings.Join(node.CORSDomains, ",")
+		commaSeperatedDomains := joinValues(node.CORSDomains)
 		if node.RPC {
 			appendArg(GethRPCHTTPCorsOrigins, commaSeperatedDomains)
 		}
@@ -149,11 +184,22 @@ func (g *GethClient) GetArgs(node *ethereumv1alpha1.Node, network *ethereumv1alp
 		}
 	}
 
+	if network.Spec.Ethstats != nil {
+		appendArg(GethEthstats, ethstatsURL(node, network))
+	}
+
+	if node.Metrics {
+		appendArg(GethMetricsEnabled)
+		appendArg(GethPprofEnabled)
+		appendArg(GethPprofHost, node.MetricsHost)
+		appendArg(GethPprofPort, fmt.Sprintf("%d", node.MetricsPort))
+	}
+
 	return args
 }
 
 // GetGenesisFile returns genesis config parameter
-func (g *GethClient) GetGenesisFile(genesis *ethereumv1alpha1.Genesis, consensus ethereumv1alpha1.ConsensusAlgorithm) (content string, err error) {
+func (g *GethClient) GetGenesisFile(genesis *ethereumv1beta1.Genesis, consensus ethereumv1beta1.ConsensusAlgorithm) (content string, err error) {
 	mixHash := genesis.MixHash
 	nonce := genesis.Nonce
 	difficulty := genesis.Difficulty
@@ -163,7 +209,7 @@ func (g *GethClient) GetGenesisFile(genesis *ethereumv1alpha1.Genesis, consensus
 	var extraData string
 	var engine string
 
-	if consensus == ethereumv1alpha1.ProofOfWork {
+	if consensus == ethereumv1beta1.ProofOfWork {
 		consensusConfig = map[string]uint{}
 
 		if genesis.Ethash.FixedDifficulty != nil {
@@ -174,7 +220,7 @@ func (g *GethClient) GetGenesisFile(genesis *ethereumv1alpha1.Genesis, consensus
 	}
 
 	// clique PoA settings
-	if consensus == ethereumv1alpha1.ProofOfAuthority {
+	if consensus == ethereumv1beta1.ProofOfAuthority {
 		consensusConfig = map[string]uint{
 			"period": genesis.Clique.BlockPeriod,
 			"epoch":  genesis.Clique.EpochLength,
@@ -184,7 +230,7 @@ func (g *GethClient) GetGenesisFile(genesis *ethereumv1alpha1.Genesis, consensus
 	}
 
 	// clique ibft2 settings
-	if consensus == ethereumv1alpha1.IstanbulBFT {
+	if consensus == ethereumv1beta1.IstanbulBFT {
 
 		consensusConfig = map[string]uint{
 			"blockperiodseconds":        genesis.IBFT2.BlockPeriod,
@@ -235,7 +281,7 @@ func (g *GethClient) GetGenesisFile(genesis *ethereumv1alpha1.Genesis, consensus
 	result["mixHash"] = mixHash
 	result["extraData"] = extraData
 
-	alloc := map[ethereumv1alpha1.EthereumAddress]interface{}{}
+	alloc := map[ethereumv1beta1.EthereumAddress]interface{}{}
 	for _, account := range genesis.Accounts {
 		m := map[string]interface{}{
 			"balance": account.Balance,
@@ -263,3 +309,18 @@ func (g *GethClient) GetGenesisFile(genesis *ethereumv1alpha1.Genesis, consensus
 
 	return
 }
+
+// gethCache returns node cache in megabytes, defaults to a quarter of node memory limit
+func gethCache(node *ethereumv1beta1.Node) uint {
+	if node.Cache != 0 {
+		return node.Cache
+	}
+	if node.Resources == nil || node.Resources.MemoryLimit == "" {
+		return 0
+	}
+	memoryLimit, err := resource.ParseQuantity(node.Resources.MemoryLimit)
+	if err != nil {
+		return 0
+	}
+	return uint(memoryLimit.Value() / 4 / (1024 * 1024))
+}
@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// integrityCheckRequestedPredicate triggers reconciliation of networks whose on demand integrity check request changed
+// network metadata changes don't change network generation
+var integrityCheckRequestedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.MetaOld.GetAnnotations()[IntegrityCheckAnnotation] != e.MetaNew.GetAnnotations()[IntegrityCheckAnnotation]
+	},
+}
+
+// integrityCheckJobName returns name of node data integrity check job
+func integrityCheckJobName(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) string {
+	return boundedJobName(fmt.Sprintf("%s-integrity-check", node.DeploymentName(network.Name)))
+}
+
+// boundedJobName returns job name short enough for job-name pod label
+// long names are truncated and suffixed with name hash to keep them unique
+func boundedJobName(name string) string {
+	if len(name) <= validation.DNS1123LabelMaxLength {
+		return name
+	}
+	suffix := fmt.Sprintf("%x", sha256.Sum256([]byte(name)))[:8]
+	prefix := strings.TrimRight(name[:validation.DNS1123LabelMaxLength-len(suffix)-1], "-")
+	return fmt.Sprintf("%s-%s", prefix, suffix)
+}
+
+// nodeStatus returns observed node state, nil is returned if node isn't observed yet
+func nodeStatus(network *ethereumv1beta1.Network, name string) *ethereumv1beta1.NodeStatus {
+	for i := range network.Status.Nodes {
+		if network.Status.Nodes[i].Name == name {
+			return &network.Status.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// integrityCheckPending is whether node data integrity check is due or running, node is stopped until it completes
+func integrityCheckPending(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) bool {
+	if node.IntegrityCheck == nil {
+		return false
+	}
+	status := nodeStatus(network, node.Name)
+	return status != nil && status.IntegrityCheck != nil && status.IntegrityCheck.Pending
+}
+
+// integrityCheckArgs returns client specific command and arguments verifying node data
+// geth verifies state snapshot against state trie, besu exports all blocks, failing on unreadable blocks
+// unsupported is why node data can't be checked, it's empty if node client can check its data
+func integrityCheckArgs(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) (command string, args []string, unsupported string) {
+	if node.Client == ethereumv1beta1.BesuClient {
+		command, args = nodeClientCommand(node, network, "blocks", "export", "--start-block=0", "--to=/dev/null")
+		return
+	}
+
+	// snapshot verify-state command was added in geth 1.10.0
+	if clientVersionBefore(node, "1.10.0") {
+		unsupported = fmt.Sprintf("%s image %s doesn't support data integrity check", node.Client, nodeClientImage(node))
+		return
+	}
+
+	command, args = nodeClientCommand(node, network, "snapshot", "verify-state")
+	return
+}
+
+// specIntegrityCheckJob updates node data integrity check job spec
+func specIntegrityCheckJob(job *batchv1.Job, node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) {
+	command, args, _ := integrityCheckArgs(node, network)
+	job.ObjectMeta.Labels = node.ResourceLabels(network.Name, network.Spec.Consensus)
+	specNodeDataJob(job, node, network, "integrity-check", command, args)
+}
+
+// integrityCheckDue returns whether node data integrity check is due and how long until the next scheduled check
+func integrityCheckDue(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network, check *ethereumv1beta1.IntegrityCheckStatus, now time.Time) (due bool, next time.Duration) {
+	if requested := network.Annotations[IntegrityCheckAnnotation]; requested != "" && requested != check.Requested {
+		return true, 0
+	}
+
+	schedule := node.IntegrityCheck.Schedule.Duration
+	if schedule == 0 {
+		return false, 0
+	}
+
+	// first check is scheduled relative to network creation
+	last := network.CreationTimestamp.Time
+	if check.Time != nil {
+		last = check.Time.Time
+	}
+
+	next = last.Add(schedule).Sub(now)
+	if next <= 0 {
+		return true, 0
+	}
+
+	return false, next
+}
+
+// reconcileIntegrityChecks runs due nodes data integrity checks, it's called before nodes are reconciled
+// due check marks node check as pending which stops the node, check job is created once node pods are gone
+// next is how long until the next scheduled check, it's 0 if no check is scheduled
+func (r *NetworkReconciler) reconcileIntegrityChecks(network *ethereumv1beta1.Network) (next time.Duration, err error) {
+	checked := false
+	failed := []string{}
+
+	for i := range network.Spec.Nodes {
+		node := &network.Spec.Nodes[i]
+		status := nodeStatus(network, node.Name)
+
+		// nodes without integrity check policy or not observed yet
+		if node.IntegrityCheck == nil || node.IsDedicatedBootnode() || status == nil {
+			if status != nil {
+				status.IntegrityCheck = nil
+			}
+			continue
+		}
+
+		// nodes whose client can't check their data aren't stopped, and aren't reported as corrupted
+		if _, _, unsupported := integrityCheckArgs(node, network); unsupported != "" {
+			status.IntegrityCheck = &ethereumv1beta1.IntegrityCheckStatus{Message: unsupported}
+			continue
+		}
+
+		checked = true
+		if status.IntegrityCheck == nil {
+			status.IntegrityCheck = &ethereumv1beta1.IntegrityCheckStatus{}
+		}
+		check := status.IntegrityCheck
+
+		if !check.Pending {
+			due, nodeNext := integrityCheckDue(node, network, check, time.Now())
+			if due {
+				check.Pending = true
+				check.Requested = network.Annotations[IntegrityCheckAnnotation]
+				r.Recorder.Eventf(network, corev1.EventTypeNormal, "IntegrityCheckStarted", "node %s is stopped to check its data integrity", node.Name)
+			}
+			next = earliestRequeue(next, nodeNext)
+		} else if err = r.runIntegrityCheck(node, network, check); err != nil {
+			return
+		}
+
+		if check.Time != nil && !check.Passed {
+			failed = append(failed, node.Name)
+		}
+	}
+
+	if !checked {
+		network.Status.RemoveCondition(ethereumv1beta1.IntegrityCheckFailedCondition)
+		return
+	}
+
+	if len(failed) != 0 {
+		network.Status.SetCondition(ethereumv1beta1.IntegrityCheckFailedCondition, corev1.ConditionTrue, "DataCorrupted", fmt.Sprintf("nodes failed data integrity check: %s", strings.Join(failed, ", ")))
+	} else {
+		network.Status.SetCondition(ethereumv1beta1.IntegrityCheckFailedCondition, corev1.ConditionFalse, "IntegrityChecksPassed", "")
+	}
+
+	return
+}
+
+// runIntegrityCheck creates pending node data integrity check job once node pods are gone
+// completed job result is recorded in node check state, and job is deleted so node is started again
+func (r *NetworkReconciler) runIntegrityCheck(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network, check *ethereumv1beta1.IntegrityCheckStatus) error {
+	job := &batchv1.Job{}
+	key := types.NamespacedName{Name: integrityCheckJobName(node, network), Namespace: network.Namespace}
+
+	err := r.Client.Get(context.Background(), key, job)
+	if err != nil && !apierrors.IsNotFound(err) {
+		r.Log.Error(err, "unable to get integrity check job")
+		return err
+	}
+
+	// check job isn't created yet
+	if apierrors.IsNotFound(err) {
+		var pods corev1.PodList
+		if err := r.Client.List(context.Background(), &pods, client.InNamespace(network.Namespace), client.MatchingLabels(node.Labels(network.Name))); err != nil {
+			r.Log.Error(err, "unable to list node pods")
+			return err
+		}
+		// node pods still hold node data
+		if len(pods.Items) != 0 {
+			return nil
+		}
+
+		job = &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+		specIntegrityCheckJob(job, node, network)
+		if err := ctrl.SetControllerReference(network, job, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Client.Create(context.Background(), job); err != nil {
+			r.Log.Error(err, "unable to create integrity check job")
+			return err
+		}
+		return nil
+	}
+
+	completed, passed, message := jobResult(job)
+	if !completed {
+		// check is still running
+		return nil
+	}
+
+	check.Passed = passed
+	if passed {
+		r.Recorder.Eventf(network, corev1.EventTypeNormal, "IntegrityCheckPassed", "node %s data integrity check passed", node.Name)
+	} else {
+		r.Recorder.Eventf(network, corev1.EventTypeWarning, "IntegrityCheckFailed", "node %s data integrity check failed: %s", node.Name, message)
+	}
+
+	now := metav1.Now()
+	check.Time = &now
+	check.Message = message
+	check.Pending = false
+
+	// job pods are deleted with the job, node is started again
+	propagation := metav1.DeletePropagationBackground
+	if err := r.Client.Delete(context.Background(), job, &client.DeleteOptions{PropagationPolicy: &propagation}); err != nil && !apierrors.IsNotFound(err) {
+		r.Log.Error(err, "unable to delete integrity check job")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestIntegrityCheckArgs(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+
+	command, args, unsupported := integrityCheckArgs(node, network)
+	if unsupported != "" || command != "geth" || strings.Join(args, " ") != "--datadir /mnt/data --rinkeby snapshot verify-state" {
+		t.Errorf("Expecting geth snapshot state verification got %s %v (%s)", command, args, unsupported)
+	}
+
+	// geth before 1.10.0 can't verify state
+	os.Setenv(EnvGethImage, "ethereum/client-go:v1.9.20")
+	defer os.Unsetenv(EnvGethImage)
+	if _, _, unsupported = integrityCheckArgs(node, network); unsupported == "" {
+		t.Errorf("Expecting geth 1.9.20 integrity check to be unsupported")
+	}
+
+	network = argsTestNetwork(ethereumv1beta1.BesuClient)
+	node = &network.Spec.Nodes[0]
+
+	command, args, unsupported = integrityCheckArgs(node, network)
+	if unsupported != "" || command != "besu" || strings.Join(args, " ") != "--data-path /mnt/data --network rinkeby blocks export --start-block=0 --to=/dev/null" {
+		t.Errorf("Expecting besu blocks export got %s %v (%s)", command, args, unsupported)
+	}
+}
+
+func TestIntegrityCheckJobName(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+
+	if name := integrityCheckJobName(node, network); name != "my-network-node-1-integrity-check" {
+		t.Errorf("Expecting job name my-network-node-1-integrity-check got %s", name)
+	}
+
+	// longest network and node names validation allows
+	network.Name = strings.Repeat("n", 30)
+	node.Name = strings.Repeat("a", 63-len(node.InternalServiceName(network.Name))+len(node.Name))
+	name := integrityCheckJobName(node, network)
+	if len(name) > 63 || !strings.HasPrefix(name, network.Name+"-") {
+		t.Errorf("Expecting job name to be shortened to 63 characters got %s", name)
+	}
+
+	// other nodes sharing the truncated prefix get different job names
+	other := node.DeepCopy()
+	other.Name = node.Name[:len(node.Name)-1] + "b"
+	if integrityCheckJobName(other, network) == name {
+		t.Errorf("Expecting shortened job names to be unique")
+	}
+}
+
+func TestIntegrityCheckDue(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	node.IntegrityCheck = &ethereumv1beta1.IntegrityCheckPolicy{Schedule: metav1.Duration{Duration: 24 * time.Hour}}
+
+	now := time.Now()
+	last := metav1.NewTime(now.Add(-time.Hour))
+	check := &ethereumv1beta1.IntegrityCheckStatus{Time: &last}
+
+	if due, next := integrityCheckDue(node, network, check, now); due || next != 23*time.Hour {
+		t.Errorf("Expecting next check to be due in 23h got %s", next)
+	}
+
+	network.Annotations = map[string]string{IntegrityCheckAnnotation: "1"}
+	if due, _ := integrityCheckDue(node, network, check, now); !due {
+		t.Errorf("Expecting requested check to be due")
+	}
+
+	check.Requested = "1"
+	if due, _ := integrityCheckDue(node, network, check, now.Add(24*time.Hour)); !due {
+		t.Errorf("Expecting scheduled check to be due")
+	}
+}
+
+func TestReconcileIntegrityChecks(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = ethereumv1beta1.AddToScheme(scheme)
+
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	network.Namespace = "default"
+	network.Annotations = map[string]string{IntegrityCheckAnnotation: "1"}
+	node := &network.Spec.Nodes[0]
+	node.IntegrityCheck = &ethereumv1beta1.IntegrityCheckPolicy{}
+	network.Status.Nodes = []ethereumv1beta1.NodeStatus{{Name: node.Name}}
+
+	recorder := record.NewFakeRecorder(10)
+	r := &NetworkReconciler{
+		Client:   fake.NewFakeClientWithScheme(scheme, network),
+		Log:      ctrl.Log,
+		Scheme:   scheme,
+		Recorder: recorder,
+	}
+
+	// requested check stops the node
+	if _, err := r.reconcileIntegrityChecks(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if !integrityCheckPending(node, network) {
+		t.Fatalf("Expecting node integrity check to be pending")
+	}
+
+	dep := &appsv1.Deployment{}
+	r.specNodeDeployment(dep, node, network, nil, nil, nil, nil)
+	if *dep.Spec.Replicas != 0 {
+		t.Errorf("Expecting node to be stopped while checked got %d replicas", *dep.Spec.Replicas)
+	}
+
+	// node pods are gone, check job is created
+	if _, err := r.reconcileIntegrityChecks(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	job := &batchv1.Job{}
+	key := types.NamespacedName{Name: integrityCheckJobName(node, network), Namespace: "default"}
+	if err := r.Client.Get(context.Background(), key, job); err != nil {
+		t.Fatalf("Expecting integrity check job to be created got %s", err)
+	}
+	if args := job.Spec.Template.Spec.Containers[0].Args; args[len(args)-1] != "verify-state" {
+		t.Errorf("Expecting geth state verification got %v", args)
+	}
+
+	// check job failed
+	job.Status.Failed = 1
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "Job has reached the specified backoff limit"}}
+	if err := r.Client.Status().Update(context.Background(), job); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.reconcileIntegrityChecks(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	check := network.Status.Nodes[0].IntegrityCheck
+	if check.Pending || check.Passed || check.Time == nil || check.Message != "Job has reached the specified backoff limit" {
+		t.Errorf("Expecting failed check result to be recorded got %+v", check)
+	}
+	if condition := network.Status.GetCondition(ethereumv1beta1.IntegrityCheckFailedCondition); condition == nil || condition.Status != corev1.ConditionTrue {
+		t.Errorf("Expecting integrity check failed condition to be true got %v", condition)
+	}
+	if err := r.Client.Get(context.Background(), key, job); !apierrors.IsNotFound(err) {
+		t.Errorf("Expecting completed integrity check job to be deleted got %v", err)
+	}
+
+	events := []string{<-recorder.Events, <-recorder.Events}
+	if !strings.Contains(events[0], "IntegrityCheckStarted") || !strings.Contains(events[1], "IntegrityCheckFailed") {
+		t.Errorf("Expecting integrity check started and failed events got %v", events)
+	}
+
+	// same request isn't checked again
+	if _, err := r.reconcileIntegrityChecks(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if integrityCheckPending(node, network) {
+		t.Errorf("Expecting handled check request not to be checked again")
+	}
+	// node client can't check its data
+	os.Setenv(EnvGethImage, "ethereum/client-go:v1.9.20")
+	defer os.Unsetenv(EnvGethImage)
+	network.Annotations[IntegrityCheckAnnotation] = "2"
+	if _, err := r.reconcileIntegrityChecks(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if integrityCheckPending(node, network) {
+		t.Errorf("Expecting node not to be stopped if its client can't check its data")
+	}
+	if check := network.Status.Nodes[0].IntegrityCheck; check.Time != nil || !strings.Contains(check.Message, "doesn't support") {
+		t.Errorf("Expecting unsupported check to be reported got %+v", check)
+	}
+	if condition := network.Status.GetCondition(ethereumv1beta1.IntegrityCheckFailedCondition); condition != nil {
+		t.Errorf("Expecting no data corruption to be reported got %v", condition)
+	}
+}
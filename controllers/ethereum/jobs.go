@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	"github.com/kotalco/kotal/helpers"
+)
+
+// nodeClientCommand returns node client command and arguments loading node data, followed by client subcommand
+// geth loads public network data using network flag, besu using network or private network genesis file
+func nodeClientCommand(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network, subcommand ...string) (command string, args []string) {
+	if node.Client == ethereumv1beta1.BesuClient {
+		args = []string{BesuDataPath, PathBlockchainData}
+		if network.Spec.Genesis != nil {
+			args = append(args, BesuGenesisFile, fmt.Sprintf("%s/genesis.json", PathConfig))
+		}
+		if network.Spec.Join != "" {
+			args = append(args, BesuNetwork, network.Spec.Join)
+		}
+		return "besu", append(args, subcommand...)
+	}
+
+	args = []string{GethDataDir, PathBlockchainData}
+	if network.Spec.Join != "" && network.Spec.Join != ethereumv1beta1.MainNetwork {
+		args = append(args, fmt.Sprintf("--%s", network.Spec.Join))
+	}
+	return "geth", append(args, subcommand...)
+}
+
+// nodeClientImage returns node client image built for node cpu architecture
+func nodeClientImage(node *ethereumv1beta1.Node) string {
+	if node.Client == ethereumv1beta1.BesuClient {
+		return BesuImage(node.Architecture)
+	}
+	return GethImage(node.Architecture)
+}
+
+// clientVersionBefore is whether node client image version is older than the given version
+// images without version tag like latest are assumed to be recent
+func clientVersionBefore(node *ethereumv1beta1.Node, version string) bool {
+	current, ok := imageVersion(nodeClientImage(node))
+	if !ok {
+		return false
+	}
+	min, _ := imageVersion(":" + version)
+
+	for i := range min {
+		if current[i] != min[i] {
+			return current[i] < min[i]
+		}
+	}
+	return false
+}
+
+// imageVersion returns major, minor and patch versions of image tag, ok is false if tag isn't a version
+// tag suffixes like -alpine are ignored
+func imageVersion(image string) (version [3]int, ok bool) {
+	name := image[strings.LastIndex(image, "/")+1:]
+	i := strings.LastIndex(name, ":")
+	if i == -1 {
+		return
+	}
+
+	tag := strings.TrimPrefix(name[i+1:], "v")
+	if j := strings.IndexAny(tag, "-+"); j != -1 {
+		tag = tag[:j]
+	}
+
+	parts := strings.Split(tag, ".")
+	if len(parts) > len(version) {
+		return
+	}
+	for k, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return version, false
+		}
+		version[k] = n
+	}
+
+	return version, true
+}
+
+// specNodeDataJob updates spec of job running node client command against node data
+// job pod mounts node volumes and runs on the kubernetes node holding node local data
+// it runs once, failures are reported instead of retried
+func specNodeDataJob(job *batchv1.Job, node *ethereumv1beta1.Node, network *ethereumv1beta1.Network, name, command string, args []string) {
+	image := nodeClientImage(node)
+
+	backoffLimit := int32(0)
+
+	job.Spec = batchv1.JobSpec{
+		BackoffLimit: &backoffLimit,
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				Containers: []corev1.Container{
+					{
+						Name:         name,
+						Image:        image,
+						Command:      []string{command},
+						Args:         args,
+						VolumeMounts: createNodeVolumeMounts(node, network),
+					},
+				},
+				Volumes:          createNodeVolumes(node, network),
+				Affinity:         withNodeAffinity(nil, node),
+				ImagePullSecrets: helpers.ImagePullSecrets(),
+			},
+		},
+	}
+}
+
+// jobResult returns whether job completed, whether it succeeded, and failure message
+func jobResult(job *batchv1.Job) (completed, succeeded bool, message string) {
+	switch {
+	case job.Status.Succeeded > 0:
+		return true, true, ""
+	case job.Status.Failed > 0:
+		message = "job failed"
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batchv1.JobFailed && condition.Message != "" {
+				message = condition.Message
+			}
+		}
+		return true, false, message
+	}
+	return false, false, ""
+}
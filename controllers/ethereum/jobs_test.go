@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"os"
+	"testing"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestImageVersion(t *testing.T) {
+	cases := []struct {
+		image   string
+		version [3]int
+		ok      bool
+	}{
+		{"ethereum/client-go:v1.10.8", [3]int{1, 10, 8}, true},
+		{"hyperledger/besu:21.7.4-openjdk-latest", [3]int{21, 7, 4}, true},
+		{"registry.example.com:5000/geth:1.9", [3]int{1, 9, 0}, true},
+		{"registry.example.com:5000/geth", [3]int{}, false},
+		{"ethereum/client-go:latest", [3]int{}, false},
+		{"ethereum/client-go:stable", [3]int{}, false},
+	}
+
+	for _, c := range cases {
+		if version, ok := imageVersion(c.image); ok != c.ok || version != c.version {
+			t.Errorf("Expecting image %s version %v (%t) got %v (%t)", c.image, c.version, c.ok, version, ok)
+		}
+	}
+}
+
+func TestClientVersionBefore(t *testing.T) {
+	node := &ethereumv1beta1.Node{Client: ethereumv1beta1.GethClient}
+	defer os.Unsetenv(EnvGethImage)
+
+	os.Setenv(EnvGethImage, "ethereum/client-go:v1.9.20")
+	if !clientVersionBefore(node, "1.10.0") {
+		t.Errorf("Expecting geth 1.9.20 to be before 1.10.0")
+	}
+
+	os.Setenv(EnvGethImage, "ethereum/client-go:v1.10.0")
+	if clientVersionBefore(node, "1.10.0") {
+		t.Errorf("Expecting geth 1.10.0 not to be before 1.10.0")
+	}
+
+	// images without version tag are assumed to be recent
+	os.Setenv(EnvGethImage, "ethereum/client-go:latest")
+	if clientVersionBefore(node, "1.10.0") {
+		t.Errorf("Expecting geth latest not to be before 1.10.0")
+	}
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// specNodeLocalPV updates node local persistent volume spec
+// volume is pre-bound to node data pvc and pinned to the kubernetes node holding node data directory
+func specNodeLocalPV(pv *corev1.PersistentVolume, node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) {
+	pv.ObjectMeta.Labels = node.ResourceLabels(network.Name, network.Spec.Consensus)
+	pv.Spec = corev1.PersistentVolumeSpec{
+		Capacity: corev1.ResourceList{
+			corev1.ResourceStorage: resource.MustParse(node.Resources.Storage),
+		},
+		AccessModes: []corev1.PersistentVolumeAccessMode{
+			corev1.ReadWriteOnce,
+		},
+		// chain data is kept if network is deleted, persistent volumes can't be owned by namespaced networks
+		PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+		PersistentVolumeSource: corev1.PersistentVolumeSource{
+			Local: &corev1.LocalVolumeSource{
+				Path: node.Local.Path,
+			},
+		},
+		ClaimRef: &corev1.ObjectReference{
+			Kind:      "PersistentVolumeClaim",
+			Namespace: network.Namespace,
+			Name:      node.PVCName(network.Name),
+		},
+		NodeAffinity: &corev1.VolumeNodeAffinity{
+			Required: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{
+								Key:      corev1.LabelHostname,
+								Operator: corev1.NodeSelectorOpIn,
+								Values:   []string{node.Local.NodeName},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// reconcileNodeLocalPV creates node local persistent volume if it doesn't exist
+// volume released by a deleted node data pvc is made available again to the re-created pvc of the same name
+func (r *NetworkReconciler) reconcileNodeLocalPV(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) error {
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: node.LocalPVName(network.Namespace, network.Name),
+		},
+	}
+
+	_, err := ctrl.CreateOrUpdate(context.Background(), r.Client, pv, func() error {
+		if pv.CreationTimestamp.IsZero() {
+			specNodeLocalPV(pv, node, network)
+			return nil
+		}
+		if pv.Status.Phase == corev1.VolumeReleased && pv.Spec.ClaimRef != nil {
+			pv.Spec.ClaimRef.UID = ""
+			pv.Spec.ClaimRef.ResourceVersion = ""
+		}
+		return nil
+	})
+
+	return err
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// localVolumeTestNetwork returns network with local storage node
+func localVolumeTestNetwork() (*ethereumv1beta1.Network, *ethereumv1beta1.Node) {
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	network.Namespace = "default"
+	node := &network.Spec.Nodes[0]
+	node.Storage = ethereumv1beta1.LocalVolumeStorage
+	node.Local = &ethereumv1beta1.LocalStorage{Path: "/mnt/nvme0", NodeName: "worker-1"}
+	return network, node
+}
+
+func TestSpecNodeLocalPV(t *testing.T) {
+	network, node := localVolumeTestNetwork()
+
+	pv := &corev1.PersistentVolume{}
+	specNodeLocalPV(pv, node, network)
+
+	if pv.Spec.Local == nil || pv.Spec.Local.Path != "/mnt/nvme0" {
+		t.Errorf("Expecting local volume of node data directory got %v", pv.Spec.PersistentVolumeSource)
+	}
+	if claim := pv.Spec.ClaimRef; claim == nil || claim.Name != node.PVCName(network.Name) || claim.Namespace != "default" {
+		t.Errorf("Expecting local volume to be pre-bound to node data pvc got %v", claim)
+	}
+	if values := pv.Spec.NodeAffinity.Required.NodeSelectorTerms[0].MatchExpressions[0].Values; values[0] != "worker-1" {
+		t.Errorf("Expecting local volume to be pinned to worker-1 got %v", values)
+	}
+
+	r := &NetworkReconciler{}
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specNodeDataPVC(pvc, node, network)
+
+	if pvc.Spec.VolumeName != node.LocalPVName("default", network.Name) || *pvc.Spec.StorageClassName != "" {
+		t.Errorf("Expecting node data pvc to be bound to local volume got %s", pvc.Spec.VolumeName)
+	}
+}
+
+func TestReconcileNodeLocalPVReleased(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = ethereumv1beta1.AddToScheme(scheme)
+
+	network, node := localVolumeTestNetwork()
+
+	released := &corev1.PersistentVolume{}
+	specNodeLocalPV(released, node, network)
+	released.Name = node.LocalPVName(network.Namespace, network.Name)
+	released.CreationTimestamp = metav1.NewTime(time.Now())
+	released.Spec.ClaimRef.UID = "deleted-pvc-uid"
+	released.Status.Phase = corev1.VolumeReleased
+
+	r := &NetworkReconciler{
+		Client: fake.NewFakeClientWithScheme(scheme, released),
+		Log:    ctrl.Log,
+	}
+
+	if err := r.reconcileNodeLocalPV(node, network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	pv := &corev1.PersistentVolume{}
+	if err := r.Client.Get(context.Background(), types.NamespacedName{Name: released.Name}, pv); err != nil {
+		t.Fatal(err)
+	}
+	if pv.Spec.ClaimRef.UID != "" || pv.Spec.ClaimRef.Name != node.PVCName(network.Name) {
+		t.Errorf("Expecting released local volume to be available to node data pvc got %v", pv.Spec.ClaimRef)
+	}
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	"github.com/kotalco/kotal/helpers"
+)
+
+// loadMnemonicAccounts adds accounts derived from genesis mnemonic to genesis accounts
+// network spec is updated in memory only, it's not persisted
+func (r *NetworkReconciler) loadMnemonicAccounts(network *ethereumv1beta1.Network) error {
+	if network.Spec.Genesis == nil || network.Spec.Genesis.AccountsFromMnemonic == nil {
+		return nil
+	}
+
+	source := network.Spec.Genesis.AccountsFromMnemonic
+
+	var secret corev1.Secret
+
+	key := types.NamespacedName{
+		Name:      source.SecretName,
+		Namespace: network.Namespace,
+	}
+
+	if err := r.Client.Get(context.Background(), key, &secret); err != nil {
+		r.Log.Error(err, "unable to get genesis mnemonic secret")
+		return err
+	}
+
+	mnemonic, ok := secret.Data[source.SecretKey]
+	if !ok {
+		return fmt.Errorf("secret %s has no key %s", source.SecretName, source.SecretKey)
+	}
+
+	return addMnemonicAccounts(network.Spec.Genesis, string(mnemonic))
+}
+
+// addMnemonicAccounts adds accounts derived from mnemonic to genesis accounts
+// accounts already in genesis accounts are kept as is
+func addMnemonicAccounts(genesis *ethereumv1beta1.Genesis, mnemonic string) error {
+	source := genesis.AccountsFromMnemonic
+
+	addresses, err := helpers.DeriveMnemonicAddresses(mnemonic, int(source.Count))
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]bool{}
+	for _, account := range genesis.Accounts {
+		existing[strings.ToLower(string(account.Address))] = true
+	}
+
+	for _, address := range addresses {
+		if existing[strings.ToLower(address)] {
+			continue
+		}
+		genesis.Accounts = append(genesis.Accounts, ethereumv1beta1.Account{
+			Address: ethereumv1beta1.EthereumAddress(address),
+			Balance: source.Balance,
+		})
+	}
+
+	return nil
+}
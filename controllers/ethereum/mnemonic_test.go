@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"testing"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestAddMnemonicAccounts(t *testing.T) {
+	genesis := &ethereumv1beta1.Genesis{
+		Accounts: []ethereumv1beta1.Account{
+			{
+				Address: "0x70997970c51812dc3a010c7d01b50e0d17dc79c8",
+				Balance: "0x1",
+			},
+		},
+		AccountsFromMnemonic: &ethereumv1beta1.MnemonicAccounts{
+			Count:   3,
+			Balance: "0x21e19e0c9bab2400000",
+		},
+	}
+
+	// hardhat default test accounts mnemonic
+	if err := addMnemonicAccounts(genesis, "test test test test test test test test test test test junk"); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	expected := []ethereumv1beta1.Account{
+		{Address: "0x70997970c51812dc3a010c7d01b50e0d17dc79c8", Balance: "0x1"},
+		{Address: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", Balance: "0x21e19e0c9bab2400000"},
+		{Address: "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC", Balance: "0x21e19e0c9bab2400000"},
+	}
+
+	if len(genesis.Accounts) != len(expected) {
+		t.Fatalf("Expecting %d accounts got %v", len(expected), genesis.Accounts)
+	}
+
+	for i := range expected {
+		if genesis.Accounts[i].Address != expected[i].Address || genesis.Accounts[i].Balance != expected[i].Balance {
+			t.Errorf("Expecting account %v got %v", expected[i], genesis.Accounts[i])
+		}
+	}
+}
+
+func TestAddMnemonicAccountsEmptyMnemonic(t *testing.T) {
+	genesis := &ethereumv1beta1.Genesis{
+		AccountsFromMnemonic: &ethereumv1beta1.MnemonicAccounts{Count: 1},
+	}
+
+	if err := addMnemonicAccounts(genesis, " "); err == nil {
+		t.Errorf("Expecting empty mnemonic error got nil")
+	}
+}
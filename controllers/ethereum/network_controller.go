@@ -2,20 +2,43 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/label"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-
-	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
 	"github.com/kotalco/kotal/helpers"
+	"github.com/kotalco/kotal/metrics"
+	"github.com/kotalco/kotal/rpcproxy"
+	"github.com/kotalco/kotal/tracing"
 )
 
 // NetworkReconciler reconciles a Network object
@@ -23,43 +46,447 @@ type NetworkReconciler struct {
 	client.Client
 	Log    logr.Logger
 	Scheme *runtime.Scheme
+	// StatusRefreshInterval is how often nodes sync status and peers are refreshed, zero disables polling nodes
+	StatusRefreshInterval time.Duration
+	// NoPeersThreshold is how long a node can stay without peers before network is considered degraded
+	NoPeersThreshold time.Duration
+	// Recorder records network events
+	Recorder record.EventRecorder
+	// RateLimiter limits how frequently failing networks are requeued, nil uses controller-runtime default
+	RateLimiter ratelimiter.RateLimiter
+	// VolumeStats gets nodes data pvcs disk usage, nil disables nodes data pvcs auto expansion
+	VolumeStats VolumeStats
 }
 
 // +kubebuilder:rbac:groups=ethereum.kotal.io,resources=networks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ethereum.kotal.io,resources=networks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ethereum.kotal.io,resources=nodetasks,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=watch;get;list;create;update;delete
 // +kubebuilder:rbac:groups=core,resources=secrets;services;configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=watch;get;list
+// +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=watch;get;list;create;update
+// +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;update
+// +kubebuilder:rbac:groups=core,resources=nodes/proxy,verbs=get
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=watch;get;list;create;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;create;update;delete
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=list;create;delete
 
 // Reconcile reconciles ethereum networks
 func (r *NetworkReconciler) Reconcile(req ctrl.Request) (result ctrl.Result, err error) {
-	var _ = context.Background()
+	ctx, span := tracing.Start(context.Background(), "Network.Reconcile",
+		label.String("namespace", req.Namespace),
+		label.String("name", req.Name),
+	)
+	defer func() { tracing.End(ctx, span, err) }()
+
+	var network ethereumv1beta1.Network
 
-	var network ethereumv1alpha1.Network
+	defer func(start time.Time) {
+		metrics.ObserveReconcile("network", req.NamespacedName, start, err)
+	}(time.Now())
 
 	// Get desired ethereum network
 	if err = r.Client.Get(context.Background(), req.NamespacedName, &network); err != nil {
-		err = client.IgnoreNotFound(err)
+		if err = client.IgnoreNotFound(err); err == nil {
+			metrics.Forget("network", req.NamespacedName)
+		}
+		return
+	}
+
+	// delete network once its ttl expires
+	ttlRemaining, expired, err := r.reconcileTTL(&network)
+	if err != nil || expired {
+		return
+	}
+
+	// record spec changes before spec is completed by imported network and mnemonic accounts
+	if err = r.recordSpecChange(&network); err != nil {
+		return
+	}
+
+	// load network id, consensus, genesis and bootnodes of exported network
+	if err = r.importNetwork(&network); err != nil {
+		return
+	}
+
+	// copy consensus and genesis of cloned network, and restore nodes data from its snapshots
+	if err = r.cloneNetwork(&network); err != nil {
+		return
+	}
+
+	// create pools nodes, pools scaled through scale subresource aren't expanded by defaulting webhook
+	expandNodePools(&network)
+
+	// fund accounts derived from genesis mnemonic
+	if err = r.loadMnemonicAccounts(&network); err != nil {
+		return
+	}
+
+	// reconcile ethstats server before nodes, nodes report to it using its generated secret
+	if err = r.reconcileEthstats(&network); err != nil {
+		return
+	}
+
+	// record nodes paused by node tasks before nodes are reconciled, so paused nodes are stopped
+	if err = r.reconcilePausedNodes(&network); err != nil {
+		return
+	}
+
+	// start due nodes data integrity checks before nodes are reconciled, so checked nodes are stopped
+	integrityCheckDue, err := r.reconcileIntegrityChecks(&network)
+	if err != nil {
+		return
+	}
+
+	// reconcile network nodes
+	pending, err := r.reconcileNodes(ctx, &network)
+	if err != nil {
+		return
+	}
+
+	// reflect missing bootnodes in network status
+	updateBootnodesCondition(&network, pending)
+
+	// reconcile network rpc service load balancing requests across nodes
+	if err = r.reconcileRPCService(&network); err != nil {
+		return
+	}
+
+	// run nodes console scripts, after nodes so their http-rpc servers are exposed
+	if err = r.reconcileConsoleScripts(&network); err != nil {
 		return
 	}
 
+	// take nodes data volume snapshots, after nodes so data volumes exist
+	snapshotsDue, err := r.reconcileSnapshots(&network)
+	if err != nil {
+		return
+	}
+
+	// export private network bootstrap information, after nodes so bootnodes are known
+	if err = r.reconcileExport(&network); err != nil {
+		return
+	}
+
+	// publish genesis.json, static nodes and bootnodes for external nodes, after nodes so enode urls are known
+	if err = r.reconcileBootstrap(&network); err != nil {
+		return
+	}
+
+	// reflect failing nodes pods in network status
+	if err = r.updateNodesFailures(&network); err != nil {
+		return
+	}
+
+	// poll nodes sync status and peers
+	if r.StatusRefreshInterval != 0 {
+		r.updateNodesStatus(&network)
+		r.updateNodesSyncedCondition(&network)
+		r.updateNodesBackupStatus(&network)
+		if r.VolumeStats != nil {
+			r.expandNodesVolumes(&network)
+		}
+		r.updateDegradedCondition(&network)
+		result.RequeueAfter = r.StatusRefreshInterval
+	}
+
 	// update network status
 	if err = r.updateStatus(&network); err != nil {
 		return
 	}
 
-	// reconcile network nodes
-	if err = r.reconcileNodes(&network); err != nil {
+	// reconcile grafana dashboards
+	if err = r.reconcileDashboards(&network); err != nil {
+		return
+	}
+
+	// reconcile prometheus alerting rules
+	if err = r.reconcileAlerts(&network); err != nil {
+		return
+	}
+
+	// reconcile private network faucet
+	if err = r.reconcileFaucet(&network); err != nil {
+		return
+	}
+
+	// reconcile blockscout block explorer
+	if err = r.reconcileExplorer(&network); err != nil {
 		return
 	}
 
+	// requeue to delete network when its ttl expires
+	result.RequeueAfter = earliestRequeue(result.RequeueAfter, ttlRemaining)
+
+	// requeue to take the next due nodes data volume snapshot
+	result.RequeueAfter = earliestRequeue(result.RequeueAfter, snapshotsDue)
+
+	// requeue to start the next scheduled nodes data integrity check
+	result.RequeueAfter = earliestRequeue(result.RequeueAfter, integrityCheckDue)
+
+	// requeue with backoff until bootnodes services addresses are assigned
+	// requeue after takes precedence over rate limited requeue, so it's reset
+	if pending {
+		result = ctrl.Result{Requeue: true}
+	}
+
 	return
 
 }
 
+// updateMetrics updates operator metrics about managed networks and their nodes
+func (r *NetworkReconciler) updateMetrics() error {
+	var networks ethereumv1beta1.NetworkList
+
+	if err := r.Client.List(context.Background(), &networks); err != nil {
+		return err
+	}
+
+	nodesByClient := map[string]int{
+		string(ethereumv1beta1.BesuClient): 0,
+		string(ethereumv1beta1.GethClient): 0,
+	}
+
+	for i := range networks.Items {
+		network := &networks.Items[i]
+		// pools scaled through scale subresource are expanded by the controller only
+		expandNodePools(network)
+		for _, node := range network.Spec.Nodes {
+			nodesByClient[string(node.Client)]++
+		}
+	}
+
+	metrics.SetResources("network", len(networks.Items), nodesByClient)
+
+	return nil
+}
+
+// getNodePodIP returns ip address of a running pod of the node
+func (r *NetworkReconciler) getNodePodIP(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) (string, error) {
+	var pods corev1.PodList
+
+	matchingLabels := client.MatchingLabels(node.Labels(network.Name))
+	inNamespace := client.InNamespace(network.Namespace)
+
+	if err := r.Client.List(context.Background(), &pods, matchingLabels, inNamespace); err != nil {
+		return "", err
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != "" {
+			return pod.Status.PodIP, nil
+		}
+	}
+
+	return "", fmt.Errorf("node %s has no running pods", node.Name)
+}
+
+// updateNodesStatus updates block height, sync progress and peers of nodes with rpc enabled
+// failing to get node status is not an error, node might be starting or restarting
+func (r *NetworkReconciler) updateNodesStatus(network *ethereumv1beta1.Network) {
+	log := r.Log.WithName("sync status")
+
+	for i := range network.Status.Nodes {
+		status := &network.Status.Nodes[i]
+		node := network.Spec.Nodes[i]
+
+		if !node.RPC {
+			continue
+		}
+
+		ip, err := r.getNodePodIP(&node, network)
+		if err != nil {
+			log.Info(fmt.Sprintf("unable to get node (%s) pod ip: %s", node.Name, err))
+			continue
+		}
+
+		url := fmt.Sprintf("http://%s:%d", ip, node.RPCPort)
+		current, highest, syncing, err := getSyncStatus(url)
+		if err != nil {
+			log.Info(fmt.Sprintf("unable to get node (%s) sync status: %s", node.Name, err))
+			continue
+		}
+
+		status.CurrentBlock = current
+		status.HighestBlock = highest
+		status.Syncing = syncing
+
+		peers, err := getPeerCount(url)
+		if err != nil {
+			log.Info(fmt.Sprintf("unable to get node (%s) peer count: %s", node.Name, err))
+			continue
+		}
+
+		status.Peers = peers
+
+		if peers != 0 {
+			status.NoPeersSince = nil
+		} else if status.NoPeersSince == nil {
+			now := metav1.Now()
+			status.NoPeersSince = &now
+		}
+	}
+}
+
+// updateNodesFailures records failing and ready nodes pods in nodes status, and failing nodes in network conditions
+// an event is recorded whenever a node starts failing or recovers
+func (r *NetworkReconciler) updateNodesFailures(network *ethereumv1beta1.Network) error {
+	failing := []string{}
+
+	for i := range network.Status.Nodes {
+		status := &network.Status.Nodes[i]
+		node := network.Spec.Nodes[i]
+
+		var pods corev1.PodList
+		matchingLabels := client.MatchingLabels(node.Labels(network.Name))
+		inNamespace := client.InNamespace(network.Namespace)
+
+		if err := r.Client.List(context.Background(), &pods, matchingLabels, inNamespace); err != nil {
+			r.Log.Error(err, "unable to list node pods")
+			return err
+		}
+
+		status.Ready = false
+		for _, pod := range pods.Items {
+			if podReady(&pod) {
+				status.Ready = true
+				break
+			}
+		}
+
+		var reason, message string
+		for _, pod := range pods.Items {
+			if reason, message = podFailure(&pod); reason != "" {
+				break
+			}
+		}
+
+		if reason != "" {
+			failing = append(failing, fmt.Sprintf("%s (%s)", node.Name, reason))
+			if reason != status.Failure {
+				r.Recorder.Eventf(network, corev1.EventTypeWarning, reason, "node %s is failing: %s", node.Name, message)
+			}
+		} else if status.Failure != "" {
+			r.Recorder.Eventf(network, corev1.EventTypeNormal, "NodeRecovered", "node %s recovered from %s", node.Name, status.Failure)
+		}
+
+		status.Failure = reason
+	}
+
+	if len(failing) == 0 {
+		network.Status.SetCondition(ethereumv1beta1.NodesFailingCondition, corev1.ConditionFalse, "NodesHealthy", "no failing nodes")
+		return nil
+	}
+
+	msg := fmt.Sprintf("failing nodes: %s", strings.Join(failing, ", "))
+	network.Status.SetCondition(ethereumv1beta1.NodesFailingCondition, corev1.ConditionTrue, "NodesFailing", msg)
+
+	return nil
+}
+
+// updateDegradedCondition sets network degraded condition if any node has been without peers longer than threshold
+// single node private and development networks are never degraded, the node has no one to peer with
+func (r *NetworkReconciler) updateDegradedCondition(network *ethereumv1beta1.Network) {
+	if (network.Spec.Genesis != nil || network.Spec.Dev != nil) && len(network.Spec.Nodes) == 1 {
+		return
+	}
+
+	isolated := []string{}
+
+	for _, status := range network.Status.Nodes {
+		if status.NoPeersSince != nil && time.Since(status.NoPeersSince.Time) > r.NoPeersThreshold {
+			isolated = append(isolated, status.Name)
+		}
+	}
+
+	if len(isolated) == 0 {
+		network.Status.SetCondition(ethereumv1beta1.DegradedCondition, corev1.ConditionFalse, "PeersConnected", "all nodes have peers")
+		return
+	}
+
+	msg := fmt.Sprintf("nodes without peers for more than %s: %s", r.NoPeersThreshold, strings.Join(isolated, ", "))
+	network.Status.SetCondition(ethereumv1beta1.DegradedCondition, corev1.ConditionTrue, "NoPeers", msg)
+}
+
+// updateBootnodesCondition sets network bootnodes missing condition if private network nodes have no bootnodes to discover each other
+// networks created before bootnodes validation, or with bootnodes waiting for their service address have no published bootnodes
+func updateBootnodesCondition(network *ethereumv1beta1.Network, pending bool) {
+	if !network.IsPrivate() || len(network.Spec.Nodes) == 1 {
+		return
+	}
+
+	if len(network.Status.Bootnodes) != 0 || len(network.Spec.Bootnodes) != 0 {
+		network.Status.SetCondition(ethereumv1beta1.BootnodesMissingCondition, corev1.ConditionFalse, "BootnodesPublished", "nodes discover each other through bootnodes")
+		return
+	}
+
+	if pending {
+		network.Status.SetCondition(ethereumv1beta1.BootnodesMissingCondition, corev1.ConditionTrue, "BootnodesPending", "bootnodes are waiting for their service address")
+		return
+	}
+
+	msg := "no bootnode with nodekey, nodes can't discover each other"
+	network.Status.SetCondition(ethereumv1beta1.BootnodesMissingCondition, corev1.ConditionTrue, "NoBootnodes", msg)
+}
+
+// recordSpecChange records changed spec fields in network status history whenever network generation changes
+// history is persisted with the rest of network status
+func (r *NetworkReconciler) recordSpecChange(network *ethereumv1beta1.Network) error {
+	if network.Generation == network.Status.ObservedGeneration {
+		return nil
+	}
+
+	hashes, err := helpers.SpecFieldsHashes(network.Spec)
+	if err != nil {
+		return err
+	}
+
+	changes := []string{"created"}
+	if network.Status.SpecHashes != nil {
+		changes = helpers.ChangedFields(network.Status.SpecHashes, hashes)
+	}
+
+	network.Status.ObservedGeneration = network.Generation
+	network.Status.SpecHashes = hashes
+
+	// generation changes without spec fields changes aren't recorded
+	if len(changes) == 0 {
+		return nil
+	}
+
+	change := ethereumv1beta1.SpecChange{
+		Generation: network.Generation,
+		Time:       metav1.Now(),
+		Manager:    helpers.SpecManager(network),
+		Changes:    changes,
+	}
+
+	history := append([]ethereumv1beta1.SpecChange{change}, network.Status.History...)
+	if len(history) > helpers.SpecHistoryLimit {
+		history = history[:helpers.SpecHistoryLimit]
+	}
+	network.Status.History = history
+
+	r.Recorder.Eventf(network, corev1.EventTypeNormal, "SpecChanged", "generation %d changed by %s: %s", network.Generation, change.Manager, strings.Join(changes, ", "))
+
+	return nil
+}
+
 // updateStatus updates network status
 // TODO: don't update statuse on network deletion
-func (r *NetworkReconciler) updateStatus(network *ethereumv1alpha1.Network) error {
+func (r *NetworkReconciler) updateStatus(network *ethereumv1beta1.Network) error {
 	network.Status.NodesCount = len(network.Spec.Nodes)
+	network.Status.Clients = clientsSummary(network)
+	network.Status.Replicas, network.Status.Selector = poolScaleStatus(network)
+
+	network.Status.ReadyNodes = 0
+	for _, status := range network.Status.Nodes {
+		if status.Ready {
+			network.Status.ReadyNodes++
+		}
+	}
 
 	if err := r.Status().Update(context.Background(), network); err != nil {
 		r.Log.Error(err, "unable to update network status")
@@ -69,68 +496,306 @@ func (r *NetworkReconciler) updateStatus(network *ethereumv1alpha1.Network) erro
 	return nil
 }
 
+// clientsSummary returns network nodes clients and their nodes count sorted by client, like besu:2,geth:1
+func clientsSummary(network *ethereumv1beta1.Network) string {
+	counts := map[string]int{}
+	for _, node := range network.Spec.Nodes {
+		counts[string(node.Client)]++
+	}
+
+	clients := make([]string, 0, len(counts))
+	for client := range counts {
+		clients = append(clients, client)
+	}
+	sort.Strings(clients)
+
+	for i, client := range clients {
+		clients[i] = fmt.Sprintf("%s:%d", client, counts[client])
+	}
+
+	return strings.Join(clients, ",")
+}
+
 // reconcileNodes creates or updates nodes according to nodes spec
 // deletes nodes missing from nodes spec
-func (r *NetworkReconciler) reconcileNodes(network *ethereumv1alpha1.Network) error {
+// published bootnodes and nodes enode URLs are recorded in network status
+// pending is whether any bootnode is waiting for its service address, so its enode url isn't published yet
+func (r *NetworkReconciler) reconcileNodes(ctx context.Context, network *ethereumv1beta1.Network) (pending bool, err error) {
+	ctx, span := tracing.Start(ctx, "reconcileNodes")
+	defer func() { tracing.End(ctx, span, err) }()
+
 	bootnodes := []string{}
+	nodes := []ethereumv1beta1.NodeStatus{}
+	// previously observed nodes state
+	observed := map[string]ethereumv1beta1.NodeStatus{}
+
+	for _, status := range network.Status.Nodes {
+		observed[status.Name] = status
+	}
+
+	// client config maps are shared by nodes, reconcile them once
+	if err = tracing.Trace(ctx, "reconcileConfigmaps", func() error {
+		return r.reconcileConfigmaps(network)
+	}); err != nil {
+		return
+	}
 
 	for _, node := range network.Spec.Nodes {
 
-		bootnode, err := r.reconcileNode(&node, network, bootnodes)
+		enodeURL, err := r.reconcileNode(ctx, &node, network, nodeBootnodes(network, bootnodes))
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		if node.IsBootnode() {
-			bootnodes = append(bootnodes, bootnode)
+			if enodeURL == "" {
+				pending = true
+			} else {
+				bootnodes = append(bootnodes, enodeURL)
+			}
 		}
 
-	}
+		status := observed[node.Name]
+		status.Name = node.Name
+		status.Bootnode = node.IsBootnode()
+		status.EnodeURL = enodeURL
+
+		nodes = append(nodes, status)
 
-	if err := r.deleteRedundantNodes(network); err != nil {
-		return err
 	}
 
-	return nil
+	network.Status.Bootnodes = bootnodes
+	network.Status.Nodes = nodes
+
+	err = tracing.Trace(ctx, "deleteRedundantNodes", func() error {
+		return r.deleteRedundantNodes(network)
+	})
+
+	return
 }
 
-// specNodeConfigmap updates genesis configmap spec
-func (r *NetworkReconciler) specNodeConfigmap(configmap *corev1.ConfigMap, genesis, initGenesisScript, importAccountScript string) {
-	configmap.Data = make(map[string]string)
-	configmap.Data["genesis.json"] = genesis
-	configmap.Data["init-genesis.sh"] = initGenesisScript
-	configmap.Data["import-account.sh"] = importAccountScript
+// specDashboardsConfigmap updates grafana dashboards configmap spec
+func (r *NetworkReconciler) specDashboardsConfigmap(configmap *corev1.ConfigMap, network *ethereumv1beta1.Network, dashboards map[string]string) {
+	configmap.ObjectMeta.Labels = map[string]string{
+		"network":             network.Name,
+		GrafanaDashboardLabel: "1",
+	}
+	configmap.Data = dashboards
 }
 
-// reconcileNodeConfigmap creates genesis config map if it doesn't exist or update it
-func (r *NetworkReconciler) reconcileNodeConfigmap(node *ethereumv1alpha1.Node, network *ethereumv1alpha1.Network) error {
+// reconcileDashboards creates grafana dashboards configmap for network clients if monitoring dashboards are enabled
+// deletes the dashboards configmap if dashboards are disabled
+func (r *NetworkReconciler) reconcileDashboards(network *ethereumv1beta1.Network) error {
 
 	configmap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      node.ConfigmapName(network.Name, node.Client),
+			Name:      network.DashboardsConfigmapName(),
 			Namespace: network.Namespace,
 		},
 	}
 
-	var genesis, initGenesisScript, importAccountScript string
+	if network.Spec.Monitoring == nil || !network.Spec.Monitoring.Dashboards {
+		if err := r.Client.Delete(context.Background(), configmap); err != nil && !apierrors.IsNotFound(err) {
+			r.Log.Error(err, "unable to delete grafana dashboards configmap")
+			return err
+		}
+		return nil
+	}
+
+	dashboards := map[string]string{}
+
+	// dashboard for every client used by network nodes
+	for _, node := range network.Spec.Nodes {
+		key := fmt.Sprintf("%s.json", node.Client)
+		if _, exists := dashboards[key]; exists {
+			continue
+		}
+		dashboard, err := generateDashboard(network, node.Client)
+		if err != nil {
+			return err
+		}
+		dashboards[key] = dashboard
+	}
+
+	_, err := ctrl.CreateOrUpdate(context.Background(), r.Client, configmap, func() error {
+		if err := ctrl.SetControllerReference(network, configmap, r.Scheme); err != nil {
+			r.Log.Error(err, "Unable to set controller reference on grafana dashboards configmap")
+			return err
+		}
+
+		r.specDashboardsConfigmap(configmap, network, dashboards)
 
-	// no genesis or init scripts are required for besu clients in public networks
-	if network.Spec.Genesis == nil && node.Client == ethereumv1alpha1.BesuClient {
 		return nil
+	})
+
+	return err
+}
+
+// reconcileAlerts creates prometheus operator alerting rules if monitoring alerts are enabled
+// deletes the alerting rules if alerts are disabled
+func (r *NetworkReconciler) reconcileAlerts(network *ethereumv1beta1.Network) error {
+	rule := &unstructured.Unstructured{}
+	rule.SetGroupVersionKind(PrometheusRuleGVK)
+	rule.SetName(network.AlertsName())
+	rule.SetNamespace(network.Namespace)
+
+	if network.Spec.Monitoring == nil || !network.Spec.Monitoring.Alerts {
+		err := r.Client.Delete(context.Background(), rule)
+		// prometheus operator custom resources are not installed
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			r.Log.Error(err, "unable to delete prometheus alerting rules")
+			return err
+		}
+		return nil
+	}
+
+	rules := generateAlertRules(network)
+
+	_, err := ctrl.CreateOrUpdate(context.Background(), r.Client, rule, func() error {
+		if err := ctrl.SetControllerReference(network, rule, r.Scheme); err != nil {
+			return err
+		}
+
+		rule.SetLabels(map[string]string{
+			"network": network.Name,
+		})
+
+		groups := []interface{}{
+			map[string]interface{}{
+				"name":  network.AlertsName(),
+				"rules": rules,
+			},
+		}
+
+		return unstructured.SetNestedSlice(rule.Object, groups, "spec", "groups")
+	})
+
+	if meta.IsNoMatchError(err) {
+		r.Log.Error(err, "prometheus operator must be installed to create alerting rules")
 	}
 
+	return err
+}
+
+// specClientConfigmap updates client genesis configmap spec
+func (r *NetworkReconciler) specClientConfigmap(configmap *corev1.ConfigMap, network *ethereumv1beta1.Network, client ethereumv1beta1.EthereumClient, genesis, initGenesisScript, importAccountScript, log4jConfig string) {
+	configmap.ObjectMeta.Labels = network.ConfigmapLabels(client)
+	configmap.Data = make(map[string]string)
+	configmap.Data["genesis.json"] = genesis
+	configmap.Data["init-genesis.sh"] = initGenesisScript
+	configmap.Data["import-account.sh"] = importAccountScript
+	if log4jConfig != "" {
+		configmap.Data["log4j2.xml"] = log4jConfig
+	}
+}
+
+// withConfigmap returns whether node requires client config map to be created and mounted
+// config map contains genesis, init scripts and logging configuration
+func withConfigmap(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) bool {
+	// dedicated bootnodes don't use genesis or client configuration
+	if node.IsDedicatedBootnode() {
+		return false
+	}
 	// private network with custom genesis
 	if network.Spec.Genesis != nil {
-		client, err := NewEthereumClient(node.Client)
-		if err != nil {
+		return true
+	}
+	// geth only, import account script
+	if node.Import != nil {
+		return true
+	}
+	// besu only, json logging configuration
+	return node.Client == ethereumv1beta1.BesuClient && node.LogFormat == ethereumv1beta1.JSONLogFormat
+}
+
+// reconcileConfigmaps creates or updates a single config map for every client
+// that has at least one node requiring it, and deletes config maps of clients no longer in use
+func (r *NetworkReconciler) reconcileConfigmaps(network *ethereumv1beta1.Network) error {
+	// clients requiring config map, in order of first appearance
+	clients := []ethereumv1beta1.EthereumClient{}
+	required := map[ethereumv1beta1.EthereumClient]bool{}
+
+	for i := range network.Spec.Nodes {
+		node := &network.Spec.Nodes[i]
+		if required[node.Client] || !withConfigmap(node, network) {
+			continue
+		}
+		required[node.Client] = true
+		clients = append(clients, node.Client)
+	}
+
+	for _, client := range clients {
+		if err := r.reconcileClientConfigmap(client, network); err != nil {
 			return err
 		}
-		// create client specific genesis configuration
-		if genesis, err = client.GetGenesisFile(network.Spec.Genesis, network.Spec.Consensus); err != nil {
+	}
+
+	var configmaps corev1.ConfigMapList
+	matchingLabels := client.MatchingLabels{"name": "genesis", "network": network.Name}
+	inNamespace := client.InNamespace(network.Namespace)
+
+	if err := r.Client.List(context.Background(), &configmaps, matchingLabels, inNamespace); err != nil {
+		r.Log.Error(err, "unable to list all client configmaps")
+		return err
+	}
+
+	for i := range configmaps.Items {
+		configmap := &configmaps.Items[i]
+		if required[ethereumv1beta1.EthereumClient(configmap.Labels["client"])] {
+			continue
+		}
+
+		r.Log.Info(fmt.Sprintf("deleting client configmap (%s)", configmap.Name))
+
+		if err := r.Client.Delete(context.Background(), configmap); err != nil && !apierrors.IsNotFound(err) {
+			r.Log.Error(err, fmt.Sprintf("unable to delete client configmap (%s)", configmap.Name))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// clientGenesis returns private network genesis.json used by client nodes
+func (r *NetworkReconciler) clientGenesis(ethereumClient ethereumv1beta1.EthereumClient, network *ethereumv1beta1.Network) (string, error) {
+	// use externally maintained genesis verbatim
+	if network.Spec.Genesis.IsExternal() {
+		return r.getExternalGenesis(network)
+	}
+
+	client, err := NewEthereumClient(ethereumClient)
+	if err != nil {
+		return "", err
+	}
+
+	// create client specific genesis configuration
+	return client.GetGenesisFile(network.Spec.Genesis, network.Spec.Consensus)
+}
+
+// reconcileClientConfigmap creates client genesis config map if it doesn't exist or update it
+// config map is shared by all network nodes running the same client
+func (r *NetworkReconciler) reconcileClientConfigmap(ethereumClient ethereumv1beta1.EthereumClient, network *ethereumv1beta1.Network) error {
+
+	configmap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      network.ConfigmapName(ethereumClient),
+			Namespace: network.Namespace,
+		},
+	}
+
+	var genesis, initGenesisScript, importAccountScript, log4jConfig string
+	var err error
+
+	// private network with custom genesis
+	if network.Spec.Genesis != nil {
+		if genesis, err = r.clientGenesis(ethereumClient, network); err != nil {
 			return err
 		}
 		// create init genesis script if client is geth
-		if node.Client == ethereumv1alpha1.GethClient {
+		if ethereumClient == ethereumv1beta1.GethClient {
 			initGenesisScript, err = generateInitGenesisScript()
 			if err != nil {
 				return err
@@ -140,21 +805,27 @@ func (r *NetworkReconciler) reconcileNodeConfigmap(node *ethereumv1alpha1.Node,
 
 	// geth only
 	// create import account script
-	if node.Import != nil {
-		var err error
+	// it's not node specific, because config map is shared by all geth nodes
+	if ethereumClient == ethereumv1beta1.GethClient {
 		importAccountScript, err = generateImportAccountScript()
 		if err != nil {
 			return err
 		}
 	}
 
-	_, err := ctrl.CreateOrUpdate(context.Background(), r.Client, configmap, func() error {
+	// besu only
+	// json logging configuration
+	if ethereumClient == ethereumv1beta1.BesuClient {
+		log4jConfig = besuLog4jConfig
+	}
+
+	_, err = ctrl.CreateOrUpdate(context.Background(), r.Client, configmap, func() error {
 		if err := ctrl.SetControllerReference(network, configmap, r.Scheme); err != nil {
 			r.Log.Error(err, "Unable to set controller reference on genesis configmap")
 			return err
 		}
 
-		r.specNodeConfigmap(configmap, genesis, initGenesisScript, importAccountScript)
+		r.specClientConfigmap(configmap, network, ethereumClient, genesis, initGenesisScript, importAccountScript, log4jConfig)
 
 		return nil
 	})
@@ -166,13 +837,15 @@ func (r *NetworkReconciler) reconcileNodeConfigmap(node *ethereumv1alpha1.Node,
 // network is the owner of the redundant resources (node deployment, svc, secret and pvc)
 // removing nodes from spec won't remove these resources by grabage collection
 // that's why we're deleting them manually
-func (r *NetworkReconciler) deleteRedundantNodes(network *ethereumv1alpha1.Network) error {
+func (r *NetworkReconciler) deleteRedundantNodes(network *ethereumv1beta1.Network) error {
 	log := r.Log.WithName("delete redundant nodes")
 
 	var deps appsv1.DeploymentList
 	var pvcs corev1.PersistentVolumeClaimList
 	var secrets corev1.SecretList
 	var services corev1.ServiceList
+	var configmaps corev1.ConfigMapList
+	var ingresses networkingv1beta1.IngressList
 
 	nodes := network.Spec.Nodes
 	names := map[string]bool{}
@@ -185,6 +858,8 @@ func (r *NetworkReconciler) deleteRedundantNodes(network *ethereumv1alpha1.Netwo
 	for _, node := range nodes {
 		depName := node.DeploymentName(network.Name)
 		names[depName] = true
+		names[node.ConfigName(network.Name)] = true
+		names[node.InternalServiceName(network.Name)] = true
 	}
 
 	// Node deployments
@@ -259,12 +934,48 @@ func (r *NetworkReconciler) deleteRedundantNodes(network *ethereumv1alpha1.Netwo
 		}
 	}
 
+	// Node ConfigMaps
+	if err := r.Client.List(context.Background(), &configmaps, matchingLabels, inNamespace); err != nil {
+		log.Error(err, "unable to list all node configmaps")
+		return err
+	}
+
+	for _, configmap := range configmaps.Items {
+		name := configmap.GetName()
+		if exist := names[name]; !exist {
+			log.Info(fmt.Sprintf("deleting node (%s) configmap", name))
+
+			if err := r.Client.Delete(context.Background(), &configmap); err != nil {
+				log.Error(err, fmt.Sprintf("unable to delete node (%s) configmap", name))
+				return err
+			}
+		}
+	}
+
+	// Node Ingresses
+	if err := r.Client.List(context.Background(), &ingresses, matchingLabels, inNamespace); err != nil {
+		log.Error(err, "unable to list all node ingresses")
+		return err
+	}
+
+	for _, ingress := range ingresses.Items {
+		name := ingress.GetName()
+		if exist := names[name]; !exist {
+			log.Info(fmt.Sprintf("deleting node (%s) ingress", name))
+
+			if err := r.Client.Delete(context.Background(), &ingress); err != nil {
+				log.Error(err, fmt.Sprintf("unable to delete node (%s) ingress", name))
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
 // specNodeDataPVC update node data pvc spec
-func (r *NetworkReconciler) specNodeDataPVC(pvc *corev1.PersistentVolumeClaim, node *ethereumv1alpha1.Node, network *ethereumv1alpha1.Network) {
-	pvc.ObjectMeta.Labels = node.Labels(network.Name)
+func (r *NetworkReconciler) specNodeDataPVC(pvc *corev1.PersistentVolumeClaim, node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) {
+	pvc.ObjectMeta.Labels = node.ResourceLabels(network.Name, network.Spec.Consensus)
 	pvc.Spec = corev1.PersistentVolumeClaimSpec{
 		AccessModes: []corev1.PersistentVolumeAccessMode{
 			corev1.ReadWriteOnce,
@@ -274,12 +985,29 @@ func (r *NetworkReconciler) specNodeDataPVC(pvc *corev1.PersistentVolumeClaim, n
 				corev1.ResourceStorage: resource.MustParse(node.Resources.Storage),
 			},
 		},
-		StorageClassName: node.Resources.StorageClass,
+		StorageClassName: helpers.StorageClass(node.Resources.StorageClass),
+	}
+
+	// node data pvc is bound to node local volume, empty storage class disables dynamic provisioning
+	if node.WithLocalVolume() {
+		noClass := ""
+		pvc.Spec.StorageClassName = &noClass
+		pvc.Spec.VolumeName = node.LocalPVName(network.Namespace, network.Name)
+	}
+
+	// node data is cloned from volume snapshot, like a snapshot of an already synced node
+	if name := node.RestoreSnapshotName(); name != "" {
+		group := VolumeSnapshotGVK.Group
+		pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+			APIGroup: &group,
+			Kind:     VolumeSnapshotGVK.Kind,
+			Name:     name,
+		}
 	}
 }
 
 // reconcileNodeDataPVC creates node data pvc if it doesn't exist
-func (r *NetworkReconciler) reconcileNodeDataPVC(node *ethereumv1alpha1.Node, network *ethereumv1alpha1.Network) error {
+func (r *NetworkReconciler) reconcileNodeDataPVC(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) error {
 
 	pvc := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
@@ -302,7 +1030,7 @@ func (r *NetworkReconciler) reconcileNodeDataPVC(node *ethereumv1alpha1.Node, ne
 }
 
 // createNodeVolumes creates all the required volumes for the node
-func (r *NetworkReconciler) createNodeVolumes(node *ethereumv1alpha1.Node, network *ethereumv1alpha1.Network) []corev1.Volume {
+func createNodeVolumes(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) []corev1.Volume {
 
 	volumes := []corev1.Volume{}
 
@@ -318,13 +1046,35 @@ func (r *NetworkReconciler) createNodeVolumes(node *ethereumv1alpha1.Node, netwo
 		volumes = append(volumes, nodekeyVolume)
 	}
 
-	if network.Spec.Genesis != nil {
+	if node.Keystore != nil {
+		keystoreVolume := corev1.Volume{
+			Name: "keystore",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: node.Keystore.SecretName,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  node.Keystore.KeystoreKey,
+							Path: "keystore/account.json",
+						},
+						{
+							Key:  node.Keystore.PasswordKey,
+							Path: "account.password",
+						},
+					},
+				},
+			},
+		}
+		volumes = append(volumes, keystoreVolume)
+	}
+
+	if withConfigmap(node, network) {
 		genesisVolume := corev1.Volume{
 			Name: "config",
 			VolumeSource: corev1.VolumeSource{
 				ConfigMap: &corev1.ConfigMapVolumeSource{
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: node.ConfigmapName(network.Name, node.Client),
+						Name: network.ConfigmapName(node.Client),
 					},
 				},
 			},
@@ -332,6 +1082,39 @@ func (r *NetworkReconciler) createNodeVolumes(node *ethereumv1alpha1.Node, netwo
 		volumes = append(volumes, genesisVolume)
 	}
 
+	for _, script := range node.InitScripts {
+		scriptVolume := corev1.Volume{
+			Name: initScriptVolumeName(script),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: script.ConfigMapName,
+					},
+				},
+			},
+		}
+		volumes = append(volumes, scriptVolume)
+	}
+
+	// dedicated bootnodes don't keep chain data
+	if node.IsDedicatedBootnode() {
+		return volumes
+	}
+
+	if node.Client == ethereumv1beta1.BesuClient {
+		configVolume := corev1.Volume{
+			Name: "node-config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: node.ConfigName(network.Name),
+					},
+				},
+			},
+		}
+		volumes = append(volumes, configVolume)
+	}
+
 	dataVolume := corev1.Volume{
 		Name: "data",
 		VolumeSource: corev1.VolumeSource{
@@ -340,13 +1123,35 @@ func (r *NetworkReconciler) createNodeVolumes(node *ethereumv1alpha1.Node, netwo
 			},
 		},
 	}
+
+	// ephemeral storage is limited to node storage size
+	if node.WithEphemeralStorage() {
+		size := resource.MustParse(node.Resources.Storage)
+		emptyDir := &corev1.EmptyDirVolumeSource{SizeLimit: &size}
+		if node.StorageMemory {
+			emptyDir.Medium = corev1.StorageMediumMemory
+		}
+		dataVolume.VolumeSource = corev1.VolumeSource{EmptyDir: emptyDir}
+	}
+
+	// hostPath storage node pod is pinned to the kubernetes node holding its data directory
+	if node.WithHostPathStorage() {
+		hostPathType := corev1.HostPathDirectoryOrCreate
+		dataVolume.VolumeSource = corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: node.Local.Path,
+				Type: &hostPathType,
+			},
+		}
+	}
+
 	volumes = append(volumes, dataVolume)
 
 	return volumes
 }
 
 // createNodeVolumeMounts creates all required volume mounts for the node
-func (r *NetworkReconciler) createNodeVolumeMounts(node *ethereumv1alpha1.Node, network *ethereumv1alpha1.Network) []corev1.VolumeMount {
+func createNodeVolumeMounts(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) []corev1.VolumeMount {
 
 	volumeMounts := []corev1.VolumeMount{}
 
@@ -359,7 +1164,16 @@ func (r *NetworkReconciler) createNodeVolumeMounts(node *ethereumv1alpha1.Node,
 		volumeMounts = append(volumeMounts, nodekeyMount)
 	}
 
-	if network.Spec.Genesis != nil {
+	if node.Keystore != nil {
+		keystoreMount := corev1.VolumeMount{
+			Name:      "keystore",
+			MountPath: PathKeystore,
+			ReadOnly:  true,
+		}
+		volumeMounts = append(volumeMounts, keystoreMount)
+	}
+
+	if withConfigmap(node, network) {
 		genesisMount := corev1.VolumeMount{
 			Name:      "config",
 			MountPath: PathConfig,
@@ -368,17 +1182,143 @@ func (r *NetworkReconciler) createNodeVolumeMounts(node *ethereumv1alpha1.Node,
 		volumeMounts = append(volumeMounts, genesisMount)
 	}
 
-	dataMount := corev1.VolumeMount{
-		Name:      "data",
-		MountPath: PathBlockchainData,
+	if node.IsDedicatedBootnode() {
+		return volumeMounts
+	}
+
+	if node.Client == ethereumv1beta1.BesuClient {
+		configMount := corev1.VolumeMount{
+			Name:      "node-config",
+			MountPath: PathNodeConfig,
+			ReadOnly:  true,
+		}
+		volumeMounts = append(volumeMounts, configMount)
+	}
+
+	dataMount := corev1.VolumeMount{
+		Name:      "data",
+		MountPath: PathBlockchainData,
+	}
+	volumeMounts = append(volumeMounts, dataMount)
+
+	return volumeMounts
+}
+
+// initScriptVolumeName returns name of the volume holding init script config map
+func initScriptVolumeName(script ethereumv1beta1.InitScript) string {
+	return fmt.Sprintf("init-script-%s", script.Name)
+}
+
+// nodeInitScripts returns init containers running node init scripts
+// init scripts mount node volumes, and use node client image if they don't specify image
+func nodeInitScripts(node *ethereumv1beta1.Node, image string, volumeMounts []corev1.VolumeMount) []corev1.Container {
+	containers := []corev1.Container{}
+
+	for _, script := range node.InitScripts {
+		path := fmt.Sprintf("%s/%s", PathInitScripts, script.Name)
+
+		container := corev1.Container{
+			Name:    script.Name,
+			Image:   script.Image,
+			Command: []string{"/bin/sh"},
+			Args:    []string{fmt.Sprintf("%s/%s", path, script.Key)},
+			VolumeMounts: append(append([]corev1.VolumeMount{}, volumeMounts...), corev1.VolumeMount{
+				Name:      initScriptVolumeName(script),
+				MountPath: path,
+				ReadOnly:  true,
+			}),
+		}
+
+		if container.Image == "" {
+			container.Image = image
+		}
+
+		containers = append(containers, container)
+	}
+
+	return containers
+}
+
+// nodeSidecars returns node sidecar containers
+// node data volume is mounted read-only in sidecars if requested
+func nodeSidecars(node *ethereumv1beta1.Node) []corev1.Container {
+	sidecars := []corev1.Container{}
+
+	for i := range node.Sidecars {
+		sidecar := *node.Sidecars[i].DeepCopy()
+		// dedicated bootnodes don't keep chain data
+		if node.SidecarsMountData && !node.IsDedicatedBootnode() {
+			sidecar.VolumeMounts = append(sidecar.VolumeMounts, corev1.VolumeMount{
+				Name:      "data",
+				MountPath: PathBlockchainData,
+				ReadOnly:  true,
+			})
+		}
+		sidecars = append(sidecars, sidecar)
+	}
+
+	return sidecars
+}
+
+// nodeRPCProxy returns rpc proxy sidecar container forwarding allowed requests to node http-rpc server
+func nodeRPCProxy(node *ethereumv1beta1.Node) corev1.Container {
+	proxy := node.RPCProxy
+
+	args := []string{
+		rpcproxy.Command,
+		RPCProxyListen, fmt.Sprintf(":%d", proxy.Port),
+		RPCProxyUpstream, fmt.Sprintf("http://127.0.0.1:%d", node.RPCPort),
+		RPCProxyMaxBatchSize, fmt.Sprintf("%d", proxy.MaxBatchSize),
+	}
+
+	if len(proxy.AllowedMethods) != 0 {
+		args = append(args, RPCProxyAllowedMethods, strings.Join(proxy.AllowedMethods, ","))
 	}
-	volumeMounts = append(volumeMounts, dataMount)
 
-	return volumeMounts
+	if proxy.RateLimit != 0 {
+		args = append(args,
+			RPCProxyRateLimit, fmt.Sprintf("%d", proxy.RateLimit),
+			RPCProxyRateLimitBurst, fmt.Sprintf("%d", proxy.RateLimitBurst),
+		)
+	}
+
+	return corev1.Container{
+		Name:    "rpc-proxy",
+		Image:   RPCProxyImage(node.Architecture),
+		Command: []string{"/manager"},
+		Args:    args,
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          PublicRPCPortName,
+				ContainerPort: int32(proxy.Port),
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+	}
+}
+
+// nodeMetricsExporter returns chain metrics exporter sidecar container polling node http-rpc server
+func nodeMetricsExporter(node *ethereumv1beta1.Node) corev1.Container {
+	return corev1.Container{
+		Name:  "metrics-exporter",
+		Image: MetricsExporterImage(node.Architecture),
+		Args: []string{
+			MetricsExporterExecutionURL, fmt.Sprintf("http://127.0.0.1:%d", node.RPCPort),
+			MetricsExporterExecutionModules, joinAPIs(node.RPCAPI),
+			MetricsExporterPort, fmt.Sprintf("%d", node.MetricsExporter.Port),
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "exporter",
+				ContainerPort: int32(node.MetricsExporter.Port),
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+	}
 }
 
 // getNodeAffinity returns affinity settings to be use by the node pod
-func (r *NetworkReconciler) getNodeAffinity(network *ethereumv1alpha1.Network) *corev1.Affinity {
+func (r *NetworkReconciler) getNodeAffinity(network *ethereumv1beta1.Network) *corev1.Affinity {
 	if network.Spec.HighlyAvailable {
 		return &corev1.Affinity{
 			PodAntiAffinity: &corev1.PodAntiAffinity{
@@ -399,8 +1339,50 @@ func (r *NetworkReconciler) getNodeAffinity(network *ethereumv1alpha1.Network) *
 	return nil
 }
 
+// withNodeAffinity returns node pod affinity requiring kubernetes nodes of node architecture
+// and the kubernetes node holding node data directory if node uses hostPath or local storage
+// network affinity is shared by all nodes, so it's copied instead of modified
+func withNodeAffinity(affinity *corev1.Affinity, node *ethereumv1beta1.Node) *corev1.Affinity {
+	requirements := []corev1.NodeSelectorRequirement{}
+
+	if node.Architecture != "" {
+		requirements = append(requirements, corev1.NodeSelectorRequirement{
+			Key:      corev1.LabelArchStable,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{string(node.Architecture)},
+		})
+	}
+
+	if node.WithLocalStorage() && node.Local != nil {
+		requirements = append(requirements, corev1.NodeSelectorRequirement{
+			Key:      corev1.LabelHostname,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{node.Local.NodeName},
+		})
+	}
+
+	if len(requirements) == 0 {
+		return affinity
+	}
+
+	withNode := &corev1.Affinity{}
+	if affinity != nil {
+		withNode = affinity.DeepCopy()
+	}
+
+	withNode.NodeAffinity = &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: requirements},
+			},
+		},
+	}
+
+	return withNode
+}
+
 // specNodeDeployment updates node deployment spec
-func (r *NetworkReconciler) specNodeDeployment(dep *appsv1.Deployment, node *ethereumv1alpha1.Node, network *ethereumv1alpha1.Network, args []string, volumes []corev1.Volume, volumeMounts []corev1.VolumeMount, affinity *corev1.Affinity) {
+func (r *NetworkReconciler) specNodeDeployment(dep *appsv1.Deployment, node *ethereumv1beta1.Node, network *ethereumv1beta1.Network, args []string, volumes []corev1.Volume, volumeMounts []corev1.VolumeMount, affinity *corev1.Affinity) {
 	labels := node.Labels(network.Name)
 	// used by geth to init genesis and import account(s)
 	initContainers := []corev1.Container{}
@@ -421,11 +1403,32 @@ func (r *NetworkReconciler) specNodeDeployment(dep *appsv1.Deployment, node *eth
 		VolumeMounts: volumeMounts,
 	}
 
-	if node.Client == ethereumv1alpha1.GethClient {
+	// annotations used by prometheus to discover and scrape node metrics
+	var annotations map[string]string
+	if node.Metrics {
+		annotations = map[string]string{
+			"prometheus.io/scrape": "true",
+			"prometheus.io/port":   fmt.Sprintf("%d", node.MetricsPort),
+		}
+	}
+
+	// nodes without native metrics are scraped through metrics exporter
+	if !node.Metrics && node.RPC && node.MetricsExporter != nil {
+		annotations = map[string]string{
+			"prometheus.io/scrape": "true",
+			"prometheus.io/port":   fmt.Sprintf("%d", node.MetricsExporter.Port),
+			"prometheus.io/path":   MetricsExporterPath,
+		}
+	}
+
+	if node.IsDedicatedBootnode() {
+		nodeContainer.Image = BootnodeImage(node.Architecture)
+		nodeContainer.Command = []string{"bootnode"}
+	} else if node.Client == ethereumv1beta1.GethClient {
 		if network.Spec.Genesis != nil {
 			initGenesis := corev1.Container{
 				Name:         "init-genesis",
-				Image:        GethImage(),
+				Image:        GethImage(node.Architecture),
 				Command:      []string{"/bin/sh"},
 				Args:         []string{fmt.Sprintf("%s/init-genesis.sh", PathConfig)},
 				VolumeMounts: volumeMounts,
@@ -435,7 +1438,7 @@ func (r *NetworkReconciler) specNodeDeployment(dep *appsv1.Deployment, node *eth
 		if node.Import != nil {
 			importAccount := corev1.Container{
 				Name:         "import-account",
-				Image:        GethImage(),
+				Image:        GethImage(node.Architecture),
 				Command:      []string{"/bin/sh"},
 				Args:         []string{fmt.Sprintf("%s/import-account.sh", PathConfig)},
 				VolumeMounts: volumeMounts,
@@ -443,30 +1446,120 @@ func (r *NetworkReconciler) specNodeDeployment(dep *appsv1.Deployment, node *eth
 			initContainers = append(initContainers, importAccount)
 		}
 
-		nodeContainer.Image = GethImage()
+		nodeContainer.Image = GethImage(node.Architecture)
 		nodeContainer.Command = []string{"geth"}
 
-	} else if node.Client == ethereumv1alpha1.BesuClient {
-		nodeContainer.Image = BesuImage()
+		// ethstats secret is expanded in --ethstats argument
+		if network.Spec.Ethstats != nil {
+			nodeContainer.Env = append(nodeContainer.Env, ethstatsSecretEnv(network))
+		}
+
+		if node.Metrics {
+			annotations["prometheus.io/path"] = GethMetricsPath
+		}
+
+	} else if node.Client == ethereumv1beta1.BesuClient {
+		nodeContainer.Image = BesuImage(node.Architecture)
 		nodeContainer.Command = []string{"besu"}
+
+		if node.LogFormat == ethereumv1beta1.JSONLogFormat {
+			nodeContainer.Env = []corev1.EnvVar{
+				{
+					Name:  EnvBesuLog4jConfig,
+					Value: fmt.Sprintf("%s/log4j2.xml", PathConfig),
+				},
+			}
+		}
+
+		// default metrics and GraphQL host allowlists include node pod ip
+		if node.Metrics || node.GraphQL {
+			nodeContainer.Env = append(nodeContainer.Env, corev1.EnvVar{
+				Name: EnvPodIP,
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"},
+				},
+			})
+		}
+
+		if node.Metrics {
+			annotations["prometheus.io/path"] = BesuMetricsPath
+		}
+	}
+
+	nodeContainer.Env = append(nodeContainer.Env, node.Env...)
+
+	// user init scripts run before generated init containers, so they can prepare node data
+	initContainers = append(nodeInitScripts(node, nodeContainer.Image, volumeMounts), initContainers...)
+
+	// pods have node client, consensus and roles labels, deployment selector is immutable
+	podLabels := node.ResourceLabels(network.Name, network.Spec.Consensus)
+
+	var readinessGates []corev1.PodReadinessGate
+
+	// network rpc service forwards requests to node client or rpc proxy public rpc port
+	// pods are ready once node is synced, if nodes sync status is refreshed
+	if inRPCService(node, network) {
+		podLabels[RPCServiceLabel] = "true"
+		if node.RPCProxy == nil {
+			nodeContainer.Ports = append(nodeContainer.Ports, corev1.ContainerPort{
+				Name:          PublicRPCPortName,
+				ContainerPort: int32(node.RPCPort),
+				Protocol:      corev1.ProtocolTCP,
+			})
+		}
+		if r.StatusRefreshInterval != 0 {
+			readinessGates = []corev1.PodReadinessGate{{ConditionType: SyncedCondition}}
+		}
+	}
+
+	containers := []corev1.Container{nodeContainer}
+	if node.RPC && node.RPCProxy != nil {
+		containers = append(containers, nodeRPCProxy(node))
+	}
+	if node.RPC && node.MetricsExporter != nil {
+		containers = append(containers, nodeMetricsExporter(node))
 	}
+	// dedicated bootnodes don't keep chain data
+	if node.Backup != nil && !node.IsDedicatedBootnode() {
+		containers = append(containers, nodeBackup(node, network))
+	}
+	containers = append(containers, nodeSidecars(node)...)
 
-	dep.ObjectMeta.Labels = labels
+	dep.ObjectMeta.Labels = node.ResourceLabels(network.Name, network.Spec.Consensus)
 	if dep.Spec.Selector == nil {
 		dep.Spec.Selector = &metav1.LabelSelector{}
 	}
 	dep.Spec.Selector.MatchLabels = labels
-	dep.Spec.Template.ObjectMeta.Labels = labels
+	// old pod releases node data directory before new pod starts, both pods run on the same kubernetes node
+	if node.WithLocalStorage() {
+		dep.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+	}
+	// node is stopped while its data integrity is checked or a node task pausing it runs
+	replicas := int32(1)
+	if integrityCheckPending(node, network) || nodePaused(node, network) {
+		replicas = 0
+	}
+	dep.Spec.Replicas = &replicas
+	dep.Spec.Template.ObjectMeta.Labels = podLabels
+	dep.Spec.Template.ObjectMeta.Annotations = annotations
 	dep.Spec.Template.Spec = corev1.PodSpec{
-		Volumes:        volumes,
-		InitContainers: initContainers,
-		Containers:     []corev1.Container{nodeContainer},
-		Affinity:       affinity,
+		Volumes:          volumes,
+		InitContainers:   initContainers,
+		Containers:       containers,
+		ReadinessGates:   readinessGates,
+		Affinity:         withNodeAffinity(affinity, node),
+		ImagePullSecrets: helpers.ImagePullSecrets(),
+	}
+
+	// backup sidecar pauses node process while archiving its data
+	if node.Backup != nil && node.Backup.PauseNode {
+		share := true
+		dep.Spec.Template.Spec.ShareProcessNamespace = &share
 	}
 }
 
 // reconcileNodeDeployment creates creates node deployment if it doesn't exist, update it if it does exist
-func (r *NetworkReconciler) reconcileNodeDeployment(node *ethereumv1alpha1.Node, network *ethereumv1alpha1.Network, bootnodes []string) error {
+func (r *NetworkReconciler) reconcileNodeDeployment(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network, bootnodes []string) error {
 
 	dep := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -480,39 +1573,176 @@ func (r *NetworkReconciler) reconcileNodeDeployment(node *ethereumv1alpha1.Node,
 		return err
 	}
 	args := client.GetArgs(node, network, bootnodes)
-	volumes := r.createNodeVolumes(node, network)
-	mounts := r.createNodeVolumeMounts(node, network)
+
+	// dedicated bootnodes run discovery only bootnode tool
+	if node.IsDedicatedBootnode() {
+		args = []string{
+			BootnodeNodekey, fmt.Sprintf("%s/nodekey", PathSecrets),
+			BootnodeAddr, fmt.Sprintf(":%d", node.P2PPort),
+		}
+	}
+
+	// besu reads arguments from toml configuration file
+	// except arguments referencing container environment variables, which are expanded on the command line only
+	var config string
+	if node.Client == ethereumv1beta1.BesuClient && !node.IsDedicatedBootnode() {
+		configArgs, commandArgs := splitBesuArgs(args)
+		if config, err = generateBesuConfig(configArgs); err != nil {
+			return err
+		}
+		args = append([]string{BesuConfigFile, fmt.Sprintf("%s/config.toml", PathNodeConfig)}, commandArgs...)
+	}
+
+	if err = r.reconcileNodeConfig(node, network, config); err != nil {
+		return err
+	}
+
+	// extra arguments are appended last, so they can override generated ones
+	args = append(args, node.ExtraArgs...)
+	volumes := createNodeVolumes(node, network)
+	mounts := createNodeVolumeMounts(node, network)
 	affinity := r.getNodeAffinity(network)
 
+	checksums, err := r.nodeChecksums(node, network)
+	if err != nil {
+		return err
+	}
+
 	_, err = ctrl.CreateOrUpdate(context.Background(), r.Client, dep, func() error {
 		if err := ctrl.SetControllerReference(network, dep, r.Scheme); err != nil {
 			return err
 		}
 		r.specNodeDeployment(dep, node, network, args, volumes, mounts, affinity)
+		// configmap changes don't restart pods, configuration checksum does
+		if config != "" {
+			if dep.Spec.Template.ObjectMeta.Annotations == nil {
+				dep.Spec.Template.ObjectMeta.Annotations = map[string]string{}
+			}
+			dep.Spec.Template.ObjectMeta.Annotations[ConfigChecksumAnnotation] = fmt.Sprintf("%x", sha256.Sum256([]byte(config)))
+		}
+		// mounted genesis config map and secret changes don't restart pods either
+		for annotation, checksum := range checksums {
+			if dep.Spec.Template.ObjectMeta.Annotations == nil {
+				dep.Spec.Template.ObjectMeta.Annotations = map[string]string{}
+			}
+			dep.Spec.Template.ObjectMeta.Annotations[annotation] = checksum
+		}
+		return nil
+	})
+
+	return err
+}
+
+// nodeChecksums returns pod annotations holding checksums of node mounted client config map and secret
+func (r *NetworkReconciler) nodeChecksums(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) (map[string]string, error) {
+	checksums := map[string]string{}
+
+	if withConfigmap(node, network) {
+		var configmap corev1.ConfigMap
+		key := types.NamespacedName{
+			Name:      network.ConfigmapName(node.Client),
+			Namespace: network.Namespace,
+		}
+		if err := r.Client.Get(context.Background(), key, &configmap); err != nil {
+			r.Log.Error(err, "unable to get client configmap")
+			return nil, err
+		}
+		checksums[GenesisChecksumAnnotation] = dataChecksum(configmap.Data)
+	}
+
+	if node.WithNodekey() || node.Import != nil {
+		// node secret is generated from node spec
+		secret := &corev1.Secret{}
+		r.specNodeSecret(secret, node, network)
+		data := map[string]string{}
+		for k, v := range secret.Data {
+			data[k] = string(v)
+		}
+		checksums[SecretChecksumAnnotation] = dataChecksum(data)
+	}
+
+	return checksums, nil
+}
+
+// dataChecksum returns sha256 checksum of config map or secret data, keys are sorted
+func dataChecksum(data map[string]string) string {
+	keys := []string{}
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(hash, "%s=%s\n", k, data[k])
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+// specNodeConfig updates node configuration file configmap spec
+func (r *NetworkReconciler) specNodeConfig(configmap *corev1.ConfigMap, node *ethereumv1beta1.Node, network *ethereumv1beta1.Network, config string) {
+	configmap.ObjectMeta.Labels = node.ResourceLabels(network.Name, network.Spec.Consensus)
+	configmap.Data = map[string]string{
+		"config.toml": config,
+	}
+}
+
+// reconcileNodeConfig creates or updates node configuration file configmap
+// deletes the configmap if node client doesn't use configuration file
+func (r *NetworkReconciler) reconcileNodeConfig(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network, config string) error {
+	configmap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      node.ConfigName(network.Name),
+			Namespace: network.Namespace,
+		},
+	}
+
+	if config == "" {
+		if err := r.Client.Delete(context.Background(), configmap); err != nil && !apierrors.IsNotFound(err) {
+			r.Log.Error(err, "unable to delete node config configmap")
+			return err
+		}
+		return nil
+	}
+
+	_, err := ctrl.CreateOrUpdate(context.Background(), r.Client, configmap, func() error {
+		if err := ctrl.SetControllerReference(network, configmap, r.Scheme); err != nil {
+			return err
+		}
+		r.specNodeConfig(configmap, node, network, config)
 		return nil
 	})
 
 	return err
 }
 
-func (r *NetworkReconciler) specNodeSecret(secret *corev1.Secret, node *ethereumv1alpha1.Node, network *ethereumv1alpha1.Network) {
-	secret.ObjectMeta.Labels = node.Labels(network.Name)
-	data := map[string]string{}
+// specNodeSecret updates node secret spec
+// data is used instead of string data, which is write-only and never read back from the api server,
+// so secret is updated only if its contents actually changed
+func (r *NetworkReconciler) specNodeSecret(secret *corev1.Secret, node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) {
+	secret.ObjectMeta.Labels = node.ResourceLabels(network.Name, network.Spec.Consensus)
+	data := map[string][]byte{}
 
 	if node.WithNodekey() {
-		data["nodekey"] = string(node.Nodekey)[2:]
+		data["nodekey"] = []byte(string(node.Nodekey)[2:])
 	}
 
 	if node.Import != nil {
-		data["account.key"] = string(node.Import.PrivateKey)[2:]
-		data["account.password"] = node.Import.Password
+		data["account.key"] = []byte(string(node.Import.PrivateKey)[2:])
+		data["account.password"] = []byte(node.Import.Password)
+		// keystore file names end with lowercase account address, used to import rotated accounts
+		// private key has been validated by the webhook
+		if address, err := helpers.DeriveAddress(string(node.Import.PrivateKey)[2:]); err == nil {
+			data["account.address"] = []byte(strings.ToLower(address[2:]))
+		}
 	}
 
-	secret.StringData = data
+	secret.Data = data
 }
 
 // reconcileNodeSecret creates node secret if it doesn't exist, update it if it exists
-func (r *NetworkReconciler) reconcileNodeSecret(node *ethereumv1alpha1.Node, network *ethereumv1alpha1.Network) (publicKey string, err error) {
+func (r *NetworkReconciler) reconcileNodeSecret(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) (publicKey string, err error) {
 
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -548,9 +1778,16 @@ func (r *NetworkReconciler) reconcileNodeSecret(node *ethereumv1alpha1.Node, net
 }
 
 // specNodeService updates node service spec
-func (r *NetworkReconciler) specNodeService(svc *corev1.Service, node *ethereumv1alpha1.Node, network *ethereumv1alpha1.Network) {
+func (r *NetworkReconciler) specNodeService(svc *corev1.Service, node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) {
 	labels := node.Labels(network.Name)
-	svc.ObjectMeta.Labels = labels
+	svc.ObjectMeta.Labels = node.ResourceLabels(network.Name, network.Spec.Consensus)
+
+	// node ports allocated by the api server, by service port name
+	nodePorts := map[string]int32{}
+	for _, port := range svc.Spec.Ports {
+		nodePorts[port.Name] = port.NodePort
+	}
+
 	svc.Spec.Ports = []corev1.ServicePort{
 		{
 			Name:       "discovery",
@@ -566,11 +1803,213 @@ func (r *NetworkReconciler) specNodeService(svc *corev1.Service, node *ethereumv
 		},
 	}
 
+	// sensitive apis never share node public service, they're exposed through node internal service
+	if node.RPC && !node.WithSensitiveRPC() {
+		// rpc proxy sidecar is put in front of node http-rpc server
+		targetPort := node.RPCPort
+		if node.RPCProxy != nil {
+			targetPort = node.RPCProxy.Port
+		}
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       "rpc",
+			Port:       int32(node.RPCPort),
+			TargetPort: intstr.FromInt(int(targetPort)),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+
+	if node.WS && node.WSIngressHost != "" {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       "ws",
+			Port:       int32(node.WSPort),
+			TargetPort: intstr.FromInt(int(node.WSPort)),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	svc.Spec.LoadBalancerIP = ""
+	svc.Spec.LoadBalancerSourceRanges = nil
+
+	if node.Service != nil {
+		if node.Service.Type != "" {
+			svc.Spec.Type = corev1.ServiceType(node.Service.Type)
+		}
+		if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			svc.Spec.LoadBalancerIP = node.Service.LoadBalancerIP
+			svc.Spec.LoadBalancerSourceRanges = node.Service.LoadBalancerSourceRanges
+		}
+	}
+
+	// keep allocated node ports, otherwise they're reallocated on every update
+	if svc.Spec.Type != corev1.ServiceTypeClusterIP {
+		for i := range svc.Spec.Ports {
+			svc.Spec.Ports[i].NodePort = nodePorts[svc.Spec.Ports[i].Name]
+		}
+	}
+
+	// ip family is immutable, cluster default family is kept if not set
+	if node.IPFamily != "" {
+		family := corev1.IPFamily(node.IPFamily)
+		svc.Spec.IPFamily = &family
+	}
+
+	specServiceAnnotations(svc, node.ServiceAnnotations)
+
+	// external-dns publishes node dns name, other annotations are kept
+	if node.DNSName != "" {
+		if svc.ObjectMeta.Annotations == nil {
+			svc.ObjectMeta.Annotations = map[string]string{}
+		}
+		svc.ObjectMeta.Annotations[ExternalDNSHostnameAnnotation] = node.DNSName
+	} else {
+		delete(svc.ObjectMeta.Annotations, ExternalDNSHostnameAnnotation)
+	}
+
+	svc.Spec.Selector = labels
+}
+
+// specServiceAnnotations sets service annotations from node spec
+// annotations previously applied from node spec and no longer specified are removed
+func specServiceAnnotations(svc *corev1.Service, annotations map[string]string) {
+	if applied := svc.ObjectMeta.Annotations[ServiceAnnotationsAnnotation]; applied != "" {
+		for _, key := range strings.Split(applied, ",") {
+			delete(svc.ObjectMeta.Annotations, key)
+		}
+	}
+	delete(svc.ObjectMeta.Annotations, ServiceAnnotationsAnnotation)
+
+	if len(annotations) == 0 {
+		return
+	}
+
+	if svc.ObjectMeta.Annotations == nil {
+		svc.ObjectMeta.Annotations = map[string]string{}
+	}
+
+	keys := []string{}
+	for key, value := range annotations {
+		svc.ObjectMeta.Annotations[key] = value
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	svc.ObjectMeta.Annotations[ServiceAnnotationsAnnotation] = strings.Join(keys, ",")
+}
+
+// specNodeInternalService updates node internal service spec
+// internal service is always cluster ip service, so sensitive apis are never exposed outside the cluster
+func specNodeInternalService(svc *corev1.Service, node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) {
+	labels := node.Labels(network.Name)
+	svc.ObjectMeta.Labels = node.ResourceLabels(network.Name, network.Spec.Consensus)
+
+	svc.Spec.Ports = []corev1.ServicePort{}
+
+	if node.WithSensitiveRPC() {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       "rpc",
+			Port:       int32(node.RPCPort),
+			TargetPort: intstr.FromInt(int(node.RPCPort)),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+
+	if node.WithSensitiveWS() {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       "ws",
+			Port:       int32(node.WSPort),
+			TargetPort: intstr.FromInt(int(node.WSPort)),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+
+	// ip family is immutable, cluster default family is kept if not set
+	if node.IPFamily != "" {
+		family := corev1.IPFamily(node.IPFamily)
+		svc.Spec.IPFamily = &family
+	}
+
 	svc.Spec.Selector = labels
 }
 
+// reconcileNodeInternalService creates node internal service if node serves sensitive apis
+// deletes node internal service otherwise
+func (r *NetworkReconciler) reconcileNodeInternalService(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) error {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      node.InternalServiceName(network.Name),
+			Namespace: network.Namespace,
+		},
+	}
+
+	if !node.WithInternalService() {
+		if err := r.Client.Delete(context.Background(), svc); err != nil && !apierrors.IsNotFound(err) {
+			r.Log.Error(err, "unable to delete node internal service")
+			return err
+		}
+		return nil
+	}
+
+	_, err := ctrl.CreateOrUpdate(context.Background(), r.Client, svc, func() error {
+		if err := ctrl.SetControllerReference(network, svc, r.Scheme); err != nil {
+			return err
+		}
+
+		specNodeInternalService(svc, node, network)
+
+		return nil
+	})
+
+	return err
+}
+
+// nodeRPCService returns name of the service exposing node http-rpc server
+func nodeRPCService(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) string {
+	if node.WithSensitiveRPC() {
+		return node.InternalServiceName(network.Name)
+	}
+	return node.ServiceName(network.Name)
+}
+
+// withNodeService is whether node is exposed by service
+// explorer and faucet reach their node rpc server through node service
+func withNodeService(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) bool {
+	if node.WithService() {
+		return true
+	}
+	if node.Service != nil && node.Service.Type == ethereumv1beta1.NoneService {
+		return false
+	}
+	// web socket ingress routes requests to node service
+	if node.WSIngressHost != "" {
+		return true
+	}
+	if network.Spec.Explorer != nil && network.Spec.Explorer.Node == node.Name {
+		return true
+	}
+	return network.Spec.Faucet != nil && network.Spec.Faucet.Node == node.Name
+}
+
+// deleteNodeService deletes node service if node is no longer exposed
+func (r *NetworkReconciler) deleteNodeService(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) error {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      node.ServiceName(network.Name),
+			Namespace: network.Namespace,
+		},
+	}
+
+	if err := r.Client.Delete(context.Background(), svc); err != nil && !apierrors.IsNotFound(err) {
+		r.Log.Error(err, "unable to delete node service")
+		return err
+	}
+
+	return nil
+}
+
 // reconcileNodeService reconciles node service
-func (r *NetworkReconciler) reconcileNodeService(node *ethereumv1alpha1.Node, network *ethereumv1alpha1.Network) (ip string, err error) {
+func (r *NetworkReconciler) reconcileNodeService(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) (ip string, err error) {
 
 	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -593,34 +2032,94 @@ func (r *NetworkReconciler) reconcileNodeService(node *ethereumv1alpha1.Node, ne
 		return
 	}
 
-	ip = svc.Spec.ClusterIP
+	ip = serviceAddress(svc)
 
 	return
 }
 
+// serviceAddress returns service address nodes use to reach the node
+// load balancer services are reached using load balancer address, so they're reachable outside the cluster
+// empty address is returned if it's not assigned yet
+func serviceAddress(svc *corev1.Service) string {
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return svc.Spec.ClusterIP
+	}
+
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			return ingress.IP
+		}
+		if ingress.Hostname != "" {
+			return ingress.Hostname
+		}
+	}
+
+	return ""
+}
+
 // reconcileNode create a new node deployment if it doesn't exist
 // updates existing deployments if node spec changed
-func (r *NetworkReconciler) reconcileNode(node *ethereumv1alpha1.Node, network *ethereumv1alpha1.Network, bootnodes []string) (enodeURL string, err error) {
+func (r *NetworkReconciler) reconcileNode(ctx context.Context, node *ethereumv1beta1.Node, network *ethereumv1beta1.Network, bootnodes []string) (enodeURL string, err error) {
+	ctx, span := tracing.Start(ctx, "reconcileNode", label.String("node", node.Name))
+	defer func() { tracing.End(ctx, span, err) }()
+
+	// local volume is created before node data pvc, so pvc is bound to it
+	if !node.IsDedicatedBootnode() && node.WithLocalVolume() {
+		if err = tracing.Trace(ctx, "reconcileNodeLocalPV", func() error {
+			return r.reconcileNodeLocalPV(node, network)
+		}); err != nil {
+			return
+		}
+	}
 
-	if err = r.reconcileNodeDataPVC(node, network); err != nil {
-		return
+	// dedicated bootnodes don't keep chain data, ephemeral and hostPath storage nodes don't need pvc
+	if !node.IsDedicatedBootnode() && node.WithPersistentVolumeClaim() {
+		if err = tracing.Trace(ctx, "reconcileNodeDataPVC", func() error {
+			return r.reconcileNodeDataPVC(node, network)
+		}); err != nil {
+			return
+		}
 	}
 
-	if err = r.reconcileNodeConfigmap(node, network); err != nil {
-		return
+	var publicKey string
+
+	// secret is reconciled before deployment, so rolled out pods mount the updated secret
+	if node.WithNodekey() || node.Import != nil {
+		if err = tracing.Trace(ctx, "reconcileNodeSecret", func() (err error) {
+			publicKey, err = r.reconcileNodeSecret(node, network)
+			return
+		}); err != nil {
+			return
+		}
 	}
 
-	if err = r.reconcileNodeDeployment(node, network, bootnodes); err != nil {
+	if err = tracing.Trace(ctx, "reconcileNodeDeployment", func() error {
+		return r.reconcileNodeDeployment(node, network, bootnodes)
+	}); err != nil {
 		return
 	}
 
-	if !node.WithNodekey() && node.Import == nil {
+	if err = tracing.Trace(ctx, "reconcileNodeInternalService", func() error {
+		return r.reconcileNodeInternalService(node, network)
+	}); err != nil {
 		return
 	}
 
-	var publicKey string
+	var ip string
+
+	if err = tracing.Trace(ctx, "reconcileNodeService", func() (err error) {
+		if !withNodeService(node, network) {
+			return r.deleteNodeService(node, network)
+		}
+		ip, err = r.reconcileNodeService(node, network)
+		return
+	}); err != nil {
+		return
+	}
 
-	if publicKey, err = r.reconcileNodeSecret(node, network); err != nil {
+	if err = tracing.Trace(ctx, "reconcileNodeIngress", func() error {
+		return r.reconcileIngress(network, node.Labels(network.Name), node.WSIngressHost, node.ServiceName(network.Name), int(node.WSPort), true)
+	}); err != nil {
 		return
 	}
 
@@ -628,24 +2127,45 @@ func (r *NetworkReconciler) reconcileNode(node *ethereumv1alpha1.Node, network *
 		return
 	}
 
-	ip, err := r.reconcileNodeService(node, network)
-	if err != nil {
+	// dns name survives service ip changes
+	host := ip
+	if node.DNSName != "" {
+		host = node.DNSName
+	}
+
+	// enode url is published once service address is assigned, like load balancer address
+	if host == "" {
+		r.Log.Info(fmt.Sprintf("node (%s) service address is not assigned yet", node.Name))
 		return
 	}
 
-	enodeURL = fmt.Sprintf("enode://%s@%s:%d", publicKey, ip, node.P2PPort)
+	// IPv6 addresses are enclosed in square brackets
+	enodeURL = fmt.Sprintf("enode://%s@%s", publicKey, net.JoinHostPort(host, fmt.Sprintf("%d", node.P2PPort)))
 
 	return
 }
 
 // SetupWithManager adds reconciler to the manager
 func (r *NetworkReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	updateMetrics := metrics.UpdateResources(metrics.ResourcesUpdateInterval, r.updateMetrics, r.Log)
+	if err := mgr.Add(manager.RunnableFunc(updateMetrics)); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&ethereumv1alpha1.Network{}).
+		// status updates shouldn't trigger reconciliation, nodes status is refreshed periodically
+		// on demand integrity check requests are network annotations, they don't change generation
+		For(&ethereumv1beta1.Network{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, integrityCheckRequestedPredicate))).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.Secret{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
 		Owns(&corev1.ConfigMap{}).
+		Owns(&networkingv1beta1.Ingress{}).
+		Owns(&batchv1.Job{}).
+		// node pods are owned by replicasets, they're mapped to networks by labels
+		Watches(&source.Kind{Type: &corev1.Pod{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: mapPodToNetwork}).
+		Watches(&source.Kind{Type: &ethereumv1beta1.NodeTask{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: mapNodeTaskToNetwork}).
+		WithOptions(controller.Options{RateLimiter: r.RateLimiter}).
 		Complete(r)
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"testing"
 	"time"
 
-	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
@@ -14,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -24,17 +27,17 @@ var _ = Describe("Ethereum network controller", func() {
 		interval   = 2 * time.Second
 		timeout    = 2 * time.Minute
 		networkID  = 7777
-		privatekey = ethereumv1alpha1.PrivateKey("0x608e9b6f67c65e47531e08e8e501386dfae63a540fa3c48802c8aad854510b4e")
+		privatekey = ethereumv1beta1.PrivateKey("0x608e9b6f67c65e47531e08e8e501386dfae63a540fa3c48802c8aad854510b4e")
 		// imported account
-		accountKey      = ethereumv1alpha1.PrivateKey("0x5df5eff7ef9e4e82739b68a34c6b23608d79ee8daf3b598a01ffb0dd7aa3a2fd")
-		accountAddress  = ethereumv1alpha1.EthereumAddress("0x2b3430337f12Ce89EaBC7b0d865F4253c7744c0d")
+		accountKey      = ethereumv1beta1.PrivateKey("0x5df5eff7ef9e4e82739b68a34c6b23608d79ee8daf3b598a01ffb0dd7aa3a2fd")
+		accountAddress  = ethereumv1beta1.EthereumAddress("0x2b3430337f12Ce89EaBC7b0d865F4253c7744c0d")
 		accountPassword = "secret"
 	)
 
 	var (
 		useExistingCluster = os.Getenv("USE_EXISTING_CLUSTER") == "true"
-		besuClient, _      = NewEthereumClient(ethereumv1alpha1.BesuClient)
-		gethClient, _      = NewEthereumClient(ethereumv1alpha1.GethClient)
+		besuClient, _      = NewEthereumClient(ethereumv1beta1.BesuClient)
+		gethClient, _      = NewEthereumClient(ethereumv1beta1.GethClient)
 		initGenesis        string
 		importAccount      string
 	)
@@ -52,7 +55,7 @@ var _ = Describe("Ethereum network controller", func() {
 			nodes := &v1.NodeList{}
 			Expect(k8sClient.List(context.Background(), nodes)).To(Succeed())
 			for i, node := range nodes.Items {
-				node.Labels[ethereumv1alpha1.DefaultTopologyKey] = fmt.Sprintf("zone-%d", i)
+				node.Labels[ethereumv1beta1.DefaultTopologyKey] = fmt.Sprintf("zone-%d", i)
 				Expect(k8sClient.Update(context.Background(), &node)).To(Succeed())
 			}
 		})
@@ -69,21 +72,21 @@ var _ = Describe("Ethereum network controller", func() {
 			Namespace: ns.Name,
 		}
 
-		spec := ethereumv1alpha1.NetworkSpec{
+		spec := ethereumv1beta1.NetworkSpec{
 			Join:            "mainnet",
 			HighlyAvailable: true,
-			Nodes: []ethereumv1alpha1.Node{
+			Nodes: []ethereumv1beta1.Node{
 				{
 					Name:     "node-1",
 					Bootnode: true,
 					Nodekey:  privatekey,
-					SyncMode: ethereumv1alpha1.FullSynchronization,
-					Logging:  ethereumv1alpha1.NoLogs,
+					SyncMode: ethereumv1beta1.FullSynchronization,
+					Logging:  ethereumv1beta1.NoLogs,
 				},
 			},
 		}
 
-		toCreate := &ethereumv1alpha1.Network{
+		toCreate := &ethereumv1beta1.Network{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      key.Name,
 				Namespace: key.Namespace,
@@ -93,7 +96,7 @@ var _ = Describe("Ethereum network controller", func() {
 		t := true
 		ownerReference := metav1.OwnerReference{
 			// TODO: update version
-			APIVersion:         "ethereum.kotal.io/v1alpha1",
+			APIVersion:         "ethereum.kotal.io/v1beta1",
 			Kind:               "Network",
 			Name:               toCreate.Name,
 			Controller:         &t,
@@ -125,13 +128,23 @@ var _ = Describe("Ethereum network controller", func() {
 		})
 
 		It("Should Get the network", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
 			Expect(fetched.Spec).To(Equal(toCreate.Spec))
 			Expect(fetched.Status.NodesCount).To(Equal(len(toCreate.Spec.Nodes)))
 			ownerReference.UID = fetched.GetUID()
 		})
 
+		It("Should publish bootnode enode URL in network status", func() {
+			fetched := &ethereumv1beta1.Network{}
+			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+			Expect(fetched.Status.Bootnodes).To(HaveLen(1))
+			Expect(fetched.Status.Nodes).To(HaveLen(1))
+			Expect(fetched.Status.Nodes[0].Name).To(Equal("node-1"))
+			Expect(fetched.Status.Nodes[0].Bootnode).To(BeTrue())
+			Expect(fetched.Status.Nodes[0].EnodeURL).To(Equal(fetched.Status.Bootnodes[0]))
+		})
+
 		It("Should not create genesis block configmap", func() {
 			genesisConfig := &v1.ConfigMap{}
 			genesisKey := types.NamespacedName{
@@ -158,28 +171,29 @@ var _ = Describe("Ethereum network controller", func() {
 			nodeDep := &appsv1.Deployment{}
 			Expect(k8sClient.Get(context.Background(), bootnodeKey, nodeDep)).To(Succeed())
 			Expect(nodeDep.GetOwnerReferences()).To(ContainElement(ownerReference))
-			Expect(nodeDep.Spec.Template.Spec.Containers[0].Args).To(ContainElements([]string{
+			Expect(nodeDep.Spec.Template.Spec.Containers[0].Args).To(ContainElement(BesuConfigFile))
+			expectBesuConfig(types.NamespacedName{Name: bootnodeKey.Name + "-config", Namespace: bootnodeKey.Namespace}, []string{
 				BesuNetwork,
 				"mainnet",
 				BesuDataPath,
 				BesuNodePrivateKey,
 				BesuSyncMode,
-				string(ethereumv1alpha1.FullSynchronization),
+				string(ethereumv1beta1.FullSynchronization),
 				BesuLogging,
-				besuClient.LoggingArgFromVerbosity(ethereumv1alpha1.NoLogs),
-			}))
+				besuClient.LoggingArgFromVerbosity(ethereumv1beta1.NoLogs),
+			})
 		})
 
 		It("Should allocate correct resources to bootnode deployment", func() {
 			nodeDep := &appsv1.Deployment{}
 			expectedResources := v1.ResourceRequirements{
 				Requests: v1.ResourceList{
-					v1.ResourceCPU:    resource.MustParse(ethereumv1alpha1.DefaultPublicNetworkNodeCPURequest),
-					v1.ResourceMemory: resource.MustParse(ethereumv1alpha1.DefaultPublicNetworkNodeMemoryRequest),
+					v1.ResourceCPU:    resource.MustParse(ethereumv1beta1.DefaultPublicNetworkNodeCPURequest),
+					v1.ResourceMemory: resource.MustParse(ethereumv1beta1.DefaultPublicNetworkNodeMemoryRequest),
 				},
 				Limits: v1.ResourceList{
-					v1.ResourceCPU:    resource.MustParse(ethereumv1alpha1.DefaultPublicNetworkNodeCPULimit),
-					v1.ResourceMemory: resource.MustParse(ethereumv1alpha1.DefaultPublicNetworkNodeMemoryLimit),
+					v1.ResourceCPU:    resource.MustParse(ethereumv1beta1.DefaultPublicNetworkNodeCPULimit),
+					v1.ResourceMemory: resource.MustParse(ethereumv1beta1.DefaultPublicNetworkNodeMemoryLimit),
 				},
 			}
 			Expect(k8sClient.Get(context.Background(), bootnodeKey, nodeDep)).To(Succeed())
@@ -190,7 +204,7 @@ var _ = Describe("Ethereum network controller", func() {
 			nodePVC := &v1.PersistentVolumeClaim{}
 			expectedResources := v1.ResourceRequirements{
 				Requests: v1.ResourceList{
-					v1.ResourceStorage: resource.MustParse(ethereumv1alpha1.DefaultMainNetworkFullNodeStorageRequest),
+					v1.ResourceStorage: resource.MustParse(ethereumv1beta1.DefaultMainNetworkFullNodeStorageRequest),
 				},
 			}
 			Expect(k8sClient.Get(context.Background(), bootnodeKey, nodePVC)).To(Succeed())
@@ -199,15 +213,15 @@ var _ = Describe("Ethereum network controller", func() {
 		})
 
 		It("Should update the network", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
-			newNode := ethereumv1alpha1.Node{
+			newNode := ethereumv1beta1.Node{
 				Name:    "node-2",
 				RPC:     true,
-				Client:  ethereumv1alpha1.GethClient,
+				Client:  ethereumv1beta1.GethClient,
 				RPCPort: 8547,
-				Logging: ethereumv1alpha1.ErrorLogs,
-				Resources: &ethereumv1alpha1.NodeResources{
+				Logging: ethereumv1beta1.ErrorLogs,
+				Resources: &ethereumv1beta1.NodeResources{
 					CPU:         cpu,
 					CPULimit:    cpuLimit,
 					Memory:      memory,
@@ -219,7 +233,7 @@ var _ = Describe("Ethereum network controller", func() {
 				fetched.Default()
 			}
 			Expect(k8sClient.Update(context.Background(), fetched)).To(Succeed())
-			fetchedUpdated := &ethereumv1alpha1.Network{}
+			fetchedUpdated := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetchedUpdated)).To(Succeed())
 			Expect(fetchedUpdated.Spec).To(Equal(fetched.Spec))
 			time.Sleep(sleepTime)
@@ -246,12 +260,12 @@ var _ = Describe("Ethereum network controller", func() {
 				GethRPCHTTPPort,
 				"8547",
 				GethSyncMode,
-				string(ethereumv1alpha1.FastSynchronization),
+				string(ethereumv1beta1.FastSynchronization),
 				GethLogging,
-				gethClient.LoggingArgFromVerbosity(ethereumv1alpha1.ErrorLogs),
+				gethClient.LoggingArgFromVerbosity(ethereumv1beta1.ErrorLogs),
 			}))
 			Expect(nodeDep.Spec.Template.Spec.Containers[0].Args).ToNot(ContainElements([]string{
-				ethereumv1alpha1.MainNetwork,
+				ethereumv1beta1.MainNetwork,
 			}))
 		})
 
@@ -275,7 +289,7 @@ var _ = Describe("Ethereum network controller", func() {
 			nodePVC := &v1.PersistentVolumeClaim{}
 			expectedResources := v1.ResourceRequirements{
 				Requests: v1.ResourceList{
-					v1.ResourceStorage: resource.MustParse(ethereumv1alpha1.DefaultMainNetworkFastNodeStorageRequest),
+					v1.ResourceStorage: resource.MustParse(ethereumv1beta1.DefaultMainNetworkFastNodeStorageRequest),
 				},
 			}
 			Expect(k8sClient.Get(context.Background(), node2Key, nodePVC)).To(Succeed())
@@ -294,11 +308,11 @@ var _ = Describe("Ethereum network controller", func() {
 		})
 
 		It("Should update the network by removing node-2", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
 			fetched.Spec.Nodes = fetched.Spec.Nodes[:1]
 			Expect(k8sClient.Update(context.Background(), fetched)).To(Succeed())
-			fetchedUpdated := &ethereumv1alpha1.Network{}
+			fetchedUpdated := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetchedUpdated)).To(Succeed())
 			Expect(fetchedUpdated.Spec).To(Equal(fetched.Spec))
 			time.Sleep(sleepTime)
@@ -319,14 +333,14 @@ var _ = Describe("Ethereum network controller", func() {
 		}
 
 		It("Should delete network", func() {
-			toDelete := &ethereumv1alpha1.Network{}
+			toDelete := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, toDelete)).To(Succeed())
 			Expect(k8sClient.Delete(context.Background(), toDelete)).To(Succeed())
 			time.Sleep(sleepTime)
 		})
 
 		It("Should not get network after deletion", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).ToNot(Succeed())
 		})
 
@@ -370,20 +384,20 @@ var _ = Describe("Ethereum network controller", func() {
 			Namespace: ns.Name,
 		}
 
-		spec := ethereumv1alpha1.NetworkSpec{
+		spec := ethereumv1beta1.NetworkSpec{
 			Join:            "rinkeby",
 			HighlyAvailable: true,
-			Nodes: []ethereumv1alpha1.Node{
+			Nodes: []ethereumv1beta1.Node{
 				{
 					Name:     "node-1",
 					Bootnode: true,
 					Nodekey:  privatekey,
-					Logging:  ethereumv1alpha1.FatalLogs,
+					Logging:  ethereumv1beta1.FatalLogs,
 				},
 			},
 		}
 
-		toCreate := &ethereumv1alpha1.Network{
+		toCreate := &ethereumv1beta1.Network{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      key.Name,
 				Namespace: key.Namespace,
@@ -393,7 +407,7 @@ var _ = Describe("Ethereum network controller", func() {
 		t := true
 		ownerReference := metav1.OwnerReference{
 			// TODO: update version
-			APIVersion:         "ethereum.kotal.io/v1alpha1",
+			APIVersion:         "ethereum.kotal.io/v1beta1",
 			Kind:               "Network",
 			Name:               toCreate.Name,
 			Controller:         &t,
@@ -425,7 +439,7 @@ var _ = Describe("Ethereum network controller", func() {
 		})
 
 		It("Should Get the network", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
 			Expect(fetched.Spec).To(Equal(toCreate.Spec))
 			Expect(fetched.Status.NodesCount).To(Equal(len(toCreate.Spec.Nodes)))
@@ -458,28 +472,29 @@ var _ = Describe("Ethereum network controller", func() {
 			nodeDep := &appsv1.Deployment{}
 			Expect(k8sClient.Get(context.Background(), bootnodeKey, nodeDep)).To(Succeed())
 			Expect(nodeDep.GetOwnerReferences()).To(ContainElement(ownerReference))
-			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(BesuImage()))
-			Expect(nodeDep.Spec.Template.Spec.Containers[0].Args).To(ContainElements([]string{
+			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(BesuImage("")))
+			Expect(nodeDep.Spec.Template.Spec.Containers[0].Args).To(ContainElement(BesuConfigFile))
+			expectBesuConfig(types.NamespacedName{Name: bootnodeKey.Name + "-config", Namespace: bootnodeKey.Namespace}, []string{
 				BesuNetwork,
 				"rinkeby",
 				BesuDataPath,
 				BesuNodePrivateKey,
 				BesuSyncMode,
-				string(ethereumv1alpha1.FastSynchronization),
-				besuClient.LoggingArgFromVerbosity(ethereumv1alpha1.FatalLogs),
-			}))
+				string(ethereumv1beta1.FastSynchronization),
+				besuClient.LoggingArgFromVerbosity(ethereumv1beta1.FatalLogs),
+			})
 		})
 
 		It("Should allocate correct resources to bootnode deployment", func() {
 			nodeDep := &appsv1.Deployment{}
 			expectedResources := v1.ResourceRequirements{
 				Requests: v1.ResourceList{
-					v1.ResourceCPU:    resource.MustParse(ethereumv1alpha1.DefaultPublicNetworkNodeCPURequest),
-					v1.ResourceMemory: resource.MustParse(ethereumv1alpha1.DefaultPublicNetworkNodeMemoryRequest),
+					v1.ResourceCPU:    resource.MustParse(ethereumv1beta1.DefaultPublicNetworkNodeCPURequest),
+					v1.ResourceMemory: resource.MustParse(ethereumv1beta1.DefaultPublicNetworkNodeMemoryRequest),
 				},
 				Limits: v1.ResourceList{
-					v1.ResourceCPU:    resource.MustParse(ethereumv1alpha1.DefaultPublicNetworkNodeCPULimit),
-					v1.ResourceMemory: resource.MustParse(ethereumv1alpha1.DefaultPublicNetworkNodeMemoryLimit),
+					v1.ResourceCPU:    resource.MustParse(ethereumv1beta1.DefaultPublicNetworkNodeCPULimit),
+					v1.ResourceMemory: resource.MustParse(ethereumv1beta1.DefaultPublicNetworkNodeMemoryLimit),
 				},
 			}
 			Expect(k8sClient.Get(context.Background(), bootnodeKey, nodeDep)).To(Succeed())
@@ -491,7 +506,7 @@ var _ = Describe("Ethereum network controller", func() {
 			nodePVC := &v1.PersistentVolumeClaim{}
 			expectedResources := v1.ResourceRequirements{
 				Requests: v1.ResourceList{
-					v1.ResourceStorage: resource.MustParse(ethereumv1alpha1.DefaultTestNetworkStorageRequest),
+					v1.ResourceStorage: resource.MustParse(ethereumv1beta1.DefaultTestNetworkStorageRequest),
 				},
 			}
 			Expect(k8sClient.Get(context.Background(), bootnodeKey, nodePVC)).To(Succeed())
@@ -500,32 +515,32 @@ var _ = Describe("Ethereum network controller", func() {
 		})
 
 		It("Should update the network", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
-			newNode := ethereumv1alpha1.Node{
+			newNode := ethereumv1beta1.Node{
 				Name:     "node-2",
-				Client:   ethereumv1alpha1.GethClient,
+				Client:   ethereumv1beta1.GethClient,
 				Miner:    true,
 				Coinbase: accountAddress,
-				SyncMode: ethereumv1alpha1.FullSynchronization,
-				Import: &ethereumv1alpha1.ImportedAccount{
+				SyncMode: ethereumv1beta1.FullSynchronization,
+				Import: &ethereumv1beta1.ImportedAccount{
 					PrivateKey: accountKey,
 					Password:   accountPassword,
 				},
-				Resources: &ethereumv1alpha1.NodeResources{
+				Resources: &ethereumv1beta1.NodeResources{
 					CPU:         cpu,
 					CPULimit:    cpuLimit,
 					Memory:      memory,
 					MemoryLimit: memoryLimit,
 				},
-				Logging: ethereumv1alpha1.WarnLogs,
+				Logging: ethereumv1beta1.WarnLogs,
 			}
 			fetched.Spec.Nodes = append(fetched.Spec.Nodes, newNode)
 			if !useExistingCluster {
 				fetched.Default()
 			}
 			Expect(k8sClient.Update(context.Background(), fetched)).To(Succeed())
-			fetchedUpdated := &ethereumv1alpha1.Network{}
+			fetchedUpdated := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetchedUpdated)).To(Succeed())
 			Expect(fetchedUpdated.Spec).To(Equal(fetched.Spec))
 			time.Sleep(sleepTime)
@@ -545,8 +560,8 @@ var _ = Describe("Ethereum network controller", func() {
 			nodeDep := &appsv1.Deployment{}
 			Expect(k8sClient.Get(context.Background(), node2Key, nodeDep)).To(Succeed())
 			Expect(nodeDep.GetOwnerReferences()).To(ContainElement(ownerReference))
-			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(GethImage()))
-			Expect(nodeDep.Spec.Template.Spec.InitContainers[0].Image).To(Equal(GethImage()))
+			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(GethImage("")))
+			Expect(nodeDep.Spec.Template.Spec.InitContainers[0].Image).To(Equal(GethImage("")))
 			Expect(nodeDep.Spec.Template.Spec.InitContainers[0].Args).To(ContainElements([]string{
 				fmt.Sprintf("%s/import-account.sh", PathConfig),
 			}))
@@ -559,9 +574,9 @@ var _ = Describe("Ethereum network controller", func() {
 				GethUnlock,
 				GethPassword,
 				GethSyncMode,
-				string(ethereumv1alpha1.FullSynchronization),
+				string(ethereumv1beta1.FullSynchronization),
 				GethLogging,
-				gethClient.LoggingArgFromVerbosity(ethereumv1alpha1.WarnLogs),
+				gethClient.LoggingArgFromVerbosity(ethereumv1beta1.WarnLogs),
 			}))
 		})
 
@@ -592,7 +607,7 @@ var _ = Describe("Ethereum network controller", func() {
 			nodePVC := &v1.PersistentVolumeClaim{}
 			expectedResources := v1.ResourceRequirements{
 				Requests: v1.ResourceList{
-					v1.ResourceStorage: resource.MustParse(ethereumv1alpha1.DefaultTestNetworkStorageRequest),
+					v1.ResourceStorage: resource.MustParse(ethereumv1beta1.DefaultTestNetworkStorageRequest),
 				},
 			}
 			Expect(k8sClient.Get(context.Background(), node2Key, nodePVC)).To(Succeed())
@@ -613,11 +628,11 @@ var _ = Describe("Ethereum network controller", func() {
 		})
 
 		It("Should update the network by removing node-2", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
 			fetched.Spec.Nodes = fetched.Spec.Nodes[:1]
 			Expect(k8sClient.Update(context.Background(), fetched)).To(Succeed())
-			fetchedUpdated := &ethereumv1alpha1.Network{}
+			fetchedUpdated := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetchedUpdated)).To(Succeed())
 			Expect(fetchedUpdated.Spec).To(Equal(fetched.Spec))
 			time.Sleep(sleepTime)
@@ -643,14 +658,14 @@ var _ = Describe("Ethereum network controller", func() {
 		}
 
 		It("Should delete network", func() {
-			toDelete := &ethereumv1alpha1.Network{}
+			toDelete := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, toDelete)).To(Succeed())
 			Expect(k8sClient.Delete(context.Background(), toDelete)).To(Succeed())
 			time.Sleep(sleepTime)
 		})
 
 		It("Should not get network after deletion", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).ToNot(Succeed())
 		})
 
@@ -694,18 +709,18 @@ var _ = Describe("Ethereum network controller", func() {
 			Namespace: ns.Name,
 		}
 
-		spec := ethereumv1alpha1.NetworkSpec{
+		spec := ethereumv1beta1.NetworkSpec{
 			ID:        networkID,
-			Consensus: ethereumv1alpha1.ProofOfAuthority,
-			Genesis: &ethereumv1alpha1.Genesis{
+			Consensus: ethereumv1beta1.ProofOfAuthority,
+			Genesis: &ethereumv1beta1.Genesis{
 				ChainID: 55555,
-				Clique: &ethereumv1alpha1.Clique{
-					Signers: []ethereumv1alpha1.EthereumAddress{
-						ethereumv1alpha1.EthereumAddress("0xd2c21213027cbf4d46c16b55fa98e5252b048706"),
+				Clique: &ethereumv1beta1.Clique{
+					Signers: []ethereumv1beta1.EthereumAddress{
+						ethereumv1beta1.EthereumAddress("0xd2c21213027cbf4d46c16b55fa98e5252b048706"),
 					},
 				},
 			},
-			Nodes: []ethereumv1alpha1.Node{
+			Nodes: []ethereumv1beta1.Node{
 				{
 					Name:     "node-1",
 					Bootnode: true,
@@ -714,7 +729,7 @@ var _ = Describe("Ethereum network controller", func() {
 			},
 		}
 
-		toCreate := &ethereumv1alpha1.Network{
+		toCreate := &ethereumv1beta1.Network{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      key.Name,
 				Namespace: key.Namespace,
@@ -724,7 +739,7 @@ var _ = Describe("Ethereum network controller", func() {
 		t := true
 		ownerReference := metav1.OwnerReference{
 			// TODO: update version
-			APIVersion:         "ethereum.kotal.io/v1alpha1",
+			APIVersion:         "ethereum.kotal.io/v1beta1",
 			Kind:               "Network",
 			Name:               toCreate.Name,
 			Controller:         &t,
@@ -758,7 +773,7 @@ var _ = Describe("Ethereum network controller", func() {
 		})
 
 		It("Should Get the network", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
 			Expect(fetched.Spec).To(Equal(toCreate.Spec))
 			Expect(fetched.Status.NodesCount).To(Equal(len(toCreate.Spec.Nodes)))
@@ -782,15 +797,16 @@ var _ = Describe("Ethereum network controller", func() {
 			nodeDep := &appsv1.Deployment{}
 			Expect(k8sClient.Get(context.Background(), bootnodeKey, nodeDep)).To(Succeed())
 			Expect(nodeDep.GetOwnerReferences()).To(ContainElement(ownerReference))
-			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(BesuImage()))
-			Expect(nodeDep.Spec.Template.Spec.Containers[0].Args).To(ContainElements([]string{
+			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(BesuImage("")))
+			Expect(nodeDep.Spec.Template.Spec.Containers[0].Args).To(ContainElement(BesuConfigFile))
+			expectBesuConfig(types.NamespacedName{Name: bootnodeKey.Name + "-config", Namespace: bootnodeKey.Namespace}, []string{
 				BesuDataPath,
 				BesuNodePrivateKey,
 				BesuSyncMode,
-				string(ethereumv1alpha1.FullSynchronization),
+				string(ethereumv1beta1.FullSynchronization),
 				BesuLogging,
-				besuClient.LoggingArgFromVerbosity(ethereumv1alpha1.DefaultLogging),
-			}))
+				besuClient.LoggingArgFromVerbosity(ethereumv1beta1.DefaultLogging),
+			})
 		})
 
 		It("Should create bootnode genesis block configmap", func() {
@@ -808,12 +824,12 @@ var _ = Describe("Ethereum network controller", func() {
 			nodeDep := &appsv1.Deployment{}
 			expectedResources := v1.ResourceRequirements{
 				Requests: v1.ResourceList{
-					v1.ResourceCPU:    resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeCPURequest),
-					v1.ResourceMemory: resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeMemoryRequest),
+					v1.ResourceCPU:    resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeCPURequest),
+					v1.ResourceMemory: resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeMemoryRequest),
 				},
 				Limits: v1.ResourceList{
-					v1.ResourceCPU:    resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeCPULimit),
-					v1.ResourceMemory: resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeMemoryLimit),
+					v1.ResourceCPU:    resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeCPULimit),
+					v1.ResourceMemory: resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeMemoryLimit),
 				},
 			}
 			Expect(k8sClient.Get(context.Background(), bootnodeKey, nodeDep)).To(Succeed())
@@ -824,7 +840,7 @@ var _ = Describe("Ethereum network controller", func() {
 			nodePVC := &v1.PersistentVolumeClaim{}
 			expectedResources := v1.ResourceRequirements{
 				Requests: v1.ResourceList{
-					v1.ResourceStorage: resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeStorageRequest),
+					v1.ResourceStorage: resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeStorageRequest),
 				},
 			}
 			Expect(k8sClient.Get(context.Background(), bootnodeKey, nodePVC)).To(Succeed())
@@ -833,33 +849,33 @@ var _ = Describe("Ethereum network controller", func() {
 		})
 
 		It("Should update the network", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
-			newNode := ethereumv1alpha1.Node{
+			newNode := ethereumv1beta1.Node{
 				Name:     "node-2",
-				Client:   ethereumv1alpha1.GethClient,
+				Client:   ethereumv1beta1.GethClient,
 				Miner:    true,
 				Coinbase: accountAddress,
-				Import: &ethereumv1alpha1.ImportedAccount{
+				Import: &ethereumv1beta1.ImportedAccount{
 					PrivateKey: accountKey,
 					Password:   accountPassword,
 				},
-				SyncMode: ethereumv1alpha1.FastSynchronization,
-				Resources: &ethereumv1alpha1.NodeResources{
+				SyncMode: ethereumv1beta1.FastSynchronization,
+				Resources: &ethereumv1beta1.NodeResources{
 					CPU:         cpu,
 					CPULimit:    cpuLimit,
 					Memory:      memory,
 					MemoryLimit: memoryLimit,
 					Storage:     storage,
 				},
-				Logging: ethereumv1alpha1.DebugLogs,
+				Logging: ethereumv1beta1.DebugLogs,
 			}
 			fetched.Spec.Nodes = append(fetched.Spec.Nodes, newNode)
 			if !useExistingCluster {
 				fetched.Default()
 			}
 			Expect(k8sClient.Update(context.Background(), fetched)).To(Succeed())
-			fetchedUpdated := &ethereumv1alpha1.Network{}
+			fetchedUpdated := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetchedUpdated)).To(Succeed())
 			Expect(fetchedUpdated.Spec).To(Equal(fetched.Spec))
 			time.Sleep(sleepTime)
@@ -882,12 +898,12 @@ var _ = Describe("Ethereum network controller", func() {
 			nodeDep := &appsv1.Deployment{}
 			Expect(k8sClient.Get(context.Background(), node2Key, nodeDep)).To(Succeed())
 			Expect(nodeDep.GetOwnerReferences()).To(ContainElement(ownerReference))
-			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(GethImage()))
-			Expect(nodeDep.Spec.Template.Spec.InitContainers[0].Image).To(Equal(GethImage()))
+			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(GethImage("")))
+			Expect(nodeDep.Spec.Template.Spec.InitContainers[0].Image).To(Equal(GethImage("")))
 			Expect(nodeDep.Spec.Template.Spec.InitContainers[0].Args).To(ContainElements([]string{
 				fmt.Sprintf("%s/init-genesis.sh", PathConfig),
 			}))
-			Expect(nodeDep.Spec.Template.Spec.InitContainers[1].Image).To(Equal(GethImage()))
+			Expect(nodeDep.Spec.Template.Spec.InitContainers[1].Image).To(Equal(GethImage("")))
 			Expect(nodeDep.Spec.Template.Spec.InitContainers[1].Args).To(ContainElements([]string{
 				fmt.Sprintf("%s/import-account.sh", PathConfig),
 			}))
@@ -895,9 +911,9 @@ var _ = Describe("Ethereum network controller", func() {
 				GethDataDir,
 				GethBootnodes,
 				GethSyncMode,
-				string(ethereumv1alpha1.FastSynchronization),
+				string(ethereumv1beta1.FastSynchronization),
 				GethLogging,
-				gethClient.LoggingArgFromVerbosity(ethereumv1alpha1.DebugLogs),
+				gethClient.LoggingArgFromVerbosity(ethereumv1beta1.DebugLogs),
 			}))
 		})
 
@@ -942,11 +958,11 @@ var _ = Describe("Ethereum network controller", func() {
 		})
 
 		It("Should update the network by removing node-2", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
 			fetched.Spec.Nodes = fetched.Spec.Nodes[:1]
 			Expect(k8sClient.Update(context.Background(), fetched)).To(Succeed())
-			fetchedUpdated := &ethereumv1alpha1.Network{}
+			fetchedUpdated := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetchedUpdated)).To(Succeed())
 			Expect(fetchedUpdated.Spec).To(Equal(fetched.Spec))
 			time.Sleep(sleepTime)
@@ -972,14 +988,14 @@ var _ = Describe("Ethereum network controller", func() {
 		}
 
 		It("Should delete network", func() {
-			toDelete := &ethereumv1alpha1.Network{}
+			toDelete := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, toDelete)).To(Succeed())
 			Expect(k8sClient.Delete(context.Background(), toDelete)).To(Succeed())
 			time.Sleep(sleepTime)
 		})
 
 		It("Should not get network after deletion", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).ToNot(Succeed())
 		})
 
@@ -1041,24 +1057,24 @@ var _ = Describe("Ethereum network controller", func() {
 			Namespace: ns.Name,
 		}
 
-		spec := ethereumv1alpha1.NetworkSpec{
+		spec := ethereumv1beta1.NetworkSpec{
 			ID:        networkID,
-			Consensus: ethereumv1alpha1.ProofOfWork,
-			Genesis: &ethereumv1alpha1.Genesis{
+			Consensus: ethereumv1beta1.ProofOfWork,
+			Genesis: &ethereumv1beta1.Genesis{
 				ChainID: 55555,
-				Ethash:  &ethereumv1alpha1.Ethash{},
+				Ethash:  &ethereumv1beta1.Ethash{},
 			},
-			Nodes: []ethereumv1alpha1.Node{
+			Nodes: []ethereumv1beta1.Node{
 				{
 					Name:     "node-1",
 					Bootnode: true,
 					Nodekey:  privatekey,
-					Logging:  ethereumv1alpha1.TraceLogs,
+					Logging:  ethereumv1beta1.TraceLogs,
 				},
 			},
 		}
 
-		toCreate := &ethereumv1alpha1.Network{
+		toCreate := &ethereumv1beta1.Network{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      key.Name,
 				Namespace: key.Namespace,
@@ -1068,7 +1084,7 @@ var _ = Describe("Ethereum network controller", func() {
 		t := true
 		ownerReference := metav1.OwnerReference{
 			// TODO: update version
-			APIVersion:         "ethereum.kotal.io/v1alpha1",
+			APIVersion:         "ethereum.kotal.io/v1beta1",
 			Kind:               "Network",
 			Name:               toCreate.Name,
 			Controller:         &t,
@@ -1100,7 +1116,7 @@ var _ = Describe("Ethereum network controller", func() {
 		})
 
 		It("Should Get the network", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
 			Expect(fetched.Spec).To(Equal(toCreate.Spec))
 			Expect(fetched.Status.NodesCount).To(Equal(len(toCreate.Spec.Nodes)))
@@ -1133,27 +1149,28 @@ var _ = Describe("Ethereum network controller", func() {
 			nodeDep := &appsv1.Deployment{}
 			Expect(k8sClient.Get(context.Background(), bootnodeKey, nodeDep)).To(Succeed())
 			Expect(nodeDep.GetOwnerReferences()).To(ContainElement(ownerReference))
-			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(BesuImage()))
-			Expect(nodeDep.Spec.Template.Spec.Containers[0].Args).To(ContainElements([]string{
+			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(BesuImage("")))
+			Expect(nodeDep.Spec.Template.Spec.Containers[0].Args).To(ContainElement(BesuConfigFile))
+			expectBesuConfig(types.NamespacedName{Name: bootnodeKey.Name + "-config", Namespace: bootnodeKey.Namespace}, []string{
 				BesuDataPath,
 				BesuNodePrivateKey,
 				BesuSyncMode,
-				string(ethereumv1alpha1.FullSynchronization),
+				string(ethereumv1beta1.FullSynchronization),
 				BesuLogging,
-				besuClient.LoggingArgFromVerbosity(ethereumv1alpha1.TraceLogs),
-			}))
+				besuClient.LoggingArgFromVerbosity(ethereumv1beta1.TraceLogs),
+			})
 		})
 
 		It("Should allocate correct resources to bootnode deployment", func() {
 			nodeDep := &appsv1.Deployment{}
 			expectedResources := v1.ResourceRequirements{
 				Requests: v1.ResourceList{
-					v1.ResourceCPU:    resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeCPURequest),
-					v1.ResourceMemory: resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeMemoryRequest),
+					v1.ResourceCPU:    resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeCPURequest),
+					v1.ResourceMemory: resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeMemoryRequest),
 				},
 				Limits: v1.ResourceList{
-					v1.ResourceCPU:    resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeCPULimit),
-					v1.ResourceMemory: resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeMemoryLimit),
+					v1.ResourceCPU:    resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeCPULimit),
+					v1.ResourceMemory: resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeMemoryLimit),
 				},
 			}
 			Expect(k8sClient.Get(context.Background(), bootnodeKey, nodeDep)).To(Succeed())
@@ -1164,7 +1181,7 @@ var _ = Describe("Ethereum network controller", func() {
 			nodePVC := &v1.PersistentVolumeClaim{}
 			expectedResources := v1.ResourceRequirements{
 				Requests: v1.ResourceList{
-					v1.ResourceStorage: resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeStorageRequest),
+					v1.ResourceStorage: resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeStorageRequest),
 				},
 			}
 			Expect(k8sClient.Get(context.Background(), bootnodeKey, nodePVC)).To(Succeed())
@@ -1173,32 +1190,32 @@ var _ = Describe("Ethereum network controller", func() {
 		})
 
 		It("Should update the network", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
-			newNode := ethereumv1alpha1.Node{
+			newNode := ethereumv1beta1.Node{
 				Name:     "node-2",
-				Client:   ethereumv1alpha1.GethClient,
+				Client:   ethereumv1beta1.GethClient,
 				Miner:    true,
 				Coinbase: accountAddress,
-				Import: &ethereumv1alpha1.ImportedAccount{
+				Import: &ethereumv1beta1.ImportedAccount{
 					PrivateKey: accountKey,
 					Password:   accountPassword,
 				},
-				SyncMode: ethereumv1alpha1.FastSynchronization,
-				Resources: &ethereumv1alpha1.NodeResources{
+				SyncMode: ethereumv1beta1.FastSynchronization,
+				Resources: &ethereumv1beta1.NodeResources{
 					CPU:         cpu,
 					CPULimit:    cpuLimit,
 					Memory:      memory,
 					MemoryLimit: memoryLimit,
 				},
-				Logging: ethereumv1alpha1.AllLogs,
+				Logging: ethereumv1beta1.AllLogs,
 			}
 			fetched.Spec.Nodes = append(fetched.Spec.Nodes, newNode)
 			if !useExistingCluster {
 				fetched.Default()
 			}
 			Expect(k8sClient.Update(context.Background(), fetched)).To(Succeed())
-			fetchedUpdated := &ethereumv1alpha1.Network{}
+			fetchedUpdated := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetchedUpdated)).To(Succeed())
 			Expect(fetchedUpdated.Spec).To(Equal(fetched.Spec))
 			time.Sleep(sleepTime)
@@ -1219,12 +1236,12 @@ var _ = Describe("Ethereum network controller", func() {
 			nodeDep := &appsv1.Deployment{}
 			Expect(k8sClient.Get(context.Background(), node2Key, nodeDep)).To(Succeed())
 			Expect(nodeDep.GetOwnerReferences()).To(ContainElement(ownerReference))
-			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(GethImage()))
-			Expect(nodeDep.Spec.Template.Spec.InitContainers[0].Image).To(Equal(GethImage()))
+			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(GethImage("")))
+			Expect(nodeDep.Spec.Template.Spec.InitContainers[0].Image).To(Equal(GethImage("")))
 			Expect(nodeDep.Spec.Template.Spec.InitContainers[0].Args).To(ContainElements([]string{
 				fmt.Sprintf("%s/init-genesis.sh", PathConfig),
 			}))
-			Expect(nodeDep.Spec.Template.Spec.InitContainers[1].Image).To(Equal(GethImage()))
+			Expect(nodeDep.Spec.Template.Spec.InitContainers[1].Image).To(Equal(GethImage("")))
 			Expect(nodeDep.Spec.Template.Spec.InitContainers[1].Args).To(ContainElements([]string{
 				fmt.Sprintf("%s/import-account.sh", PathConfig),
 			}))
@@ -1232,9 +1249,9 @@ var _ = Describe("Ethereum network controller", func() {
 				GethDataDir,
 				GethBootnodes,
 				GethSyncMode,
-				string(ethereumv1alpha1.FastSynchronization),
+				string(ethereumv1beta1.FastSynchronization),
 				GethLogging,
-				gethClient.LoggingArgFromVerbosity(ethereumv1alpha1.AllLogs),
+				gethClient.LoggingArgFromVerbosity(ethereumv1beta1.AllLogs),
 			}))
 		})
 
@@ -1265,7 +1282,7 @@ var _ = Describe("Ethereum network controller", func() {
 			nodePVC := &v1.PersistentVolumeClaim{}
 			expectedResources := v1.ResourceRequirements{
 				Requests: v1.ResourceList{
-					v1.ResourceStorage: resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeStorageRequest),
+					v1.ResourceStorage: resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeStorageRequest),
 				},
 			}
 			Expect(k8sClient.Get(context.Background(), node2Key, nodePVC)).To(Succeed())
@@ -1279,11 +1296,11 @@ var _ = Describe("Ethereum network controller", func() {
 		})
 
 		It("Should update the network by removing node-2", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
 			fetched.Spec.Nodes = fetched.Spec.Nodes[:1]
 			Expect(k8sClient.Update(context.Background(), fetched)).To(Succeed())
-			fetchedUpdated := &ethereumv1alpha1.Network{}
+			fetchedUpdated := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetchedUpdated)).To(Succeed())
 			Expect(fetchedUpdated.Spec).To(Equal(fetched.Spec))
 			time.Sleep(sleepTime)
@@ -1309,14 +1326,14 @@ var _ = Describe("Ethereum network controller", func() {
 		}
 
 		It("Should delete network", func() {
-			toDelete := &ethereumv1alpha1.Network{}
+			toDelete := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, toDelete)).To(Succeed())
 			Expect(k8sClient.Delete(context.Background(), toDelete)).To(Succeed())
 			time.Sleep(sleepTime)
 		})
 
 		It("Should not get network after deletion", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).ToNot(Succeed())
 		})
 
@@ -1378,25 +1395,25 @@ var _ = Describe("Ethereum network controller", func() {
 			Namespace: ns.Name,
 		}
 
-		spec := ethereumv1alpha1.NetworkSpec{
+		spec := ethereumv1beta1.NetworkSpec{
 			ID:        networkID,
-			Consensus: ethereumv1alpha1.IstanbulBFT,
-			Genesis: &ethereumv1alpha1.Genesis{
+			Consensus: ethereumv1beta1.IstanbulBFT,
+			Genesis: &ethereumv1beta1.Genesis{
 				ChainID: 55555,
-				IBFT2: &ethereumv1alpha1.IBFT2{
-					Validators: []ethereumv1alpha1.EthereumAddress{
+				IBFT2: &ethereumv1beta1.IBFT2{
+					Validators: []ethereumv1beta1.EthereumAddress{
 						"0x427e2c7cecd72bc4cdd4f7ebb8bb6e49789c8044",
 						"0xd2c21213027cbf4d46c16b55fa98e5252b048706",
 						"0x8e1f6c7c76a1d7f74eda342d330ca9749f31cc2b",
 					},
 				},
 			},
-			Nodes: []ethereumv1alpha1.Node{
+			Nodes: []ethereumv1beta1.Node{
 				{
 					Name:     "node-1",
 					Bootnode: true,
 					Nodekey:  privatekey,
-					Logging:  ethereumv1alpha1.WarnLogs,
+					Logging:  ethereumv1beta1.WarnLogs,
 				},
 			},
 		}
@@ -1404,7 +1421,7 @@ var _ = Describe("Ethereum network controller", func() {
 		cpu := "500m"
 		memory := "500Mi"
 
-		toCreate := &ethereumv1alpha1.Network{
+		toCreate := &ethereumv1beta1.Network{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      key.Name,
 				Namespace: key.Namespace,
@@ -1414,7 +1431,7 @@ var _ = Describe("Ethereum network controller", func() {
 		t := true
 		ownerReference := metav1.OwnerReference{
 			// TODO: update version
-			APIVersion:         "ethereum.kotal.io/v1alpha1",
+			APIVersion:         "ethereum.kotal.io/v1beta1",
 			Kind:               "Network",
 			Name:               toCreate.Name,
 			Controller:         &t,
@@ -1442,7 +1459,7 @@ var _ = Describe("Ethereum network controller", func() {
 		})
 
 		It("Should Get the network", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
 			Expect(fetched.Spec).To(Equal(toCreate.Spec))
 			Expect(fetched.Status.NodesCount).To(Equal(len(toCreate.Spec.Nodes)))
@@ -1477,27 +1494,28 @@ var _ = Describe("Ethereum network controller", func() {
 			nodeDep := &appsv1.Deployment{}
 			Expect(k8sClient.Get(context.Background(), bootnodeKey, nodeDep)).To(Succeed())
 			Expect(nodeDep.GetOwnerReferences()).To(ContainElement(ownerReference))
-			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(BesuImage()))
-			Expect(nodeDep.Spec.Template.Spec.Containers[0].Args).To(ContainElements([]string{
+			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(BesuImage("")))
+			Expect(nodeDep.Spec.Template.Spec.Containers[0].Args).To(ContainElement(BesuConfigFile))
+			expectBesuConfig(types.NamespacedName{Name: bootnodeKey.Name + "-config", Namespace: bootnodeKey.Namespace}, []string{
 				BesuDataPath,
 				BesuNodePrivateKey,
 				BesuSyncMode,
-				string(ethereumv1alpha1.FullSynchronization),
+				string(ethereumv1beta1.FullSynchronization),
 				BesuLogging,
-				besuClient.LoggingArgFromVerbosity(ethereumv1alpha1.WarnLogs),
-			}))
+				besuClient.LoggingArgFromVerbosity(ethereumv1beta1.WarnLogs),
+			})
 		})
 
 		It("Should allocate correct resources to bootnode deployment", func() {
 			nodeDep := &appsv1.Deployment{}
 			expectedResources := v1.ResourceRequirements{
 				Requests: v1.ResourceList{
-					v1.ResourceCPU:    resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeCPURequest),
-					v1.ResourceMemory: resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeMemoryRequest),
+					v1.ResourceCPU:    resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeCPURequest),
+					v1.ResourceMemory: resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeMemoryRequest),
 				},
 				Limits: v1.ResourceList{
-					v1.ResourceCPU:    resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeCPULimit),
-					v1.ResourceMemory: resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeMemoryLimit),
+					v1.ResourceCPU:    resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeCPULimit),
+					v1.ResourceMemory: resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeMemoryLimit),
 				},
 			}
 			Expect(k8sClient.Get(context.Background(), bootnodeKey, nodeDep)).To(Succeed())
@@ -1508,7 +1526,7 @@ var _ = Describe("Ethereum network controller", func() {
 			nodePVC := &v1.PersistentVolumeClaim{}
 			expectedResources := v1.ResourceRequirements{
 				Requests: v1.ResourceList{
-					v1.ResourceStorage: resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeStorageRequest),
+					v1.ResourceStorage: resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeStorageRequest),
 				},
 			}
 			Expect(k8sClient.Get(context.Background(), bootnodeKey, nodePVC)).To(Succeed())
@@ -1517,25 +1535,25 @@ var _ = Describe("Ethereum network controller", func() {
 		})
 
 		It("Should update the network", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
-			newNode := ethereumv1alpha1.Node{
+			newNode := ethereumv1beta1.Node{
 				Name:    "node-2",
 				RPC:     true,
 				RPCPort: 8547,
-				Resources: &ethereumv1alpha1.NodeResources{
+				Resources: &ethereumv1beta1.NodeResources{
 					CPU:    cpu,
 					Memory: memory,
 				},
-				SyncMode: ethereumv1alpha1.FastSynchronization,
-				Logging:  ethereumv1alpha1.DebugLogs,
+				SyncMode: ethereumv1beta1.FastSynchronization,
+				Logging:  ethereumv1beta1.DebugLogs,
 			}
 			fetched.Spec.Nodes = append(fetched.Spec.Nodes, newNode)
 			if !useExistingCluster {
 				fetched.Default()
 			}
 			Expect(k8sClient.Update(context.Background(), fetched)).To(Succeed())
-			fetchedUpdated := &ethereumv1alpha1.Network{}
+			fetchedUpdated := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetchedUpdated)).To(Succeed())
 			Expect(fetchedUpdated.Spec).To(Equal(fetched.Spec))
 			time.Sleep(sleepTime)
@@ -1545,17 +1563,18 @@ var _ = Describe("Ethereum network controller", func() {
 			nodeDep := &appsv1.Deployment{}
 			Expect(k8sClient.Get(context.Background(), node2Key, nodeDep)).To(Succeed())
 			Expect(nodeDep.GetOwnerReferences()).To(ContainElement(ownerReference))
-			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(BesuImage()))
-			Expect(nodeDep.Spec.Template.Spec.Containers[0].Args).To(ContainElements([]string{
+			Expect(nodeDep.Spec.Template.Spec.Containers[0].Image).To(Equal(BesuImage("")))
+			Expect(nodeDep.Spec.Template.Spec.Containers[0].Args).To(ContainElement(BesuConfigFile))
+			expectBesuConfig(types.NamespacedName{Name: bootnodeKey.Name + "-config", Namespace: bootnodeKey.Namespace}, []string{
 				BesuDataPath,
 				BesuBootnodes,
 				BesuRPCHTTPEnabled,
 				"8547",
 				BesuSyncMode,
-				string(ethereumv1alpha1.FastSynchronization),
+				string(ethereumv1beta1.FastSynchronization),
 				BesuLogging,
-				besuClient.LoggingArgFromVerbosity(ethereumv1alpha1.DebugLogs),
-			}))
+				besuClient.LoggingArgFromVerbosity(ethereumv1beta1.DebugLogs),
+			})
 		})
 
 		It("Should allocate correct resources to node-2 deployment", func() {
@@ -1566,8 +1585,8 @@ var _ = Describe("Ethereum network controller", func() {
 					v1.ResourceMemory: resource.MustParse(memory),
 				},
 				Limits: v1.ResourceList{
-					v1.ResourceCPU:    resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeCPULimit),
-					v1.ResourceMemory: resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeMemoryLimit),
+					v1.ResourceCPU:    resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeCPULimit),
+					v1.ResourceMemory: resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeMemoryLimit),
 				},
 			}
 			Expect(k8sClient.Get(context.Background(), node2Key, nodeDep)).To(Succeed())
@@ -1578,7 +1597,7 @@ var _ = Describe("Ethereum network controller", func() {
 			nodePVC := &v1.PersistentVolumeClaim{}
 			expectedResources := v1.ResourceRequirements{
 				Requests: v1.ResourceList{
-					v1.ResourceStorage: resource.MustParse(ethereumv1alpha1.DefaultPrivateNetworkNodeStorageRequest),
+					v1.ResourceStorage: resource.MustParse(ethereumv1beta1.DefaultPrivateNetworkNodeStorageRequest),
 				},
 			}
 			Expect(k8sClient.Get(context.Background(), node2Key, nodePVC)).To(Succeed())
@@ -1597,11 +1616,11 @@ var _ = Describe("Ethereum network controller", func() {
 		})
 
 		It("Should update the network by removing node-2", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
 			fetched.Spec.Nodes = fetched.Spec.Nodes[:1]
 			Expect(k8sClient.Update(context.Background(), fetched)).To(Succeed())
-			fetchedUpdated := &ethereumv1alpha1.Network{}
+			fetchedUpdated := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetchedUpdated)).To(Succeed())
 			Expect(fetchedUpdated.Spec).To(Equal(fetched.Spec))
 			time.Sleep(sleepTime)
@@ -1622,14 +1641,14 @@ var _ = Describe("Ethereum network controller", func() {
 		}
 
 		It("Should delete network", func() {
-			toDelete := &ethereumv1alpha1.Network{}
+			toDelete := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, toDelete)).To(Succeed())
 			Expect(k8sClient.Delete(context.Background(), toDelete)).To(Succeed())
 			time.Sleep(sleepTime)
 		})
 
 		It("Should not get network after deletion", func() {
-			fetched := &ethereumv1alpha1.Network{}
+			fetched := &ethereumv1beta1.Network{}
 			Expect(k8sClient.Get(context.Background(), key, fetched)).ToNot(Succeed())
 		})
 
@@ -1672,3 +1691,100 @@ var _ = Describe("Ethereum network controller", func() {
 	})
 
 })
+
+// expectBesuConfig expects besu node configuration file to contain all given arguments
+func expectBesuConfig(key types.NamespacedName, args []string) {
+	nodeConfig := &v1.ConfigMap{}
+	Expect(k8sClient.Get(context.Background(), key, nodeConfig)).To(Succeed())
+	config := nodeConfig.Data["config.toml"]
+	for _, arg := range args {
+		Expect(config).To(ContainSubstring(strings.TrimPrefix(arg, "--")))
+	}
+}
+
+func TestUpdateBootnodesCondition(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	network.Spec.Genesis = &ethereumv1beta1.Genesis{ChainID: 7777}
+	network.Spec.Nodes = append(network.Spec.Nodes, ethereumv1beta1.Node{Name: "node-2"})
+
+	cases := []struct {
+		bootnodes []string
+		pending   bool
+		status    v1.ConditionStatus
+		reason    string
+	}{
+		{nil, false, v1.ConditionTrue, "NoBootnodes"},
+		{nil, true, v1.ConditionTrue, "BootnodesPending"},
+		{[]string{"enode://node-1"}, false, v1.ConditionFalse, "BootnodesPublished"},
+	}
+
+	for _, c := range cases {
+		network.Status.Bootnodes = c.bootnodes
+		updateBootnodesCondition(network, c.pending)
+
+		condition := network.Status.GetCondition(ethereumv1beta1.BootnodesMissingCondition)
+		if condition == nil || condition.Status != c.status || condition.Reason != c.reason {
+			t.Errorf("Expecting bootnodes missing condition %s (%s) got %v", c.status, c.reason, condition)
+		}
+	}
+}
+
+func TestRecordSpecChange(t *testing.T) {
+	network := &ethereumv1beta1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "audit",
+			Namespace:  "default",
+			Generation: 1,
+		},
+		Spec: ethereumv1beta1.NetworkSpec{
+			Join: "rinkeby",
+			Nodes: []ethereumv1beta1.Node{
+				{Name: "node-1"},
+				{Name: "node-2"},
+			},
+		},
+	}
+
+	recorder := record.NewFakeRecorder(20)
+	r := &NetworkReconciler{Recorder: recorder}
+
+	if err := r.recordSpecChange(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if history := network.Status.History; len(history) != 1 || history[0].Changes[0] != "created" {
+		t.Fatalf("Expecting network creation to be recorded got %+v", history)
+	}
+	<-recorder.Events
+
+	// observed generation isn't recorded again
+	if err := r.recordSpecChange(network); err != nil || len(network.Status.History) != 1 {
+		t.Fatalf("Expecting observed generation not to be recorded again got %+v", network.Status.History)
+	}
+
+	network.Generation = 2
+	network.Spec.Nodes = []ethereumv1beta1.Node{
+		{Name: "node-1", RPC: true},
+		{Name: "node-3"},
+	}
+
+	if err := r.recordSpecChange(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	expected := "nodes[node-1] changed, nodes[node-2] removed, nodes[node-3] added"
+	if changes := strings.Join(network.Status.History[0].Changes, ", "); changes != expected {
+		t.Errorf("Expecting changes %s got %s", expected, changes)
+	}
+	if event := <-recorder.Events; !strings.Contains(event, "SpecChanged generation 2") {
+		t.Errorf("Expecting spec change event got %s", event)
+	}
+
+	for generation := int64(3); generation < 20; generation++ {
+		network.Generation = generation
+		network.Spec.Nodes[0].RPCPort = uint(generation)
+		r.recordSpecChange(network)
+	}
+	if history := network.Status.History; len(history) != 10 || history[0].Generation != 19 {
+		t.Errorf("Expecting history to keep most recent 10 changes got %d", len(history))
+	}
+}
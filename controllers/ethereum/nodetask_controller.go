@@ -0,0 +1,265 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/label"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	"github.com/kotalco/kotal/metrics"
+	"github.com/kotalco/kotal/tracing"
+)
+
+// NodeTaskReconciler reconciles a NodeTask object
+type NodeTaskReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// RateLimiter limits how frequently pending and failing tasks are requeued, nil uses controller-runtime default
+	RateLimiter ratelimiter.RateLimiter
+}
+
+// +kubebuilder:rbac:groups=ethereum.kotal.io,resources=nodetasks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ethereum.kotal.io,resources=nodetasks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=watch;get;list;create;delete
+
+// Reconcile runs node task job once its node is stopped if task pauses node, and reports task job result
+func (r *NodeTaskReconciler) Reconcile(req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(context.Background(), "NodeTask.Reconcile",
+		label.String("namespace", req.Namespace),
+		label.String("name", req.Name),
+	)
+	defer func() { tracing.End(ctx, span, err) }()
+
+	var task ethereumv1beta1.NodeTask
+
+	defer func(start time.Time) {
+		metrics.ObserveReconcile("nodetask", req.NamespacedName, start, err)
+	}(time.Now())
+
+	if err = r.Client.Get(context.Background(), req.NamespacedName, &task); err != nil {
+		if err = client.IgnoreNotFound(err); err == nil {
+			metrics.Forget("nodetask", req.NamespacedName)
+		}
+		return
+	}
+
+	// task is run once
+	if task.IsCompleted() {
+		return
+	}
+
+	pending, err := r.runTask(&task)
+	if err != nil {
+		return
+	}
+
+	if err = r.Status().Update(context.Background(), &task); err != nil {
+		r.Log.Error(err, "unable to update node task status")
+		return
+	}
+
+	// requeue with backoff until node pods are gone
+	if pending {
+		result = ctrl.Result{Requeue: true}
+	}
+
+	return
+}
+
+// runTask creates task job and updates task status from task job state
+// pending is whether task is waiting for its node to stop
+func (r *NodeTaskReconciler) runTask(task *ethereumv1beta1.NodeTask) (pending bool, err error) {
+	var network ethereumv1beta1.Network
+	if err = r.Client.Get(context.Background(), types.NamespacedName{Name: task.Spec.Network, Namespace: task.Namespace}, &network); err != nil {
+		if apierrors.IsNotFound(err) {
+			failTask(task, fmt.Sprintf("network %s is not found", task.Spec.Network))
+			return false, nil
+		}
+		r.Log.Error(err, "unable to get node task network")
+		return
+	}
+
+	// task may run against pool node created after scaling the pool
+	expandNodePools(&network)
+
+	var node *ethereumv1beta1.Node
+	for i := range network.Spec.Nodes {
+		if network.Spec.Nodes[i].Name == task.Spec.Node {
+			node = &network.Spec.Nodes[i]
+		}
+	}
+	if node == nil {
+		failTask(task, fmt.Sprintf("node %s is not found in network %s", task.Spec.Node, network.Name))
+		return false, nil
+	}
+
+	command, args, unsupported := nodeTaskCommand(task, node, &network)
+	if unsupported != "" {
+		failTask(task, unsupported)
+		return false, nil
+	}
+
+	job := &batchv1.Job{}
+	err = r.Client.Get(context.Background(), types.NamespacedName{Name: nodeTaskJobName(task), Namespace: task.Namespace}, job)
+	if err != nil && !apierrors.IsNotFound(err) {
+		r.Log.Error(err, "unable to get node task job")
+		return
+	}
+
+	// task job is running or completed
+	if err == nil {
+		completed, succeeded, message := jobResult(job)
+		if !completed {
+			task.Status.Phase = ethereumv1beta1.NodeTaskRunning
+			return false, nil
+		}
+		now := metav1.Now()
+		task.Status.CompletionTime = &now
+		task.Status.Message = message
+		task.Status.Phase = ethereumv1beta1.NodeTaskFailed
+		if succeeded {
+			task.Status.Phase = ethereumv1beta1.NodeTaskSucceeded
+		}
+		return false, nil
+	}
+
+	// paused node is stopped by network controller, task job is created once node pods are gone
+	if task.Spec.Pause {
+		var pods corev1.PodList
+		if err = r.Client.List(context.Background(), &pods, client.InNamespace(task.Namespace), client.MatchingLabels(node.Labels(network.Name))); err != nil {
+			r.Log.Error(err, "unable to list node pods")
+			return
+		}
+		if len(pods.Items) != 0 {
+			task.Status.Phase = ethereumv1beta1.NodeTaskPending
+			task.Status.Message = fmt.Sprintf("waiting for node %s to stop", node.Name)
+			return true, nil
+		}
+	}
+
+	job = &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nodeTaskJobName(task),
+			Namespace: task.Namespace,
+			Labels:    node.ResourceLabels(network.Name, network.Spec.Consensus),
+		},
+	}
+	specNodeDataJob(job, node, &network, "task", command, args)
+
+	if err = ctrl.SetControllerReference(task, job, r.Scheme); err != nil {
+		return
+	}
+
+	if err = r.Client.Create(context.Background(), job); err != nil {
+		r.Log.Error(err, "unable to create node task job")
+		return
+	}
+
+	now := metav1.Now()
+	task.Status.StartTime = &now
+	task.Status.Phase = ethereumv1beta1.NodeTaskRunning
+	task.Status.Message = ""
+
+	return false, nil
+}
+
+// nodeTaskJobName returns name of the job running the task
+func nodeTaskJobName(task *ethereumv1beta1.NodeTask) string {
+	return boundedJobName(fmt.Sprintf("%s-task", task.Name))
+}
+
+// failTask marks task as failed before its job is created
+func failTask(task *ethereumv1beta1.NodeTask, message string) {
+	now := metav1.Now()
+	task.Status.Phase = ethereumv1beta1.NodeTaskFailed
+	task.Status.CompletionTime = &now
+	task.Status.Message = message
+}
+
+// nodeTaskCommand returns node client command and arguments running the task
+// unsupported is why task can't be run against the node, it's empty if task is supported
+func nodeTaskCommand(task *ethereumv1beta1.NodeTask, node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) (command string, args []string, unsupported string) {
+	if node.IsDedicatedBootnode() || node.WithEphemeralStorage() {
+		return "", nil, fmt.Sprintf("node %s doesn't keep chain data", node.Name)
+	}
+
+	file := path.Join(PathBlockchainData, task.Spec.File)
+
+	if node.Client == ethereumv1beta1.BesuClient {
+		switch task.Spec.Task {
+		case ethereumv1beta1.ExportChainTask:
+			command, args = nodeClientCommand(node, network, "blocks", "export", fmt.Sprintf("--to=%s", file))
+		case ethereumv1beta1.ImportChainTask:
+			command, args = nodeClientCommand(node, network, "blocks", "import", fmt.Sprintf("--from=%s", file))
+		default:
+			unsupported = fmt.Sprintf("%s client doesn't support %s task", node.Client, task.Spec.Task)
+		}
+		return
+	}
+
+	switch task.Spec.Task {
+	case ethereumv1beta1.ExportChainTask:
+		command, args = nodeClientCommand(node, network, "export", file)
+	case ethereumv1beta1.ImportChainTask:
+		command, args = nodeClientCommand(node, network, "import", file)
+	case ethereumv1beta1.CompactDatabaseTask:
+		// db commands were added in geth 1.10.2
+		if clientVersionBefore(node, "1.10.2") {
+			unsupported = fmt.Sprintf("%s image %s doesn't support %s task", node.Client, nodeClientImage(node), task.Spec.Task)
+			return
+		}
+		command, args = nodeClientCommand(node, network, "db", "compact")
+	case ethereumv1beta1.PruneSnapshotTask:
+		// snapshot commands were added in geth 1.10.0
+		if clientVersionBefore(node, "1.10.0") {
+			unsupported = fmt.Sprintf("%s image %s doesn't support %s task", node.Client, nodeClientImage(node), task.Spec.Task)
+			return
+		}
+		command, args = nodeClientCommand(node, network, "snapshot", "prune-state")
+	}
+
+	return
+}
+
+// mapNodeTaskToNetwork maps node tasks to their network, network stops and starts again paused nodes
+var mapNodeTaskToNetwork = handler.ToRequestsFunc(func(obj handler.MapObject) []reconcile.Request {
+	task, ok := obj.Object.(*ethereumv1beta1.NodeTask)
+	if !ok || !task.Spec.Pause {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      task.Spec.Network,
+				Namespace: task.Namespace,
+			},
+		},
+	}
+})
+
+// SetupWithManager registers the controller to be started with the given manager
+func (r *NodeTaskReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ethereumv1beta1.NodeTask{}).
+		Owns(&batchv1.Job{}).
+		WithOptions(controller.Options{RateLimiter: r.RateLimiter}).
+		Complete(r)
+}
@@ -0,0 +1,201 @@
+package controllers
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func nodeTaskTestTask(task ethereumv1beta1.NodeTaskType, file string, pause bool) *ethereumv1beta1.NodeTask {
+	return &ethereumv1beta1.NodeTask{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-task", Namespace: "default"},
+		Spec: ethereumv1beta1.NodeTaskSpec{
+			Network: "my-network",
+			Node:    "node-1",
+			Task:    task,
+			File:    file,
+			Pause:   pause,
+		},
+	}
+}
+
+func TestNodeTaskCommand(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+
+	cases := []struct {
+		task     *ethereumv1beta1.NodeTask
+		expected string
+	}{
+		{nodeTaskTestTask(ethereumv1beta1.ExportChainTask, "chain.rlp", false), "geth --datadir /mnt/data --rinkeby export /mnt/data/chain.rlp"},
+		{nodeTaskTestTask(ethereumv1beta1.ImportChainTask, "chain.rlp", true), "geth --datadir /mnt/data --rinkeby import /mnt/data/chain.rlp"},
+		{nodeTaskTestTask(ethereumv1beta1.CompactDatabaseTask, "", true), "geth --datadir /mnt/data --rinkeby db compact"},
+		{nodeTaskTestTask(ethereumv1beta1.PruneSnapshotTask, "", true), "geth --datadir /mnt/data --rinkeby snapshot prune-state"},
+	}
+
+	for _, c := range cases {
+		command, args, unsupported := nodeTaskCommand(c.task, node, network)
+		if got := command + " " + strings.Join(args, " "); unsupported != "" || got != c.expected {
+			t.Errorf("Expecting %s task command %s got %s (%s)", c.task.Spec.Task, c.expected, got, unsupported)
+		}
+	}
+
+	network = argsTestNetwork(ethereumv1beta1.BesuClient)
+	node = &network.Spec.Nodes[0]
+
+	command, args, _ := nodeTaskCommand(nodeTaskTestTask(ethereumv1beta1.ExportChainTask, "chain.rlp", false), node, network)
+	if got := command + " " + strings.Join(args, " "); got != "besu --data-path /mnt/data --network rinkeby blocks export --to=/mnt/data/chain.rlp" {
+		t.Errorf("Expecting besu blocks export got %s", got)
+	}
+
+	if _, _, unsupported := nodeTaskCommand(nodeTaskTestTask(ethereumv1beta1.CompactDatabaseTask, "", true), node, network); unsupported == "" {
+		t.Errorf("Expecting besu database compaction to be unsupported")
+	}
+	// geth images without db and snapshot commands
+	network = argsTestNetwork(ethereumv1beta1.GethClient)
+	node = &network.Spec.Nodes[0]
+	os.Setenv(EnvGethImage, "ethereum/client-go:v1.10.1")
+	defer os.Unsetenv(EnvGethImage)
+
+	if _, _, unsupported := nodeTaskCommand(nodeTaskTestTask(ethereumv1beta1.CompactDatabaseTask, "", true), node, network); unsupported == "" {
+		t.Errorf("Expecting geth 1.10.1 database compaction to be unsupported")
+	}
+	if _, _, unsupported := nodeTaskCommand(nodeTaskTestTask(ethereumv1beta1.PruneSnapshotTask, "", true), node, network); unsupported != "" {
+		t.Errorf("Expecting geth 1.10.1 snapshot pruning to be supported got %s", unsupported)
+	}
+
+	os.Setenv(EnvGethImage, "ethereum/client-go:v1.9.20")
+	if _, _, unsupported := nodeTaskCommand(nodeTaskTestTask(ethereumv1beta1.PruneSnapshotTask, "", true), node, network); unsupported == "" {
+		t.Errorf("Expecting geth 1.9.20 snapshot pruning to be unsupported")
+	}
+}
+
+func TestNodeTaskJobName(t *testing.T) {
+	task := nodeTaskTestTask(ethereumv1beta1.CompactDatabaseTask, "", true)
+	if name := nodeTaskJobName(task); name != "my-task-task" {
+		t.Errorf("Expecting job name my-task-task got %s", name)
+	}
+
+	// task names can be up to 253 characters
+	task.Name = strings.Repeat("t", 253)
+	if name := nodeTaskJobName(task); len(name) > 63 {
+		t.Errorf("Expecting job name to be shortened to 63 characters got %s", name)
+	}
+}
+
+func TestRunNodeTask(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = ethereumv1beta1.AddToScheme(scheme)
+
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	task := nodeTaskTestTask(ethereumv1beta1.CompactDatabaseTask, "", true)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-network-node-1-abcde", Namespace: "default", Labels: node.Labels(network.Name)},
+	}
+
+	r := &NodeTaskReconciler{
+		Client: fake.NewFakeClientWithScheme(scheme, network, task, pod),
+		Log:    ctrl.Log,
+		Scheme: scheme,
+	}
+
+	// node is still running
+	pending, err := r.runTask(task)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if !pending || task.Status.Phase != ethereumv1beta1.NodeTaskPending {
+		t.Fatalf("Expecting task to wait for node to stop got phase %s", task.Status.Phase)
+	}
+
+	// node is stopped
+	if err := r.Client.Delete(context.Background(), pod); err != nil {
+		t.Fatal(err)
+	}
+	if pending, err = r.runTask(task); err != nil || pending {
+		t.Fatalf("Expecting task job to be created got pending %t and error %v", pending, err)
+	}
+	if task.Status.Phase != ethereumv1beta1.NodeTaskRunning || task.Status.StartTime == nil {
+		t.Errorf("Expecting task to be running got phase %s", task.Status.Phase)
+	}
+
+	job := &batchv1.Job{}
+	key := types.NamespacedName{Name: nodeTaskJobName(task), Namespace: task.Namespace}
+	if err := r.Client.Get(context.Background(), key, job); err != nil {
+		t.Fatalf("Expecting task job to be created got %s", err)
+	}
+	if args := strings.Join(job.Spec.Template.Spec.Containers[0].Args, " "); !strings.HasSuffix(args, "db compact") {
+		t.Errorf("Expecting task job to compact node database got %s", args)
+	}
+
+	// task job completed
+	job.Status.Succeeded = 1
+	if err := r.Client.Status().Update(context.Background(), job); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.runTask(task); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if !task.IsCompleted() || task.Status.Phase != ethereumv1beta1.NodeTaskSucceeded {
+		t.Errorf("Expecting task to succeed got phase %s", task.Status.Phase)
+	}
+
+	// unknown node
+	task = nodeTaskTestTask(ethereumv1beta1.CompactDatabaseTask, "", true)
+	task.Spec.Node = "node-99"
+	if _, err = r.runTask(task); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if task.Status.Phase != ethereumv1beta1.NodeTaskFailed {
+		t.Errorf("Expecting task against unknown node to fail got phase %s", task.Status.Phase)
+	}
+}
+
+func TestReconcilePausedNodes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = ethereumv1beta1.AddToScheme(scheme)
+
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	task := nodeTaskTestTask(ethereumv1beta1.CompactDatabaseTask, "", true)
+
+	r := &NetworkReconciler{
+		Client: fake.NewFakeClientWithScheme(scheme, network, task),
+		Log:    ctrl.Log,
+		Scheme: scheme,
+	}
+
+	if err := r.reconcilePausedNodes(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if !nodePaused(node, network) {
+		t.Fatalf("Expecting node to be paused by running task")
+	}
+
+	// completed task starts node again
+	task.Status.Phase = ethereumv1beta1.NodeTaskSucceeded
+	if err := r.Client.Status().Update(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.reconcilePausedNodes(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if nodePaused(node, network) {
+		t.Errorf("Expecting node not to be paused after task completed")
+	}
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// nodePaused is whether node is stopped by a running node task
+func nodePaused(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) bool {
+	status := nodeStatus(network, node.Name)
+	return status != nil && status.Paused
+}
+
+// reconcilePausedNodes records nodes paused by running node tasks in nodes status, it's called before nodes are reconciled
+// paused nodes are started again once their tasks are completed or deleted
+func (r *NetworkReconciler) reconcilePausedNodes(network *ethereumv1beta1.Network) error {
+	var tasks ethereumv1beta1.NodeTaskList
+	if err := r.Client.List(context.Background(), &tasks, client.InNamespace(network.Namespace)); err != nil {
+		r.Log.Error(err, "unable to list node tasks")
+		return err
+	}
+
+	paused := map[string]bool{}
+	for i := range tasks.Items {
+		task := &tasks.Items[i]
+		if task.Spec.Network == network.Name && task.PausesNode() {
+			paused[task.Spec.Node] = true
+		}
+	}
+
+	for _, node := range network.Spec.Nodes {
+		status := nodeStatus(network, node.Name)
+		if status == nil {
+			if !paused[node.Name] {
+				continue
+			}
+			// node is paused before it's created, its status is carried forward by nodes reconciliation
+			network.Status.Nodes = append(network.Status.Nodes, ethereumv1beta1.NodeStatus{Name: node.Name})
+			status = &network.Status.Nodes[len(network.Status.Nodes)-1]
+		}
+		status.Paused = paused[node.Name]
+	}
+
+	return nil
+}
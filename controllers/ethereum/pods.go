@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// failureReasons are container waiting reasons that mean the node is failing
+// and won't recover without user intervention
+var failureReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"CreateContainerConfigError": true,
+}
+
+// podFailure returns reason and message of pod failure, empty reason if pod is not failing
+func podFailure(pod *corev1.Pod) (reason, message string) {
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+	for _, status := range statuses {
+		waiting := status.State.Waiting
+		if waiting != nil && failureReasons[waiting.Reason] {
+			return waiting.Reason, waiting.Message
+		}
+	}
+
+	return "", ""
+}
+
+// podReady is whether pod is ready or no
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// mapPodToNetwork maps node pods to the network they belong to
+var mapPodToNetwork = handler.ToRequestsFunc(func(obj handler.MapObject) []reconcile.Request {
+	labels := obj.Meta.GetLabels()
+
+	if labels["name"] != "node" || labels["network"] == "" {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      labels["network"],
+				Namespace: obj.Meta.GetNamespace(),
+			},
+		},
+	}
+})
+
+// setPodCondition sets pod condition status and reason, and returns whether condition changed
+func setPodCondition(pod *corev1.Pod, conditionType corev1.PodConditionType, status corev1.ConditionStatus, reason string) bool {
+	now := metav1.Now()
+
+	for i := range pod.Status.Conditions {
+		condition := &pod.Status.Conditions[i]
+		if condition.Type != conditionType {
+			continue
+		}
+		if condition.Status == status && condition.Reason == reason {
+			return false
+		}
+		if condition.Status != status {
+			condition.LastTransitionTime = now
+		}
+		condition.Status = status
+		condition.Reason = reason
+		return true
+	}
+
+	pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		LastTransitionTime: now,
+	})
+
+	return true
+}
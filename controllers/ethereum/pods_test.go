@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestPodFailure(t *testing.T) {
+	cases := []struct {
+		title  string
+		pod    *corev1.Pod
+		reason string
+	}{
+		{
+			"running pod",
+			&corev1.Pod{
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{
+						{State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+					},
+				},
+			},
+			"",
+		},
+		{
+			"creating pod",
+			&corev1.Pod{
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{
+						{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+					},
+				},
+			},
+			"",
+		},
+		{
+			"crashlooping pod",
+			&corev1.Pod{
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{
+						{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+					},
+				},
+			},
+			"CrashLoopBackOff",
+		},
+		{
+			"pod with init container failing to pull image",
+			&corev1.Pod{
+				Status: corev1.PodStatus{
+					InitContainerStatuses: []corev1.ContainerStatus{
+						{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}},
+					},
+				},
+			},
+			"ImagePullBackOff",
+		},
+	}
+
+	for _, c := range cases {
+		if reason, _ := podFailure(c.pod); reason != c.reason {
+			t.Errorf("%s: expecting failure reason %q got %q", c.title, c.reason, reason)
+		}
+	}
+}
+
+func TestSetPodCondition(t *testing.T) {
+	pod := &corev1.Pod{}
+	conditionType := corev1.PodConditionType(SyncedCondition)
+
+	if !setPodCondition(pod, conditionType, corev1.ConditionFalse, "Syncing") {
+		t.Errorf("Expecting missing condition to be added")
+	}
+	if setPodCondition(pod, conditionType, corev1.ConditionFalse, "Syncing") {
+		t.Errorf("Expecting unchanged condition not to be updated")
+	}
+	if !setPodCondition(pod, conditionType, corev1.ConditionTrue, "Synced") {
+		t.Errorf("Expecting changed condition to be updated")
+	}
+	if len(pod.Status.Conditions) != 1 || pod.Status.Conditions[0].Status != corev1.ConditionTrue {
+		t.Errorf("Expecting single true condition got %v", pod.Status.Conditions)
+	}
+}
+
+func TestNodesReadiness(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = ethereumv1beta1.AddToScheme(scheme)
+
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	network.Spec.Nodes = append(network.Spec.Nodes, ethereumv1beta1.Node{Name: "node-2", Client: ethereumv1beta1.BesuClient})
+	network.Spec.Nodes = append(network.Spec.Nodes, ethereumv1beta1.Node{Name: "node-3", Client: ethereumv1beta1.BesuClient})
+	network.Status.Nodes = []ethereumv1beta1.NodeStatus{{Name: "node-1"}, {Name: "node-2"}, {Name: "node-3"}}
+
+	ready := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-network-node-1-abcde", Namespace: "default", Labels: network.Spec.Nodes[0].Labels(network.Name)},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	notReady := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-network-node-2-abcde", Namespace: "default", Labels: network.Spec.Nodes[1].Labels(network.Name)},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}},
+		},
+	}
+
+	r := &NetworkReconciler{
+		Client:   fake.NewFakeClientWithScheme(scheme, network, ready, notReady),
+		Log:      ctrl.Log,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	if err := r.updateNodesFailures(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if err := r.updateStatus(network); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	if !network.Status.Nodes[0].Ready || network.Status.Nodes[1].Ready || network.Status.Nodes[2].Ready {
+		t.Errorf("Expecting node-1 only to be ready got %+v", network.Status.Nodes)
+	}
+	if network.Status.ReadyNodes != 1 {
+		t.Errorf("Expecting 1 ready node got %d", network.Status.ReadyNodes)
+	}
+	if network.Status.Clients != "besu:2,geth:1" {
+		t.Errorf("Expecting clients summary besu:2,geth:1 got %s", network.Status.Clients)
+	}
+}
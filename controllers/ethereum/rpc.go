@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// rpcClient is http client used to call nodes JSON-RPC APIs
+var rpcClient = &http.Client{Timeout: 5 * time.Second}
+
+// rpcRequest is JSON-RPC request
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+// rpcResponse is JSON-RPC response
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// callRPC calls JSON-RPC method on node url and decodes result
+func callRPC(url, method string, result interface{}) error {
+	body, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  method,
+		Params:  []interface{}{},
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := rpcClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s call returned status code %d", method, resp.StatusCode)
+	}
+
+	response := rpcResponse{}
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	if response.Error != nil {
+		return fmt.Errorf("%s call failed: %s", method, response.Error.Message)
+	}
+
+	return json.Unmarshal(response.Result, result)
+}
+
+// decodeQuantity decodes hex encoded quantity
+func decodeQuantity(hex string) (uint64, error) {
+	if len(hex) < 3 || hex[:2] != "0x" {
+		return 0, fmt.Errorf("invalid hex quantity %s", hex)
+	}
+	return strconv.ParseUint(hex[2:], 16, 64)
+}
+
+// getSyncStatus returns node current block, highest known block and whether node is syncing or no
+func getSyncStatus(url string) (currentBlock, highestBlock uint64, syncing bool, err error) {
+	var raw json.RawMessage
+	if err = callRPC(url, "eth_syncing", &raw); err != nil {
+		return
+	}
+
+	// eth_syncing returns false if node is not syncing
+	if string(raw) != "false" {
+		progress := struct {
+			CurrentBlock string `json:"currentBlock"`
+			HighestBlock string `json:"highestBlock"`
+		}{}
+
+		if err = json.Unmarshal(raw, &progress); err != nil {
+			return
+		}
+		if currentBlock, err = decodeQuantity(progress.CurrentBlock); err != nil {
+			return
+		}
+		if highestBlock, err = decodeQuantity(progress.HighestBlock); err != nil {
+			return
+		}
+		syncing = true
+		return
+	}
+
+	var blockNumber string
+	if err = callRPC(url, "eth_blockNumber", &blockNumber); err != nil {
+		return
+	}
+	if currentBlock, err = decodeQuantity(blockNumber); err != nil {
+		return
+	}
+	highestBlock = currentBlock
+
+	return
+}
+
+// getPeerCount returns number of peers connected to the node
+func getPeerCount(url string) (uint64, error) {
+	var peerCount string
+	if err := callRPC(url, "net_peerCount", &peerCount); err != nil {
+		return 0, err
+	}
+	return decodeQuantity(peerCount)
+}
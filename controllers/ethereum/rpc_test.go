@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newRPCServer creates JSON-RPC test server that responds with the given results by method
+func newRPCServer(results map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := rpcRequest{}
+		json.NewDecoder(r.Body).Decode(&request)
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":%s}`, results[request.Method])
+	}))
+}
+
+func TestGetSyncStatusWhileSyncing(t *testing.T) {
+	server := newRPCServer(map[string]string{
+		"eth_syncing": `{"startingBlock":"0x0","currentBlock":"0x10","highestBlock":"0x20"}`,
+	})
+	defer server.Close()
+
+	current, highest, syncing, err := getSyncStatus(server.URL)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if current != 16 || highest != 32 || !syncing {
+		t.Errorf("Expecting current block 16, highest block 32 and syncing got %d, %d and %t", current, highest, syncing)
+	}
+}
+
+func TestGetSyncStatusWhileSynced(t *testing.T) {
+	server := newRPCServer(map[string]string{
+		"eth_syncing":     `false`,
+		"eth_blockNumber": `"0xff"`,
+	})
+	defer server.Close()
+
+	current, highest, syncing, err := getSyncStatus(server.URL)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if current != 255 || highest != 255 || syncing {
+		t.Errorf("Expecting current and highest block 255 and not syncing got %d, %d and %t", current, highest, syncing)
+	}
+}
+
+func TestGetPeerCount(t *testing.T) {
+	server := newRPCServer(map[string]string{
+		"net_peerCount": `"0x3"`,
+	})
+	defer server.Close()
+
+	peers, err := getPeerCount(server.URL)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if peers != 3 {
+		t.Errorf("Expecting peer count to be 3 got %d", peers)
+	}
+}
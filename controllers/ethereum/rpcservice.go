@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// inRPCService is whether node pods are load balanced by network rpc service
+func inRPCService(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network) bool {
+	return network.Spec.RPCService && node.ServesPublicRPC()
+}
+
+// specRPCService updates network rpc service spec
+// requests are forwarded to node client or rpc proxy sidecar using named container port
+func specRPCService(svc *corev1.Service, network *ethereumv1beta1.Network) {
+	svc.ObjectMeta.Labels = map[string]string{
+		"name":    "rpc",
+		"network": network.Name,
+	}
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "rpc",
+			Port:       int32(ethereumv1beta1.DefaultRPCPort),
+			TargetPort: intstr.FromString(PublicRPCPortName),
+			Protocol:   corev1.ProtocolTCP,
+		},
+	}
+	svc.Spec.Selector = map[string]string{
+		"name":          "node",
+		"network":       network.Name,
+		RPCServiceLabel: "true",
+	}
+}
+
+// reconcileRPCService creates network rpc service if it's enabled, deletes it otherwise
+func (r *NetworkReconciler) reconcileRPCService(network *ethereumv1beta1.Network) error {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      network.RPCServiceName(),
+			Namespace: network.Namespace,
+		},
+	}
+
+	if !network.Spec.RPCService {
+		if err := r.Client.Delete(context.Background(), svc); err != nil && !apierrors.IsNotFound(err) {
+			r.Log.Error(err, "unable to delete network rpc service")
+			return err
+		}
+		return nil
+	}
+
+	_, err := ctrl.CreateOrUpdate(context.Background(), r.Client, svc, func() error {
+		if err := ctrl.SetControllerReference(network, svc, r.Scheme); err != nil {
+			return err
+		}
+		specRPCService(svc, network)
+		return nil
+	})
+
+	return err
+}
+
+// updateNodesSyncedCondition sets synced condition of pods load balanced by network rpc service
+// pods aren't ready, and don't receive requests, until their node is synced
+// failing to update pod condition is not an error, it's updated on next status refresh
+func (r *NetworkReconciler) updateNodesSyncedCondition(network *ethereumv1beta1.Network) {
+	log := r.Log.WithName("synced condition")
+
+	for i := range network.Spec.Nodes {
+		node := &network.Spec.Nodes[i]
+
+		if !inRPCService(node, network) {
+			continue
+		}
+
+		var pods corev1.PodList
+		matchingLabels := client.MatchingLabels(node.Labels(network.Name))
+		inNamespace := client.InNamespace(network.Namespace)
+
+		if err := r.Client.List(context.Background(), &pods, matchingLabels, inNamespace); err != nil {
+			log.Info(fmt.Sprintf("unable to list node (%s) pods: %s", node.Name, err))
+			continue
+		}
+
+		for j := range pods.Items {
+			pod := &pods.Items[j]
+
+			if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+				continue
+			}
+
+			status, reason := corev1.ConditionFalse, "Syncing"
+			url := fmt.Sprintf("http://%s:%d", pod.Status.PodIP, node.RPCPort)
+			if _, _, syncing, err := getSyncStatus(url); err != nil {
+				reason = "SyncStatusUnknown"
+			} else if !syncing {
+				status, reason = corev1.ConditionTrue, "Synced"
+			}
+
+			if !setPodCondition(pod, corev1.PodConditionType(SyncedCondition), status, reason) {
+				continue
+			}
+
+			if err := r.Client.Status().Update(context.Background(), pod); err != nil {
+				log.Info(fmt.Sprintf("unable to update node (%s) pod %s synced condition: %s", node.Name, pod.Name, err))
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestSpecRPCServiceNodeDeployment(t *testing.T) {
+	r := &NetworkReconciler{StatusRefreshInterval: 30 * time.Second}
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	network.Spec.RPCService = true
+	node := &network.Spec.Nodes[0]
+
+	dep := &appsv1.Deployment{}
+	r.specNodeDeployment(dep, node, network, nil, nil, nil, nil)
+
+	if dep.Spec.Template.Labels[RPCServiceLabel] != "true" {
+		t.Errorf("Expecting node pods to be selected by network rpc service")
+	}
+	if _, exists := dep.Spec.Selector.MatchLabels[RPCServiceLabel]; exists {
+		t.Errorf("Expecting deployment selector not to include network rpc service label")
+	}
+	if gates := dep.Spec.Template.Spec.ReadinessGates; len(gates) != 1 || gates[0].ConditionType != SyncedCondition {
+		t.Errorf("Expecting synced readiness gate got %v", gates)
+	}
+	if ports := dep.Spec.Template.Spec.Containers[0].Ports; len(ports) != 1 || ports[0].Name != PublicRPCPortName {
+		t.Errorf("Expecting node container to expose public rpc port got %v", ports)
+	}
+
+	// miners are kept out of network rpc service
+	node.Miner = true
+	dep = &appsv1.Deployment{}
+	r.specNodeDeployment(dep, node, network, nil, nil, nil, nil)
+
+	if _, exists := dep.Spec.Template.Labels[RPCServiceLabel]; exists {
+		t.Errorf("Expecting miner pods not to be selected by network rpc service")
+	}
+	if len(dep.Spec.Template.Spec.ReadinessGates) != 0 {
+		t.Errorf("Expecting miner pods to have no readiness gates")
+	}
+
+	svc := &corev1.Service{}
+	specRPCService(svc, network)
+
+	if svc.Spec.Selector[RPCServiceLabel] != "true" || svc.Spec.Ports[0].TargetPort.String() != PublicRPCPortName {
+		t.Errorf("Expecting network rpc service to select public rpc port of rpc service pods got %v", svc.Spec)
+	}
+}
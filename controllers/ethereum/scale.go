@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// expandNodePools replaces pool nodes in nodes spec with nodes created from node pools, first pool is scaled to spec.replicas
+// scale subresource updates spec.replicas without going through the defaulting webhook, so pools are expanded on every reconciliation
+// network spec is updated in memory only, it's not persisted
+func expandNodePools(network *ethereumv1beta1.Network) {
+	if len(network.Spec.NodePools) == 0 {
+		return
+	}
+
+	network.DefaultNodePools()
+
+	// pool nodes inherit node template settings before falling back to defaults, like the defaulting webhook
+	for i := range network.Spec.Nodes {
+		node := &network.Spec.Nodes[i]
+		if node.Pool == "" {
+			continue
+		}
+		if network.Spec.NodeTemplate != nil {
+			network.InheritNodeTemplate(node)
+		}
+		network.DefaultNode(node)
+	}
+}
+
+// poolScaleStatus returns first node pool nodes count and pods label selector used by scale subresource
+func poolScaleStatus(network *ethereumv1beta1.Network) (replicas int32, selector string) {
+	if len(network.Spec.NodePools) == 0 {
+		return
+	}
+
+	pool := network.Spec.NodePools[0].Name
+
+	for _, node := range network.Spec.Nodes {
+		if node.Pool == pool {
+			replicas++
+		}
+	}
+
+	selector = labels.SelectorFromSet(network.PoolLabels(pool)).String()
+
+	return
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	"github.com/kotalco/kotal/metrics"
+)
+
+func TestPoolScaleStatus(t *testing.T) {
+	network := &ethereumv1beta1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-network",
+		},
+		Spec: ethereumv1beta1.NetworkSpec{
+			Nodes: []ethereumv1beta1.Node{
+				{Name: "bootnode"},
+				{Name: "rpc-0", Pool: "rpc"},
+				{Name: "rpc-1", Pool: "rpc"},
+				{Name: "miner-0", Pool: "miner"},
+			},
+			NodePools: []ethereumv1beta1.NodePool{
+				{Node: ethereumv1beta1.Node{Name: "rpc"}, Replicas: 2},
+				{Node: ethereumv1beta1.Node{Name: "miner"}, Replicas: 1},
+			},
+		},
+	}
+
+	replicas, selector := poolScaleStatus(network)
+
+	if replicas != 2 {
+		t.Errorf("Expecting replicas to be 2 got %d", replicas)
+	}
+
+	if expected := "name=node,network=test-network,pool=rpc"; selector != expected {
+		t.Errorf("Expecting selector to be %s got %s", expected, selector)
+	}
+
+	network.Spec.NodePools = nil
+	if replicas, selector := poolScaleStatus(network); replicas != 0 || selector != "" {
+		t.Errorf("Expecting no scale status without node pools got %d and %s", replicas, selector)
+	}
+}
+
+func TestExpandNodePools(t *testing.T) {
+	network := &ethereumv1beta1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-network",
+		},
+		Spec: ethereumv1beta1.NetworkSpec{
+			Join: ethereumv1beta1.RinkebyNetwork,
+			Nodes: []ethereumv1beta1.Node{
+				{Name: "bootnode", Bootnode: true},
+			},
+			NodePools: []ethereumv1beta1.NodePool{
+				{Node: ethereumv1beta1.Node{Name: "rpc", RPC: true}, Replicas: 2},
+			},
+		},
+	}
+	network.Default()
+
+	// scale subresource updates replicas only, without defaulting
+	replicas := int32(4)
+	network.Spec.Replicas = &replicas
+
+	expandNodePools(network)
+
+	if replicas, _ := poolScaleStatus(network); replicas != 4 {
+		t.Fatalf("Expecting pool to be scaled to 4 nodes got %d", replicas)
+	}
+
+	node := network.Spec.Nodes[4]
+	if node.Name != "rpc-3" || !node.RPC || node.Resources == nil || node.Client == "" {
+		t.Errorf("Expecting scaled pool node to be created from defaulted pool spec got %+v", node)
+	}
+
+	// scaled down
+	replicas = 1
+	network.Spec.Replicas = &replicas
+	expandNodePools(network)
+
+	if len(network.Spec.Nodes) != 2 || network.Spec.Nodes[0].Name != "bootnode" {
+		t.Errorf("Expecting scaled down pool nodes to be removed got %d nodes", len(network.Spec.Nodes))
+	}
+}
+
+func TestUpdateMetricsScaledPools(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = ethereumv1beta1.AddToScheme(scheme)
+
+	network := &ethereumv1beta1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-network",
+			Namespace: "default",
+		},
+		Spec: ethereumv1beta1.NetworkSpec{
+			Join: ethereumv1beta1.RinkebyNetwork,
+			Nodes: []ethereumv1beta1.Node{
+				{Name: "bootnode", Bootnode: true, Client: ethereumv1beta1.GethClient},
+			},
+			NodePools: []ethereumv1beta1.NodePool{
+				{Node: ethereumv1beta1.Node{Name: "rpc", Client: ethereumv1beta1.BesuClient}, Replicas: 1},
+			},
+		},
+	}
+	network.Default()
+
+	// scale subresource updates replicas only, stored nodes aren't expanded
+	replicas := int32(3)
+	network.Spec.Replicas = &replicas
+
+	r := &NetworkReconciler{
+		Client: fake.NewFakeClientWithScheme(scheme, network),
+		Log:    ctrl.Log,
+		Scheme: scheme,
+	}
+
+	if err := r.updateMetrics(); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	if got := testutil.ToFloat64(metrics.Nodes.WithLabelValues("network", string(ethereumv1beta1.BesuClient))); got != 3 {
+		t.Errorf("Expecting 3 besu nodes got %f", got)
+	}
+	if got := testutil.ToFloat64(metrics.Nodes.WithLabelValues("network", string(ethereumv1beta1.GethClient))); got != 1 {
+		t.Errorf("Expecting 1 geth node got %f", got)
+	}
+}
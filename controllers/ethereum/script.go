@@ -53,9 +53,11 @@ const importAccountScriptTemplate = `
 
 set -e
 
-if [ -z "$(ls -A {{.DataDir}}/keystore)" ]
+address=$(cat {{.ImportDir}}/account.address)
+
+if [ -z "$(ls -A {{.DataDir}}/keystore 2>/dev/null | grep -i $address)" ]
 then
-	echo "importing account"
+	echo "importing account $address"
 	geth account import --datadir {{.DataDir}} --password {{.ImportDir}}/account.password {{.ImportDir}}/account.key
 else
 	echo "account has been imported before!"
@@ -90,3 +92,19 @@ func generateImportAccountScript() (script string, err error) {
 
 	return
 }
+
+// besuLog4jConfig is log4j configuration used by besu to log in json format
+const besuLog4jConfig = `<?xml version="1.0" encoding="UTF-8"?>
+<Configuration status="INFO" monitorInterval="5">
+  <Appenders>
+    <Console name="Console" target="SYSTEM_OUT">
+      <JsonLayout compact="true" eventEol="true" stacktraceAsString="true"/>
+    </Console>
+  </Appenders>
+  <Loggers>
+    <Root level="${sys:root.log.level}">
+      <AppenderRef ref="Console"/>
+    </Root>
+  </Loggers>
+</Configuration>
+`
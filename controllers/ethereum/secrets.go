@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kotalco/kotal/helpers"
+)
+
+// specGeneratedSecret fills secret keys with random hex values of the given sizes in bytes
+// existing values are kept, secrets are generated only once
+func specGeneratedSecret(secret *corev1.Secret, sizes map[string]int) error {
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+
+	for key, size := range sizes {
+		if len(secret.Data[key]) != 0 {
+			continue
+		}
+		value, err := helpers.GenerateRandomHex(size)
+		if err != nil {
+			return err
+		}
+		secret.Data[key] = []byte(value)
+	}
+
+	return nil
+}
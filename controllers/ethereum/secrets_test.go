@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestSpecGeneratedSecret(t *testing.T) {
+	secret := &corev1.Secret{}
+
+	if err := specGeneratedSecret(secret, map[string]int{"password": 16}); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	password := string(secret.Data["password"])
+	if len(password) != 32 {
+		t.Errorf("Expecting 32 hex characters password got %q", password)
+	}
+
+	if err := specGeneratedSecret(secret, map[string]int{"password": 16}); err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	if got := string(secret.Data["password"]); got != password {
+		t.Errorf("Expecting generated password to be kept got %q instead of %q", got, password)
+	}
+}
+
+func TestSpecNodeSecretIsStable(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := &ethereumv1beta1.Network{ObjectMeta: metav1.ObjectMeta{Name: "my-network"}}
+	node := &ethereumv1beta1.Node{
+		Name:    "node-1",
+		Client:  ethereumv1beta1.GethClient,
+		Nodekey: "0x608e9b6f67c65e47531e08e8e501386dfae63a540fa3c48802c8aad854510b4e",
+		Import: &ethereumv1beta1.ImportedAccount{
+			PrivateKey: "0x5df5eff7ef9e4e82739b68a34c6b23608d79ee8daf3b598a01ffb0dd7aa3a2fd",
+			Password:   "secret",
+		},
+	}
+
+	secret := &corev1.Secret{}
+	r.specNodeSecret(secret, node, network)
+
+	if got := string(secret.Data["nodekey"]); got != string(node.Nodekey)[2:] {
+		t.Errorf("Expecting nodekey to be %s got %s", string(node.Nodekey)[2:], got)
+	}
+
+	// secret as read back from the api server
+	existing := secret.DeepCopy()
+	r.specNodeSecret(secret, node, network)
+
+	if !equality.Semantic.DeepEqual(existing, secret) {
+		t.Errorf("Expecting unchanged node secret spec to leave secret intact")
+	}
+}
+
+func TestNodeChecksums(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	network := &ethereumv1beta1.Network{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-network", Namespace: "default"},
+		Spec: ethereumv1beta1.NetworkSpec{
+			Genesis: &ethereumv1beta1.Genesis{},
+		},
+	}
+	node := &ethereumv1beta1.Node{
+		Name:    "node-1",
+		Client:  ethereumv1beta1.GethClient,
+		Nodekey: "0x608e9b6f67c65e47531e08e8e501386dfae63a540fa3c48802c8aad854510b4e",
+	}
+	configmap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: network.ConfigmapName(node.Client), Namespace: "default"},
+		Data:       map[string]string{"genesis.json": "{}"},
+	}
+
+	r := &NetworkReconciler{Client: fake.NewFakeClientWithScheme(scheme, configmap)}
+
+	checksums, err := r.nodeChecksums(node, network)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	if checksums[GenesisChecksumAnnotation] != dataChecksum(configmap.Data) {
+		t.Errorf("Expecting genesis checksum %s got %s", dataChecksum(configmap.Data), checksums[GenesisChecksumAnnotation])
+	}
+
+	// changing node key changes secret checksum
+	node.Nodekey = "0x608e9b6f67c65e47531e08e8e501386dfae63a540fa3c48802c8aad854510b4f"
+	changed, err := r.nodeChecksums(node, network)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	if changed[SecretChecksumAnnotation] == checksums[SecretChecksumAnnotation] {
+		t.Error("Expecting secret checksum to change with node key")
+	}
+}
+
+func TestSpecNodeSecretAccountAddress(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := &ethereumv1beta1.Network{ObjectMeta: metav1.ObjectMeta{Name: "my-network"}}
+	node := &ethereumv1beta1.Node{
+		Name:   "node-1",
+		Client: ethereumv1beta1.GethClient,
+		Import: &ethereumv1beta1.ImportedAccount{
+			PrivateKey: "0x5df5eff7ef9e4e82739b68a34c6b23608d79ee8daf3b598a01ffb0dd7aa3a2fd",
+			Password:   "secret",
+		},
+	}
+
+	secret := &corev1.Secret{}
+	r.specNodeSecret(secret, node, network)
+
+	address := "2b3430337f12ce89eabc7b0d865f4253c7744c0d"
+	got := string(secret.Data["account.address"])
+	if got != address {
+		t.Errorf("Expecting account address %s got %s", address, got)
+	}
+
+	// rotating imported account changes account address
+	node.Import.PrivateKey = "0x6df5eff7ef9e4e82739b68a34c6b23608d79ee8daf3b598a01ffb0dd7aa3a2fd"
+	r.specNodeSecret(secret, node, network)
+
+	if rotated := string(secret.Data["account.address"]); rotated == got {
+		t.Errorf("Expecting account address to change with imported private key")
+	}
+}
@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestSpecNodeServiceDNSName(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.BesuClient)
+	node := &network.Spec.Nodes[0]
+	node.DNSName = "bootnode.example.com"
+
+	svc := &corev1.Service{}
+	svc.Annotations = map[string]string{"owner": "ops"}
+	r.specNodeService(svc, node, network)
+
+	if got := svc.Annotations[ExternalDNSHostnameAnnotation]; got != node.DNSName {
+		t.Errorf("Expecting external-dns hostname to be %s got %s", node.DNSName, got)
+	}
+
+	node.DNSName = ""
+	r.specNodeService(svc, node, network)
+
+	if _, exists := svc.Annotations[ExternalDNSHostnameAnnotation]; exists {
+		t.Errorf("Expecting external-dns hostname annotation to be removed")
+	}
+	if svc.Annotations["owner"] != "ops" {
+		t.Errorf("Expecting other service annotations to be kept")
+	}
+}
+
+func TestSpecNodeServiceLoadBalancer(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.BesuClient)
+	node := &network.Spec.Nodes[0]
+	node.Service = &ethereumv1beta1.NodeService{
+		Type:                     ethereumv1beta1.LoadBalancerService,
+		LoadBalancerIP:           "34.1.2.3",
+		LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+	}
+
+	svc := &corev1.Service{}
+	r.specNodeService(svc, node, network)
+
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		t.Errorf("Expecting service type to be LoadBalancer got %s", svc.Spec.Type)
+	}
+	if svc.Spec.LoadBalancerIP != "34.1.2.3" {
+		t.Errorf("Expecting load balancer ip to be 34.1.2.3 got %s", svc.Spec.LoadBalancerIP)
+	}
+
+	// node port allocated by the api server
+	svc.Spec.Ports[0].NodePort = 31000
+	r.specNodeService(svc, node, network)
+
+	if got := svc.Spec.Ports[0].NodePort; got != 31000 {
+		t.Errorf("Expecting allocated node port to be kept got %d", got)
+	}
+
+	node.Service = nil
+	r.specNodeService(svc, node, network)
+
+	if svc.Spec.Type != corev1.ServiceTypeClusterIP || svc.Spec.LoadBalancerIP != "" || svc.Spec.Ports[0].NodePort != 0 {
+		t.Errorf("Expecting service to be reverted to cluster ip service")
+	}
+}
+
+func TestSpecNodeServiceAnnotations(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.BesuClient)
+	node := &network.Spec.Nodes[0]
+	node.ServiceAnnotations = map[string]string{
+		"service.beta.kubernetes.io/aws-load-balancer-internal":       "true",
+		"service.beta.kubernetes.io/aws-load-balancer-proxy-protocol": "*",
+	}
+
+	svc := &corev1.Service{}
+	svc.Annotations = map[string]string{"owner": "ops"}
+	r.specNodeService(svc, node, network)
+
+	for key, value := range node.ServiceAnnotations {
+		if got := svc.Annotations[key]; got != value {
+			t.Errorf("Expecting service annotation %s to be %s got %s", key, value, got)
+		}
+	}
+
+	delete(node.ServiceAnnotations, "service.beta.kubernetes.io/aws-load-balancer-proxy-protocol")
+	r.specNodeService(svc, node, network)
+
+	if _, exists := svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-proxy-protocol"]; exists {
+		t.Errorf("Expecting annotation removed from node spec to be removed from service")
+	}
+	if svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-internal"] != "true" {
+		t.Errorf("Expecting node spec service annotation to be kept")
+	}
+
+	node.ServiceAnnotations = nil
+	r.specNodeService(svc, node, network)
+
+	if len(svc.Annotations) != 1 || svc.Annotations["owner"] != "ops" {
+		t.Errorf("Expecting only other service annotations to be kept got %v", svc.Annotations)
+	}
+}
+
+func TestSpecNodeInternalService(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	node.RPCAPI = append(node.RPCAPI, ethereumv1beta1.AdminAPI)
+	node.Service = &ethereumv1beta1.NodeService{Type: ethereumv1beta1.LoadBalancerService}
+
+	svc := &corev1.Service{}
+	r.specNodeService(svc, node, network)
+
+	for _, port := range svc.Spec.Ports {
+		if port.Name == "rpc" {
+			t.Errorf("Expecting rpc port serving admin api not to be exposed by node public service")
+		}
+	}
+
+	internal := &corev1.Service{}
+	specNodeInternalService(internal, node, network)
+
+	if internal.Spec.Type != corev1.ServiceTypeClusterIP {
+		t.Errorf("Expecting internal service type to be ClusterIP got %s", internal.Spec.Type)
+	}
+	if len(internal.Spec.Ports) != 1 || internal.Spec.Ports[0].Name != "rpc" || internal.Spec.Ports[0].Port != int32(node.RPCPort) {
+		t.Errorf("Expecting internal service to expose rpc port got %v", internal.Spec.Ports)
+	}
+	if got := nodeRPCService(node, network); got != node.InternalServiceName(network.Name) {
+		t.Errorf("Expecting node rpc service to be %s got %s", node.InternalServiceName(network.Name), got)
+	}
+}
+
+func TestWithNodeService(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+
+	if withNodeService(node, network) {
+		t.Errorf("Expecting node not to be exposed by default")
+	}
+
+	node.Service = &ethereumv1beta1.NodeService{Type: ethereumv1beta1.NodePortService}
+	if !withNodeService(node, network) {
+		t.Errorf("Expecting node to be exposed by NodePort service")
+	}
+
+	node.Service = nil
+	network.Spec.Faucet = &ethereumv1beta1.Faucet{Node: node.Name}
+	if !withNodeService(node, network) {
+		t.Errorf("Expecting faucet node to be exposed")
+	}
+
+	node.Bootnode = true
+	node.Service = &ethereumv1beta1.NodeService{Type: ethereumv1beta1.NoneService}
+	if withNodeService(node, network) {
+		t.Errorf("Expecting node with None service not to be exposed")
+	}
+}
+
+func TestSpecNodeServiceRPCProxy(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	node.RPCProxy = &ethereumv1beta1.RPCProxy{
+		AllowedMethods: []string{"eth_blockNumber", "eth_call"},
+		RateLimit:      10,
+	}
+	network.Default()
+
+	svc := &corev1.Service{}
+	r.specNodeService(svc, node, network)
+
+	rpc := svc.Spec.Ports[len(svc.Spec.Ports)-1]
+	if rpc.Name != "rpc" || rpc.TargetPort.IntValue() != int(ethereumv1beta1.DefaultRPCProxyPort) {
+		t.Errorf("Expecting rpc port to target rpc proxy port got %v", rpc)
+	}
+
+	args := strings.Join(nodeRPCProxy(node).Args, " ")
+	expected := strings.Join([]string{
+		RPCProxyAllowedMethods, "eth_blockNumber,eth_call",
+		RPCProxyRateLimit, "10",
+		RPCProxyRateLimitBurst, "10",
+	}, " ")
+	if !strings.Contains(args, expected) {
+		t.Errorf("Expecting rpc proxy args to contain %s got %s", expected, args)
+	}
+}
+
+func TestServiceAddress(t *testing.T) {
+	svc := &corev1.Service{}
+	svc.Spec.ClusterIP = "10.96.0.10"
+
+	if got := serviceAddress(svc); got != "10.96.0.10" {
+		t.Errorf("Expecting cluster ip service address to be 10.96.0.10 got %s", got)
+	}
+
+	svc.Spec.Type = corev1.ServiceTypeLoadBalancer
+	if got := serviceAddress(svc); got != "" {
+		t.Errorf("Expecting pending load balancer to have no address got %s", got)
+	}
+
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}}
+	if got := serviceAddress(svc); got != "lb.example.com" {
+		t.Errorf("Expecting load balancer service address to be lb.example.com got %s", got)
+	}
+}
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// VolumeSnapshotGVK is csi VolumeSnapshot group version kind
+var VolumeSnapshotGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1beta1",
+	Kind:    "VolumeSnapshot",
+}
+
+// VolumeSnapshotListGVK is csi VolumeSnapshotList group version kind
+var VolumeSnapshotListGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1beta1",
+	Kind:    "VolumeSnapshotList",
+}
+
+// reconcileSnapshots takes due nodes data volume snapshots and deletes snapshots exceeding retention
+// next is how long until the next snapshot is due, it's 0 if no node has snapshots policy
+func (r *NetworkReconciler) reconcileSnapshots(network *ethereumv1beta1.Network) (next time.Duration, err error) {
+	for i := range network.Spec.Nodes {
+		node := &network.Spec.Nodes[i]
+
+		if node.Snapshots == nil || node.IsDedicatedBootnode() || node.StorageMode() != ethereumv1beta1.PersistentStorage {
+			continue
+		}
+
+		due, err := r.reconcileNodeSnapshots(node, network, time.Now())
+		// csi snapshot custom resources are not installed
+		if meta.IsNoMatchError(err) {
+			r.Log.Error(err, "csi snapshot custom resources must be installed to take volume snapshots")
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		next = earliestRequeue(next, due)
+	}
+
+	return
+}
+
+// reconcileNodeSnapshots takes node data volume snapshot if it's due and deletes snapshots exceeding retention
+// due is how long until the next node snapshot is due
+func (r *NetworkReconciler) reconcileNodeSnapshots(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network, now time.Time) (due time.Duration, err error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(VolumeSnapshotListGVK)
+
+	matchingLabels := client.MatchingLabels(node.Labels(network.Name))
+	inNamespace := client.InNamespace(network.Namespace)
+
+	if err = r.Client.List(context.Background(), list, matchingLabels, inNamespace); err != nil {
+		r.Log.Error(err, "unable to list node volume snapshots")
+		return
+	}
+
+	snapshots := list.Items
+	schedule := node.Snapshots.Schedule.Duration
+
+	sortSnapshots(snapshots)
+
+	if len(snapshots) == 0 || now.Sub(snapshots[0].GetCreationTimestamp().Time) >= schedule {
+		snapshot := specVolumeSnapshot(node, network, now)
+		if err = r.Client.Create(context.Background(), snapshot); err != nil {
+			r.Log.Error(err, "unable to create node volume snapshot")
+			return
+		}
+		r.Recorder.Eventf(network, corev1.EventTypeNormal, "SnapshotCreated", "node %s volume snapshot %s created", node.Name, snapshot.GetName())
+		snapshots = append([]unstructured.Unstructured{*snapshot}, snapshots...)
+		due = schedule
+	} else {
+		due = schedule - now.Sub(snapshots[0].GetCreationTimestamp().Time)
+	}
+
+	expired := expiredSnapshots(snapshots, node.Snapshots.Retention)
+	for i := range expired {
+		if err = r.Client.Delete(context.Background(), &expired[i]); err != nil && !apierrors.IsNotFound(err) {
+			r.Log.Error(err, "unable to delete expired node volume snapshot")
+			return
+		}
+	}
+
+	return due, nil
+}
+
+// specVolumeSnapshot returns node data persistent volume claim snapshot taken at the given time
+// snapshots aren't owned by the network, so node data can be recovered after network deletion
+func specVolumeSnapshot(node *ethereumv1beta1.Node, network *ethereumv1beta1.Network, now time.Time) *unstructured.Unstructured {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	snapshot.SetName(fmt.Sprintf("%s-%d", node.PVCName(network.Name), now.Unix()))
+	snapshot.SetNamespace(network.Namespace)
+	snapshot.SetLabels(node.ResourceLabels(network.Name, network.Spec.Consensus))
+
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": node.PVCName(network.Name),
+		},
+	}
+
+	if node.Snapshots.VolumeSnapshotClassName != "" {
+		spec["volumeSnapshotClassName"] = node.Snapshots.VolumeSnapshotClassName
+	}
+
+	snapshot.Object["spec"] = spec
+
+	return snapshot
+}
+
+// sortSnapshots sorts snapshots from newest to oldest
+func sortSnapshots(snapshots []unstructured.Unstructured) {
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		ti, tj := snapshots[i].GetCreationTimestamp(), snapshots[j].GetCreationTimestamp()
+		return tj.Before(&ti)
+	})
+}
+
+// expiredSnapshots returns snapshots exceeding retention, snapshots are sorted from newest to oldest
+func expiredSnapshots(snapshots []unstructured.Unstructured, retention int) []unstructured.Unstructured {
+	if len(snapshots) <= retention {
+		return nil
+	}
+	return snapshots[retention:]
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestSpecVolumeSnapshot(t *testing.T) {
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	node.Snapshots = &ethereumv1beta1.SnapshotPolicy{VolumeSnapshotClassName: "csi-snapclass"}
+
+	now := time.Unix(1600000000, 0)
+	snapshot := specVolumeSnapshot(node, network, now)
+
+	if name := node.PVCName(network.Name) + "-1600000000"; snapshot.GetName() != name {
+		t.Errorf("Expecting snapshot name %s got %s", name, snapshot.GetName())
+	}
+
+	source, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName")
+	if source != node.PVCName(network.Name) {
+		t.Errorf("Expecting snapshot of node data pvc got %s", source)
+	}
+
+	class, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName")
+	if class != "csi-snapclass" {
+		t.Errorf("Expecting snapshot class csi-snapclass got %s", class)
+	}
+
+	if len(snapshot.GetOwnerReferences()) != 0 {
+		t.Errorf("Expecting snapshot not to be owned by network")
+	}
+}
+
+func TestExpiredSnapshots(t *testing.T) {
+	now := time.Now()
+	snapshots := []unstructured.Unstructured{}
+
+	for _, age := range []time.Duration{2 * time.Hour, 0, 3 * time.Hour, time.Hour} {
+		snapshot := unstructured.Unstructured{}
+		snapshot.SetName(age.String())
+		snapshot.SetCreationTimestamp(metav1.NewTime(now.Add(-age)))
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sortSnapshots(snapshots)
+
+	expired := expiredSnapshots(snapshots, 2)
+	if len(expired) != 2 || expired[0].GetName() != "2h0m0s" || expired[1].GetName() != "3h0m0s" {
+		t.Errorf("Expecting two oldest snapshots to expire got %v", expired)
+	}
+
+	if expired := expiredSnapshots(snapshots, 7); len(expired) != 0 {
+		t.Errorf("Expecting no expired snapshots within retention got %d", len(expired))
+	}
+}
+
+func TestSpecNodeDataPVCRestore(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	r.specNodeDataPVC(pvc, node, network)
+
+	if pvc.Spec.DataSource != nil {
+		t.Errorf("Expecting empty node data pvc got data source %v", pvc.Spec.DataSource)
+	}
+
+	node.Restore = &ethereumv1beta1.NodeRestore{VolumeSnapshotName: "synced-node-1600000000"}
+	r.specNodeDataPVC(pvc, node, network)
+
+	source := pvc.Spec.DataSource
+	if source == nil || source.Kind != "VolumeSnapshot" || *source.APIGroup != "snapshot.storage.k8s.io" || source.Name != "synced-node-1600000000" {
+		t.Errorf("Expecting node data pvc to be restored from volume snapshot got %v", source)
+	}
+}
@@ -15,7 +15,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
-	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -48,7 +48,7 @@ var _ = BeforeSuite(func(done Done) {
 	Expect(err).ToNot(HaveOccurred())
 	Expect(cfg).ToNot(BeNil())
 
-	err = ethereumv1alpha1.AddToScheme(scheme.Scheme)
+	err = ethereumv1beta1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
 	// +kubebuilder:scaffold:scheme
@@ -63,9 +63,10 @@ var _ = BeforeSuite(func(done Done) {
 	Expect(k8sClient).ToNot(BeNil())
 
 	reconciler = &NetworkReconciler{
-		Client: k8sManager.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("network"),
-		Scheme: scheme.Scheme,
+		Client:   k8sManager.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("network"),
+		Scheme:   scheme.Scheme,
+		Recorder: k8sManager.GetEventRecorderFor("network-controller"),
 	}
 	reconciler.SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
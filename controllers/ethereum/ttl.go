@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// networkTTLRemaining returns how long network has before its ttl expires
+// ok is false if network has no ttl
+func networkTTLRemaining(network *ethereumv1beta1.Network, now time.Time) (remaining time.Duration, ok bool) {
+	if network.Spec.TTLSecondsAfterCreation == nil {
+		return
+	}
+
+	ttl := time.Duration(*network.Spec.TTLSecondsAfterCreation) * time.Second
+	expiry := network.CreationTimestamp.Add(ttl)
+
+	return expiry.Sub(now), true
+}
+
+// reconcileTTL deletes network once its ttl expires
+// remaining is how long network has before its ttl expires, it's 0 if network has no ttl
+func (r *NetworkReconciler) reconcileTTL(network *ethereumv1beta1.Network) (remaining time.Duration, expired bool, err error) {
+	remaining, ok := networkTTLRemaining(network, time.Now())
+	if !ok || !network.DeletionTimestamp.IsZero() {
+		return 0, false, nil
+	}
+
+	if remaining > 0 {
+		return remaining, false, nil
+	}
+
+	r.Recorder.Event(network, corev1.EventTypeNormal, "TTLExpired", "network ttl expired, deleting network")
+
+	// network resources are garbage collected with the network
+	if err = r.Client.Delete(context.Background(), network); err != nil {
+		if err = client.IgnoreNotFound(err); err != nil {
+			r.Log.Error(err, "unable to delete expired network")
+		}
+		return 0, true, err
+	}
+
+	return 0, true, nil
+}
+
+// earliestRequeue returns the earliest of two requeue delays, 0 delay means no requeue
+func earliestRequeue(a, b time.Duration) time.Duration {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestNetworkTTLRemaining(t *testing.T) {
+	created := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	network := &ethereumv1beta1.Network{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+	}
+
+	if _, ok := networkTTLRemaining(network, created); ok {
+		t.Errorf("Expecting network without ttl to be kept")
+	}
+
+	ttl := int32(3600)
+	network.Spec.TTLSecondsAfterCreation = &ttl
+
+	remaining, ok := networkTTLRemaining(network, created.Add(20*time.Minute))
+	if !ok || remaining != 40*time.Minute {
+		t.Errorf("Expecting 40m remaining got %s", remaining)
+	}
+
+	if remaining, _ := networkTTLRemaining(network, created.Add(2*time.Hour)); remaining > 0 {
+		t.Errorf("Expecting ttl to be expired got %s remaining", remaining)
+	}
+}
+
+func TestReconcileTTL(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = ethereumv1beta1.AddToScheme(scheme)
+
+	ttl := int32(60)
+	network := &ethereumv1beta1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "ci-network",
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+		Spec: ethereumv1beta1.NetworkSpec{TTLSecondsAfterCreation: &ttl},
+	}
+
+	r := &NetworkReconciler{
+		Client:   fake.NewFakeClientWithScheme(scheme, network.DeepCopy()),
+		Log:      ctrl.Log,
+		Recorder: record.NewFakeRecorder(1),
+	}
+
+	_, expired, err := r.reconcileTTL(network)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if !expired {
+		t.Fatalf("Expecting network ttl to be expired")
+	}
+
+	key := types.NamespacedName{Name: network.Name, Namespace: network.Namespace}
+	if err := r.Client.Get(context.Background(), key, &ethereumv1beta1.Network{}); err == nil {
+		t.Errorf("Expecting expired network to be deleted")
+	}
+}
+
+func TestEarliestRequeue(t *testing.T) {
+	cases := []struct{ a, b, expected time.Duration }{
+		{0, 0, 0},
+		{30 * time.Second, 0, 30 * time.Second},
+		{0, time.Minute, time.Minute},
+		{30 * time.Second, time.Minute, 30 * time.Second},
+		{time.Minute, 10 * time.Second, 10 * time.Second},
+	}
+
+	for _, c := range cases {
+		if got := earliestRequeue(c.a, c.b); got != c.expected {
+			t.Errorf("Expecting earliest requeue of %s and %s to be %s got %s", c.a, c.b, c.expected, got)
+		}
+	}
+}
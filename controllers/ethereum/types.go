@@ -1,22 +1,83 @@
 package controllers
 
-import "os"
+import (
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+	"github.com/kotalco/kotal/helpers"
+)
 
 const (
 	// PathConfig is the genesis file path
 	PathConfig = "/mnt/config"
+	// PathNodeConfig is the node configuration file path
+	PathNodeConfig = "/mnt/node-config"
 	// PathBlockchainData is the blockchain data path
 	PathBlockchainData = "/mnt/data"
 	// PathSecrets is the secrets (private keys, password ... etc) path
 	PathSecrets = "/mnt/secrets"
+	// PathKeystore is the signer account keystore and password path
+	PathKeystore = "/mnt/keystore"
+	// PathInitScripts is the node init scripts path
+	PathInitScripts = "/mnt/init-scripts"
+)
+
+const (
+	// ConfigChecksumAnnotation is the pod annotation used to roll out pods on configuration file changes
+	ConfigChecksumAnnotation = "kotal.io/config-checksum"
+	// GenesisChecksumAnnotation is the pod annotation used to roll out pods on client genesis and scripts config map changes
+	GenesisChecksumAnnotation = "kotal.io/genesis-checksum"
+	// SecretChecksumAnnotation is the pod annotation used to roll out pods on node secret changes
+	SecretChecksumAnnotation = "kotal.io/secret-checksum"
+	// ExternalDNSHostnameAnnotation is the annotation used by external-dns to publish service dns name
+	ExternalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+	// ServiceAnnotationsAnnotation is the service annotation listing annotation keys applied from node spec
+	// it's used to remove annotations deleted from node spec, while keeping annotations added by others
+	ServiceAnnotationsAnnotation = "kotal.io/service-annotations"
+	// IntegrityCheckAnnotation is the network annotation requesting on demand nodes data integrity check
+	// check is requested again whenever annotation value changes
+	IntegrityCheckAnnotation = "kotal.io/integrity-check"
+	// ConsoleScriptLabel is the job label holding the name of the console script run by the job
+	ConsoleScriptLabel = "kotal.io/console-script"
+	// ConsoleScriptChecksumAnnotation is the job annotation holding checksum of the console script run by the job
+	ConsoleScriptChecksumAnnotation = "kotal.io/console-script-checksum"
+	// RPCServiceLabel is the pod label selecting node pods load balanced by network rpc service
+	RPCServiceLabel = "kotal.io/rpc-service"
+	// SyncedCondition is the pod readiness gate condition set once node is synced
+	SyncedCondition = "kotal.io/synced"
+	// PublicRPCPortName is the name of the container port network rpc service forwards requests to
+	PublicRPCPortName = "public-rpc"
+)
+
+const (
+	// EnvBesuLog4jConfig is the environment variable used by besu (log4j) to load logging configuration
+	EnvBesuLog4jConfig = "LOG4J_CONFIGURATION_FILE"
+	// EnvPodIP is the environment variable holding node pod ip, it's referenced by client arguments
+	EnvPodIP = "POD_IP"
+	// EnvConsoleScript is the environment variable holding JavaScript evaluated by geth console
+	EnvConsoleScript = "CONSOLE_SCRIPT"
 )
 
 // Images
 const (
 	// DefaultBesuImage is hyperledger besu image
-	DefaultBesuImage = "hyperledger/besu:1.5.3"
+	DefaultBesuImage = "hyperledger/besu:21.7.4"
 	// DefaultGethImage is go-ethereum image
-	DefaultGethImage = "ethereum/client-go:v1.9.20"
+	DefaultGethImage = "ethereum/client-go:v1.10.8"
+	// DefaultFaucetImage is ethereum faucet image
+	DefaultFaucetImage = "chainflag/eth-faucet:1.0.0"
+	// DefaultBlockscoutImage is blockscout block explorer image
+	DefaultBlockscoutImage = "blockscout/blockscout:4.0.0"
+	// DefaultPostgresImage is postgres image used by blockscout
+	DefaultPostgresImage = "postgres:12.5-alpine"
+	// DefaultEthstatsImage is ethstats server image
+	DefaultEthstatsImage = "puppeth/ethstats:latest"
+	// DefaultRPCProxyImage is kotal operator image running rpc proxy
+	DefaultRPCProxyImage = "kotalco/kotal:latest"
+	// DefaultMetricsExporterImage is ethereum chain metrics exporter image
+	DefaultMetricsExporterImage = "ethpandaops/ethereum-metrics-exporter:latest"
+	// DefaultBackupImage is kotal operator image running node data backup
+	DefaultBackupImage = "kotalco/kotal:latest"
+	// DefaultBootnodeImage is go-ethereum image with bootnode tool
+	DefaultBootnodeImage = "ethereum/client-go:alltools-v1.10.8"
 )
 
 const (
@@ -24,26 +85,148 @@ const (
 	EnvBesuImage = "BESU_IMAGE"
 	// EnvGethImage is the environment variable used for go ethereum image
 	EnvGethImage = "GETH_IMAGE"
+	// EnvFaucetImage is the environment variable used for faucet image
+	EnvFaucetImage = "FAUCET_IMAGE"
+	// EnvBlockscoutImage is the environment variable used for blockscout image
+	EnvBlockscoutImage = "BLOCKSCOUT_IMAGE"
+	// EnvPostgresImage is the environment variable used for postgres image
+	EnvPostgresImage = "POSTGRES_IMAGE"
+	// EnvEthstatsImage is the environment variable used for ethstats server image
+	EnvEthstatsImage = "ETHSTATS_IMAGE"
+	// EnvRPCProxyImage is the environment variable used for rpc proxy image
+	EnvRPCProxyImage = "RPC_PROXY_IMAGE"
+	// EnvMetricsExporterImage is the environment variable used for chain metrics exporter image
+	EnvMetricsExporterImage = "METRICS_EXPORTER_IMAGE"
+	// EnvBackupImage is the environment variable used for node data backup image
+	EnvBackupImage = "BACKUP_IMAGE"
+	// EnvBootnodeImage is the environment variable used for bootnode tool image
+	EnvBootnodeImage = "BOOTNODE_IMAGE"
 )
 
-// GethImage returns geth docker image
-func GethImage() string {
-	if os.Getenv(EnvGethImage) == "" {
-		return DefaultGethImage
-	}
-	return os.Getenv(EnvGethImage)
+// GethImage returns geth docker image built for cpu architecture
+func GethImage(arch ethereumv1beta1.Architecture) string {
+	return helpers.ArchImage(string(arch), "geth", EnvGethImage, DefaultGethImage)
+}
+
+// BesuImage returns besu docker image built for cpu architecture
+func BesuImage(arch ethereumv1beta1.Architecture) string {
+	return helpers.ArchImage(string(arch), "besu", EnvBesuImage, DefaultBesuImage)
+}
+
+// FaucetImage returns faucet docker image
+func FaucetImage() string {
+	return helpers.Image("faucet", EnvFaucetImage, DefaultFaucetImage)
+}
+
+// BlockscoutImage returns blockscout docker image
+func BlockscoutImage() string {
+	return helpers.Image("blockscout", EnvBlockscoutImage, DefaultBlockscoutImage)
+}
+
+// PostgresImage returns postgres docker image
+func PostgresImage() string {
+	return helpers.Image("postgres", EnvPostgresImage, DefaultPostgresImage)
+}
+
+// EthstatsImage returns ethstats server docker image
+func EthstatsImage() string {
+	return helpers.Image("ethstats", EnvEthstatsImage, DefaultEthstatsImage)
+}
+
+// BootnodeImage returns bootnode tool docker image built for cpu architecture
+func BootnodeImage(arch ethereumv1beta1.Architecture) string {
+	return helpers.ArchImage(string(arch), "bootnode", EnvBootnodeImage, DefaultBootnodeImage)
+}
+
+// RPCProxyImage returns rpc proxy docker image built for cpu architecture
+func RPCProxyImage(arch ethereumv1beta1.Architecture) string {
+	return helpers.ArchImage(string(arch), "rpcproxy", EnvRPCProxyImage, DefaultRPCProxyImage)
 }
 
-// BesuImage returns besu docker image
-func BesuImage() string {
-	if os.Getenv(EnvBesuImage) == "" {
-		return DefaultBesuImage
-	}
-	return os.Getenv(EnvBesuImage)
+// MetricsExporterImage returns chain metrics exporter docker image built for cpu architecture
+func MetricsExporterImage(arch ethereumv1beta1.Architecture) string {
+	return helpers.ArchImage(string(arch), "metricsexporter", EnvMetricsExporterImage, DefaultMetricsExporterImage)
 }
 
+// BackupImage returns node data backup docker image built for cpu architecture
+func BackupImage(arch ethereumv1beta1.Architecture) string {
+	return helpers.ArchImage(string(arch), "backup", EnvBackupImage, DefaultBackupImage)
+}
+
+// RPC proxy arguments
+const (
+	// RPCProxyListen is the argument used for rpc proxy listening address
+	RPCProxyListen = "--listen"
+	// RPCProxyUpstream is the argument used for node http-rpc server url
+	RPCProxyUpstream = "--upstream"
+	// RPCProxyAllowedMethods is the argument used for comma separated allowed methods
+	RPCProxyAllowedMethods = "--allowed-methods"
+	// RPCProxyRateLimit is the argument used for requests per second per client ip
+	RPCProxyRateLimit = "--rate-limit"
+	// RPCProxyRateLimitBurst is the argument used for requests per client ip in a single burst
+	RPCProxyRateLimitBurst = "--rate-limit-burst"
+	// RPCProxyMaxBatchSize is the argument used for maximum number of requests in a batch
+	RPCProxyMaxBatchSize = "--max-batch-size"
+)
+
+// Chain metrics exporter arguments
+const (
+	// MetricsExporterExecutionURL is the argument used for node http-rpc server url
+	MetricsExporterExecutionURL = "--execution-url"
+	// MetricsExporterExecutionModules is the argument used for comma separated node apis polled by exporter
+	MetricsExporterExecutionModules = "--execution-modules"
+	// MetricsExporterPort is the argument used for exporter metrics port
+	MetricsExporterPort = "--metrics-port"
+	// MetricsExporterPath is the exporter metrics path
+	MetricsExporterPath = "/metrics"
+)
+
+// Node data backup arguments
+const (
+	// BackupDataDir is the argument used for node data directory
+	BackupDataDir = "--data-dir"
+	// BackupEndpoint is the argument used for object storage endpoint
+	BackupEndpoint = "--endpoint"
+	// BackupRegion is the argument used for object storage region
+	BackupRegion = "--region"
+	// BackupBucket is the argument used for object storage bucket
+	BackupBucket = "--bucket"
+	// BackupPrefix is the argument used for backups objects key prefix
+	BackupPrefix = "--prefix"
+	// BackupInterval is the argument used for interval between backups
+	BackupInterval = "--interval"
+	// BackupFullEvery is the argument used for number of backups between full backups
+	BackupFullEvery = "--full-every"
+	// BackupPauseProcess is the argument used for node process paused while archiving data
+	BackupPauseProcess = "--pause-process"
+	// BackupListen is the argument used for backup status server listening address
+	BackupListen = "--listen"
+)
+
+// Bootnode tool arguments
+const (
+	// BootnodeNodekey is the argument used for bootnode private key file
+	BootnodeNodekey = "-nodekey"
+	// BootnodeAddr is the argument used for bootnode listening address
+	BootnodeAddr = "-addr"
+)
+
+// Faucet arguments
+const (
+	// FaucetHTTPPort is the argument used for faucet http server port
+	FaucetHTTPPort = "-httpport"
+	// FaucetProvider is the argument used for rpc endpoint faucet sends transactions to
+	FaucetProvider = "-wallet.provider"
+	// FaucetAmount is the argument used for amount of ether sent per request
+	FaucetAmount = "-faucet.amount"
+	// FaucetInterval is the argument used for number of minutes between requests
+	FaucetInterval = "-faucet.minutes"
+)
+
 // Hyperledger Besu client arguments
 const (
+	// BesuConfigFile is the argument used for toml configuration file
+	BesuConfigFile = "--config-file"
 	// BesuLogging is the argument used for logging verbosity level
 	BesuLogging = "--logging"
 	// BesuNetworkID is the argument used for network id
@@ -60,8 +243,16 @@ const (
 	BesuNetwork = "--network"
 	// BesuP2PPort is the argument used for p2p port
 	BesuP2PPort = "--p2p-port"
+	// BesuP2PHost is the argument used for p2p host advertised to peers
+	BesuP2PHost = "--p2p-host"
 	// BesuBootnodes is the argument used for bootnodes
 	BesuBootnodes = "--bootnodes"
+	// BesuDNSEnabled is the argument used to enable dns names in enode urls
+	BesuDNSEnabled = "--Xdns-enabled"
+	// BesuRocksDBCacheCapacity is the argument used for bytes of memory allocated to database cache
+	BesuRocksDBCacheCapacity = "--Xplugin-rocksdb-cache-capacity"
+	// BesuDataStorageFormat is the argument used for database storage format
+	BesuDataStorageFormat = "--data-storage-format"
 	// BesuSyncMode is the argument used for sync mode
 	BesuSyncMode = "--sync-mode"
 	// BesuMinerEnabled is the argument used for turning on mining
@@ -94,14 +285,34 @@ const (
 	BesuGraphQLHTTPHost = "--graphql-http-host"
 	// BesuGraphQLHTTPCorsOrigins is the argument used for GraphQL HTTP Cors origins
 	BesuGraphQLHTTPCorsOrigins = "--graphql-http-cors-origins"
+	// BesuGraphQLHTTPHostAllowlist is the argument used for allowing hosts to access GraphQL server
+	BesuGraphQLHTTPHostAllowlist = "--graphql-http-host-allowlist"
 	// BesuHostWhitelist is the argument used for whitelisting hosts
 	BesuHostWhitelist = "--host-whitelist"
+	// BesuMetricsEnabled is the argument used to enable metrics
+	BesuMetricsEnabled = "--metrics-enabled"
+	// BesuMetricsHost is the argument used for metrics host
+	BesuMetricsHost = "--metrics-host"
+	// BesuMetricsPort is the argument used for metrics port
+	BesuMetricsPort = "--metrics-port"
+	// BesuMetricsHostAllowlist is the argument used for allowing hosts to scrape metrics
+	BesuMetricsHostAllowlist = "--metrics-host-allowlist"
+	// BesuPermissionsNodesContractEnabled is the argument used to enable onchain nodes permissioning
+	BesuPermissionsNodesContractEnabled = "--permissions-nodes-contract-enabled"
+	// BesuPermissionsNodesContractAddress is the argument used for nodes ingress contract address
+	BesuPermissionsNodesContractAddress = "--permissions-nodes-contract-address"
+	// BesuPermissionsAccountsContractEnabled is the argument used to enable onchain accounts permissioning
+	BesuPermissionsAccountsContractEnabled = "--permissions-accounts-contract-enabled"
+	// BesuPermissionsAccountsContractAddress is the argument used for accounts ingress contract address
+	BesuPermissionsAccountsContractAddress = "--permissions-accounts-contract-address"
 )
 
 // Go ethereum client arguments
 const (
 	// GethLogging is the argument used for logging verbosity level
 	GethLogging = "--verbosity"
+	// GethLogJSON is the argument used for json logging format
+	GethLogJSON = "--log.json"
 	// GethNetworkID is the argument used for network id
 	GethNetworkID = "--networkid"
 	// GethNodeKey is the argument used for node private key
@@ -110,10 +321,16 @@ const (
 	GethDataDir = "--datadir"
 	// GethP2PPort is the argument used for p2p port
 	GethP2PPort = "--port"
+	// GethNat is the argument used for nat port mapping mechanism
+	GethNat = "--nat"
 	// GethBootnodes is the argument used for bootnodes
 	GethBootnodes = "--bootnodes"
 	// GethSyncMode is the argument used for sync mode
 	GethSyncMode = "--syncmode"
+	// GethLightServe is the argument used for maximum percentage of time serving light clients
+	GethLightServe = "--light.serve"
+	// GethCache is the argument used for megabytes of memory allocated to internal caching
+	GethCache = "--cache"
 
 	// GethMinerEnabled is the argument used for turning on mining
 	GethMinerEnabled = "--mine"
@@ -156,4 +373,31 @@ const (
 	GethUnlock = "--unlock"
 	// GethPassword is the argument used for locking imported ethereum address
 	GethPassword = "--password"
+	// GethKeyStore is the argument used for setting keystore directory
+	GethKeyStore = "--keystore"
+	// GethAllowInsecureUnlock is the argument used for unlocking accounts while http apis are exposed
+	GethAllowInsecureUnlock = "--allow-insecure-unlock"
+	// GethEthstats is the argument used for reporting node stats to ethstats server
+	GethEthstats = "--ethstats"
+	// GethDev is the argument used for running ephemeral proof of authority development network
+	GethDev = "--dev"
+	// GethDevPeriod is the argument used for development network block period, 0 seals blocks on demand
+	GethDevPeriod = "--dev.period"
+
+	// GethMetricsEnabled is the argument used to enable metrics collection
+	GethMetricsEnabled = "--metrics"
+	// GethPprofEnabled is the argument used to enable pprof server which serves prometheus metrics
+	GethPprofEnabled = "--pprof"
+	// GethPprofHost is the argument used for pprof server host
+	GethPprofHost = "--pprof.addr"
+	// GethPprofPort is the argument used for pprof server port
+	GethPprofPort = "--pprof.port"
+)
+
+// Metrics paths
+const (
+	// BesuMetricsPath is the path besu serves prometheus metrics on
+	BesuMetricsPath = "/metrics"
+	// GethMetricsPath is the path geth serves prometheus metrics on
+	GethMetricsPath = "/debug/metrics/prometheus"
 )
@@ -3,19 +3,21 @@ package controllers
 import (
 	"os"
 	"testing"
+
+	"github.com/kotalco/kotal/helpers"
 )
 
 func TestBesuImage(t *testing.T) {
 	// without environment variables
 	expected := DefaultBesuImage
-	got := BesuImage()
+	got := BesuImage("")
 	if got != expected {
 		t.Errorf("Expecting besu image to be %s got %s", expected, got)
 	}
 	// with environment variables
 	expected = "kotalco/besu:v2.0"
 	os.Setenv(EnvBesuImage, expected)
-	got = BesuImage()
+	got = BesuImage("")
 	if got != expected {
 		t.Errorf("Expecting besu image to be %s got %s", expected, got)
 	}
@@ -24,15 +26,112 @@ func TestBesuImage(t *testing.T) {
 func TestGethImage(t *testing.T) {
 	// without environment variables
 	expected := DefaultGethImage
-	got := GethImage()
+	got := GethImage("")
 	if got != expected {
 		t.Errorf("Expecting besu image to be %s got %s", expected, got)
 	}
 	// with environment variables
 	expected = "kotalco/geth:v2.0"
 	os.Setenv(EnvGethImage, expected)
-	got = GethImage()
+	got = GethImage("")
 	if got != expected {
 		t.Errorf("Expecting besu image to be %s got %s", expected, got)
 	}
 }
+
+func TestFaucetImage(t *testing.T) {
+	// without environment variables
+	expected := DefaultFaucetImage
+	got := FaucetImage()
+	if got != expected {
+		t.Errorf("Expecting faucet image to be %s got %s", expected, got)
+	}
+	// with environment variables
+	expected = "kotalco/faucet:v2.0"
+	os.Setenv(EnvFaucetImage, expected)
+	got = FaucetImage()
+	if got != expected {
+		t.Errorf("Expecting faucet image to be %s got %s", expected, got)
+	}
+}
+
+func TestBlockscoutImage(t *testing.T) {
+	// without environment variables
+	expected := DefaultBlockscoutImage
+	got := BlockscoutImage()
+	if got != expected {
+		t.Errorf("Expecting blockscout image to be %s got %s", expected, got)
+	}
+	// with environment variables
+	expected = "kotalco/blockscout:v2.0"
+	os.Setenv(EnvBlockscoutImage, expected)
+	got = BlockscoutImage()
+	if got != expected {
+		t.Errorf("Expecting blockscout image to be %s got %s", expected, got)
+	}
+}
+
+func TestPostgresImage(t *testing.T) {
+	// without environment variables
+	expected := DefaultPostgresImage
+	got := PostgresImage()
+	if got != expected {
+		t.Errorf("Expecting postgres image to be %s got %s", expected, got)
+	}
+	// with environment variables
+	expected = "kotalco/postgres:v2.0"
+	os.Setenv(EnvPostgresImage, expected)
+	got = PostgresImage()
+	if got != expected {
+		t.Errorf("Expecting postgres image to be %s got %s", expected, got)
+	}
+}
+
+func TestEthstatsImage(t *testing.T) {
+	// without environment variables
+	expected := DefaultEthstatsImage
+	got := EthstatsImage()
+	if got != expected {
+		t.Errorf("Expecting ethstats image to be %s got %s", expected, got)
+	}
+	// with environment variables
+	expected = "kotalco/ethstats:v2.0"
+	os.Setenv(EnvEthstatsImage, expected)
+	got = EthstatsImage()
+	if got != expected {
+		t.Errorf("Expecting ethstats image to be %s got %s", expected, got)
+	}
+}
+
+func TestBootnodeImage(t *testing.T) {
+	// without environment variables
+	expected := DefaultBootnodeImage
+	got := BootnodeImage("")
+	if got != expected {
+		t.Errorf("Expecting bootnode image to be %s got %s", expected, got)
+	}
+	// with environment variables
+	expected = "ethereum/client-go:alltools-v1.9.25"
+	os.Setenv(EnvBootnodeImage, expected)
+	got = BootnodeImage("")
+	if got != expected {
+		t.Errorf("Expecting bootnode image to be %s got %s", expected, got)
+	}
+}
+
+func TestImageFromOperatorConfig(t *testing.T) {
+	defer helpers.SetConfig(helpers.OperatorConfig{})
+
+	os.Setenv(EnvEthstatsImage, "kotalco/ethstats:env")
+	defer os.Unsetenv(EnvEthstatsImage)
+
+	expected := "kotalco/ethstats:config"
+	helpers.SetConfig(helpers.OperatorConfig{
+		Images: map[string]string{"ethstats": expected},
+	})
+
+	// operator config image takes precedence over environment variable
+	if got := EthstatsImage(); got != expected {
+		t.Errorf("Expecting ethstats image to be %s got %s", expected, got)
+	}
+}
@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+// findNode returns network node by name
+func findNode(network *ethereumv1beta1.Network, name string) (*ethereumv1beta1.Node, error) {
+	for i := range network.Spec.Nodes {
+		if network.Spec.Nodes[i].Name == name {
+			return &network.Spec.Nodes[i], nil
+		}
+	}
+	return nil, fmt.Errorf("node %s doesn't exist", name)
+}
+
+// specHTTPService updates spec of service exposing http server on port 80
+func specHTTPService(svc *corev1.Service, labels map[string]string, targetPort int) {
+	svc.ObjectMeta.Labels = labels
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "http",
+			Port:       80,
+			TargetPort: intstr.FromInt(targetPort),
+			Protocol:   corev1.ProtocolTCP,
+		},
+	}
+	svc.Spec.Selector = labels
+}
+
+// IngressClassAnnotation is the annotation used by ingress controllers to pick ingresses of their class
+const IngressClassAnnotation = "kubernetes.io/ingress.class"
+
+// websocketAnnotations are ingress controllers annotations keeping web socket connections open
+// and routing clients to the same backend pod
+var websocketAnnotations = map[ethereumv1beta1.IngressClass]map[string]string{
+	ethereumv1beta1.NginxIngressClass: {
+		"nginx.ingress.kubernetes.io/proxy-read-timeout": "3600",
+		"nginx.ingress.kubernetes.io/proxy-send-timeout": "3600",
+		"nginx.ingress.kubernetes.io/affinity":           "cookie",
+	},
+	ethereumv1beta1.TraefikIngressClass: {
+		"traefik.ingress.kubernetes.io/affinity": "true",
+	},
+	ethereumv1beta1.HAProxyIngressClass: {
+		"haproxy-ingress.github.io/timeout-tunnel": "1h",
+		"haproxy-ingress.github.io/affinity":       "cookie",
+	},
+}
+
+// specIngressAnnotations sets ingress class and web socket annotations of network ingress class
+// annotations of other ingress classes are removed, other annotations are kept
+func specIngressAnnotations(ingress *networkingv1beta1.Ingress, class ethereumv1beta1.IngressClass, websocket bool) {
+	delete(ingress.ObjectMeta.Annotations, IngressClassAnnotation)
+	for _, annotations := range websocketAnnotations {
+		for key := range annotations {
+			delete(ingress.ObjectMeta.Annotations, key)
+		}
+	}
+
+	if class == "" {
+		return
+	}
+
+	if ingress.ObjectMeta.Annotations == nil {
+		ingress.ObjectMeta.Annotations = map[string]string{}
+	}
+
+	ingress.ObjectMeta.Annotations[IngressClassAnnotation] = string(class)
+
+	if !websocket {
+		return
+	}
+
+	for key, value := range websocketAnnotations[class] {
+		ingress.ObjectMeta.Annotations[key] = value
+	}
+}
+
+// specIngress updates ingress spec routing host to service port
+func specIngress(ingress *networkingv1beta1.Ingress, labels map[string]string, host, service string, port int) {
+	ingress.ObjectMeta.Labels = labels
+	ingress.Spec.Rules = []networkingv1beta1.IngressRule{
+		{
+			Host: host,
+			IngressRuleValue: networkingv1beta1.IngressRuleValue{
+				HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+					Paths: []networkingv1beta1.HTTPIngressPath{
+						{
+							Path: "/",
+							Backend: networkingv1beta1.IngressBackend{
+								ServiceName: service,
+								ServicePort: intstr.FromInt(port),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// reconcileIngress creates ingress named after service if host is specified, deletes it otherwise
+// web socket ingresses get long timeouts and session affinity annotations of network ingress class
+func (r *NetworkReconciler) reconcileIngress(network *ethereumv1beta1.Network, labels map[string]string, host, service string, port int, websocket bool) error {
+	ingress := &networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      service,
+			Namespace: network.Namespace,
+		},
+	}
+
+	if host == "" {
+		if err := r.Client.Delete(context.Background(), ingress); err != nil && !apierrors.IsNotFound(err) {
+			r.Log.Error(err, "unable to delete ingress")
+			return err
+		}
+		return nil
+	}
+
+	_, err := ctrl.CreateOrUpdate(context.Background(), r.Client, ingress, func() error {
+		if err := ctrl.SetControllerReference(network, ingress, r.Scheme); err != nil {
+			return err
+		}
+		specIngress(ingress, labels, host, service, port)
+		specIngressAnnotations(ingress, network.Spec.IngressClass, websocket)
+		return nil
+	})
+
+	return err
+}
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
+)
+
+func TestSpecIngressAnnotations(t *testing.T) {
+	ingress := &networkingv1beta1.Ingress{}
+	ingress.Annotations = map[string]string{"owner": "ops"}
+
+	specIngressAnnotations(ingress, ethereumv1beta1.NginxIngressClass, true)
+
+	if got := ingress.Annotations[IngressClassAnnotation]; got != "nginx" {
+		t.Errorf("Expecting ingress class to be nginx got %s", got)
+	}
+	if got := ingress.Annotations["nginx.ingress.kubernetes.io/proxy-read-timeout"]; got != "3600" {
+		t.Errorf("Expecting long nginx read timeout got %s", got)
+	}
+
+	specIngressAnnotations(ingress, ethereumv1beta1.HAProxyIngressClass, false)
+
+	if _, exists := ingress.Annotations["nginx.ingress.kubernetes.io/affinity"]; exists {
+		t.Errorf("Expecting nginx annotations to be removed")
+	}
+	if _, exists := ingress.Annotations["haproxy-ingress.github.io/timeout-tunnel"]; exists {
+		t.Errorf("Expecting web socket annotations only for web socket ingresses")
+	}
+	if ingress.Annotations["owner"] != "ops" {
+		t.Errorf("Expecting other ingress annotations to be kept")
+	}
+}
+
+func TestSpecNodeServiceWSIngress(t *testing.T) {
+	r := &NetworkReconciler{}
+	network := argsTestNetwork(ethereumv1beta1.GethClient)
+	node := &network.Spec.Nodes[0]
+	node.WS = true
+	node.WSIngressHost = "ws.example.com"
+	network.Default()
+
+	if !withNodeService(node, network) {
+		t.Fatalf("Expecting node with web socket ingress to be exposed by service")
+	}
+
+	svc := &corev1.Service{}
+	r.specNodeService(svc, node, network)
+
+	ws := svc.Spec.Ports[len(svc.Spec.Ports)-1]
+	if ws.Name != "ws" || ws.Port != int32(node.WSPort) {
+		t.Errorf("Expecting node service to expose web socket port got %v", ws)
+	}
+}
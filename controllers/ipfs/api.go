@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// apiClient is http client used to call ipfs nodes http APIs
+// publishing ipns records waits for the record to be put in the dht, which is slow
+var apiClient = &http.Client{Timeout: 2 * time.Minute}
+
+// statusClient is http client used to poll ipfs nodes http APIs for status
+var statusClient = &http.Client{Timeout: 5 * time.Second}
+
+// publishName publishes ipfs path under the given key using ipfs node api
+// it returns the ipns name the path has been published under
+func publishName(api, key, path string, lifetime time.Duration) (string, error) {
+	query := url.Values{}
+	query.Set("arg", path)
+	query.Set("key", key)
+	query.Set("lifetime", lifetime.String())
+
+	resp, err := apiClient.Post(fmt.Sprintf("%s/api/v0/name/publish?%s", api, query.Encode()), "", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("name publish returned status code %d", resp.StatusCode)
+	}
+
+	response := struct {
+		Name  string
+		Value string
+	}{}
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", err
+	}
+
+	return response.Name, nil
+}
+
+// swarmPeers returns number of peers connected to ipfs node using node api
+func swarmPeers(api string) (int, error) {
+	resp, err := statusClient.Post(fmt.Sprintf("%s/api/v0/swarm/peers", api), "", nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("swarm peers returned status code %d", resp.StatusCode)
+	}
+
+	response := struct {
+		Peers []struct {
+			Peer string
+		}
+	}{}
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return 0, err
+	}
+
+	return len(response.Peers), nil
+}
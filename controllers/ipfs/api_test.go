@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPublishName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/api/v0/name/publish" || query.Get("key") != "self" || query.Get("lifetime") != "24h0m0s" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"Name":"k51qzi5uqu5dlvj2baxnqndepeb86cbk3ng7n3i46uzyxzyqj2xjonzllnv0v8","Value":"%s"}`, query.Get("arg"))
+	}))
+	defer server.Close()
+
+	name, err := publishName(server.URL, "self", "/ipfs/QmUNLLsPACCz1vLxQVkXqqLX5R1X345qqfHbsf67hvA3Nn", 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if name != "k51qzi5uqu5dlvj2baxnqndepeb86cbk3ng7n3i46uzyxzyqj2xjonzllnv0v8" {
+		t.Errorf("Expecting published name got %s", name)
+	}
+}
+
+func TestPublishNameFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if _, err := publishName(server.URL, "self", "/ipfs/QmUNLLsPACCz1vLxQVkXqqLX5R1X345qqfHbsf67hvA3Nn", time.Hour); err == nil {
+		t.Errorf("Expecting error got nil")
+	}
+}
+
+func TestSwarmPeers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v0/swarm/peers" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"Peers":[{"Addr":"/ip4/10.0.0.2/tcp/4001","Peer":"QmNnooDu7bfjPFoTZYxMNLWUQJyrVwtbZg5gBMjTezGAJN"},{"Addr":"/ip4/10.0.0.3/tcp/4001","Peer":"QmQCU2EcMqAqQPR2i9bChDtGNJchTbq5TbXJJ16u19uLTa"}]}`)
+	}))
+	defer server.Close()
+
+	peers, err := swarmPeers(server.URL)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if peers != 2 {
+		t.Errorf("Expecting 2 peers got %d", peers)
+	}
+}
+
+func TestSwarmPeersWithoutPeers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Peers":null}`)
+	}))
+	defer server.Close()
+
+	peers, err := swarmPeers(server.URL)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if peers != 0 {
+		t.Errorf("Expecting 0 peers got %d", peers)
+	}
+}
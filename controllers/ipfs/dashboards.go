@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"fmt"
+
+	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	"github.com/kotalco/kotal/helpers"
+)
+
+// GrafanaDashboardLabel is the label used by grafana sidecar to discover dashboards config maps
+const GrafanaDashboardLabel = "grafana_dashboard"
+
+// MetricsPath is the path ipfs serves prometheus metrics on
+const MetricsPath = "/debug/metrics/prometheus"
+
+// generateDashboard generates grafana dashboard for swarm nodes
+func generateDashboard(swarm *ipfsv1alpha1.Swarm) (string, error) {
+	// select metrics of swarm node pods only
+	selector := fmt.Sprintf(`namespace="%s",pod=~"%s-.*"`, swarm.Namespace, swarm.Name)
+
+	panels := []helpers.DashboardPanel{
+		{Title: "Peers", Query: fmt.Sprintf("ipfs_p2p_peers_total{%s}", selector), Legend: "{{pod}}"},
+		{Title: "HTTP API Requests", Query: fmt.Sprintf("sum by (pod) (rate(ipfs_http_requests_total{%s}[5m]))", selector), Legend: "{{pod}}"},
+		{Title: "Memory", Query: fmt.Sprintf("go_memstats_alloc_bytes{%s}", selector), Legend: "{{pod}}"},
+		{Title: "Goroutines", Query: fmt.Sprintf("go_goroutines{%s}", selector), Legend: "{{pod}}"},
+	}
+
+	title := fmt.Sprintf("%s/%s (ipfs)", swarm.Namespace, swarm.Name)
+
+	return helpers.GenerateDashboard(title, panels)
+}
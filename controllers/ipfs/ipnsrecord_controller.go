@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/label"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+
+	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	"github.com/kotalco/kotal/metrics"
+	"github.com/kotalco/kotal/tracing"
+)
+
+// IPNSRecordReconciler reconciles a IPNSRecord object
+type IPNSRecordReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// RateLimiter limits how frequently failing records are requeued, nil uses controller-runtime default
+	RateLimiter ratelimiter.RateLimiter
+}
+
+// +kubebuilder:rbac:groups=ipfs.kotal.io,resources=ipnsrecords,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ipfs.kotal.io,resources=ipnsrecords/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ipfs.kotal.io,resources=peers,verbs=get;list;watch
+
+// Reconcile publishes ipns record using its peer api and requeues it to be republished
+func (r *IPNSRecordReconciler) Reconcile(req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(context.Background(), "IPNSRecord.Reconcile",
+		label.String("namespace", req.Namespace),
+		label.String("name", req.Name),
+	)
+	defer func() { tracing.End(ctx, span, err) }()
+
+	var record ipfsv1alpha1.IPNSRecord
+
+	defer func(start time.Time) {
+		metrics.ObserveReconcile("ipnsrecord", req.NamespacedName, start, err)
+	}(time.Now())
+
+	if err = r.Client.Get(context.Background(), req.NamespacedName, &record); err != nil {
+		if err = client.IgnoreNotFound(err); err == nil {
+			metrics.Forget("ipnsrecord", req.NamespacedName)
+		}
+		return
+	}
+
+	interval := record.Spec.RepublishInterval.Duration
+
+	// record is still fresh, requeue it to be republished when interval elapses
+	if published := record.Status.LastPublished; published != nil && record.Status.Value == record.Path() {
+		if elapsed := time.Since(published.Time); elapsed < interval {
+			result.RequeueAfter = interval - elapsed
+			return
+		}
+	}
+
+	var peer ipfsv1alpha1.Peer
+	if err = r.Client.Get(context.Background(), types.NamespacedName{Name: record.Spec.Peer, Namespace: record.Namespace}, &peer); err != nil {
+		r.Log.Error(err, fmt.Sprintf("unable to get peer (%s)", record.Spec.Peer))
+		return
+	}
+
+	// peer api listens on localhost unless it's exposed to the cluster
+	if !peer.Spec.Metrics || peer.Status.ServiceIP == "" {
+		err = fmt.Errorf("peer (%s) api is not reachable from the cluster", peer.Name)
+		return
+	}
+
+	api := fmt.Sprintf("http://%s", net.JoinHostPort(peer.Status.ServiceIP, "5001"))
+
+	if err = tracing.Trace(ctx, "publishName", func() (err error) {
+		record.Status.Name, err = publishName(api, record.Spec.Key, record.Path(), record.Spec.Lifetime.Duration)
+		return
+	}); err != nil {
+		r.Log.Error(err, fmt.Sprintf("unable to publish record (%s)", record.Name))
+		return
+	}
+
+	now := metav1.Now()
+	record.Status.Value = record.Path()
+	record.Status.LastPublished = &now
+
+	if err = r.Status().Update(context.Background(), &record); err != nil {
+		r.Log.Error(err, "unable to update ipns record status")
+		return
+	}
+
+	result.RequeueAfter = interval
+
+	return
+}
+
+// SetupWithManager registers the controller to be started with the given manager
+func (r *IPNSRecordReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ipfsv1alpha1.IPNSRecord{}).
+		WithOptions(controller.Options{RateLimiter: r.RateLimiter}).
+		Complete(r)
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/label"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	"github.com/kotalco/kotal/metrics"
+	"github.com/kotalco/kotal/tracing"
+)
+
+// KeyReconciler reconciles a Key object
+type KeyReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// RateLimiter limits how frequently failing keys are requeued, nil uses controller-runtime default
+	RateLimiter ratelimiter.RateLimiter
+}
+
+// +kubebuilder:rbac:groups=ipfs.kotal.io,resources=keys,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ipfs.kotal.io,resources=keys/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=watch;get;create;update;list;delete
+
+// Reconcile generates ipfs key secret and reports key peer ID
+func (r *KeyReconciler) Reconcile(req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(context.Background(), "Key.Reconcile",
+		label.String("namespace", req.Namespace),
+		label.String("name", req.Name),
+	)
+	defer func() { tracing.End(ctx, span, err) }()
+
+	var key ipfsv1alpha1.Key
+
+	defer func(start time.Time) {
+		metrics.ObserveReconcile("key", req.NamespacedName, start, err)
+	}(time.Now())
+
+	if err = r.Client.Get(context.Background(), req.NamespacedName, &key); err != nil {
+		if err = client.IgnoreNotFound(err); err == nil {
+			metrics.Forget("key", req.NamespacedName)
+		}
+		return
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.SecretName(),
+			Namespace: key.Namespace,
+		},
+	}
+
+	if _, err = ctrl.CreateOrUpdate(context.Background(), r.Client, secret, func() error {
+		if err := ctrl.SetControllerReference(&key, secret, r.Scheme); err != nil {
+			return err
+		}
+		return specKeySecret(secret, &key)
+	}); err != nil {
+		r.Log.Error(err, "unable to reconcile key secret")
+		return
+	}
+
+	if key.Status.ID, err = keyPeerID(secret.Data["key"]); err != nil {
+		return
+	}
+
+	if err = r.Status().Update(context.Background(), &key); err != nil {
+		r.Log.Error(err, "unable to update key status")
+	}
+
+	return
+}
+
+// specKeySecret updates key secret, key is generated only once
+func specKeySecret(secret *corev1.Secret, key *ipfsv1alpha1.Key) error {
+	secret.ObjectMeta.Labels = map[string]string{
+		"name":     "key",
+		"instance": key.Name,
+		"peer":     key.Spec.Peer,
+	}
+
+	if len(secret.Data["key"]) != 0 {
+		return nil
+	}
+
+	generated, err := generateKey()
+	if err != nil {
+		return err
+	}
+
+	secret.Data = map[string][]byte{
+		"key": generated,
+	}
+
+	return nil
+}
+
+// mapKeyToPeer maps ipfs keys to the peer they're added to
+var mapKeyToPeer = handler.ToRequestsFunc(func(obj handler.MapObject) []reconcile.Request {
+	key, ok := obj.Object.(*ipfsv1alpha1.Key)
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      key.Spec.Peer,
+				Namespace: key.Namespace,
+			},
+		},
+	}
+})
+
+// SetupWithManager registers the controller to be started with the given manager
+func (r *KeyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ipfsv1alpha1.Key{}).
+		Owns(&corev1.Secret{}).
+		WithOptions(controller.Options{RateLimiter: r.RateLimiter}).
+		Complete(r)
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// PathKeys is the path peer keys are mounted at in node init container
+const PathKeys = "/keys"
+
+// libp2p protobuf encoded ed25519 keys prefixes (key type 1 and key data length)
+var (
+	ed25519PrivateKeyPrefix = []byte{0x08, 0x01, 0x12, 0x40}
+	ed25519PublicKeyPrefix  = []byte{0x08, 0x01, 0x12, 0x20}
+)
+
+// base58Alphabet is bitcoin base58 alphabet used by ipfs peer IDs
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// generateKey generates ed25519 private key encoded the way ipfs keystore stores it
+func generateKey() ([]byte, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, ed25519PrivateKeyPrefix...), priv...), nil
+}
+
+// keyPeerID returns peer ID of ipfs keystore encoded ed25519 private key
+func keyPeerID(key []byte) (string, error) {
+	if len(key) != len(ed25519PrivateKeyPrefix)+ed25519.PrivateKeySize || string(key[:4]) != string(ed25519PrivateKeyPrefix) {
+		return "", fmt.Errorf("invalid ed25519 private key")
+	}
+
+	pub := ed25519.PrivateKey(key[4:]).Public().(ed25519.PublicKey)
+	encoded := append(append([]byte{}, ed25519PublicKeyPrefix...), pub...)
+
+	// small public keys are inlined using identity multihash (code 0x00)
+	multihash := append([]byte{0x00, byte(len(encoded))}, encoded...)
+
+	return base58Encode(multihash), nil
+}
+
+// base58Encode encodes bytes using bitcoin base58 alphabet
+func base58Encode(data []byte) string {
+	n := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+
+	encoded := []byte{}
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+
+	// leading zero bytes are encoded as leading 1s
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+
+	return string(encoded)
+}
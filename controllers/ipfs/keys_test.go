@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"encoding/base64"
+	"testing"
+
+	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKeyPeerID(t *testing.T) {
+	key, _ := base64.StdEncoding.DecodeString("CAESQMbyIcsxBsn8kIk9sbL2NdVwSBf/Uj9BOA5KbXnrgmNHtQwF4rgzxd2XXpmdhIBxnlghaYVNBLzcRj2f6PCKnD0=")
+
+	id, err := keyPeerID(key)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if id != "12D3KooWN16bUqeedKUQHXtHJjUT1oEyFBr6YnKQ7B4LSTAnbTye" {
+		t.Errorf("Expecting peer ID 12D3KooWN16bUqeedKUQHXtHJjUT1oEyFBr6YnKQ7B4LSTAnbTye got %s", id)
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	key, err := generateKey()
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	if _, err := keyPeerID(key); err != nil {
+		t.Errorf("Expecting generated key to be valid got %s", err)
+	}
+}
+
+func TestKeyPeerIDInvalidKey(t *testing.T) {
+	if _, err := keyPeerID([]byte("invalid")); err == nil {
+		t.Errorf("Expecting error got nil")
+	}
+}
+
+func TestSpecPeerKeys(t *testing.T) {
+	peer := &ipfsv1alpha1.Peer{ObjectMeta: metav1.ObjectMeta{Name: "peer-1"}}
+	node := peer.Node()
+	node.Resources = &ipfsv1alpha1.NodeResources{CPU: "1", CPULimit: "1", Memory: "1Gi", MemoryLimit: "1Gi", Storage: "1Gi"}
+
+	keys := []ipfsv1alpha1.Key{
+		{ObjectMeta: metav1.ObjectMeta{Name: "website"}},
+	}
+
+	dep := &appsv1.Deployment{}
+	specNodeDeployment(dep, node, peer.Labels(), peer.Name, peer.Name)
+	specPeerKeys(dep, keys)
+
+	volumes := dep.Spec.Template.Spec.Volumes
+	keysVolume := volumes[len(volumes)-1]
+	if keysVolume.Name != "keys" || keysVolume.Projected.Sources[0].Secret.Name != "website-ipfs-key" {
+		t.Errorf("Expecting keys volume projecting website-ipfs-key secret got %+v", keysVolume)
+	}
+
+	mounts := dep.Spec.Template.Spec.InitContainers[0].VolumeMounts
+	if mount := mounts[len(mounts)-1]; mount.Name != "keys" || mount.MountPath != PathKeys {
+		t.Errorf("Expecting keys mounted at %s got %+v", PathKeys, mount)
+	}
+}
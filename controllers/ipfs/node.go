@@ -0,0 +1,361 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"text/template"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	"github.com/kotalco/kotal/helpers"
+)
+
+// Images
+const (
+	// DefaultGoIPFSImage is go-ipfs image
+	DefaultGoIPFSImage = "ipfs/go-ipfs:v0.6.0"
+	// DefaultGoIPFSInitImage is go-ipfs image used to initialize node repo
+	DefaultGoIPFSInitImage = "kotalco/go-ipfs:v0.6.0"
+)
+
+const (
+	// EnvGoIPFSImage is the environment variable used for go-ipfs image
+	EnvGoIPFSImage = "GO_IPFS_IMAGE"
+	// EnvGoIPFSInitImage is the environment variable used for go-ipfs init image
+	EnvGoIPFSInitImage = "GO_IPFS_INIT_IMAGE"
+)
+
+// GoIPFSImage returns go-ipfs docker image
+func GoIPFSImage() string {
+	return helpers.Image("go-ipfs", EnvGoIPFSImage, DefaultGoIPFSImage)
+}
+
+// GoIPFSInitImage returns go-ipfs init docker image
+func GoIPFSInitImage() string {
+	return helpers.Image("go-ipfs-init", EnvGoIPFSInitImage, DefaultGoIPFSInitImage)
+}
+
+// generateInitScript generates init script from node spec
+// peers are bootstrap swarm peers, and peering is peers the node keeps protected connections with
+func generateInitScript(node *ipfsv1alpha1.Node, peers []string, peering []ipfsv1alpha1.PeeringPeer) (script string, err error) {
+
+	type Input struct {
+		InitProfile    string
+		Profiles       []ipfsv1alpha1.Profile
+		ClearBootstrap bool
+		BootstrapPeers []string
+		Peers          []string
+		Config         map[string]string
+		Metrics        bool
+		IPv6           bool
+	}
+
+	config, err := nodeConfig(node, peering)
+	if err != nil {
+		return
+	}
+
+	input := &Input{
+		InitProfile:    initProfile(node.Datastore),
+		Profiles:       node.Profiles,
+		ClearBootstrap: node.Bootstrap == ipfsv1alpha1.NoBootstrap || node.Bootstrap == ipfsv1alpha1.CustomBootstrap,
+		BootstrapPeers: node.BootstrapPeers,
+		Peers:          peers,
+		Config:         config,
+		Metrics:        node.Metrics,
+		IPv6:           node.IPFamily == ipfsv1alpha1.IPv6Family,
+	}
+
+	tmpl, err := template.New("master").Parse(initScriptTemplate)
+	if err != nil {
+		return
+	}
+
+	buff := new(bytes.Buffer)
+	if err = tmpl.Execute(buff, input); err != nil {
+		return
+	}
+
+	script = buff.String()
+
+	return
+}
+
+// swarmPeering returns peers swarm node keeps protected connections with
+// they're the other swarm nodes, addressed by their services dns names, followed by node peering peers
+func swarmPeering(node *ipfsv1alpha1.Node, swarm *ipfsv1alpha1.Swarm) []ipfsv1alpha1.PeeringPeer {
+	peering := []ipfsv1alpha1.PeeringPeer{}
+
+	for i := range swarm.Spec.Nodes {
+		member := &swarm.Spec.Nodes[i]
+		if member.Name == node.Name {
+			continue
+		}
+		peering = append(peering, ipfsv1alpha1.PeeringPeer{
+			ID:    member.ID,
+			Addrs: []string{member.PeeringAddress(swarm.Name, swarm.Namespace)},
+		})
+	}
+
+	return append(peering, node.PeeringPeers...)
+}
+
+// initProfile returns ipfs init profile that configures the node datastore
+func initProfile(datastore ipfsv1alpha1.Datastore) string {
+	if datastore == ipfsv1alpha1.BadgerDatastore {
+		return "badgerds"
+	}
+	return "flatfs"
+}
+
+// nodeConfig returns ipfs config keys and their json values to be set during init
+func nodeConfig(node *ipfsv1alpha1.Node, peering []ipfsv1alpha1.PeeringPeer) (map[string]string, error) {
+	values := map[string]interface{}{}
+
+	// peering peers are always set so removing them from spec resets them
+	type Peer struct {
+		ID    string
+		Addrs []string
+	}
+
+	peers := []Peer{}
+	for _, peer := range peering {
+		addrs := peer.Addrs
+		if addrs == nil {
+			addrs = []string{}
+		}
+		peers = append(peers, Peer{ID: peer.ID, Addrs: addrs})
+	}
+	values["Peering.Peers"] = peers
+
+	// announce addresses are always set so removing them from spec resets them
+	values["Addresses.Announce"] = append([]string{}, node.Announce...)
+	values["Addresses.NoAnnounce"] = append([]string{}, node.NoAnnounce...)
+
+	// relay and autonat config keys of the pinned go-ipfs release
+	values["Swarm.EnableAutoRelay"] = node.RelayClient
+	values["Swarm.EnableRelayHop"] = node.RelayService
+	values["Swarm.EnableAutoNATService"] = node.AutoNATService
+
+	if gateway := node.Gateway; gateway != nil {
+		type PublicGateway struct {
+			Paths         []string
+			UseSubdomains bool
+			NoDNSLink     bool
+		}
+
+		publicGateways := map[string]PublicGateway{}
+		for _, public := range gateway.PublicGateways {
+			paths := public.Paths
+			if paths == nil {
+				paths = []string{}
+			}
+			publicGateways[public.Hostname] = PublicGateway{
+				Paths:         paths,
+				UseSubdomains: public.UseSubdomains,
+				NoDNSLink:     public.NoDNSLink,
+			}
+		}
+
+		pathPrefixes := gateway.PathPrefixes
+		if pathPrefixes == nil {
+			pathPrefixes = []string{}
+		}
+
+		values["Gateway.PathPrefixes"] = pathPrefixes
+		values["Gateway.NoFetch"] = gateway.NoFetch
+		values["Gateway.Writable"] = gateway.Writable
+		values["Gateway.PublicGateways"] = publicGateways
+	}
+
+	config := map[string]string{}
+	for key, value := range values {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		config[key] = string(encoded)
+	}
+
+	return config, nil
+}
+
+// specNodeConfig updates node config map
+func specNodeConfig(config *corev1.ConfigMap, labels map[string]string, script string) {
+
+	config.ObjectMeta.Labels = labels
+	config.Data = make(map[string]string)
+	config.Data["init.sh"] = script
+
+}
+
+// specNodePVC updates node persistent volume spec
+func specNodePVC(pvc *corev1.PersistentVolumeClaim, node *ipfsv1alpha1.Node, labels map[string]string) {
+
+	pvc.ObjectMeta.Labels = labels
+
+	pvc.Spec = corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{
+			corev1.ReadWriteOnce,
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse(node.Resources.Storage),
+			},
+		},
+		StorageClassName: helpers.StorageClass(nil),
+	}
+
+}
+
+// specNodeService updates node service spec
+func specNodeService(svc *corev1.Service, node *ipfsv1alpha1.Node, labels map[string]string) {
+
+	svc.ObjectMeta.Labels = labels
+
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "swarm",
+			Port:       4001,
+			TargetPort: intstr.FromInt(4001),
+			Protocol:   corev1.ProtocolTCP,
+		},
+		{
+			Name:       "swarm-udp",
+			Port:       4002,
+			TargetPort: intstr.FromInt(4002),
+			Protocol:   corev1.ProtocolUDP,
+		},
+		{
+			Name:       "api",
+			Port:       5001,
+			TargetPort: intstr.FromInt(5001),
+			Protocol:   corev1.ProtocolTCP,
+		},
+		{
+			Name:       "gateway",
+			Port:       8080,
+			TargetPort: intstr.FromInt(8080),
+			Protocol:   corev1.ProtocolTCP,
+		},
+	}
+
+	// ip family is immutable, cluster default family is kept if not set
+	if node.IPFamily != "" {
+		family := corev1.IPFamily(node.IPFamily)
+		svc.Spec.IPFamily = &family
+	}
+
+	svc.Spec.Selector = labels
+
+}
+
+// specNodeDeployment updates node deployment spec
+func specNodeDeployment(dep *appsv1.Deployment, node *ipfsv1alpha1.Node, labels map[string]string, pvcName, configName string) {
+	dep.ObjectMeta.Labels = labels
+
+	// annotations used by prometheus to discover and scrape node metrics
+	var annotations map[string]string
+	if node.Metrics {
+		annotations = map[string]string{
+			"prometheus.io/scrape": "true",
+			"prometheus.io/port":   "5001",
+			"prometheus.io/path":   MetricsPath,
+		}
+	}
+
+	initNode := corev1.Container{
+		Name:  "init-node",
+		Image: GoIPFSInitImage(),
+		Env: []corev1.EnvVar{
+			{
+				Name:  "IPFS_PEER_ID",
+				Value: node.ID,
+			},
+			{
+				Name:  "IPFS_PRIVATE_KEY",
+				Value: node.PrivateKey,
+			},
+		},
+		Command: []string{"/bin/sh"},
+		Args:    []string{"/script/init.sh"},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "data",
+				MountPath: "/data/ipfs",
+			},
+			{
+				Name:      "script",
+				MountPath: "/script",
+			},
+		},
+	}
+
+	dep.Spec = appsv1.DeploymentSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: labels,
+		},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      labels,
+				Annotations: annotations,
+			},
+			Spec: corev1.PodSpec{
+				ImagePullSecrets: helpers.ImagePullSecrets(),
+				InitContainers: []corev1.Container{
+					initNode,
+				},
+				Containers: []corev1.Container{
+					{
+						Name:    "node",
+						Image:   GoIPFSImage(),
+						Command: []string{"ipfs"},
+						Args:    append([]string{"daemon"}, node.ExtraArgs...),
+						Env:     node.Env,
+						VolumeMounts: []corev1.VolumeMount{
+							{
+								Name:      "data",
+								MountPath: "/data/ipfs",
+							},
+						},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(node.Resources.CPU),
+								corev1.ResourceMemory: resource.MustParse(node.Resources.Memory),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(node.Resources.CPULimit),
+								corev1.ResourceMemory: resource.MustParse(node.Resources.MemoryLimit),
+							},
+						},
+					},
+				},
+				Volumes: []corev1.Volume{
+					{
+						Name: "data",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: pvcName,
+							},
+						},
+					},
+					{
+						Name: "script",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: configName,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
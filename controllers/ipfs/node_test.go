@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+)
+
+func TestGenerateInitScriptGateway(t *testing.T) {
+	node := &ipfsv1alpha1.Node{
+		Name: "node-1",
+		Gateway: &ipfsv1alpha1.Gateway{
+			NoFetch: true,
+			PublicGateways: []ipfsv1alpha1.PublicGateway{
+				{
+					Hostname:      "dweb.link",
+					Paths:         []string{"/ipfs", "/ipns"},
+					UseSubdomains: true,
+				},
+			},
+		},
+	}
+
+	script, err := generateInitScript(node, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`ipfs config --json Gateway.NoFetch 'true'`,
+		`ipfs config --json Gateway.PathPrefixes '[]'`,
+		`ipfs config --json Gateway.PublicGateways '{"dweb.link":{"Paths":["/ipfs","/ipns"],"UseSubdomains":true,"NoDNSLink":false}}'`,
+		`ipfs config --json Gateway.Writable 'false'`,
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(script, line) {
+			t.Errorf("expected init script to contain %s", line)
+		}
+	}
+}
+
+func TestGenerateInitScriptWithoutGateway(t *testing.T) {
+	script, err := generateInitScript(&ipfsv1alpha1.Node{Name: "node-1"}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(script, "Gateway.") {
+		t.Errorf("expected init script not to configure gateway")
+	}
+}
+
+func TestGenerateInitScriptDatastore(t *testing.T) {
+	tests := map[ipfsv1alpha1.Datastore]string{
+		"":                           "ipfs init --profile flatfs",
+		ipfsv1alpha1.FlatFSDatastore: "ipfs init --profile flatfs",
+		ipfsv1alpha1.BadgerDatastore: "ipfs init --profile badgerds",
+	}
+
+	for datastore, expected := range tests {
+		node := &ipfsv1alpha1.Node{Name: "node-1", Datastore: datastore}
+		script, err := generateInitScript(node, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(script, expected) {
+			t.Errorf("expected %s datastore init script to contain %s", datastore, expected)
+		}
+	}
+}
+
+func TestGenerateInitScriptAnnounce(t *testing.T) {
+	node := &ipfsv1alpha1.Node{
+		Name:       "node-1",
+		Announce:   []string{"/ip4/1.2.3.4/tcp/4001"},
+		NoAnnounce: []string{"/ip4/10.0.0.0/ipcidr/8"},
+	}
+
+	script, err := generateInitScript(node, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`ipfs config --json Addresses.Announce '["/ip4/1.2.3.4/tcp/4001"]'`,
+		`ipfs config --json Addresses.NoAnnounce '["/ip4/10.0.0.0/ipcidr/8"]'`,
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(script, line) {
+			t.Errorf("expected init script to contain %s", line)
+		}
+	}
+}
+
+func TestGenerateInitScriptRelay(t *testing.T) {
+	node := &ipfsv1alpha1.Node{
+		Name:           "node-1",
+		RelayClient:    true,
+		AutoNATService: true,
+	}
+
+	script, err := generateInitScript(node, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`ipfs config --json Swarm.EnableAutoRelay 'true'`,
+		`ipfs config --json Swarm.EnableRelayHop 'false'`,
+		`ipfs config --json Swarm.EnableAutoNATService 'true'`,
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(script, line) {
+			t.Errorf("expected init script to contain %s", line)
+		}
+	}
+}
+
+func TestGenerateInitScriptPeering(t *testing.T) {
+	swarm := &ipfsv1alpha1.Swarm{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-swarm", Namespace: "default"},
+		Spec: ipfsv1alpha1.SwarmSpec{
+			Nodes: []ipfsv1alpha1.Node{
+				{
+					Name: "node-1",
+					ID:   "12D3KooWNode1",
+					PeeringPeers: []ipfsv1alpha1.PeeringPeer{
+						{ID: "QmExternal"},
+					},
+				},
+				{
+					Name:     "node-2",
+					ID:       "12D3KooWNode2",
+					IPFamily: ipfsv1alpha1.IPv6Family,
+				},
+			},
+		},
+	}
+
+	node := &swarm.Spec.Nodes[0]
+	script, err := generateInitScript(node, nil, swarmPeering(node, swarm))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `ipfs config --json Peering.Peers '[{"ID":"12D3KooWNode2","Addrs":["/dns6/my-swarm-node-2.default.svc/tcp/4001"]},{"ID":"QmExternal","Addrs":[]}]'`
+	if !strings.Contains(script, expected) {
+		t.Errorf("expected init script to contain %s", expected)
+	}
+
+	// peering is reset if node has no peering peers
+	script, err = generateInitScript(&ipfsv1alpha1.Node{Name: "node-1"}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := `ipfs config --json Peering.Peers '[]'`; !strings.Contains(script, expected) {
+		t.Errorf("expected init script to contain %s", expected)
+	}
+}
+
+func TestSpecNodeServicePorts(t *testing.T) {
+	svc := &corev1.Service{}
+	specNodeService(svc, &ipfsv1alpha1.Node{Name: "node-1"}, map[string]string{"name": "node"})
+
+	expected := map[string]corev1.Protocol{
+		"swarm":     corev1.ProtocolTCP,
+		"swarm-udp": corev1.ProtocolUDP,
+		"api":       corev1.ProtocolTCP,
+		"gateway":   corev1.ProtocolTCP,
+	}
+
+	for _, port := range svc.Spec.Ports {
+		if port.Protocol != expected[port.Name] {
+			t.Errorf("expected %s port protocol to be %s got %s", port.Name, expected[port.Name], port.Protocol)
+		}
+	}
+}
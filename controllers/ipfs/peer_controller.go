@@ -0,0 +1,298 @@
+package controllers
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/label"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	"github.com/kotalco/kotal/metrics"
+	"github.com/kotalco/kotal/tracing"
+)
+
+// PeerReconciler reconciles a Peer object
+type PeerReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// RateLimiter limits how frequently failing peers are requeued, nil uses controller-runtime default
+	RateLimiter ratelimiter.RateLimiter
+}
+
+// +kubebuilder:rbac:groups=ipfs.kotal.io,resources=peers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ipfs.kotal.io,resources=peers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ipfs.kotal.io,resources=keys,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=watch;get;list;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=services;configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+
+// Reconcile reconciles ipfs peer
+func (r *PeerReconciler) Reconcile(req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(context.Background(), "Peer.Reconcile",
+		label.String("namespace", req.Namespace),
+		label.String("name", req.Name),
+	)
+	defer func() { tracing.End(ctx, span, err) }()
+
+	var peer ipfsv1alpha1.Peer
+
+	defer func(start time.Time) {
+		metrics.ObserveReconcile("peer", req.NamespacedName, start, err)
+	}(time.Now())
+
+	if err = r.Client.Get(context.Background(), req.NamespacedName, &peer); err != nil {
+		if err = client.IgnoreNotFound(err); err == nil {
+			metrics.Forget("peer", req.NamespacedName)
+		}
+		return
+	}
+
+	// peer is a single ipfs node that isn't part of a swarm
+	// it joins the public network using default bootstrap peers
+	node := peer.Node()
+
+	if err = tracing.Trace(ctx, "reconcilePeerPVC", func() error {
+		return r.reconcilePVC(&peer, node)
+	}); err != nil {
+		return
+	}
+
+	if err = tracing.Trace(ctx, "reconcilePeerConfig", func() error {
+		return r.reconcileConfig(&peer, node)
+	}); err != nil {
+		return
+	}
+
+	var ip string
+	if err = tracing.Trace(ctx, "reconcilePeerService", func() (err error) {
+		ip, err = r.reconcileService(&peer, node)
+		return
+	}); err != nil {
+		return
+	}
+
+	var keys []ipfsv1alpha1.Key
+	if keys, err = r.peerKeys(&peer); err != nil {
+		return
+	}
+
+	if err = tracing.Trace(ctx, "reconcilePeerDeployment", func() error {
+		return r.reconcileDeployment(&peer, node, keys)
+	}); err != nil {
+		return
+	}
+
+	err = r.updateStatus(&peer, ip)
+
+	return
+}
+
+// updateMetrics updates operator metrics about managed peers
+func (r *PeerReconciler) updateMetrics() error {
+	var peers ipfsv1alpha1.PeerList
+
+	if err := r.Client.List(context.Background(), &peers); err != nil {
+		return err
+	}
+
+	metrics.SetResources("peer", len(peers.Items), map[string]int{"go-ipfs": len(peers.Items)})
+
+	return nil
+}
+
+// updateStatus updates peer status
+func (r *PeerReconciler) updateStatus(peer *ipfsv1alpha1.Peer, ip string) error {
+	peer.Status.ServiceIP = ip
+
+	if err := r.Status().Update(context.Background(), peer); err != nil {
+		r.Log.Error(err, "unable to update peer status")
+		return err
+	}
+
+	return nil
+}
+
+// peerKeys returns keys added to the peer sorted by name
+func (r *PeerReconciler) peerKeys(peer *ipfsv1alpha1.Peer) ([]ipfsv1alpha1.Key, error) {
+	var keys ipfsv1alpha1.KeyList
+
+	if err := r.Client.List(context.Background(), &keys, client.InNamespace(peer.Namespace)); err != nil {
+		r.Log.Error(err, "unable to list keys")
+		return nil, err
+	}
+
+	peerKeys := []ipfsv1alpha1.Key{}
+	for _, key := range keys.Items {
+		if key.Spec.Peer == peer.Name {
+			peerKeys = append(peerKeys, key)
+		}
+	}
+
+	sort.Slice(peerKeys, func(i, j int) bool {
+		return peerKeys[i].Name < peerKeys[j].Name
+	})
+
+	return peerKeys, nil
+}
+
+// reconcilePVC reconciles peer data persistent volume claim
+func (r *PeerReconciler) reconcilePVC(peer *ipfsv1alpha1.Peer, node *ipfsv1alpha1.Node) error {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      peer.Name,
+			Namespace: peer.Namespace,
+		},
+	}
+
+	_, err := ctrl.CreateOrUpdate(context.Background(), r.Client, pvc, func() error {
+		if err := ctrl.SetControllerReference(peer, pvc, r.Scheme); err != nil {
+			return err
+		}
+		if pvc.CreationTimestamp.IsZero() {
+			specNodePVC(pvc, node, peer.Labels())
+		}
+		return nil
+	})
+
+	return err
+}
+
+// reconcileConfig reconciles peer init script config map
+func (r *PeerReconciler) reconcileConfig(peer *ipfsv1alpha1.Peer, node *ipfsv1alpha1.Node) error {
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      peer.Name,
+			Namespace: peer.Namespace,
+		},
+	}
+
+	script, err := generateInitScript(node, nil, node.PeeringPeers)
+	if err != nil {
+		return err
+	}
+
+	_, err = ctrl.CreateOrUpdate(context.Background(), r.Client, config, func() error {
+		if err := ctrl.SetControllerReference(peer, config, r.Scheme); err != nil {
+			return err
+		}
+		specNodeConfig(config, peer.Labels(), script)
+		return nil
+	})
+
+	return err
+}
+
+// reconcileService reconciles peer service
+func (r *PeerReconciler) reconcileService(peer *ipfsv1alpha1.Peer, node *ipfsv1alpha1.Node) (string, error) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      peer.Name,
+			Namespace: peer.Namespace,
+		},
+	}
+
+	_, err := ctrl.CreateOrUpdate(context.Background(), r.Client, svc, func() error {
+		if err := ctrl.SetControllerReference(peer, svc, r.Scheme); err != nil {
+			return err
+		}
+		specNodeService(svc, node, peer.Labels())
+		return nil
+	})
+
+	return svc.Spec.ClusterIP, err
+}
+
+// reconcileDeployment reconciles peer deployment
+func (r *PeerReconciler) reconcileDeployment(peer *ipfsv1alpha1.Peer, node *ipfsv1alpha1.Node, keys []ipfsv1alpha1.Key) error {
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      peer.Name,
+			Namespace: peer.Namespace,
+		},
+	}
+
+	_, err := ctrl.CreateOrUpdate(context.Background(), r.Client, dep, func() error {
+		if err := ctrl.SetControllerReference(peer, dep, r.Scheme); err != nil {
+			return err
+		}
+		specNodeDeployment(dep, node, peer.Labels(), peer.Name, peer.Name)
+		specPeerKeys(dep, keys)
+		return nil
+	})
+
+	return err
+}
+
+// specPeerKeys mounts peer keys secrets into node init container to be copied into the keystore
+func specPeerKeys(dep *appsv1.Deployment, keys []ipfsv1alpha1.Key) {
+	if len(keys) == 0 {
+		return
+	}
+
+	sources := []corev1.VolumeProjection{}
+	for _, key := range keys {
+		sources = append(sources, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: key.SecretName(),
+				},
+				Items: []corev1.KeyToPath{
+					{
+						Key:  "key",
+						Path: key.Name,
+					},
+				},
+			},
+		})
+	}
+
+	podSpec := &dep.Spec.Template.Spec
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "keys",
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: sources,
+			},
+		},
+	})
+
+	for i := range podSpec.InitContainers {
+		podSpec.InitContainers[i].VolumeMounts = append(podSpec.InitContainers[i].VolumeMounts, corev1.VolumeMount{
+			Name:      "keys",
+			MountPath: PathKeys,
+			ReadOnly:  true,
+		})
+	}
+}
+
+// SetupWithManager registers the controller to be started with the given manager
+func (r *PeerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	updateMetrics := metrics.UpdateResources(metrics.ResourcesUpdateInterval, r.updateMetrics, r.Log)
+	if err := mgr.Add(manager.RunnableFunc(updateMetrics)); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ipfsv1alpha1.Peer{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Owns(&corev1.PersistentVolumeClaim{}).
+		Owns(&corev1.ConfigMap{}).
+		Watches(&source.Kind{Type: &ipfsv1alpha1.Key{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: mapKeyToPeer}).
+		WithOptions(controller.Options{RateLimiter: r.RateLimiter}).
+		Complete(r)
+}
@@ -10,9 +10,26 @@ then
 	echo "ipfs repo has already been initialized"
 else 
 	echo "initializing ipfs repo"
-	ipfs init
+	ipfs init --profile {{ .InitProfile }}
 fi
 
+if [ -d /keys ]
+then
+	echo "copying keys into the keystore"
+	mkdir -p /data/ipfs/keystore
+	cp /keys/* /data/ipfs/keystore/
+fi
+
+{{ if .ClearBootstrap }}
+echo "removing default bootstrap peers"
+ipfs bootstrap rm --all
+{{ end }}
+
+echo "adding custom bootstrap peers"
+{{ range .BootstrapPeers }}
+	ipfs bootstrap add {{ . }}
+{{ end }}
+
 echo "adding bootstrap swarm peers"
 {{ range .Peers }}
 	ipfs bootstrap add {{ . }}
@@ -22,4 +39,18 @@ echo "applying profiles"
 {{ range .Profiles }}
 	ipfs config profile apply {{ . }}
 {{ end }}
+
+echo "applying node config"
+{{ range $key, $value := .Config }}
+	ipfs config --json {{ $key }} '{{ $value }}'
+{{ end }}
+
+{{ if .Metrics }}
+echo "exposing api to the cluster"
+{{ if .IPv6 }}
+ipfs config Addresses.API /ip6/::/tcp/5001
+{{ else }}
+ipfs config Addresses.API /ip4/0.0.0.0/tcp/5001
+{{ end }}
+{{ end }}
 `
@@ -1,21 +1,28 @@
 package controllers
 
 import (
-	"bytes"
 	"context"
 	"fmt"
+	"net"
+
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/label"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"text/template"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+	"time"
 
 	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	"github.com/kotalco/kotal/helpers"
+	"github.com/kotalco/kotal/metrics"
+	"github.com/kotalco/kotal/tracing"
 )
 
 // SwarmReconciler reconciles a Swarm object
@@ -23,40 +30,181 @@ type SwarmReconciler struct {
 	client.Client
 	Log    logr.Logger
 	Scheme *runtime.Scheme
+	// RateLimiter limits how frequently failing swarms are requeued, nil uses controller-runtime default
+	RateLimiter ratelimiter.RateLimiter
+	// StatusRefreshInterval is how often nodes peers are refreshed in status, 0 disables it
+	StatusRefreshInterval time.Duration
 }
 
 // +kubebuilder:rbac:groups=ipfs.kotal.io,resources=swarms,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ipfs.kotal.io,resources=swarms/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=watch;get;list;create;update;delete
 // +kubebuilder:rbac:groups=core,resources=services;configmaps;persistentvolumeclaims,verbs=watch;get;create;update;list;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=watch;get;list
 
 // Reconcile reconciles ipfs swarm
 func (r *SwarmReconciler) Reconcile(req ctrl.Request) (result ctrl.Result, err error) {
-	var _ = context.Background()
-	_ = r.Log.WithValues("swarm", req.NamespacedName)
+	ctx, span := tracing.Start(context.Background(), "Swarm.Reconcile",
+		label.String("namespace", req.Namespace),
+		label.String("name", req.Name),
+	)
+	defer func() { tracing.End(ctx, span, err) }()
 
 	var swarm ipfsv1alpha1.Swarm
 
+	defer func(start time.Time) {
+		metrics.ObserveReconcile("swarm", req.NamespacedName, start, err)
+	}(time.Now())
+
 	if err = r.Client.Get(context.Background(), req.NamespacedName, &swarm); err != nil {
-		err = client.IgnoreNotFound(err)
+		if err = client.IgnoreNotFound(err); err == nil {
+			metrics.Forget("swarm", req.NamespacedName)
+		}
+		return
+	}
+
+	// record spec changes in swarm status history
+	if err = recordSpecChange(&swarm); err != nil {
+		return
+	}
+
+	if err = r.reconcileNodes(ctx, &swarm); err != nil {
+		return
+	}
+
+	// reflect nodes pods readiness in swarm status
+	if err = r.updateNodesReadiness(&swarm); err != nil {
 		return
 	}
 
+	// poll ready nodes peers
+	if r.StatusRefreshInterval != 0 {
+		r.updateNodesPeers(&swarm)
+		result.RequeueAfter = r.StatusRefreshInterval
+	}
+
 	if err = r.updateStatus(&swarm); err != nil {
 		return
 	}
 
-	if err = r.reconcileNodes(&swarm); err != nil {
+	if err = r.reconcileDashboards(&swarm); err != nil {
 		return
 	}
 
 	return
 }
 
+// reconcileDashboards creates grafana dashboards config map if monitoring dashboards are enabled
+// deletes the dashboards config map if dashboards are disabled
+func (r *SwarmReconciler) reconcileDashboards(swarm *ipfsv1alpha1.Swarm) error {
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      swarm.DashboardsConfigName(),
+			Namespace: swarm.Namespace,
+		},
+	}
+
+	if swarm.Spec.Monitoring == nil || !swarm.Spec.Monitoring.Dashboards {
+		if err := r.Client.Delete(context.Background(), config); err != nil && !apierrors.IsNotFound(err) {
+			r.Log.Error(err, "unable to delete grafana dashboards config map")
+			return err
+		}
+		return nil
+	}
+
+	dashboard, err := generateDashboard(swarm)
+	if err != nil {
+		return err
+	}
+
+	_, err = ctrl.CreateOrUpdate(context.Background(), r.Client, config, func() error {
+		if err := ctrl.SetControllerReference(swarm, config, r.Scheme); err != nil {
+			return err
+		}
+
+		config.ObjectMeta.Labels = map[string]string{
+			"swarm":               swarm.Name,
+			GrafanaDashboardLabel: "1",
+		}
+		config.Data = map[string]string{
+			"ipfs.json": dashboard,
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// updateMetrics updates operator metrics about managed swarms and their nodes
+func (r *SwarmReconciler) updateMetrics() error {
+	var swarms ipfsv1alpha1.SwarmList
+
+	if err := r.Client.List(context.Background(), &swarms); err != nil {
+		return err
+	}
+
+	nodes := 0
+	for _, swarm := range swarms.Items {
+		nodes += len(swarm.Spec.Nodes)
+	}
+
+	metrics.SetResources("swarm", len(swarms.Items), map[string]int{"go-ipfs": nodes})
+
+	return nil
+}
+
+// recordSpecChange records changed spec fields in swarm status history whenever swarm generation changes
+func recordSpecChange(swarm *ipfsv1alpha1.Swarm) error {
+	if swarm.Generation == swarm.Status.ObservedGeneration {
+		return nil
+	}
+
+	hashes, err := helpers.SpecFieldsHashes(swarm.Spec)
+	if err != nil {
+		return err
+	}
+
+	changes := []string{"created"}
+	if swarm.Status.SpecHashes != nil {
+		changes = helpers.ChangedFields(swarm.Status.SpecHashes, hashes)
+	}
+
+	swarm.Status.ObservedGeneration = swarm.Generation
+	swarm.Status.SpecHashes = hashes
+
+	// generation changes without spec fields changes aren't recorded
+	if len(changes) == 0 {
+		return nil
+	}
+
+	change := ipfsv1alpha1.SpecChange{
+		Generation: swarm.Generation,
+		Time:       metav1.Now(),
+		Manager:    helpers.SpecManager(swarm),
+		Changes:    changes,
+	}
+
+	history := append([]ipfsv1alpha1.SpecChange{change}, swarm.Status.History...)
+	if len(history) > helpers.SpecHistoryLimit {
+		history = history[:helpers.SpecHistoryLimit]
+	}
+	swarm.Status.History = history
+
+	return nil
+}
+
 // updateStatus updates swarm status
 func (r *SwarmReconciler) updateStatus(swarm *ipfsv1alpha1.Swarm) error {
 	swarm.Status.NodesCount = len(swarm.Spec.Nodes)
 
+	swarm.Status.ReadyNodes = 0
+	for _, status := range swarm.Status.Nodes {
+		if status.Ready {
+			swarm.Status.ReadyNodes++
+		}
+	}
+
 	if err := r.Status().Update(context.Background(), swarm); err != nil {
 		r.Log.Error(err, "unable to update swarm status")
 		return err
@@ -65,23 +213,126 @@ func (r *SwarmReconciler) updateStatus(swarm *ipfsv1alpha1.Swarm) error {
 	return nil
 }
 
+// updateNodesReadiness records whether nodes pods are ready in nodes status
+func (r *SwarmReconciler) updateNodesReadiness(swarm *ipfsv1alpha1.Swarm) error {
+	for i := range swarm.Status.Nodes {
+		status := &swarm.Status.Nodes[i]
+		node := swarm.Spec.Nodes[i]
+
+		pod, err := r.getNodePod(&node, swarm)
+		if err != nil {
+			return err
+		}
+
+		status.Ready = pod != nil && podReady(pod)
+		if !status.Ready {
+			status.Peers = 0
+		}
+	}
+
+	return nil
+}
+
+// updateNodesPeers updates ready nodes connected peers count in nodes status
+// failing to get node peers doesn't fail the reconciliation
+func (r *SwarmReconciler) updateNodesPeers(swarm *ipfsv1alpha1.Swarm) {
+	log := r.Log.WithName("peers status")
+
+	for i := range swarm.Status.Nodes {
+		status := &swarm.Status.Nodes[i]
+		node := swarm.Spec.Nodes[i]
+
+		if !status.Ready {
+			continue
+		}
+
+		pod, err := r.getNodePod(&node, swarm)
+		if err != nil || pod == nil {
+			log.Info(fmt.Sprintf("unable to get node (%s) pod: %v", node.Name, err))
+			continue
+		}
+
+		api := fmt.Sprintf("http://%s", net.JoinHostPort(pod.Status.PodIP, "5001"))
+		peers, err := swarmPeers(api)
+		if err != nil {
+			log.Info(fmt.Sprintf("unable to get node (%s) peers: %s", node.Name, err))
+			continue
+		}
+
+		status.Peers = peers
+	}
+}
+
+// getNodePod returns node pod, nil is returned if node has no pods
+func (r *SwarmReconciler) getNodePod(node *ipfsv1alpha1.Node, swarm *ipfsv1alpha1.Swarm) (*corev1.Pod, error) {
+	var pods corev1.PodList
+	matchingLabels := client.MatchingLabels(node.Labels(swarm.Name))
+	inNamespace := client.InNamespace(swarm.Namespace)
+
+	if err := r.Client.List(context.Background(), &pods, matchingLabels, inNamespace); err != nil {
+		r.Log.Error(err, "unable to list node pods")
+		return nil, err
+	}
+
+	// prefer ready pod during rollouts
+	for i := range pods.Items {
+		if podReady(&pods.Items[i]) {
+			return &pods.Items[i], nil
+		}
+	}
+
+	if len(pods.Items) == 0 {
+		return nil, nil
+	}
+
+	return &pods.Items[0], nil
+}
+
+// podReady returns whether pod is ready or no
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 // reconcileNodes reconcile ipfs swarm nodes
-func (r *SwarmReconciler) reconcileNodes(swarm *ipfsv1alpha1.Swarm) error {
+// nodes peer ids and swarm addresses are recorded in swarm status
+func (r *SwarmReconciler) reconcileNodes(ctx context.Context, swarm *ipfsv1alpha1.Swarm) (err error) {
+	ctx, span := tracing.Start(ctx, "reconcileNodes")
+	defer func() { tracing.End(ctx, span, err) }()
+
 	peers := []string{}
+	nodes := []ipfsv1alpha1.NodeStatus{}
+	// previously observed nodes state
+	observed := map[string]ipfsv1alpha1.NodeStatus{}
+
+	for _, status := range swarm.Status.Nodes {
+		observed[status.Name] = status
+	}
 
 	for _, node := range swarm.Spec.Nodes {
-		addr, err := r.reconcileNode(&node, swarm, peers)
+		addr, err := r.reconcileNode(ctx, &node, swarm, peers)
 		if err != nil {
 			return err
 		}
 		peers = append(peers, addr)
-	}
 
-	if err := r.deleteRedundantNodes(swarm); err != nil {
-		return err
+		status := observed[node.Name]
+		status.Name = node.Name
+		status.ID = node.ID
+		status.SwarmAddress = addr
+
+		nodes = append(nodes, status)
 	}
 
-	return nil
+	swarm.Status.Nodes = nodes
+
+	return tracing.Trace(ctx, "deleteRedundantNodes", func() error {
+		return r.deleteRedundantNodes(swarm)
+	})
 }
 
 // deleteRedundantNodes deletes redundant ipfs node that has been removed from spec
@@ -167,22 +418,34 @@ func (r *SwarmReconciler) deleteRedundantNodes(swarm *ipfsv1alpha1.Swarm) error
 
 // reconcileNode reconciles a single ipfs node
 // it creates node deployment, service and data pvc if it doesn't exist
-func (r *SwarmReconciler) reconcileNode(node *ipfsv1alpha1.Node, swarm *ipfsv1alpha1.Swarm, peers []string) (addr string, err error) {
+func (r *SwarmReconciler) reconcileNode(ctx context.Context, node *ipfsv1alpha1.Node, swarm *ipfsv1alpha1.Swarm, peers []string) (addr string, err error) {
+	ctx, span := tracing.Start(ctx, "reconcileNode", label.String("node", node.Name))
+	defer func() { tracing.End(ctx, span, err) }()
+
 	var ip string
 
-	if err = r.reconcileNodePVC(node, swarm); err != nil {
+	if err = tracing.Trace(ctx, "reconcileNodePVC", func() error {
+		return r.reconcileNodePVC(node, swarm)
+	}); err != nil {
 		return
 	}
 
-	if err = r.reconcileNodeConfig(node, swarm, peers); err != nil {
+	if err = tracing.Trace(ctx, "reconcileNodeConfig", func() error {
+		return r.reconcileNodeConfig(node, swarm, peers)
+	}); err != nil {
 		return
 	}
 
-	if ip, err = r.reconcileNodeService(node, swarm); err != nil {
+	if err = tracing.Trace(ctx, "reconcileNodeService", func() (err error) {
+		ip, err = r.reconcileNodeService(node, swarm)
+		return
+	}); err != nil {
 		return
 	}
 
-	if err = r.reconcileNodeDeployment(node, swarm, peers); err != nil {
+	if err = tracing.Trace(ctx, "reconcileNodeDeployment", func() error {
+		return r.reconcileNodeDeployment(node, swarm, peers)
+	}); err != nil {
 		return
 	}
 
@@ -200,7 +463,7 @@ func (r *SwarmReconciler) reconcileNodeConfig(node *ipfsv1alpha1.Node, swarm *ip
 		},
 	}
 
-	script, err := generateInitScript(node, peers)
+	script, err := generateInitScript(node, peers, swarmPeering(node, swarm))
 	if err != nil {
 		return err
 	}
@@ -210,50 +473,13 @@ func (r *SwarmReconciler) reconcileNodeConfig(node *ipfsv1alpha1.Node, swarm *ip
 			return err
 		}
 
-		r.specNodeConfig(config, node, swarm, script)
+		specNodeConfig(config, node.Labels(swarm.Name), script)
 		return nil
 	})
 
 	return err
 }
 
-// generateInitScript generates init script from node spec
-func generateInitScript(node *ipfsv1alpha1.Node, peers []string) (script string, err error) {
-
-	type Input struct {
-		Profiles []ipfsv1alpha1.Profile
-		Peers    []string
-	}
-
-	input := &Input{
-		Profiles: node.Profiles,
-		Peers:    peers,
-	}
-
-	tmpl, err := template.New("master").Parse(initScriptTemplate)
-	if err != nil {
-		return
-	}
-
-	buff := new(bytes.Buffer)
-	if err = tmpl.Execute(buff, input); err != nil {
-		return
-	}
-
-	script = buff.String()
-
-	return
-}
-
-// specNodeConfig updates node config map
-func (r *SwarmReconciler) specNodeConfig(config *corev1.ConfigMap, node *ipfsv1alpha1.Node, swarm *ipfsv1alpha1.Swarm, script string) {
-
-	config.ObjectMeta.Labels = node.Labels(swarm.Name)
-	config.Data = make(map[string]string)
-	config.Data["init.sh"] = script
-
-}
-
 // reconcileNodePVC reconciles ipfs node data persistent volume claim
 func (r *SwarmReconciler) reconcileNodePVC(node *ipfsv1alpha1.Node, swarm *ipfsv1alpha1.Swarm) error {
 	pvc := &corev1.PersistentVolumeClaim{
@@ -268,7 +494,7 @@ func (r *SwarmReconciler) reconcileNodePVC(node *ipfsv1alpha1.Node, swarm *ipfsv
 			return err
 		}
 		if pvc.CreationTimestamp.IsZero() {
-			r.specNodePVC(pvc, node, swarm)
+			specNodePVC(pvc, node, node.Labels(swarm.Name))
 		}
 		return nil
 	})
@@ -276,24 +502,6 @@ func (r *SwarmReconciler) reconcileNodePVC(node *ipfsv1alpha1.Node, swarm *ipfsv
 	return err
 }
 
-// specNodePVC updates node persistent volume spec
-func (r *SwarmReconciler) specNodePVC(pvc *corev1.PersistentVolumeClaim, node *ipfsv1alpha1.Node, swarm *ipfsv1alpha1.Swarm) {
-
-	pvc.ObjectMeta.Labels = node.Labels(swarm.Name)
-
-	pvc.Spec = corev1.PersistentVolumeClaimSpec{
-		AccessModes: []corev1.PersistentVolumeAccessMode{
-			corev1.ReadWriteOnce,
-		},
-		Resources: corev1.ResourceRequirements{
-			Requests: corev1.ResourceList{
-				corev1.ResourceStorage: resource.MustParse(node.Resources.Storage),
-			},
-		},
-	}
-
-}
-
 // reconcileNodeService reconciles node service
 func (r *SwarmReconciler) reconcileNodeService(node *ipfsv1alpha1.Node, swarm *ipfsv1alpha1.Swarm) (string, error) {
 
@@ -308,50 +516,13 @@ func (r *SwarmReconciler) reconcileNodeService(node *ipfsv1alpha1.Node, swarm *i
 		if err := ctrl.SetControllerReference(swarm, svc, r.Scheme); err != nil {
 			return err
 		}
-		r.specNodeService(svc, node, swarm)
+		specNodeService(svc, node, node.Labels(swarm.Name))
 		return nil
 	})
 
 	return svc.Spec.ClusterIP, err
 }
 
-// specNodeService updates node service spec
-func (r *SwarmReconciler) specNodeService(svc *corev1.Service, node *ipfsv1alpha1.Node, swarm *ipfsv1alpha1.Swarm) {
-
-	labels := node.Labels(swarm.Name)
-	svc.ObjectMeta.Labels = labels
-
-	svc.Spec.Ports = []corev1.ServicePort{
-		{
-			Name:       "swarm",
-			Port:       4001,
-			TargetPort: intstr.FromInt(4001),
-			Protocol:   corev1.ProtocolTCP,
-		},
-		{
-			Name:       "swarm-udp",
-			Port:       4002,
-			TargetPort: intstr.FromInt(4002),
-			Protocol:   corev1.ProtocolUDP,
-		},
-		{
-			Name:       "api",
-			Port:       5001,
-			TargetPort: intstr.FromInt(5001),
-			Protocol:   corev1.ProtocolUDP,
-		},
-		{
-			Name:       "gateway",
-			Port:       8080,
-			TargetPort: intstr.FromInt(8080),
-			Protocol:   corev1.ProtocolUDP,
-		},
-	}
-
-	svc.Spec.Selector = labels
-
-}
-
 // reconcileNodeDeployment reconciles node deployment
 func (r *SwarmReconciler) reconcileNodeDeployment(node *ipfsv1alpha1.Node, swarm *ipfsv1alpha1.Swarm, peers []string) error {
 
@@ -366,113 +537,26 @@ func (r *SwarmReconciler) reconcileNodeDeployment(node *ipfsv1alpha1.Node, swarm
 		if err := ctrl.SetControllerReference(swarm, dep, r.Scheme); err != nil {
 			return err
 		}
-		r.specNodeDeployment(dep, node, swarm, peers)
+		specNodeDeployment(dep, node, node.Labels(swarm.Name), node.PVCName(swarm.Name), node.ConfigName(swarm.Name))
 		return nil
 	})
 
 	return err
 }
 
-// specNodeDeployment updates node deployment spec
-func (r *SwarmReconciler) specNodeDeployment(dep *appsv1.Deployment, node *ipfsv1alpha1.Node, swarm *ipfsv1alpha1.Swarm, peers []string) {
-	labels := node.Labels(swarm.Name)
-
-	dep.ObjectMeta.Labels = labels
-
-	initNode := corev1.Container{
-		Name:  "init-node",
-		Image: "kotalco/go-ipfs:v0.6.0",
-		Env: []corev1.EnvVar{
-			{
-				Name:  "IPFS_PEER_ID",
-				Value: node.ID,
-			},
-			{
-				Name:  "IPFS_PRIVATE_KEY",
-				Value: node.PrivateKey,
-			},
-		},
-		Command: []string{"/bin/sh"},
-		Args:    []string{"/script/init.sh"},
-		VolumeMounts: []corev1.VolumeMount{
-			{
-				Name:      "data",
-				MountPath: "/data/ipfs",
-			},
-			{
-				Name:      "script",
-				MountPath: "/script",
-			},
-		},
-	}
-
-	dep.Spec = appsv1.DeploymentSpec{
-		Selector: &metav1.LabelSelector{
-			MatchLabels: labels,
-		},
-		Template: corev1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: labels,
-			},
-			Spec: corev1.PodSpec{
-				InitContainers: []corev1.Container{
-					initNode,
-				},
-				Containers: []corev1.Container{
-					{
-						Name:    "node",
-						Image:   "ipfs/go-ipfs:v0.6.0",
-						Command: []string{"ipfs"},
-						Args:    []string{"daemon"},
-						VolumeMounts: []corev1.VolumeMount{
-							{
-								Name:      "data",
-								MountPath: "/data/ipfs",
-							},
-						},
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Resources.CPU),
-								corev1.ResourceMemory: resource.MustParse(node.Resources.Memory),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse(node.Resources.CPULimit),
-								corev1.ResourceMemory: resource.MustParse(node.Resources.MemoryLimit),
-							},
-						},
-					},
-				},
-				Volumes: []corev1.Volume{
-					{
-						Name: "data",
-						VolumeSource: corev1.VolumeSource{
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-								ClaimName: node.PVCName(swarm.Name),
-							},
-						},
-					},
-					{
-						Name: "script",
-						VolumeSource: corev1.VolumeSource{
-							ConfigMap: &corev1.ConfigMapVolumeSource{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: node.ConfigName(swarm.Name),
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-	}
-}
-
 // SetupWithManager registers the controller to be started with the given manager
 func (r *SwarmReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	updateMetrics := metrics.UpdateResources(metrics.ResourcesUpdateInterval, r.updateMetrics, r.Log)
+	if err := mgr.Add(manager.RunnableFunc(updateMetrics)); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&ipfsv1alpha1.Swarm{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
+		Owns(&corev1.ConfigMap{}).
+		WithOptions(controller.Options{RateLimiter: r.RateLimiter}).
 		Complete(r)
 }
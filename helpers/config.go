@@ -0,0 +1,168 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// OperatorConfig is operator wide defaults set once by platform teams instead of per resource
+type OperatorConfig struct {
+	// Images is docker images by client or component name (geth, besu, go-ipfs ...)
+	Images map[string]string `json:"images,omitempty"`
+	// ArchImages is docker images by cpu architecture then by client or component name
+	// they're used by pods scheduled on that architecture, instead of images
+	ArchImages map[string]map[string]string `json:"archImages,omitempty"`
+	// StorageClass is storage class of volumes that don't specify one
+	StorageClass string `json:"storageClass,omitempty"`
+	// ImagePullSecrets is names of secrets used to pull images of all managed pods
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+	// Resources is node resources used instead of built-in defaults
+	Resources ResourcesConfig `json:"resources,omitempty"`
+	// StorageSizes is node storage sizes by node kind, used instead of resources storage and built-in defaults
+	// ethereum node kinds are private, <network> and <network>-<sync mode> like mainnet-full, ipfs node kind is ipfs
+	StorageSizes map[string]string `json:"storageSizes,omitempty"`
+}
+
+// ResourcesConfig is default node compute and storage resources
+type ResourcesConfig struct {
+	CPU         string `json:"cpu,omitempty"`
+	CPULimit    string `json:"cpuLimit,omitempty"`
+	Memory      string `json:"memory,omitempty"`
+	MemoryLimit string `json:"memoryLimit,omitempty"`
+	Storage     string `json:"storage,omitempty"`
+}
+
+var (
+	operatorConfig   OperatorConfig
+	operatorConfigMu sync.RWMutex
+)
+
+// Config returns current operator config
+func Config() OperatorConfig {
+	operatorConfigMu.RLock()
+	defer operatorConfigMu.RUnlock()
+	return operatorConfig
+}
+
+// SetConfig replaces current operator config
+func SetConfig(config OperatorConfig) {
+	operatorConfigMu.Lock()
+	defer operatorConfigMu.Unlock()
+	operatorConfig = config
+}
+
+// LoadConfig loads operator config from json file
+func LoadConfig(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	config := OperatorConfig{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+
+	SetConfig(config)
+
+	return nil
+}
+
+// WatchConfig reloads operator config file whenever its content changes until stop channel is closed
+// config map volumes are updated in place by kubelet, that's why file content is polled
+func WatchConfig(path string, interval time.Duration, log logr.Logger) func(stop <-chan struct{}) error {
+	return func(stop <-chan struct{}) error {
+		last, _ := ioutil.ReadFile(path)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return nil
+			case <-ticker.C:
+				data, err := ioutil.ReadFile(path)
+				if err != nil || bytes.Equal(data, last) {
+					continue
+				}
+				if err := LoadConfig(path); err != nil {
+					log.Error(err, "unable to reload operator config")
+					continue
+				}
+				last = data
+				log.Info("operator config reloaded")
+			}
+		}
+	}
+}
+
+// Image returns docker image of name from operator config
+// falls back to image in env environment variable, then to fallback image
+func Image(name, env, fallback string) string {
+	if image := Config().Images[name]; image != "" {
+		return image
+	}
+	if image := os.Getenv(env); image != "" {
+		return image
+	}
+	return fallback
+}
+
+// ArchImage returns docker image of name built for cpu architecture arch from operator config
+// falls back to image in env_<ARCH> environment variable, like GETH_IMAGE_ARM64, then to Image
+// multi-arch images are used for all architectures if no architecture image is set
+func ArchImage(arch, name, env, fallback string) string {
+	if arch != "" {
+		if image := Config().ArchImages[arch][name]; image != "" {
+			return image
+		}
+		if image := os.Getenv(env + "_" + strings.ToUpper(arch)); image != "" {
+			return image
+		}
+	}
+	return Image(name, env, fallback)
+}
+
+// StorageClass returns storage class to be used by volume that requested the given class
+// operator config storage class is used if volume didn't request one
+func StorageClass(class *string) *string {
+	if class != nil {
+		return class
+	}
+	if config := Config().StorageClass; config != "" {
+		return &config
+	}
+	return nil
+}
+
+// StorageSize returns storage size of the first node kind found in operator config storage sizes
+// node kinds are ordered from the most to the least specific
+func StorageSize(kinds ...string) string {
+	sizes := Config().StorageSizes
+	for _, kind := range kinds {
+		if size := sizes[kind]; size != "" {
+			return size
+		}
+	}
+	return ""
+}
+
+// ImagePullSecrets returns image pull secrets from operator config
+func ImagePullSecrets() []corev1.LocalObjectReference {
+	secrets := []corev1.LocalObjectReference{}
+	for _, name := range Config().ImagePullSecrets {
+		secrets = append(secrets, corev1.LocalObjectReference{Name: name})
+	}
+	if len(secrets) == 0 {
+		return nil
+	}
+	return secrets
+}
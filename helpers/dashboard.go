@@ -0,0 +1,60 @@
+package helpers
+
+import "encoding/json"
+
+// DashboardPanel is a grafana dashboard graph panel
+type DashboardPanel struct {
+	// Title is panel title
+	Title string
+	// Query is prometheus query
+	Query string
+	// Legend is the legend format of panel series
+	Legend string
+}
+
+// GenerateDashboard generates grafana dashboard json model from panels
+func GenerateDashboard(title string, panels []DashboardPanel) (string, error) {
+	grafanaPanels := []map[string]interface{}{}
+
+	for i, panel := range panels {
+		grafanaPanels = append(grafanaPanels, map[string]interface{}{
+			"id":         i + 1,
+			"type":       "graph",
+			"title":      panel.Title,
+			"datasource": "Prometheus",
+			"gridPos": map[string]int{
+				"h": 8,
+				"w": 12,
+				"x": (i % 2) * 12,
+				"y": (i / 2) * 8,
+			},
+			"targets": []map[string]string{
+				{
+					"expr":         panel.Query,
+					"legendFormat": panel.Legend,
+					"refId":        "A",
+				},
+			},
+		})
+	}
+
+	dashboard := map[string]interface{}{
+		"title":         title,
+		"tags":          []string{"kotal"},
+		"editable":      true,
+		"schemaVersion": 22,
+		"refresh":       "30s",
+		"time": map[string]string{
+			"from": "now-1h",
+			"to":   "now",
+		},
+		"panels": grafanaPanels,
+	}
+
+	data, err := json.Marshal(dashboard)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,27 @@
+package helpers
+
+import (
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// InformerSyncedCheck returns health check of controller watching given object kind
+// controller is healthy once the informer of its watched object kind has synced
+func InformerSyncedCheck(informers cache.Informers, obj runtime.Object) healthz.Checker {
+	return func(req *http.Request) error {
+		informer, err := informers.GetInformer(req.Context(), obj)
+		if err != nil {
+			return err
+		}
+
+		if !informer.HasSynced() {
+			return fmt.Errorf("%T informer has not synced", obj)
+		}
+
+		return nil
+	}
+}
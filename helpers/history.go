@@ -0,0 +1,119 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SpecHistoryLimit is the maximum number of spec changes kept in status history
+const SpecHistoryLimit = 10
+
+// hash returns fnv hash of content
+func hash(content []byte) string {
+	h := fnv.New64a()
+	h.Write(content)
+	return fmt.Sprintf("%x", h.Sum64())
+}
+
+// namedItems returns list items names, ok is false if list is empty or any item has no name
+func namedItems(items []map[string]json.RawMessage) (names []string, ok bool) {
+	if len(items) == 0 {
+		return nil, false
+	}
+
+	for _, item := range items {
+		var name string
+		if err := json.Unmarshal(item["name"], &name); err != nil || name == "" {
+			return nil, false
+		}
+		names = append(names, name)
+	}
+
+	return names, true
+}
+
+// SpecFieldsHashes returns hashes of spec top level fields
+// lists of named items like nodes are hashed per item as field[name], so changed items are told apart
+func SpecFieldsHashes(spec interface{}) (map[string]string, error) {
+	content, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, err
+	}
+
+	hashes := map[string]string{}
+
+	for field, value := range fields {
+		items := []map[string]json.RawMessage{}
+		if err := json.Unmarshal(value, &items); err == nil {
+			if names, ok := namedItems(items); ok {
+				for i, name := range names {
+					// map keys are marshalled sorted, so item hash doesn't depend on fields order
+					item, err := json.Marshal(items[i])
+					if err != nil {
+						return nil, err
+					}
+					hashes[fmt.Sprintf("%s[%s]", field, name)] = hash(item)
+				}
+				continue
+			}
+		}
+		hashes[field] = hash(value)
+	}
+
+	return hashes, nil
+}
+
+// ChangedFields returns sorted summary of spec fields added, removed or changed between old and new hashes
+func ChangedFields(old, new map[string]string) []string {
+	changes := []string{}
+
+	for field, hash := range new {
+		oldHash, found := old[field]
+		if !found {
+			changes = append(changes, field+" added")
+		} else if oldHash != hash {
+			changes = append(changes, field+" changed")
+		}
+	}
+
+	for field := range old {
+		if _, found := new[field]; !found {
+			changes = append(changes, field+" removed")
+		}
+	}
+
+	sort.Strings(changes)
+
+	return changes
+}
+
+// SpecManager returns the field manager that most recently changed object spec, like kubectl
+func SpecManager(obj metav1.Object) string {
+	var manager string
+	var latest metav1.Time
+
+	for _, entry := range obj.GetManagedFields() {
+		if entry.FieldsV1 == nil || !bytes.Contains(entry.FieldsV1.Raw, []byte(`"f:spec"`)) {
+			continue
+		}
+		if entry.Time != nil && entry.Time.Before(&latest) {
+			continue
+		}
+		manager = entry.Manager
+		if entry.Time != nil {
+			latest = *entry.Time
+		}
+	}
+
+	return manager
+}
@@ -0,0 +1,108 @@
+package helpers
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// hardened is the offset of hardened child key indexes
+const hardened = 0x80000000
+
+// extendedKey is BIP-32 private key with its chain code
+type extendedKey struct {
+	key       []byte
+	chainCode []byte
+}
+
+// child derives BIP-32 child private key at index
+func (k *extendedKey) child(index uint32) (*extendedKey, error) {
+	data := []byte{}
+
+	if index >= hardened {
+		data = append(data, 0)
+		data = append(data, k.key...)
+	} else {
+		curve := crypto.S256()
+		x, y := curve.ScalarBaseMult(k.key)
+		data = append(data, crypto.CompressPubkey(&ecdsa.PublicKey{Curve: curve, X: x, Y: y})...)
+	}
+
+	data = append(data, make([]byte, 4)...)
+	binary.BigEndian.PutUint32(data[len(data)-4:], index)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(n) >= 0 {
+		return nil, errors.New("invalid child key")
+	}
+
+	key := il.Add(il, new(big.Int).SetBytes(k.key))
+	key.Mod(key, n)
+	if key.Sign() == 0 {
+		return nil, errors.New("invalid child key")
+	}
+
+	return &extendedKey{key: padKey(key.Bytes()), chainCode: sum[32:]}, nil
+}
+
+// padKey left pads private key to 32 bytes
+func padKey(key []byte) []byte {
+	padded := make([]byte, 32)
+	copy(padded[32-len(key):], key)
+	return padded
+}
+
+// DeriveMnemonicAddresses derives ethereum addresses of the first count accounts of BIP-39 mnemonic
+// accounts are derived using m/44'/60'/0'/0/i path like hardhat, ganache and metamask
+// mnemonic words aren't checked against BIP-39 wordlist
+func DeriveMnemonicAddresses(mnemonic string, count int) ([]string, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if mnemonic == "" {
+		return nil, errors.New("mnemonic is empty")
+	}
+
+	seed := pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"), 2048, 64, sha512.New)
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	// m/44'/60'/0'/0
+	account := &extendedKey{key: sum[:32], chainCode: sum[32:]}
+	for _, index := range []uint32{44 + hardened, 60 + hardened, 0 + hardened, 0} {
+		child, err := account.child(index)
+		if err != nil {
+			return nil, err
+		}
+		account = child
+	}
+
+	addresses := []string{}
+	for i := 0; i < count; i++ {
+		child, err := account.child(uint32(i))
+		if err != nil {
+			return nil, err
+		}
+
+		privateKey, err := crypto.ToECDSA(child.key)
+		if err != nil {
+			return nil, err
+		}
+
+		addresses = append(addresses, crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+	}
+
+	return addresses, nil
+}
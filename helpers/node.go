@@ -1,12 +1,79 @@
 package helpers
 
 import (
+	"container/list"
 	"crypto/ecdsa"
+	"crypto/sha256"
 	"errors"
+	"sync"
+
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// publicKeysCacheSize is the maximum number of cached public keys
+const publicKeysCacheSize = 1024
+
+// publicKeys caches derived public keys keyed by private key hash
+// private keys are never kept in memory by the cache
+var publicKeys = newPublicKeysCache(publicKeysCacheSize)
+
+// publicKeysCache is least recently used cache of public keys, least recently used key is evicted once cache is full
+type publicKeysCache struct {
+	sync.Mutex
+	size int
+	// used is cache entries ordered by use, most recently used first
+	used *list.List
+	keys map[[sha256.Size]byte]*list.Element
+}
+
+// publicKeysCacheEntry is cached public key and its private key hash
+type publicKeysCacheEntry struct {
+	hash      [sha256.Size]byte
+	publicKey string
+}
+
+// newPublicKeysCache returns public keys cache holding up to size keys
+func newPublicKeysCache(size int) *publicKeysCache {
+	return &publicKeysCache{
+		size: size,
+		used: list.New(),
+		keys: map[[sha256.Size]byte]*list.Element{},
+	}
+}
+
+// get returns cached public key of private key hash
+func (c *publicKeysCache) get(hash [sha256.Size]byte) (publicKey string, ok bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	elem, ok := c.keys[hash]
+	if !ok {
+		return
+	}
+	c.used.MoveToFront(elem)
+	return elem.Value.(*publicKeysCacheEntry).publicKey, true
+}
+
+// add caches public key of private key hash
+func (c *publicKeysCache) add(hash [sha256.Size]byte, publicKey string) {
+	c.Lock()
+	defer c.Unlock()
+
+	if elem, ok := c.keys[hash]; ok {
+		c.used.MoveToFront(elem)
+		return
+	}
+
+	c.keys[hash] = c.used.PushFront(&publicKeysCacheEntry{hash: hash, publicKey: publicKey})
+
+	if c.used.Len() > c.size {
+		oldest := c.used.Back()
+		c.used.Remove(oldest)
+		delete(c.keys, oldest.Value.(*publicKeysCacheEntry).hash)
+	}
+}
+
 func derive(fromPrivateKey string) (publicKeyECDSA *ecdsa.PublicKey, err error) {
 	// private key
 	privateKey, err := crypto.HexToECDSA(fromPrivateKey)
@@ -26,7 +93,14 @@ func derive(fromPrivateKey string) (publicKeyECDSA *ecdsa.PublicKey, err error)
 }
 
 // DerivePublicKey drives node public key from private key
+// derived public keys are cached to avoid recomputing them on every reconcile
 func DerivePublicKey(fromPrivateKey string) (publicKeyHex string, err error) {
+	hash := sha256.Sum256([]byte(fromPrivateKey))
+
+	if cachedKey, cached := publicKeys.get(hash); cached {
+		return cachedKey, nil
+	}
+
 	publicKeyECDSA, err := derive(fromPrivateKey)
 	if err != nil {
 		return
@@ -35,6 +109,8 @@ func DerivePublicKey(fromPrivateKey string) (publicKeyHex string, err error) {
 	publicKeyBytes := crypto.FromECDSAPub(publicKeyECDSA)
 	publicKeyHex = hexutil.Encode(publicKeyBytes)[4:]
 
+	publicKeys.add(hash, publicKeyHex)
+
 	return
 
 }
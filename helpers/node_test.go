@@ -0,0 +1,68 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"testing"
+)
+
+func TestPublicKeysCache(t *testing.T) {
+	cache := newPublicKeysCache(2)
+	first := sha256.Sum256([]byte("first"))
+	second := sha256.Sum256([]byte("second"))
+	third := sha256.Sum256([]byte("third"))
+
+	if _, ok := cache.get(first); ok {
+		t.Errorf("Expecting empty cache miss")
+	}
+
+	cache.add(first, "first-public-key")
+	cache.add(second, "second-public-key")
+
+	if key, ok := cache.get(first); !ok || key != "first-public-key" {
+		t.Errorf("Expecting cache hit with first-public-key got %s (%t)", key, ok)
+	}
+
+	// second key is the least recently used
+	cache.add(third, "third-public-key")
+
+	if _, ok := cache.get(second); ok {
+		t.Errorf("Expecting least recently used key to be evicted")
+	}
+	if _, ok := cache.get(first); !ok {
+		t.Errorf("Expecting recently used key to be kept")
+	}
+	if _, ok := cache.get(third); !ok {
+		t.Errorf("Expecting added key to be cached")
+	}
+	if n := cache.used.Len(); n != 2 || len(cache.keys) != 2 {
+		t.Errorf("Expecting cache to hold 2 keys got %d", n)
+	}
+}
+
+func TestDerivePublicKey(t *testing.T) {
+	privateKey := "608e9b6f67c65e47531e08e8e501386dfae63a540fa3c48802c8aad854510b4e"
+	hash := sha256.Sum256([]byte(privateKey))
+
+	publicKey, err := DerivePublicKey(privateKey)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	if len(publicKey) != 128 {
+		t.Errorf("Expecting 64 bytes hex encoded public key got %s", publicKey)
+	}
+
+	if cached, ok := publicKeys.get(hash); !ok || cached != publicKey {
+		t.Errorf("Expecting derived public key to be cached got %s (%t)", cached, ok)
+	}
+
+	if cached, err := DerivePublicKey(privateKey); err != nil || cached != publicKey {
+		t.Errorf("Expecting cached public key %s got %s (%v)", publicKey, cached, err)
+	}
+
+	if _, err := DerivePublicKey("invalid"); err == nil {
+		t.Errorf("Expecting error deriving public key of invalid private key")
+	}
+	if _, ok := publicKeys.get(sha256.Sum256([]byte("invalid"))); ok {
+		t.Errorf("Expecting invalid private key not to be cached")
+	}
+}
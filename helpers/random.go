@@ -0,0 +1,15 @@
+package helpers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// GenerateRandomHex generates cryptographically secure random hex string of n bytes
+func GenerateRandomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
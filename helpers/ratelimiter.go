@@ -0,0 +1,30 @@
+package helpers
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// RateLimiterOptions is controller workqueue rate limiter settings
+type RateLimiterOptions struct {
+	// BaseDelay is the delay of first retry of a failing item
+	BaseDelay time.Duration
+	// MaxDelay is the maximum delay between retries of a failing item
+	MaxDelay time.Duration
+	// QPS is the overall rate of requeued items per second
+	QPS float64
+	// Burst is the overall burst of requeued items
+	Burst int
+}
+
+// NewRateLimiter creates workqueue rate limiter from options
+// retries of the same item are exponentially delayed from base delay up to max delay
+// and all items are limited by the overall token bucket, the worst of both is used
+func NewRateLimiter(options RateLimiterOptions) workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(options.BaseDelay, options.MaxDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(options.QPS), options.Burst)},
+	)
+}
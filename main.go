@@ -3,17 +3,26 @@ package main
 import (
 	"flag"
 	"os"
+	"strconv"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	ethereumv1alpha1 "github.com/kotalco/kotal/apis/ethereum/v1alpha1"
+	ethereumv1beta1 "github.com/kotalco/kotal/apis/ethereum/v1beta1"
 	ipfsv1alpha1 "github.com/kotalco/kotal/apis/ipfs/v1alpha1"
+	"github.com/kotalco/kotal/backup"
 	controllers "github.com/kotalco/kotal/controllers/ethereum"
 	ipfscontroller "github.com/kotalco/kotal/controllers/ipfs"
+	"github.com/kotalco/kotal/helpers"
+	"github.com/kotalco/kotal/rpcproxy"
+	"github.com/kotalco/kotal/tracing"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -26,14 +35,66 @@ func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
 
 	_ = ethereumv1alpha1.AddToScheme(scheme)
+	_ = ethereumv1beta1.AddToScheme(scheme)
 	_ = ipfsv1alpha1.AddToScheme(scheme)
 	// +kubebuilder:scaffold:scheme
 }
 
 func main() {
+	// rpc proxy is run as ethereum nodes sidecar using operator image
+	if len(os.Args) > 1 && os.Args[1] == rpcproxy.Command {
+		ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+		if err := rpcproxy.Run(os.Args[2:]); err != nil {
+			setupLog.Error(err, "unable to run rpc proxy")
+			os.Exit(1)
+		}
+		return
+	}
+
+	// node data backup is run as ethereum nodes sidecar using operator image
+	if len(os.Args) > 1 && os.Args[1] == backup.Command {
+		ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+		if err := backup.Run(os.Args[2:]); err != nil {
+			setupLog.Error(err, "unable to run node data backup")
+			os.Exit(1)
+		}
+		return
+	}
+
 	var metricsAddr string
+	var healthProbeAddr string
+	var webhookPort int
+	var webhookCertDir string
 	var enableLeaderElection bool
-	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
+	var statusRefreshInterval time.Duration
+	var noPeersThreshold time.Duration
+	var rateLimiterOptions helpers.RateLimiterOptions
+	var configPath string
+	var configReloadInterval time.Duration
+	flag.StringVar(&metricsAddr, "metrics-addr", envOrDefault("METRICS_ADDR", ":8080"),
+		"The address the metric endpoint binds to, 0 disables it.")
+	flag.StringVar(&healthProbeAddr, "health-probe-addr", envOrDefault("HEALTH_PROBE_ADDR", ":8081"),
+		"The address the /healthz and /readyz probe endpoints bind to, 0 disables it.")
+	flag.IntVar(&webhookPort, "webhook-port", envIntOrDefault("WEBHOOK_PORT", 9443),
+		"The port the webhook server listens on.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", envOrDefault("WEBHOOK_CERT_DIR", ""),
+		"Directory of webhook server tls.crt and tls.key, defaults to /tmp/k8s-webhook-server/serving-certs.")
+	flag.DurationVar(&statusRefreshInterval, "status-refresh-interval", 30*time.Second,
+		"How often ethereum nodes block height, sync progress and peers and ipfs nodes peers are refreshed in status, 0 disables it.")
+	flag.DurationVar(&noPeersThreshold, "no-peers-threshold", 5*time.Minute,
+		"How long a node can stay without peers before its network is marked as degraded.")
+	flag.DurationVar(&rateLimiterOptions.BaseDelay, "rate-limiter-base-delay", 5*time.Millisecond,
+		"How long to wait before first retry of a failing reconcile, doubled on every following failure.")
+	flag.DurationVar(&rateLimiterOptions.MaxDelay, "rate-limiter-max-delay", 1000*time.Second,
+		"Maximum delay between retries of a failing reconcile.")
+	flag.Float64Var(&rateLimiterOptions.QPS, "rate-limiter-qps", 10,
+		"Overall number of reconcile retries allowed per second.")
+	flag.IntVar(&rateLimiterOptions.Burst, "rate-limiter-burst", 100,
+		"Overall number of reconcile retries allowed in a single burst.")
+	flag.StringVar(&configPath, "config", "",
+		"Path of operator config file with default images, storage class, image pull secrets and resources.")
+	flag.DurationVar(&configReloadInterval, "config-reload-interval", 30*time.Second,
+		"How often operator config file is checked for changes.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -41,34 +102,77 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
 
+	// tracing is enabled by setting JAEGER_ENDPOINT environment variable
+	flushTraces, err := tracing.Setup("kotal")
+	if err != nil {
+		setupLog.Error(err, "unable to setup tracing")
+		os.Exit(1)
+	}
+	defer flushTraces()
+
+	if configPath != "" {
+		if err := helpers.LoadConfig(configPath); err != nil {
+			setupLog.Error(err, "unable to load operator config")
+			os.Exit(1)
+		}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:             scheme,
-		MetricsBindAddress: metricsAddr,
-		Port:               9443,
-		LeaderElection:     enableLeaderElection,
-		LeaderElectionID:   "2b1fce2f.kotal.io",
+		Scheme:                 scheme,
+		MetricsBindAddress:     metricsAddr,
+		HealthProbeBindAddress: healthProbeAddr,
+		Port:                   webhookPort,
+		CertDir:                webhookCertDir,
+		LeaderElection:         enableLeaderElection,
+		LeaderElectionID:       "2b1fce2f.kotal.io",
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	volumeStats, err := controllers.NewKubeletVolumeStats(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create kubelet volume stats client")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.NetworkReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("Network"),
-		Scheme: mgr.GetScheme(),
+		Client:                mgr.GetClient(),
+		Log:                   ctrl.Log.WithName("controllers").WithName("Network"),
+		Scheme:                mgr.GetScheme(),
+		StatusRefreshInterval: statusRefreshInterval,
+		NoPeersThreshold:      noPeersThreshold,
+		Recorder:              mgr.GetEventRecorderFor("network-controller"),
+		RateLimiter:           helpers.NewRateLimiter(rateLimiterOptions),
+		VolumeStats:           volumeStats,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Network")
 		os.Exit(1)
 	}
-	if err = (&ethereumv1alpha1.Network{}).SetupWebhookWithManager(mgr); err != nil {
+	if err = (&ethereumv1beta1.Network{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Network")
 		os.Exit(1)
 	}
+	if err = (&controllers.NodeTaskReconciler{
+		Client:      mgr.GetClient(),
+		Log:         ctrl.Log.WithName("controllers").WithName("NodeTask"),
+		Scheme:      mgr.GetScheme(),
+		RateLimiter: helpers.NewRateLimiter(rateLimiterOptions),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NodeTask")
+		os.Exit(1)
+	}
+	if err = (&ethereumv1beta1.NodeTask{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "NodeTask")
+		os.Exit(1)
+	}
 	if err = (&ipfscontroller.SwarmReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("Swarm"),
-		Scheme: mgr.GetScheme(),
+		Client:                mgr.GetClient(),
+		Log:                   ctrl.Log.WithName("controllers").WithName("Swarm"),
+		Scheme:                mgr.GetScheme(),
+		RateLimiter:           helpers.NewRateLimiter(rateLimiterOptions),
+		StatusRefreshInterval: statusRefreshInterval,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Swarm")
 		os.Exit(1)
@@ -77,11 +181,99 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Swarm")
 		os.Exit(1)
 	}
+	if err = (&ipfscontroller.PeerReconciler{
+		Client:      mgr.GetClient(),
+		Log:         ctrl.Log.WithName("controllers").WithName("Peer"),
+		Scheme:      mgr.GetScheme(),
+		RateLimiter: helpers.NewRateLimiter(rateLimiterOptions),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Peer")
+		os.Exit(1)
+	}
+	if err = (&ipfsv1alpha1.Peer{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Peer")
+		os.Exit(1)
+	}
+	if err = (&ipfscontroller.IPNSRecordReconciler{
+		Client:      mgr.GetClient(),
+		Log:         ctrl.Log.WithName("controllers").WithName("IPNSRecord"),
+		Scheme:      mgr.GetScheme(),
+		RateLimiter: helpers.NewRateLimiter(rateLimiterOptions),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "IPNSRecord")
+		os.Exit(1)
+	}
+	if err = (&ipfsv1alpha1.IPNSRecord{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "IPNSRecord")
+		os.Exit(1)
+	}
+	if err = (&ipfscontroller.KeyReconciler{
+		Client:      mgr.GetClient(),
+		Log:         ctrl.Log.WithName("controllers").WithName("Key"),
+		Scheme:      mgr.GetScheme(),
+		RateLimiter: helpers.NewRateLimiter(rateLimiterOptions),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Key")
+		os.Exit(1)
+	}
+	if err = (&ipfsv1alpha1.Key{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Key")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
+	// controllers are healthy and ready once their watched resources informers have synced
+	watched := map[string]runtime.Object{
+		"network":    &ethereumv1beta1.Network{},
+		"nodetask":   &ethereumv1beta1.NodeTask{},
+		"swarm":      &ipfsv1alpha1.Swarm{},
+		"peer":       &ipfsv1alpha1.Peer{},
+		"ipnsrecord": &ipfsv1alpha1.IPNSRecord{},
+		"key":        &ipfsv1alpha1.Key{},
+	}
+	for name, obj := range watched {
+		check := helpers.InformerSyncedCheck(mgr.GetCache(), obj)
+		if err := mgr.AddHealthzCheck(name, check); err != nil {
+			setupLog.Error(err, "unable to add health check", "controller", name)
+			os.Exit(1)
+		}
+		if err := mgr.AddReadyzCheck(name, check); err != nil {
+			setupLog.Error(err, "unable to add ready check", "controller", name)
+			os.Exit(1)
+		}
+	}
+	if err := mgr.AddReadyzCheck("ping", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to add ready check")
+		os.Exit(1)
+	}
+
+	if configPath != "" {
+		watchConfig := helpers.WatchConfig(configPath, configReloadInterval, ctrl.Log.WithName("config"))
+		if err := mgr.Add(manager.RunnableFunc(watchConfig)); err != nil {
+			setupLog.Error(err, "unable to watch operator config")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// envOrDefault returns environment variable value if set, otherwise returns the default value
+func envOrDefault(key, value string) string {
+	if env := os.Getenv(key); env != "" {
+		return env
+	}
+	return value
+}
+
+// envIntOrDefault returns environment variable integer value if set and valid, otherwise returns the default value
+func envIntOrDefault(key string, value int) int {
+	if env, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return env
+	}
+	return value
+}
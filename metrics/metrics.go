@@ -0,0 +1,108 @@
+// Package metrics contains kotal operator custom prometheus metrics
+// metrics are registered with controller-runtime registry and served by the manager metrics endpoint
+package metrics
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// ResourcesUpdateInterval is how often managed resources metrics are updated
+const ResourcesUpdateInterval = 30 * time.Second
+
+var (
+	// ManagedResources is the number of resources managed by the operator by kind
+	ManagedResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kotal_managed_resources",
+			Help: "Number of resources managed by kotal operator",
+		},
+		[]string{"kind"},
+	)
+
+	// Nodes is the number of nodes managed by the operator by kind and client
+	Nodes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kotal_nodes",
+			Help: "Number of nodes managed by kotal operator per client",
+		},
+		[]string{"kind", "client"},
+	)
+
+	// ReconcileErrors is the number of reconcile errors per resource
+	ReconcileErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kotal_reconcile_errors_total",
+			Help: "Number of reconcile errors per resource",
+		},
+		[]string{"kind", "namespace", "name"},
+	)
+
+	// LastReconcileDuration is the duration of the last reconcile per resource
+	LastReconcileDuration = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kotal_last_reconcile_duration_seconds",
+			Help: "Duration of the last reconcile per resource in seconds",
+		},
+		[]string{"kind", "namespace", "name"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		ManagedResources,
+		Nodes,
+		ReconcileErrors,
+		LastReconcileDuration,
+	)
+}
+
+// ObserveReconcile records reconcile duration and error (if any) of a resource
+func ObserveReconcile(kind string, name types.NamespacedName, start time.Time, err error) {
+	LastReconcileDuration.WithLabelValues(kind, name.Namespace, name.Name).Set(time.Since(start).Seconds())
+
+	if err != nil {
+		ReconcileErrors.WithLabelValues(kind, name.Namespace, name.Name).Inc()
+	}
+}
+
+// Forget removes metrics of a deleted resource
+func Forget(kind string, name types.NamespacedName) {
+	LastReconcileDuration.DeleteLabelValues(kind, name.Namespace, name.Name)
+	ReconcileErrors.DeleteLabelValues(kind, name.Namespace, name.Name)
+}
+
+// SetResources sets managed resources count and nodes count per client of a given kind
+// nodesByClient should contain all supported clients, so clients without nodes are reported as 0
+func SetResources(kind string, count int, nodesByClient map[string]int) {
+	ManagedResources.WithLabelValues(kind).Set(float64(count))
+
+	for client, nodes := range nodesByClient {
+		Nodes.WithLabelValues(kind, client).Set(float64(nodes))
+	}
+}
+
+// UpdateResources calls update every interval until stop channel is closed
+// managed resources are counted cluster wide, that's why they're updated periodically instead of on every reconcile
+func UpdateResources(interval time.Duration, update func() error, log logr.Logger) func(stop <-chan struct{}) error {
+	return func(stop <-chan struct{}) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := update(); err != nil {
+				log.Error(err, "unable to update managed resources metrics")
+			}
+
+			select {
+			case <-stop:
+				return nil
+			case <-ticker.C:
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestObserveReconcile(t *testing.T) {
+	name := types.NamespacedName{Namespace: "default", Name: "my-network"}
+
+	ObserveReconcile("network", name, time.Now(), nil)
+	ObserveReconcile("network", name, time.Now(), errors.New("reconcile error"))
+
+	got := testutil.ToFloat64(ReconcileErrors.WithLabelValues("network", name.Namespace, name.Name))
+	if got != 1 {
+		t.Errorf("Expecting reconcile errors to be 1 got %f", got)
+	}
+
+	Forget("network", name)
+
+	got = testutil.ToFloat64(ReconcileErrors.WithLabelValues("network", name.Namespace, name.Name))
+	if got != 0 {
+		t.Errorf("Expecting reconcile errors to be 0 after forgetting resource got %f", got)
+	}
+}
+
+func TestSetResources(t *testing.T) {
+	SetResources("network", 2, map[string]int{"besu": 3, "geth": 0})
+
+	got := testutil.ToFloat64(ManagedResources.WithLabelValues("network"))
+	if got != 2 {
+		t.Errorf("Expecting managed networks to be 2 got %f", got)
+	}
+
+	got = testutil.ToFloat64(Nodes.WithLabelValues("network", "besu"))
+	if got != 3 {
+		t.Errorf("Expecting besu nodes to be 3 got %f", got)
+	}
+}
+
+func TestUpdateResources(t *testing.T) {
+	updates := make(chan struct{}, 10)
+	update := func() error {
+		updates <- struct{}{}
+		return errors.New("list error")
+	}
+
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- UpdateResources(time.Millisecond, update, ctrl.Log)(stop) }()
+
+	// failed updates are retried
+	for i := 0; i < 2; i++ {
+		select {
+		case <-updates:
+		case <-time.After(time.Second):
+			t.Fatalf("Expecting resources metrics to be updated periodically")
+		}
+	}
+
+	close(stop)
+	if err := <-done; err != nil {
+		t.Errorf("Expecting no error after stop got %s", err)
+	}
+}
@@ -0,0 +1,243 @@
+// Package rpcproxy contains json-rpc proxy run as ethereum node sidecar
+// requests are filtered by method, rate limited per client ip and limited in batch size before reaching node http-rpc server
+package rpcproxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// Command is the operator binary command running rpc proxy
+const Command = "rpc-proxy"
+
+// maxBodySize is the maximum size of proxied request body
+const maxBodySize = 5 * 1024 * 1024
+
+// maxClients is the number of client rate limiters kept before they're reset
+const maxClients = 10000
+
+// json-rpc error codes
+const (
+	invalidRequestCode   = -32600
+	methodNotFoundCode   = -32601
+	limitExceededCode    = -32005
+	invalidRequestReason = "invalid request"
+)
+
+// Options is rpc proxy options
+type Options struct {
+	// Listen is the proxy listening address
+	Listen string
+	// Upstream is node http-rpc server url
+	Upstream string
+	// AllowedMethods is a list of allowed methods, all methods are allowed if empty
+	AllowedMethods []string
+	// RateLimit is the number of requests allowed per second per client ip, 0 disables rate limiting
+	RateLimit int
+	// RateLimitBurst is the number of requests allowed per client ip in a single burst
+	RateLimitBurst int
+	// MaxBatchSize is the maximum number of requests in a batch, 0 disables batch size limit
+	MaxBatchSize int
+}
+
+// Proxy is json-rpc reverse proxy
+type Proxy struct {
+	options  Options
+	allowed  map[string]bool
+	upstream *httputil.ReverseProxy
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// rpcRequest is the part of json-rpc request checked by the proxy
+type rpcRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+// rpcError is json-rpc error object
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// rpcResponse is json-rpc error response
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   rpcError        `json:"error"`
+}
+
+// New creates a new rpc proxy
+func New(options Options) (*Proxy, error) {
+	upstream, err := url.Parse(options.Upstream)
+	if err != nil {
+		return nil, err
+	}
+	if upstream.Scheme == "" || upstream.Host == "" {
+		return nil, fmt.Errorf("invalid upstream url %s", options.Upstream)
+	}
+
+	allowed := map[string]bool{}
+	for _, method := range options.AllowedMethods {
+		allowed[method] = true
+	}
+
+	return &Proxy{
+		options:  options,
+		allowed:  allowed,
+		upstream: httputil.NewSingleHostReverseProxy(upstream),
+		limiters: map[string]*rate.Limiter{},
+	}, nil
+}
+
+// limiter returns rate limiter of client ip
+func (p *Proxy) limiter(ip string) *rate.Limiter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// limiters are reset instead of growing without bound
+	if len(p.limiters) >= maxClients {
+		p.limiters = map[string]*rate.Limiter{}
+	}
+
+	limiter, ok := p.limiters[ip]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(p.options.RateLimit), p.options.RateLimitBurst)
+		p.limiters[ip] = limiter
+	}
+
+	return limiter
+}
+
+// clientIP returns request client ip
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// parseRequests parses single or batch json-rpc request body
+func parseRequests(body []byte) (requests []rpcRequest, batch bool, err error) {
+	body = bytes.TrimSpace(body)
+
+	if len(body) != 0 && body[0] == '[' {
+		err = json.Unmarshal(body, &requests)
+		return requests, true, err
+	}
+
+	request := rpcRequest{}
+	if err = json.Unmarshal(body, &request); err != nil {
+		return nil, false, err
+	}
+
+	return []rpcRequest{request}, false, nil
+}
+
+// writeError writes json-rpc error response
+func writeError(w http.ResponseWriter, status int, id json.RawMessage, code int, message string) {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(rpcResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   rpcError{Code: code, Message: message},
+	})
+}
+
+// ServeHTTP checks request against proxy options and forwards it to node http-rpc server
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if p.options.RateLimit > 0 && !p.limiter(clientIP(r)).Allow() {
+		writeError(w, http.StatusTooManyRequests, nil, limitExceededCode, "rate limit exceeded")
+		return
+	}
+
+	// cors preflight requests have no json-rpc body
+	if r.Method != http.MethodPost {
+		p.upstream.ServeHTTP(w, r)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, nil, invalidRequestCode, "request body too large")
+		return
+	}
+
+	requests, batch, err := parseRequests(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, nil, invalidRequestCode, invalidRequestReason)
+		return
+	}
+
+	if batch && p.options.MaxBatchSize > 0 && len(requests) > p.options.MaxBatchSize {
+		writeError(w, http.StatusBadRequest, nil, invalidRequestCode, fmt.Sprintf("batch size exceeds %d", p.options.MaxBatchSize))
+		return
+	}
+
+	if len(p.allowed) != 0 {
+		for _, request := range requests {
+			if !p.allowed[request.Method] {
+				writeError(w, http.StatusForbidden, request.ID, methodNotFoundCode, fmt.Sprintf("method %s is not allowed", request.Method))
+				return
+			}
+		}
+	}
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	p.upstream.ServeHTTP(w, r)
+}
+
+// Run parses rpc proxy command line arguments and serves proxy
+func Run(args []string) error {
+	options := Options{}
+	var methods string
+
+	flags := flag.NewFlagSet(Command, flag.ContinueOnError)
+	flags.StringVar(&options.Listen, "listen", ":8555", "The address the proxy listens on.")
+	flags.StringVar(&options.Upstream, "upstream", "http://127.0.0.1:8545", "Node http-rpc server url.")
+	flags.StringVar(&methods, "allowed-methods", "", "Comma separated list of allowed methods, all methods are allowed if empty.")
+	flags.IntVar(&options.RateLimit, "rate-limit", 0, "Number of requests allowed per second per client ip, 0 disables it.")
+	flags.IntVar(&options.RateLimitBurst, "rate-limit-burst", 0, "Number of requests allowed per client ip in a single burst.")
+	flags.IntVar(&options.MaxBatchSize, "max-batch-size", 0, "Maximum number of requests in a batch, 0 disables it.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	for _, method := range strings.Split(methods, ",") {
+		if method = strings.TrimSpace(method); method != "" {
+			options.AllowedMethods = append(options.AllowedMethods, method)
+		}
+	}
+
+	if options.RateLimit > 0 && options.RateLimitBurst < 1 {
+		return errors.New("rate limit burst must be at least 1")
+	}
+
+	proxy, err := New(options)
+	if err != nil {
+		return err
+	}
+
+	return http.ListenAndServe(options.Listen, proxy)
+}
@@ -0,0 +1,80 @@
+package rpcproxy
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestProxy returns proxy in front of upstream echoing request body
+func newTestProxy(t *testing.T, options Options) (*Proxy, func()) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}))
+
+	options.Upstream = upstream.URL
+	proxy, err := New(options)
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+
+	return proxy, upstream.Close
+}
+
+// post sends json-rpc request body to proxy, and returns response status and body
+func post(proxy *Proxy, body string) (int, string) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.RemoteAddr = "10.0.0.1:40000"
+	rec := httptest.NewRecorder()
+	proxy.ServeHTTP(rec, req)
+	return rec.Code, rec.Body.String()
+}
+
+func TestProxyAllowedMethods(t *testing.T) {
+	proxy, close := newTestProxy(t, Options{AllowedMethods: []string{"eth_blockNumber"}})
+	defer close()
+
+	allowed := `{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`
+	if status, body := post(proxy, allowed); status != http.StatusOK || body != allowed {
+		t.Errorf("Expecting allowed request to be forwarded got %d %s", status, body)
+	}
+
+	denied := `[{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"},{"jsonrpc":"2.0","id":2,"method":"admin_peers"}]`
+	status, body := post(proxy, denied)
+	if status != http.StatusForbidden || !strings.Contains(body, `"id":2`) {
+		t.Errorf("Expecting batch with denied method to be rejected got %d %s", status, body)
+	}
+}
+
+func TestProxyMaxBatchSize(t *testing.T) {
+	proxy, close := newTestProxy(t, Options{MaxBatchSize: 1})
+	defer close()
+
+	batch := `[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"method":"eth_chainId"}]`
+	if status, _ := post(proxy, batch); status != http.StatusBadRequest {
+		t.Errorf("Expecting batch exceeding max size to be rejected got %d", status)
+	}
+
+	if status, _ := post(proxy, "not json"); status != http.StatusBadRequest {
+		t.Errorf("Expecting invalid request to be rejected got %d", status)
+	}
+}
+
+func TestProxyRateLimit(t *testing.T) {
+	proxy, close := newTestProxy(t, Options{RateLimit: 1, RateLimitBurst: 2})
+	defer close()
+
+	request := `{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`
+	for i := 0; i < 2; i++ {
+		if status, _ := post(proxy, request); status != http.StatusOK {
+			t.Fatalf("Expecting request %d within burst to be forwarded got %d", i, status)
+		}
+	}
+
+	if status, _ := post(proxy, request); status != http.StatusTooManyRequests {
+		t.Errorf("Expecting request exceeding rate limit to be rejected got %d", status)
+	}
+}
@@ -0,0 +1,58 @@
+// Package tracing contains kotal operator opentelemetry tracing helpers
+// spans are exported to jaeger collector set by JAEGER_ENDPOINT environment variable
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/trace/jaeger"
+	"go.opentelemetry.io/otel/label"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TracerName is the name of kotal operator tracer
+const TracerName = "github.com/kotalco/kotal"
+
+// Setup installs jaeger exporter as global trace provider if JAEGER_ENDPOINT environment variable is set
+// service name and tags can be overridden by JAEGER_SERVICE_NAME and JAEGER_TAGS environment variables
+// returned flush function exports buffered spans, it should be called before exit
+func Setup(service string) (flush func(), err error) {
+	endpoint := jaeger.CollectorEndpointFromEnv()
+
+	// tracing is disabled, global trace provider is noop by default
+	if endpoint == "" {
+		return func() {}, nil
+	}
+
+	return jaeger.InstallNewPipeline(
+		jaeger.WithCollectorEndpoint(endpoint, jaeger.WithCollectorEndpointOptionFromEnv()),
+		jaeger.WithProcess(jaeger.Process{ServiceName: service}),
+		jaeger.WithProcessFromEnv(),
+		jaeger.WithSDK(&sdktrace.Config{DefaultSampler: sdktrace.AlwaysSample()}),
+	)
+}
+
+// Start starts a new span, child of the span in ctx if any
+func Start(ctx context.Context, name string, labels ...label.KeyValue) (context.Context, trace.Span) {
+	return global.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(labels...))
+}
+
+// End records error if any and ends the span
+func End(ctx context.Context, span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(ctx, err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Trace calls fn within a new span, child of the span in ctx if any
+func Trace(ctx context.Context, name string, fn func() error) error {
+	ctx, span := Start(ctx, name)
+	err := fn()
+	End(ctx, span, err)
+	return err
+}
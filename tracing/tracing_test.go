@@ -0,0 +1,32 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSetupWithoutEndpoint(t *testing.T) {
+	flush, err := Setup("kotal")
+	if err != nil {
+		t.Fatalf("Expecting no error got %s", err)
+	}
+	flush()
+}
+
+func TestTrace(t *testing.T) {
+	called := false
+	expected := errors.New("reconcile error")
+
+	err := Trace(context.Background(), "reconcile", func() error {
+		called = true
+		return expected
+	})
+
+	if !called {
+		t.Error("Expecting traced function to be called")
+	}
+	if err != expected {
+		t.Errorf("Expecting error %s got %v", expected, err)
+	}
+}